@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/bloxapp/ssv/api"
 	networkpeers "github.com/bloxapp/ssv/network/peers"
@@ -45,6 +46,20 @@ type peerJSON struct {
 	Connectedness string           `json:"connectedness"`
 	Subnets       string           `json:"subnets"`
 	Version       string           `json:"version"`
+	Reputation    reputationJSON   `json:"reputation"`
+}
+
+type reputationJSON struct {
+	Score       float64           `json:"score"`
+	Events      map[string]uint64 `json:"events,omitempty"`
+	Banned      bool              `json:"banned"`
+	BannedUntil *time.Time        `json:"banned_until,omitempty"`
+	Allowed     bool              `json:"allowed"`
+}
+
+type banJSON struct {
+	ID    peer.ID    `json:"id"`
+	Until *time.Time `json:"until,omitempty"`
 }
 
 type identityJSON struct {
@@ -113,6 +128,90 @@ func (h *Node) Peers(w http.ResponseWriter, r *http.Request) error {
 	return api.Render(w, r, resp)
 }
 
+func (h *Node) Bans(w http.ResponseWriter, r *http.Request) error {
+	var response struct {
+		Banned  []banJSON `json:"banned"`
+		Allowed []peer.ID `json:"allowed"`
+	}
+	now := time.Now()
+	for id, reputation := range h.PeersIndex.Reputations() {
+		if reputation.Allowed {
+			response.Allowed = append(response.Allowed, id)
+		}
+		if !reputation.Banned(now) {
+			continue
+		}
+		ban := banJSON{ID: id}
+		if !reputation.BannedIndefinitely {
+			until := reputation.BannedUntil
+			ban.Until = &until
+		}
+		response.Banned = append(response.Banned, ban)
+	}
+	return api.Render(w, r, response)
+}
+
+func (h *Node) Ban(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PeerID   string `json:"peer_id" form:"peer_id"`
+		Duration string `json:"duration" form:"duration"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	id, err := peer.Decode(request.PeerID)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+	var duration time.Duration
+	if request.Duration != "" {
+		duration, err = time.ParseDuration(request.Duration)
+		if err != nil {
+			return api.InvalidRequestError(err)
+		}
+		if duration <= 0 {
+			return api.InvalidRequestError(errors.New("duration must be positive"))
+		}
+	}
+	h.PeersIndex.Ban(id, duration)
+	if err := h.Network.ClosePeer(id); err != nil {
+		return api.Error(err)
+	}
+	return api.Render(w, r, h.reputation(id))
+}
+
+func (h *Node) Unban(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PeerID string `json:"peer_id" form:"peer_id"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	id, err := peer.Decode(request.PeerID)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+	h.PeersIndex.Unban(id)
+	return api.Render(w, r, h.reputation(id))
+}
+
+func (h *Node) Allow(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PeerID string `json:"peer_id" form:"peer_id"`
+		// Revoke revokes the exemption instead of granting it.
+		Revoke bool `json:"revoke" form:"revoke"`
+	}
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	id, err := peer.Decode(request.PeerID)
+	if err != nil {
+		return api.InvalidRequestError(err)
+	}
+	h.PeersIndex.Allow(id, !request.Revoke)
+	return api.Render(w, r, h.reputation(id))
+}
+
 func (h *Node) Topics(w http.ResponseWriter, r *http.Request) error {
 	peers, byTopic := h.TopicIndex.PeersByTopic()
 
@@ -172,6 +271,7 @@ func (h *Node) peers(peers []peer.ID) []peerJSON {
 			ID:            id,
 			Connectedness: h.Network.Connectedness(id).String(),
 			Subnets:       h.PeersIndex.GetPeerSubnets(id).String(),
+			Reputation:    h.reputation(id),
 		}
 
 		for _, addr := range h.Network.Peerstore().Addrs(id) {
@@ -194,3 +294,22 @@ func (h *Node) peers(peers []peer.ID) []peerJSON {
 	}
 	return resp
 }
+
+func (h *Node) reputation(id peer.ID) reputationJSON {
+	reputation := h.PeersIndex.Reputation(id)
+	resp := reputationJSON{
+		Score:   reputation.Score,
+		Banned:  reputation.Banned(time.Now()),
+		Allowed: reputation.Allowed,
+	}
+	if len(reputation.Events) > 0 {
+		resp.Events = make(map[string]uint64, len(reputation.Events))
+		for event, count := range reputation.Events {
+			resp.Events[event.String()] = count
+		}
+	}
+	if !reputation.BannedUntil.IsZero() {
+		resp.BannedUntil = &reputation.BannedUntil
+	}
+	return resp
+}
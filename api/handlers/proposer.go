@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/bloxapp/ssv/api"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+type Proposer struct {
+	// Config is nil when no proposer config file is configured.
+	Config *beaconprotocol.ProposerConfigManager
+
+	// ReloadConfig reloads the proposer config from its configured file.
+	ReloadConfig func() error
+}
+
+func (h *Proposer) GetConfig(w http.ResponseWriter, r *http.Request) error {
+	if h.Config == nil {
+		return api.Error(errors.New("no proposer config file is configured"))
+	}
+	return api.Render(w, r, h.Config.ProposerConfig())
+}
+
+func (h *Proposer) Reload(w http.ResponseWriter, r *http.Request) error {
+	if err := h.ReloadConfig(); err != nil {
+		return api.Error(err)
+	}
+	return h.GetConfig(w, r)
+}
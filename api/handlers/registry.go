@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"bytes"
+	"math/big"
+	"net/http"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/api"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// RegistryStorage is the storage of the operators and clusters which the node builds from the contract's events.
+type RegistryStorage interface {
+	registrystorage.Operators
+	Clusters() registrystorage.Clusters
+	GetLastProcessedBlock(r basedb.Reader) (*big.Int, bool, error)
+}
+
+type Registry struct {
+	Storage RegistryStorage
+	// OperatorID returns the node's operator ID, which is zero until the operator is registered.
+	OperatorID func() spectypes.OperatorID
+	// BlockTime is the time between blocks, used to convert runways from blocks to days.
+	BlockTime time.Duration
+}
+
+// Operators lists the operators with their fees and whitelisted addresses.
+func (h *Registry) Operators(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		IDs api.Uint64Slice `json:"ids" form:"ids"`
+	}
+	var response struct {
+		Data []*operatorJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	operators, err := h.Storage.ListOperators(nil, 0, 0)
+	if err != nil {
+		return api.Error(err)
+	}
+	response.Data = []*operatorJSON{}
+	for _, od := range operators {
+		if len(request.IDs) > 0 && !containsID(request.IDs, od.ID) {
+			continue
+		}
+		response.Data = append(response.Data, &operatorJSON{
+			ID:                 od.ID,
+			PublicKey:          string(od.PublicKey),
+			Owner:              api.Hex(od.OwnerAddress[:]),
+			Fee:                od.Fee,
+			DeclaredFee:        od.DeclaredFee,
+			WhitelistedAddress: api.Hex(od.WhitelistedAddress[:]),
+		})
+	}
+	return api.Render(w, r, response)
+}
+
+// Clusters lists the clusters with their balances, and estimates how close they are to liquidation
+// as of the node's last processed block. With own, only the clusters of the node's operator are listed.
+func (h *Registry) Clusters(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		Owners    api.HexSlice    `json:"owners" form:"owners"`
+		Operators api.Uint64Slice `json:"operators" form:"operators"`
+		Own       bool            `json:"own" form:"own"`
+	}
+	var response struct {
+		Data []*clusterJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	lastProcessedBlock, found, err := h.Storage.GetLastProcessedBlock(nil)
+	if err != nil {
+		return api.Error(err)
+	}
+	if !found {
+		lastProcessedBlock = new(big.Int)
+	}
+	networkFees, err := h.Storage.Clusters().GetNetworkFees(nil)
+	if err != nil {
+		return api.Error(err)
+	}
+	clusters, err := h.Storage.Clusters().ListClusters(nil)
+	if err != nil {
+		return api.Error(err)
+	}
+
+	response.Data = []*clusterJSON{}
+	for _, cluster := range clusters {
+		if len(request.Owners) > 0 && !containsOwner(request.Owners, cluster) {
+			continue
+		}
+		if len(request.Operators) > 0 && !containsAnyID(request.Operators, cluster.OperatorIDs) {
+			continue
+		}
+		if request.Own && (h.OperatorID == nil || !containsID(cluster.OperatorIDs, h.OperatorID())) {
+			continue
+		}
+
+		burnRate, err := registrystorage.ClusterBurnRate(nil, h.Storage, cluster, networkFees)
+		if err != nil {
+			return api.Error(err)
+		}
+		c := &clusterJSON{
+			Owner:                 api.Hex(cluster.Owner[:]),
+			Operators:             cluster.OperatorIDs,
+			ValidatorCount:        cluster.ValidatorCount,
+			Active:                cluster.Active,
+			Balance:               cluster.Balance,
+			UpdatedBlock:          cluster.UpdatedBlock,
+			Block:                 lastProcessedBlock.Uint64(),
+			EstimatedBalance:      cluster.BalanceAt(lastProcessedBlock.Uint64(), burnRate),
+			BurnRate:              burnRate,
+			LiquidationCollateral: networkFees.LiquidationCollateral(burnRate),
+		}
+		if blocks, ok := cluster.BlocksToLiquidation(lastProcessedBlock.Uint64(), burnRate, networkFees); ok && cluster.Active {
+			runwayDays := registrystorage.RunwayDays(blocks, h.BlockTime)
+			c.BlocksToLiquidation = &blocks
+			c.RunwayDays = &runwayDays
+		}
+		response.Data = append(response.Data, c)
+	}
+	return api.Render(w, r, response)
+}
+
+// NetworkFees returns the network fee and the liquidation parameters of the contract.
+func (h *Registry) NetworkFees(w http.ResponseWriter, r *http.Request) error {
+	var response struct {
+		Data *registrystorage.NetworkFees `json:"data"`
+	}
+
+	networkFees, err := h.Storage.Clusters().GetNetworkFees(nil)
+	if err != nil {
+		return api.Error(err)
+	}
+	response.Data = networkFees
+	return api.Render(w, r, response)
+}
+
+func containsID(ids []uint64, id uint64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAnyID(ids []uint64, of []uint64) bool {
+	for _, id := range of {
+		if containsID(ids, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsOwner(owners []api.Hex, cluster *registrystorage.Cluster) bool {
+	for _, owner := range owners {
+		if bytes.Equal(owner, cluster.Owner[:]) {
+			return true
+		}
+	}
+	return false
+}
+
+type operatorJSON struct {
+	ID                 uint64   `json:"id"`
+	PublicKey          string   `json:"public_key"`
+	Owner              api.Hex  `json:"owner"`
+	Fee                *big.Int `json:"fee"`
+	DeclaredFee        *big.Int `json:"declared_fee,omitempty"`
+	WhitelistedAddress api.Hex  `json:"whitelisted_address"`
+}
+
+type clusterJSON struct {
+	Owner          api.Hex  `json:"owner"`
+	Operators      []uint64 `json:"operators"`
+	ValidatorCount uint32   `json:"validator_count"`
+	Active         bool     `json:"active"`
+	Balance        *big.Int `json:"balance"`
+	UpdatedBlock   uint64   `json:"updated_block"`
+
+	// Block is the block as of which the estimates are made.
+	Block                 uint64   `json:"block"`
+	EstimatedBalance      *big.Int `json:"estimated_balance"`
+	BurnRate              *big.Int `json:"burn_rate"`
+	LiquidationCollateral *big.Int `json:"liquidation_collateral"`
+	// BlocksToLiquidation is omitted for clusters which are liquidated or don't pay fees.
+	BlocksToLiquidation *uint64  `json:"blocks_to_liquidation,omitempty"`
+	RunwayDays          *float64 `json:"runway_days,omitempty"`
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/bloxapp/ssv/api"
+	"github.com/bloxapp/ssv/message/validation"
+)
+
+type Validation struct {
+	Policy validation.PolicyManager
+
+	// ReloadPolicy reloads the validation policy from its configured source.
+	ReloadPolicy func() error
+}
+
+func (h *Validation) GetPolicy(w http.ResponseWriter, r *http.Request) error {
+	return api.Render(w, r, h.Policy.Policy())
+}
+
+func (h *Validation) Reload(w http.ResponseWriter, r *http.Request) error {
+	if err := h.ReloadPolicy(); err != nil {
+		return api.Error(err)
+	}
+	return api.Render(w, r, h.Policy.Policy())
+}
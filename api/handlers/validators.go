@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
@@ -14,8 +15,16 @@ import (
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
 )
 
+// ExitRequester schedules the exit of a validator upon its owner's request.
+type ExitRequester interface {
+	RequestExit(request *types.ExitRequest) error
+	VerifyExitClaim(claim *types.ExitClaim) error
+}
+
 type Validators struct {
-	Shares registrystorage.Shares
+	Shares      registrystorage.Shares
+	Exits       ExitRequester
+	SignedExits registrystorage.SignedExits
 }
 
 func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
@@ -63,6 +72,101 @@ func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
 	return api.Render(w, r, response)
 }
 
+// Exit accepts an exit request signed by the validator's owner,
+// and has the validator's committee exit it without an on-chain transaction.
+func (h *Validators) Exit(w http.ResponseWriter, r *http.Request) error {
+	var request types.ExitRequest
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if err := h.Exits.RequestExit(&request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	return api.Render(w, r, &request)
+}
+
+// ListExits lists the voluntary exits signed by the validators' committees,
+// including the pre-signed exits which were returned to their owners rather than submitted.
+// Only the exits' metadata is listed, since a signed exit lets anyone exit the validator.
+func (h *Validators) ListExits(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PubKeys api.HexSlice `json:"pubkeys" form:"pubkeys"`
+	}
+	var response struct {
+		Data []*signedExitJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	var signedExits []*registrystorage.SignedExit
+	if len(request.PubKeys) == 0 {
+		all, err := h.SignedExits.ListSignedExits(nil, nil)
+		if err != nil {
+			return api.Error(err)
+		}
+		signedExits = all
+	}
+	for _, pubKey := range request.PubKeys {
+		validatorExits, err := h.SignedExits.ListSignedExits(nil, pubKey)
+		if err != nil {
+			return api.Error(err)
+		}
+		signedExits = append(signedExits, validatorExits...)
+	}
+	response.Data = make([]*signedExitJSON, len(signedExits))
+	for i, signedExit := range signedExits {
+		response.Data[i] = signedExitFromStorage(signedExit)
+	}
+	return api.Render(w, r, response)
+}
+
+// ClaimExits returns the voluntary exits signed for a validator, including their signatures,
+// to the validator's owner, who presents an exit claim signed for this operator.
+func (h *Validators) ClaimExits(w http.ResponseWriter, r *http.Request) error {
+	var request types.ExitClaim
+	var response struct {
+		Data []*registrystorage.SignedExit `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+	if err := h.Exits.VerifyExitClaim(&request); err != nil {
+		return api.InvalidRequestError(err)
+	}
+
+	signedExits, err := h.SignedExits.ListSignedExits(nil, request.ValidatorPubKey)
+	if err != nil {
+		return api.Error(err)
+	}
+	response.Data = signedExits
+	return api.Render(w, r, response)
+}
+
+// signedExitJSON is the metadata of a signed exit, without its signature.
+type signedExitJSON struct {
+	ValidatorPubKey api.Hex               `json:"validator_pubkey"`
+	ValidatorIndex  phase0.ValidatorIndex `json:"validator_index"`
+	Epoch           phase0.Epoch          `json:"epoch"`
+	PreSigned       bool                  `json:"pre_signed"`
+	SignedAt        time.Time             `json:"signed_at"`
+}
+
+func signedExitFromStorage(signedExit *registrystorage.SignedExit) *signedExitJSON {
+	v := &signedExitJSON{
+		ValidatorPubKey: api.Hex(signedExit.ValidatorPubKey),
+		PreSigned:       signedExit.PreSigned,
+		SignedAt:        signedExit.SignedAt,
+	}
+	if signedExit.Exit != nil && signedExit.Exit.Message != nil {
+		v.ValidatorIndex = signedExit.Exit.Message.ValidatorIndex
+		v.Epoch = signedExit.Exit.Message.Epoch
+	}
+	return v
+}
+
 func byOwners(owners []api.Hex) registrystorage.SharesFilter {
 	return func(share *types.SSVShare) bool {
 		for _, a := range owners {
@@ -1,10 +1,22 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/api"
 	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
 )
 
 func mockShare(operatorIDs ...uint64) *types.SSVShare {
@@ -92,3 +104,92 @@ func TestByClusters(t *testing.T) {
 		})
 	}
 }
+
+type mockExits struct {
+	owner []byte
+}
+
+func (m *mockExits) RequestExit(request *types.ExitRequest) error {
+	return nil
+}
+
+func (m *mockExits) VerifyExitClaim(claim *types.ExitClaim) error {
+	if !bytes.Equal(claim.Claimant[:], m.owner) {
+		return errors.New("not signed by the owner")
+	}
+	return nil
+}
+
+type mockSignedExits []*registrystorage.SignedExit
+
+func (m mockSignedExits) SaveSignedExit(rw basedb.ReadWriter, signedExit *registrystorage.SignedExit) error {
+	return nil
+}
+
+func (m mockSignedExits) ListSignedExits(r basedb.Reader, pubKey []byte) ([]*registrystorage.SignedExit, error) {
+	return m, nil
+}
+
+func TestExits(t *testing.T) {
+	signedExit := &registrystorage.SignedExit{
+		ValidatorPubKey: make([]byte, 48),
+		Exit: &phase0.SignedVoluntaryExit{
+			Message:   &phase0.VoluntaryExit{Epoch: 100, ValidatorIndex: 123},
+			Signature: phase0.BLSSignature{1},
+		},
+		PreSigned: true,
+		SignedAt:  time.Now(),
+	}
+	owner := bytes.Repeat([]byte{1}, 20)
+	h := &Validators{
+		Exits:       &mockExits{owner: owner},
+		SignedExits: mockSignedExits{signedExit},
+	}
+
+	t.Run("list without signatures", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		api.Handler(h.ListExits)(w, httptest.NewRequest(http.MethodGet, "/v1/validators/exits", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NotContains(t, w.Body.String(), "signature")
+
+		var response struct {
+			Data []*signedExitJSON `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		require.EqualValues(t, 100, response.Data[0].Epoch)
+		require.EqualValues(t, 123, response.Data[0].ValidatorIndex)
+		require.True(t, response.Data[0].PreSigned)
+	})
+
+	claim := func(owner []byte) *httptest.ResponseRecorder {
+		request := &types.ExitClaim{ValidatorPubKey: signedExit.ValidatorPubKey}
+		copy(request.Claimant[:], owner)
+		data, err := json.Marshal(request)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/v1/validators/exits/claim", bytes.NewReader(data))
+		r.Header.Set("Content-Type", "application/json")
+		api.Handler(h.ClaimExits)(w, r)
+		return w
+	}
+
+	t.Run("claim by owner", func(t *testing.T) {
+		w := claim(owner)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []*registrystorage.SignedExit `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		require.Equal(t, signedExit.Exit.Signature, response.Data[0].Exit.Signature)
+	})
+
+	t.Run("claim by others", func(t *testing.T) {
+		w := claim(bytes.Repeat([]byte{2}, 20))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.NotContains(t, w.Body.String(), "signature")
+	})
+}
@@ -13,45 +13,88 @@ import (
 	"github.com/bloxapp/ssv/api/handlers"
 )
 
+// Server serves the SSV API, which is read-only except for submitting signed requests,
+// and the admin API, whose routes change the node's state and which should only be reachable by the node's operator.
 type Server struct {
-	logger *zap.Logger
-	addr   string
+	logger    *zap.Logger
+	addr      string
+	adminAddr string
 
 	node       *handlers.Node
 	validators *handlers.Validators
+	validation *handlers.Validation
+	proposer   *handlers.Proposer
+	registry   *handlers.Registry
 }
 
 func New(
 	logger *zap.Logger,
 	addr string,
+	adminAddr string,
 	node *handlers.Node,
 	validators *handlers.Validators,
+	validation *handlers.Validation,
+	proposer *handlers.Proposer,
+	registry *handlers.Registry,
 ) *Server {
 	return &Server{
 		logger:     logger,
 		addr:       addr,
+		adminAddr:  adminAddr,
 		node:       node,
 		validators: validators,
+		validation: validation,
+		proposer:   proposer,
+		registry:   registry,
 	}
 }
 
 func (s *Server) Run() error {
-	router := chi.NewRouter()
-	router.Use(middleware.Recoverer)
-	router.Use(middleware.Throttle(runtime.NumCPU() * 4))
-	router.Use(middleware.Compress(5, "application/json"))
-	router.Use(middlewareLogger(s.logger))
-
+	router := s.newRouter()
 	router.Get("/v1/node/identity", api.Handler(s.node.Identity))
 	router.Get("/v1/node/peers", api.Handler(s.node.Peers))
+	router.Get("/v1/node/peers/bans", api.Handler(s.node.Bans))
 	router.Get("/v1/node/topics", api.Handler(s.node.Topics))
 	router.Get("/v1/node/health", api.Handler(s.node.Health))
+	router.Get("/v1/node/validation/policy", api.Handler(s.validation.GetPolicy))
+	router.Get("/v1/node/proposer/config", api.Handler(s.proposer.GetConfig))
 	router.Get("/v1/validators", api.Handler(s.validators.List))
+	router.Post("/v1/validators/exit", api.Handler(s.validators.Exit))
+	router.Get("/v1/validators/exits", api.Handler(s.validators.ListExits))
+	router.Post("/v1/validators/exits/claim", api.Handler(s.validators.ClaimExits))
+	router.Get("/v1/operators", api.Handler(s.registry.Operators))
+	router.Get("/v1/clusters", api.Handler(s.registry.Clusters))
+	router.Get("/v1/network/fees", api.Handler(s.registry.NetworkFees))
 
 	s.logger.Info("Serving SSV API", zap.String("addr", s.addr))
+	return serve(s.addr, router)
+}
+
+// RunAdmin serves the admin API on the admin address, which should be bound to localhost.
+func (s *Server) RunAdmin() error {
+	router := s.newRouter()
+	router.Post("/v1/node/peers/ban", api.Handler(s.node.Ban))
+	router.Post("/v1/node/peers/unban", api.Handler(s.node.Unban))
+	router.Post("/v1/node/peers/allow", api.Handler(s.node.Allow))
+	router.Post("/v1/node/validation/policy/reload", api.Handler(s.validation.Reload))
+	router.Post("/v1/node/proposer/config/reload", api.Handler(s.proposer.Reload))
+
+	s.logger.Info("Serving SSV admin API", zap.String("addr", s.adminAddr))
+	return serve(s.adminAddr, router)
+}
+
+func (s *Server) newRouter() *chi.Mux {
+	router := chi.NewRouter()
+	router.Use(middleware.Recoverer)
+	router.Use(middleware.Throttle(runtime.NumCPU() * 4))
+	router.Use(middleware.Compress(5, "application/json"))
+	router.Use(middlewareLogger(s.logger))
+	return router
+}
 
+func serve(addr string, router http.Handler) error {
 	server := &http.Server{
-		Addr:         s.addr,
+		Addr:         addr,
 		Handler:      router,
 		ReadTimeout:  12 * time.Second,
 		WriteTimeout: 12 * time.Second,
@@ -0,0 +1,293 @@
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	currentFileName   = "signatures.log"
+	rotatedFilePrefix = "signatures-"
+	fileSuffix        = ".log"
+)
+
+// Config configures the audit log of signatures.
+type Config struct {
+	// Dir is the directory of the log's files, the log is disabled if empty.
+	Dir           string `yaml:"Dir" env:"SIGNING_AUDIT_LOG_DIR" env-description:"Directory of the append-only audit log of the signatures produced by the node, disabled if empty"`
+	MaxFileSizeMB int    `yaml:"MaxFileSizeMB" env:"SIGNING_AUDIT_LOG_MAX_FILE_SIZE_MB" env-default:"100" env-description:"Size at which the audit log's file is rotated"`
+	MaxFiles      int    `yaml:"MaxFiles" env:"SIGNING_AUDIT_LOG_MAX_FILES" env-description:"Number of rotated files of the audit log to keep, or all of them if zero"`
+	// StreamURL receives the records as they're appended, in batches of newline-delimited JSON.
+	StreamURL     string            `yaml:"StreamURL" env:"SIGNING_AUDIT_LOG_STREAM_URL" env-description:"URL to stream the records of the audit log to, as batches of newline-delimited JSON"`
+	StreamHeaders map[string]string `yaml:"StreamHeaders"`
+}
+
+// Enabled returns whether the audit log is configured.
+func (c Config) Enabled() bool {
+	return c.Dir != ""
+}
+
+// Log is an append-only, hash-chained log of the signatures produced by the node.
+// Records are appended to a file, which is rotated once it reaches the configured size.
+// Rotated files are named after the sequence number of their first record, so that
+// they sort in the order of the chain.
+type Log struct {
+	logger *zap.Logger
+	config Config
+	stream *stream
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	firstSeq uint64
+	seq      uint64
+	lastHash string
+	closed   bool
+}
+
+// Open opens the log in the configured directory, continuing the chain of its last record.
+// A partially written record at the end of the log, such as after a crash, is discarded.
+func Open(logger *zap.Logger, config Config) (*Log, error) {
+	if err := os.MkdirAll(config.Dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "could not create audit log directory")
+	}
+
+	l := &Log{
+		logger: logger,
+		config: config,
+	}
+
+	path := filepath.Join(config.Dir, currentFileName)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open audit log")
+	}
+	first, last, size, err := scanFile(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, errors.Wrapf(err, "could not read %s", path)
+	}
+	if info, err := file.Stat(); err == nil && info.Size() > size {
+		logger.Warn("discarding partially written record at the end of the audit log",
+			zap.Int64("bytes", info.Size()-size))
+		if err := file.Truncate(size); err != nil {
+			_ = file.Close()
+			return nil, errors.Wrap(err, "could not truncate audit log")
+		}
+	}
+	if _, err := file.Seek(size, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, errors.Wrap(err, "could not seek audit log")
+	}
+	l.file = file
+	l.size = size
+
+	if last == nil {
+		// The current file is empty, so the chain continues from the last rotated file.
+		rotated, err := rotatedFiles(config.Dir)
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		if len(rotated) > 0 {
+			path := rotated[len(rotated)-1]
+			_, last, _, err = scanPath(path)
+			if err != nil {
+				_ = file.Close()
+				return nil, errors.Wrapf(err, "could not read %s", path)
+			}
+		}
+	}
+	if first != nil {
+		l.firstSeq = first.Seq
+	}
+	if last != nil {
+		l.seq = last.Seq
+		l.lastHash = last.Hash
+	}
+
+	if config.StreamURL != "" {
+		l.stream = newStream(logger, config.StreamURL, config.StreamHeaders)
+		go l.stream.run()
+	}
+	return l, nil
+}
+
+// Append chains the record to the log, assigning its sequence number and hashes.
+func (l *Log) Append(record *Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return errors.New("audit log is closed")
+	}
+
+	record.Seq = l.seq + 1
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	record.Time = record.Time.UTC()
+	record.PrevHash = l.lastHash
+	hash, err := record.computeHash()
+	if err != nil {
+		return err
+	}
+	record.Hash = hash
+	line, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "could not encode record")
+	}
+	line = append(line, '\n')
+
+	maxSize := int64(l.config.MaxFileSizeMB) << 20
+	if maxSize > 0 && l.size > 0 && l.size+int64(len(line)) > maxSize {
+		if err := l.rotate(); err != nil {
+			return errors.Wrap(err, "could not rotate audit log")
+		}
+	}
+	if _, err := l.file.Write(line); err != nil {
+		return errors.Wrap(err, "could not write to audit log")
+	}
+	if l.size == 0 {
+		l.firstSeq = record.Seq
+	}
+	l.size += int64(len(line))
+	l.seq = record.Seq
+	l.lastHash = record.Hash
+
+	if l.stream != nil {
+		recordCopy := *record
+		l.stream.send(&recordCopy)
+	}
+	return nil
+}
+
+// rotate renames the current file after its first record and starts a new one,
+// removing the oldest rotated files beyond the configured number.
+func (l *Log) rotate() error {
+	if err := l.file.Sync(); err != nil {
+		return err
+	}
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	current := filepath.Join(l.config.Dir, currentFileName)
+	if err := os.Rename(current, filepath.Join(l.config.Dir, rotatedFileName(l.firstSeq))); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(current, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	l.file = file
+	l.size = 0
+
+	if l.config.MaxFiles <= 0 {
+		return nil
+	}
+	rotated, err := rotatedFiles(l.config.Dir)
+	if err != nil {
+		return err
+	}
+	for len(rotated) > l.config.MaxFiles {
+		if err := os.Remove(rotated[0]); err != nil {
+			return err
+		}
+		l.logger.Info("removed rotated audit log file", zap.String("file", rotated[0]))
+		rotated = rotated[1:]
+	}
+	return nil
+}
+
+// Close flushes the log to disk and stops streaming it.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	if l.stream != nil {
+		l.stream.close()
+	}
+	if err := l.file.Sync(); err != nil {
+		_ = l.file.Close()
+		return err
+	}
+	return l.file.Close()
+}
+
+func rotatedFileName(firstSeq uint64) string {
+	return fmt.Sprintf("%s%020d%s", rotatedFilePrefix, firstSeq, fileSuffix)
+}
+
+// rotatedFiles returns the paths of the rotated files in the directory, in the order of the chain.
+func rotatedFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list audit log files")
+	}
+	var paths []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, rotatedFilePrefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func scanPath(path string) (first, last *Record, size int64, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer file.Close()
+	return scanFile(file)
+}
+
+// scanFile returns the first and last records of the file, and the size of its complete lines.
+func scanFile(file *os.File) (first, last *Record, size int64, err error) {
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// A line without a newline wasn't completely written.
+			return first, last, size, nil
+		}
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		var record Record
+		if err := json.Unmarshal(bytes.TrimSpace(line), &record); err != nil {
+			return nil, nil, 0, errors.Wrapf(err, "could not decode record after seq %d", seqOf(last))
+		}
+		if first == nil {
+			first = &record
+		}
+		last = &record
+		size += int64(len(line))
+	}
+}
+
+func seqOf(record *Record) uint64 {
+	if record == nil {
+		return 0
+	}
+	return record.Seq
+}
@@ -0,0 +1,276 @@
+package auditlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/operator/keys"
+)
+
+func appendRecords(t *testing.T, log *Log, count int) {
+	for i := 0; i < count; i++ {
+		require.NoError(t, log.Append(&Record{
+			Signer:      SignerValidator,
+			PubKey:      "a8cb269bd7741740cfe90de2f8db6ea35a9da443385155da0fa2f621ba80e5ac14b5c8f65d23fd9ccc170cc85f29e27d",
+			DomainType:  "01000000",
+			ObjectType:  "phase0.AttestationData",
+			SigningRoot: strings.Repeat("ab", 32),
+		}))
+	}
+}
+
+func readLines(t *testing.T, path string) []string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestLog(t *testing.T) {
+	logger := logging.TestLogger(t)
+	dir := t.TempDir()
+
+	log, err := Open(logger, Config{Dir: dir})
+	require.NoError(t, err)
+	appendRecords(t, log, 3)
+	require.NoError(t, log.Close())
+
+	// The chain continues after reopening the log.
+	log, err = Open(logger, Config{Dir: dir})
+	require.NoError(t, err)
+	appendRecords(t, log, 2)
+	require.NoError(t, log.Close())
+
+	lines := readLines(t, filepath.Join(dir, currentFileName))
+	require.Len(t, lines, 5)
+	var first, fourth Record
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[3]), &fourth))
+	require.Equal(t, uint64(1), first.Seq)
+	require.Empty(t, first.PrevHash)
+	require.Equal(t, uint64(4), fourth.Seq)
+
+	result, err := Verify(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), result.Records)
+	require.Equal(t, uint64(1), result.FirstSeq)
+	require.Equal(t, uint64(5), result.LastSeq)
+	require.False(t, result.Pruned)
+}
+
+func TestLogRotation(t *testing.T) {
+	logger := logging.TestLogger(t)
+	dir := t.TempDir()
+
+	// Records are ~400 bytes, so a file of 1MB is rotated every ~2600 records.
+	log, err := Open(logger, Config{Dir: dir, MaxFileSizeMB: 1})
+	require.NoError(t, err)
+	appendRecords(t, log, 6000)
+	require.NoError(t, log.Close())
+
+	rotated, err := rotatedFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, rotated, 2)
+	require.Equal(t, rotatedFileName(1), filepath.Base(rotated[0]))
+
+	result, err := Verify(dir)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Files)
+	require.Equal(t, uint64(6000), result.Records)
+	require.False(t, result.Pruned)
+
+	// The chain continues from the last rotated file when the current one is empty.
+	require.NoError(t, os.Remove(filepath.Join(dir, currentFileName)))
+	log, err = Open(logger, Config{Dir: dir, MaxFileSizeMB: 1, MaxFiles: 1})
+	require.NoError(t, err)
+	lastRotated := readLines(t, rotated[1])
+	var last Record
+	require.NoError(t, json.Unmarshal([]byte(lastRotated[len(lastRotated)-1]), &last))
+	appendRecords(t, log, 6000)
+	require.NoError(t, log.Close())
+
+	// Rotation removed the oldest files beyond MaxFiles.
+	rotated, err = rotatedFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, rotated, 1)
+
+	result, err = Verify(dir)
+	require.NoError(t, err)
+	require.True(t, result.Pruned)
+	require.Equal(t, last.Seq+6000, result.LastSeq)
+}
+
+func TestVerifyTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(lines []string) []string
+		err    string
+	}{
+		{
+			name: "modified record",
+			tamper: func(lines []string) []string {
+				lines[2] = strings.Replace(lines[2], `"signing_root":"ab`, `"signing_root":"cd`, 1)
+				return lines
+			},
+			err: "record 3 was modified",
+		},
+		{
+			name: "removed record",
+			tamper: func(lines []string) []string {
+				return append(lines[:2], lines[3:]...)
+			},
+			err: "record 4 follows record 2",
+		},
+		{
+			name: "reordered records",
+			tamper: func(lines []string) []string {
+				lines[1], lines[2] = lines[2], lines[1]
+				return lines
+			},
+			err: "record 3 follows record 1",
+		},
+		{
+			name: "rechained record",
+			tamper: func(lines []string) []string {
+				var record Record
+				if err := json.Unmarshal([]byte(lines[2]), &record); err != nil {
+					panic(err)
+				}
+				record.PrevHash = strings.Repeat("00", 32)
+				record.Hash, _ = record.computeHash()
+				data, _ := json.Marshal(&record)
+				lines[2] = string(data)
+				return lines
+			},
+			err: "record 3 doesn't chain to record 2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			log, err := Open(logging.TestLogger(t), Config{Dir: dir})
+			require.NoError(t, err)
+			appendRecords(t, log, 5)
+			require.NoError(t, log.Close())
+
+			path := filepath.Join(dir, currentFileName)
+			lines := test.tamper(readLines(t, path))
+			require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+
+			_, err = Verify(dir)
+			var verifyErr *VerifyError
+			require.True(t, errors.As(err, &verifyErr))
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestLogPartialRecord(t *testing.T) {
+	logger := logging.TestLogger(t)
+	dir := t.TempDir()
+
+	log, err := Open(logger, Config{Dir: dir})
+	require.NoError(t, err)
+	appendRecords(t, log, 2)
+	require.NoError(t, log.Close())
+
+	// A record interrupted by a crash is discarded when the log is reopened.
+	path := filepath.Join(dir, currentFileName)
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"seq":3,"time":`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	result, err := Verify(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), result.Records)
+
+	log, err = Open(logger, Config{Dir: dir})
+	require.NoError(t, err)
+	appendRecords(t, log, 1)
+	require.NoError(t, log.Close())
+
+	result, err = Verify(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), result.Records)
+}
+
+func TestLogStream(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		records []*Record
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		require.Equal(t, "secret", r.Header.Get("Authorization"))
+		scanner := bufio.NewScanner(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		for scanner.Scan() {
+			var record Record
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+			records = append(records, &record)
+		}
+	}))
+	defer server.Close()
+
+	log, err := Open(logging.TestLogger(t), Config{
+		Dir:           t.TempDir(),
+		StreamURL:     server.URL,
+		StreamHeaders: map[string]string{"Authorization": "secret"},
+	})
+	require.NoError(t, err)
+	appendRecords(t, log, 3)
+
+	// Closing the log sends the queued records.
+	require.NoError(t, log.Close())
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, records, 3)
+	for i, record := range records {
+		require.Equal(t, uint64(i+1), record.Seq)
+		hash, err := record.computeHash()
+		require.NoError(t, err)
+		require.Equal(t, record.Hash, hash)
+	}
+}
+
+func TestOperatorKey(t *testing.T) {
+	dir := t.TempDir()
+	log, err := Open(logging.TestLogger(t), Config{Dir: dir})
+	require.NoError(t, err)
+
+	privateKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	key, err := OperatorKey(privateKey, log)
+	require.NoError(t, err)
+
+	data := []byte("message")
+	signature, err := key.Sign(data)
+	require.NoError(t, err)
+	require.NoError(t, key.Public().Verify(data, signature))
+
+	// Signatures aren't released once the log can't record them.
+	require.NoError(t, log.Close())
+	_, err = key.Sign(data)
+	require.ErrorContains(t, err, "could not record signature in audit log")
+
+	lines := readLines(t, filepath.Join(dir, currentFileName))
+	require.Len(t, lines, 1)
+	var record Record
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	require.Equal(t, SignerOperator, record.Signer)
+	require.Equal(t, "bytes", record.ObjectType)
+	require.Equal(t, "ab530a13e45914982b79f9b7e3fba994cfd1f3fb22f71cea1afbf02b460c6d1d", record.SigningRoot)
+}
@@ -0,0 +1,50 @@
+package auditlog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/operator/keys"
+	"github.com/bloxapp/ssv/utils/format"
+)
+
+type operatorKey struct {
+	keys.OperatorPrivateKey
+	log     *Log
+	keyHash string
+}
+
+// OperatorKey returns the key, recording its signatures in the log.
+// A signature is only returned once it's recorded.
+func OperatorKey(key keys.OperatorPrivateKey, log *Log) (keys.OperatorPrivateKey, error) {
+	publicKey, err := key.Public().Base64()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode operator public key")
+	}
+	return &operatorKey{
+		OperatorPrivateKey: key,
+		log:                log,
+		keyHash:            format.OperatorID(publicKey),
+	}, nil
+}
+
+func (k *operatorKey) Sign(data []byte) ([]byte, error) {
+	signature, err := k.OperatorPrivateKey.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+	// The RSA signature is over the SHA-256 of the data.
+	hash := sha256.Sum256(data)
+	err = k.log.Append(&Record{
+		Signer:      SignerOperator,
+		PubKey:      k.keyHash,
+		ObjectType:  "bytes",
+		SigningRoot: hex.EncodeToString(hash[:]),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not record signature in audit log")
+	}
+	return signature, nil
+}
@@ -0,0 +1,52 @@
+package auditlog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Signer is the kind of key a signature was produced with.
+type Signer string
+
+const (
+	// SignerValidator is a validator's share key, signing beacon objects and SSV messages.
+	SignerValidator Signer = "validator"
+	// SignerOperator is the operator's RSA key, signing network messages and handshakes.
+	SignerOperator Signer = "operator"
+)
+
+// Record is an entry of the audit log, describing a signature the node produced.
+// Each record commits to the previous one with PrevHash, chaining the log so that
+// modifying, removing or reordering records is detected by Verify.
+type Record struct {
+	Seq    uint64    `json:"seq"`
+	Time   time.Time `json:"time"`
+	Signer Signer    `json:"signer"`
+	// PubKey is the hex public key of the validator share, or the hash of the operator's public key.
+	PubKey string `json:"pubkey"`
+	// DomainType is the hex domain type of beacon objects, or the signature type of SSV messages.
+	DomainType  string  `json:"domain_type,omitempty"`
+	ObjectType  string  `json:"object_type"`
+	Slot        *uint64 `json:"slot,omitempty"`
+	Epoch       *uint64 `json:"epoch,omitempty"`
+	SigningRoot string  `json:"signing_root"`
+	DutyID      string  `json:"duty_id,omitempty"`
+	PrevHash    string  `json:"prev_hash"`
+	Hash        string  `json:"hash,omitempty"`
+}
+
+// computeHash returns the hash of the record's fields other than Hash.
+func (r *Record) computeHash() (string, error) {
+	unhashed := *r
+	unhashed.Hash = ""
+	data, err := json.Marshal(&unhashed)
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode record")
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,141 @@
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	streamBufferSize    = 10000
+	streamBatchSize     = 500
+	streamBatchInterval = time.Second
+	streamTimeout       = 10 * time.Second
+	streamMaxAttempts   = 3
+)
+
+// stream posts the records of the log to a URL as they're appended.
+// It's best-effort: records are dropped when the receiver can't keep up,
+// which it can detect by gaps in their sequence numbers, while the local log remains complete.
+type stream struct {
+	logger  *zap.Logger
+	url     string
+	headers map[string]string
+	client  *http.Client
+
+	records chan *Record
+	done    chan struct{}
+	dropped atomic.Uint64
+}
+
+func newStream(logger *zap.Logger, url string, headers map[string]string) *stream {
+	return &stream{
+		logger:  logger,
+		url:     url,
+		headers: headers,
+		client:  &http.Client{Timeout: streamTimeout},
+		records: make(chan *Record, streamBufferSize),
+		done:    make(chan struct{}),
+	}
+}
+
+// send queues the record without blocking the signer.
+func (s *stream) send(record *Record) {
+	select {
+	case s.records <- record:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// close sends the queued records and stops the stream.
+func (s *stream) close() {
+	close(s.records)
+	<-s.done
+}
+
+func (s *stream) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(streamBatchInterval)
+	defer ticker.Stop()
+
+	var batch []*Record
+	for {
+		select {
+		case record, ok := <-s.records:
+			if !ok {
+				s.post(batch)
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) < streamBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+		s.post(batch)
+		batch = nil
+
+		if dropped := s.dropped.Swap(0); dropped > 0 {
+			s.logger.Warn("dropped audit log records the stream couldn't keep up with", zap.Uint64("count", dropped))
+		}
+	}
+}
+
+func (s *stream) post(batch []*Record) {
+	if len(batch) == 0 {
+		return
+	}
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, record := range batch {
+		if err := encoder.Encode(record); err != nil {
+			s.logger.Error("could not encode audit log record", zap.Error(err))
+			return
+		}
+	}
+
+	var err error
+	for attempt := 1; attempt <= streamMaxAttempts; attempt++ {
+		if err = s.postBody(body.Bytes()); err == nil {
+			return
+		}
+		if attempt < streamMaxAttempts {
+			time.Sleep(time.Duration(attempt) * time.Second)
+		}
+	}
+	s.logger.Warn("could not stream audit log records",
+		zap.Uint64("first_seq", batch[0].Seq),
+		zap.Uint64("last_seq", batch[len(batch)-1].Seq),
+		zap.Error(err))
+}
+
+func (s *stream) postBody(body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// VerifyResult summarizes a verified log.
+type VerifyResult struct {
+	Files    int
+	Records  uint64
+	FirstSeq uint64
+	LastSeq  uint64
+	LastHash string
+	// Pruned is whether the log's oldest records were removed by rotation,
+	// in which case the chain is verified from its oldest remaining record.
+	Pruned bool
+}
+
+// VerifyError describes where the chain of a log is broken.
+type VerifyError struct {
+	File string
+	Line int
+	Err  error
+}
+
+func (e *VerifyError) Error() string {
+	return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+}
+
+func (e *VerifyError) Unwrap() error {
+	return e.Err
+}
+
+// Verify checks the chain of the log in the directory across its rotated files,
+// returning a VerifyError at the first record that was modified, removed or reordered.
+func Verify(dir string) (*VerifyResult, error) {
+	paths, err := rotatedFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	current := filepath.Join(dir, currentFileName)
+	if _, err := os.Stat(current); err == nil {
+		paths = append(paths, current)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	result := &VerifyResult{}
+	var prev *Record
+	for _, path := range paths {
+		result.Files++
+		if err := verifyFile(path, result, &prev); err != nil {
+			return result, err
+		}
+	}
+	if prev != nil {
+		result.LastSeq = prev.Seq
+		result.LastHash = prev.Hash
+	}
+	return result, nil
+}
+
+func verifyFile(path string, result *VerifyResult, prev **Record) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	line := 0
+	for {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// A line without a newline is a record still being written by the node.
+			return nil
+		}
+		line++
+		fail := func(err error) error {
+			return &VerifyError{File: path, Line: line, Err: err}
+		}
+		if err != nil {
+			return fail(err)
+		}
+
+		var record Record
+		if err := json.Unmarshal(bytes.TrimSpace(data), &record); err != nil {
+			return fail(errors.Wrap(err, "could not decode record"))
+		}
+		hash, err := record.computeHash()
+		if err != nil {
+			return fail(err)
+		}
+		if hash != record.Hash {
+			return fail(errors.Errorf("record %d was modified, its hash is %s instead of %s", record.Seq, hash, record.Hash))
+		}
+
+		if *prev == nil {
+			// The chain begins at the first record, unless older files were pruned.
+			result.FirstSeq = record.Seq
+			if record.Seq != 1 || record.PrevHash != "" {
+				result.Pruned = true
+			}
+		} else {
+			if record.Seq != (*prev).Seq+1 {
+				return fail(errors.Errorf("record %d follows record %d", record.Seq, (*prev).Seq))
+			}
+			if record.PrevHash != (*prev).Hash {
+				return fail(errors.Errorf("record %d doesn't chain to record %d", record.Seq, (*prev).Seq))
+			}
+		}
+		result.Records++
+		*prev = &record
+	}
+}
@@ -0,0 +1,176 @@
+package builder
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+)
+
+// maxBlobCommitmentsPerBlock is the maximum number of blob KZG commitments of a Deneb builder bid.
+const maxBlobCommitmentsPerBlock = 4096
+
+// Domain returns the domain of builder bids, which depends only on the network's genesis fork version.
+func Domain(genesisForkVersion phase0.Version) (phase0.Domain, error) {
+	forkData := phase0.ForkData{
+		CurrentVersion:        genesisForkVersion,
+		GenesisValidatorsRoot: phase0.Root{},
+	}
+	root, err := forkData.HashTreeRoot()
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "could not hash fork data")
+	}
+	var domain phase0.Domain
+	copy(domain[:], spectypes.DomainApplicationBuilder[:])
+	copy(domain[4:], root[:])
+	return domain, nil
+}
+
+// signedBid is a relay's getHeader response, with the message which the builder signed.
+type signedBid struct {
+	Version string `json:"version"`
+	Data    struct {
+		Message   json.RawMessage `json:"message"`
+		Signature string          `json:"signature"`
+	} `json:"data"`
+}
+
+// builderBid is the message of a builder's bid, whose hash tree root the builder signs.
+type builderBid struct {
+	header interface {
+		HashTreeRootWith(hh ssz.HashWalker) error
+	}
+	// blobKZGCommitments are only part of Deneb bids.
+	blobKZGCommitments []deneb.KZGCommitment
+	deneb              bool
+	value              *uint256.Int
+	pubKey             phase0.BLSPubKey
+}
+
+// HashTreeRoot ssz hashes the builderBid object
+func (b *builderBid) HashTreeRoot() ([32]byte, error) {
+	hh := ssz.DefaultHasherPool.Get()
+	defer ssz.DefaultHasherPool.Put(hh)
+	if err := b.HashTreeRootWith(hh); err != nil {
+		return [32]byte{}, err
+	}
+	return hh.HashRoot()
+}
+
+// HashTreeRootWith ssz hashes the builderBid object with a hasher
+func (b *builderBid) HashTreeRootWith(hh ssz.HashWalker) error {
+	indx := hh.Index()
+
+	if err := b.header.HashTreeRootWith(hh); err != nil {
+		return err
+	}
+	if b.deneb {
+		if size := len(b.blobKZGCommitments); size > maxBlobCommitmentsPerBlock {
+			return ssz.ErrListTooBigFn("BuilderBid.BlobKZGCommitments", size, maxBlobCommitmentsPerBlock)
+		}
+		subIndx := hh.Index()
+		for _, commitment := range b.blobKZGCommitments {
+			hh.PutBytes(commitment[:])
+		}
+		hh.MerkleizeWithMixin(subIndx, uint64(len(b.blobKZGCommitments)), maxBlobCommitmentsPerBlock)
+	}
+	// Uint256 values are hashed in little-endian.
+	value := b.value.Bytes32()
+	for i, j := 0, len(value)-1; i < j; i, j = i+1, j-1 {
+		value[i], value[j] = value[j], value[i]
+	}
+	hh.PutBytes(value[:])
+	hh.PutBytes(b.pubKey[:])
+
+	hh.Merkleize(indx)
+	return nil
+}
+
+// verifyBidSignature verifies that the bid in the relay's response was signed by the given public key.
+func verifyBidSignature(raw []byte, domain phase0.Domain, pubKey phase0.BLSPubKey) error {
+	var resp signedBid
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return errors.Wrap(err, "could not unmarshal bid")
+	}
+	var message struct {
+		Header             json.RawMessage       `json:"header"`
+		BlobKZGCommitments []deneb.KZGCommitment `json:"blob_kzg_commitments"`
+		Value              string                `json:"value"`
+		Pubkey             string                `json:"pubkey"`
+	}
+	if err := json.Unmarshal(resp.Data.Message, &message); err != nil {
+		return errors.Wrap(err, "could not unmarshal bid message")
+	}
+
+	bid := &builderBid{pubKey: pubKey}
+	switch strings.ToLower(resp.Version) {
+	case "bellatrix":
+		header := &bellatrix.ExecutionPayloadHeader{}
+		if err := json.Unmarshal(message.Header, header); err != nil {
+			return errors.Wrap(err, "could not unmarshal bid header")
+		}
+		bid.header = header
+	case "capella":
+		header := &capella.ExecutionPayloadHeader{}
+		if err := json.Unmarshal(message.Header, header); err != nil {
+			return errors.Wrap(err, "could not unmarshal bid header")
+		}
+		bid.header = header
+	case "deneb":
+		header := &deneb.ExecutionPayloadHeader{}
+		if err := json.Unmarshal(message.Header, header); err != nil {
+			return errors.Wrap(err, "could not unmarshal bid header")
+		}
+		bid.header = header
+		bid.deneb = true
+		bid.blobKZGCommitments = message.BlobKZGCommitments
+	default:
+		return errors.Errorf("unsupported bid version %q", resp.Version)
+	}
+	var err error
+	bid.value, err = uint256.FromDecimal(message.Value)
+	if err != nil {
+		return errors.Wrap(err, "invalid bid value")
+	}
+	bidPubKey, err := parsePubKey(message.Pubkey)
+	if err != nil {
+		return errors.Wrap(err, "invalid bid public key")
+	}
+	if bidPubKey != pubKey {
+		return errors.Errorf("bid public key %#x doesn't match the relay's", bidPubKey)
+	}
+
+	root, err := bid.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not hash bid")
+	}
+	signingRoot, err := (&phase0.SigningData{ObjectRoot: root, Domain: domain}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+
+	signature, err := parseHex(resp.Data.Signature)
+	if err != nil {
+		return errors.Wrap(err, "invalid bid signature")
+	}
+	var sig bls.Sign
+	if err := sig.Deserialize(signature); err != nil {
+		return errors.Wrap(err, "could not deserialize bid signature")
+	}
+	var pk bls.PublicKey
+	if err := pk.Deserialize(pubKey[:]); err != nil {
+		return errors.Wrap(err, "could not deserialize relay public key")
+	}
+	if !sig.VerifyByte(&pk, signingRoot[:]) {
+		return errors.New("invalid bid signature")
+	}
+	return nil
+}
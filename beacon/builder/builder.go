@@ -0,0 +1,387 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	apiv1bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+// servedBidsSlots is the number of slots for which the relays of served bids are remembered.
+const servedBidsSlots = 32
+
+// Service gets builder blocks directly from the configured relays,
+// registering validators with all of them and choosing the best of their bids.
+// When no relay offers a bid of at least the minimum value,
+// the beacon node falls back to a local block.
+type Service struct {
+	logger        *zap.Logger
+	relays        []*Relay
+	minBid        *uint256.Int
+	headerTimeout time.Duration
+	listenAddress string
+
+	proposerSettings beaconprotocol.ProposerSettingsProvider
+	pubKeyOf         func(index phase0.ValidatorIndex) (phase0.BLSPubKey, bool)
+
+	// servedBids are the chosen bids by their block hash, so that their blocks are unblinded by the relay which bid them.
+	servedBids     map[phase0.Hash32]servedBid
+	servedBidsLock sync.Mutex
+}
+
+// servedBid is a bid which was served to the beacon node.
+type servedBid struct {
+	relay *Relay
+	slot  phase0.Slot
+}
+
+// New creates a new Service from the given config, verifying bids in the network of the given genesis fork version.
+// proposerSettings, if not nil, restricts the relays used for each validator, whose public keys are looked up
+// by their index with pubKeyOf when submitting blocks built on bids which weren't served by this node.
+func New(
+	logger *zap.Logger,
+	cfg Config,
+	genesisForkVersion phase0.Version,
+	proposerSettings beaconprotocol.ProposerSettingsProvider,
+	pubKeyOf func(index phase0.ValidatorIndex) (phase0.BLSPubKey, bool),
+) (*Service, error) {
+	minBid, err := ParseEther(cfg.MinBid)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid minimum bid")
+	}
+	domain, err := Domain(genesisForkVersion)
+	if err != nil {
+		return nil, err
+	}
+	headerTimeout := cfg.HeaderTimeout
+	if headerTimeout == 0 {
+		headerTimeout = DefaultHeaderTimeout
+	}
+	s := &Service{
+		logger:        logger.Named("builder"),
+		minBid:        minBid,
+		headerTimeout: headerTimeout,
+		listenAddress: cfg.ListenAddress,
+
+		proposerSettings: proposerSettings,
+		pubKeyOf:         pubKeyOf,
+		servedBids:       make(map[phase0.Hash32]servedBid),
+	}
+	for _, u := range cfg.relayURLs() {
+		relay, err := NewRelay(u, domain)
+		if err != nil {
+			return nil, err
+		}
+		s.relays = append(s.relays, relay)
+	}
+	if len(s.relays) == 0 {
+		return nil, errors.New("no relays configured")
+	}
+	return s, nil
+}
+
+// Status checks that at least one of the relays is up.
+func (s *Service) Status(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultRequestTimeout)
+	defer cancel()
+
+	errs := forEachRelay(s.relays, func(i int, relay *Relay) error {
+		return relay.Status(ctx)
+	})
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.New("no relay is available")
+}
+
+// SubmitValidatorRegistrations registers validators with their relays.
+// It fails only if none of the relays accepted their registrations.
+func (s *Service) SubmitValidatorRegistrations(ctx context.Context, registrations []*api.VersionedSignedValidatorRegistration) error {
+	byRelay := make([][]*eth2apiv1.SignedValidatorRegistration, len(s.relays))
+	for _, registration := range registrations {
+		if registration.V1 == nil || registration.V1.Message == nil {
+			return errors.Errorf("unsupported validator registration version %s", registration.Version)
+		}
+		for i, relay := range s.relays {
+			if s.usesRelay(registration.V1.Message.Pubkey, relay) {
+				byRelay[i] = append(byRelay[i], registration.V1)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultRequestTimeout)
+	defer cancel()
+
+	errs := forEachRelay(s.relays, func(i int, relay *Relay) error {
+		if len(byRelay[i]) == 0 {
+			return nil
+		}
+		return relay.RegisterValidators(ctx, byRelay[i])
+	})
+	succeeded := 0
+	for i, err := range errs {
+		if err != nil {
+			s.logger.Warn("could not register validators with relay",
+				zap.String("relay", s.relays[i].Name()),
+				fields.Count(len(byRelay[i])),
+				zap.Error(err))
+			continue
+		}
+		succeeded++
+	}
+	if succeeded == 0 {
+		return errors.New("no relay accepted the validator registrations")
+	}
+	return nil
+}
+
+// BestBid gets bids from all relays in parallel, and returns the highest bid of at least the minimum value,
+// or nil if there's no such bid.
+func (s *Service) BestBid(ctx context.Context, slot phase0.Slot, parentHash phase0.Hash32, pubKey phase0.BLSPubKey) *Bid {
+	ctx, cancel := context.WithTimeout(ctx, s.headerTimeout)
+	defer cancel()
+
+	logger := s.logger.With(fields.Slot(slot))
+	var relays []*Relay
+	for _, relay := range s.relays {
+		if s.usesRelay(pubKey, relay) {
+			relays = append(relays, relay)
+		}
+	}
+	bids := make([]*Bid, len(relays))
+	errs := forEachRelay(relays, func(i int, relay *Relay) error {
+		bid, err := relay.GetHeader(ctx, slot, parentHash, pubKey)
+		bids[i] = bid
+		return err
+	})
+
+	var best *Bid
+	var bestRelay *Relay
+	for i, bid := range bids {
+		relay := relays[i].Name()
+		if errs[i] != nil {
+			logger.Debug("could not get bid from relay", zap.String("relay", relay), zap.Error(errs[i]))
+			continue
+		}
+		if bid == nil {
+			logger.Debug("relay has no bid", zap.String("relay", relay))
+			continue
+		}
+		logger.Debug("got bid from relay",
+			zap.String("relay", relay),
+			zap.Stringer("value", bid.Value),
+			zap.String("block_hash", bid.BlockHash.String()))
+		if bid.Value.Lt(s.minBid) {
+			continue
+		}
+		if best == nil || bid.Value.Gt(best.Value) {
+			best, bestRelay = bid, relays[i]
+		}
+	}
+	if best == nil {
+		logger.Info("no relay bid reaches the minimum value, falling back to a local block",
+			zap.Stringer("min_bid", s.minBid))
+		return nil
+	}
+	logger.Info("chose relay bid",
+		zap.String("relay", best.Relay),
+		zap.Stringer("value", best.Value),
+		zap.String("block_hash", best.BlockHash.String()))
+	s.serveBid(slot, best.BlockHash, bestRelay)
+	return best
+}
+
+// serveBid remembers the relay of the bid served for the slot, and forgets the bids of past slots.
+func (s *Service) serveBid(slot phase0.Slot, blockHash phase0.Hash32, relay *Relay) {
+	s.servedBidsLock.Lock()
+	defer s.servedBidsLock.Unlock()
+
+	for hash, bid := range s.servedBids {
+		if bid.slot+servedBidsSlots < slot {
+			delete(s.servedBids, hash)
+		}
+	}
+	s.servedBids[blockHash] = servedBid{relay: relay, slot: slot}
+}
+
+// SubmitBlindedBlock submits a signed blinded block to the relay whose bid it was built on,
+// which is the only one that can unblind it, and returns the relay's response.
+// If this node didn't serve the bid, such as when another operator of the validator's committee proposed the block,
+// it's submitted to all the relays which the validator may use, and the first response is returned.
+func (s *Service) SubmitBlindedBlock(ctx context.Context, body []byte, header http.Header) ([]byte, error) {
+	block, err := parseBlindedBlock(body, header)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read the blinded block")
+	}
+	s.servedBidsLock.Lock()
+	bid, ok := s.servedBids[block.blockHash]
+	s.servedBidsLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultRequestTimeout)
+	defer cancel()
+
+	if !ok {
+		return s.submitBlindedBlockToAll(ctx, block, body, header)
+	}
+	resp, err := bid.relay.SubmitBlindedBlock(ctx, body, header)
+	if err == nil && len(resp) == 0 {
+		err = errors.New("empty response")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "relay %s could not unblind the block", bid.relay.Name())
+	}
+	s.logger.Info("relay unblinded block", zap.String("relay", bid.relay.Name()), fields.Slot(bid.slot))
+	return resp, nil
+}
+
+// submitBlindedBlockToAll submits a signed blinded block, whose bid wasn't served by this node,
+// to all the relays which its proposer may use, and returns the first response of a relay which unblinded it.
+func (s *Service) submitBlindedBlockToAll(ctx context.Context, block blindedBlock, body []byte, header http.Header) ([]byte, error) {
+	relays := s.relays
+	if s.proposerSettings != nil {
+		var pubKey phase0.BLSPubKey
+		var ok bool
+		if s.pubKeyOf != nil {
+			pubKey, ok = s.pubKeyOf(block.proposerIndex)
+		}
+		if !ok {
+			return nil, errors.Errorf("no relay bid was served for block hash %#x, and its proposer %d is unknown",
+				block.blockHash, block.proposerIndex)
+		}
+		relays = nil
+		for _, relay := range s.relays {
+			if s.usesRelay(pubKey, relay) {
+				relays = append(relays, relay)
+			}
+		}
+	}
+
+	resps := make([][]byte, len(relays))
+	errs := forEachRelay(relays, func(i int, relay *Relay) error {
+		resp, err := relay.SubmitBlindedBlock(ctx, body, header)
+		if err == nil && len(resp) == 0 {
+			err = errors.New("empty response")
+		}
+		resps[i] = resp
+		return err
+	})
+	var unblinded []byte
+	for i, err := range errs {
+		if err != nil {
+			s.logger.Debug("relay could not unblind block whose bid wasn't served",
+				zap.String("relay", relays[i].Name()),
+				zap.String("block_hash", block.blockHash.String()),
+				zap.Error(err))
+			continue
+		}
+		s.logger.Info("relay unblinded block whose bid wasn't served",
+			zap.String("relay", relays[i].Name()),
+			zap.String("block_hash", block.blockHash.String()))
+		if unblinded == nil {
+			unblinded = resps[i]
+		}
+	}
+	if unblinded == nil {
+		return nil, errors.Errorf("no relay could unblind the block of block hash %#x", block.blockHash)
+	}
+	return unblinded, nil
+}
+
+// blindedBlock is the part of a signed blinded block which identifies its bid and proposer.
+type blindedBlock struct {
+	blockHash     phase0.Hash32
+	proposerIndex phase0.ValidatorIndex
+}
+
+// parseBlindedBlock reads the block hash of the execution payload header and the proposer of a signed blinded block,
+// encoded in JSON or, with the octet-stream content type, in SSZ of the given consensus version.
+func parseBlindedBlock(body []byte, header http.Header) (blindedBlock, error) {
+	if !strings.HasPrefix(header.Get("Content-Type"), "application/octet-stream") {
+		var block struct {
+			Message struct {
+				ProposerIndex string `json:"proposer_index"`
+				Body          struct {
+					ExecutionPayloadHeader struct {
+						BlockHash string `json:"block_hash"`
+					} `json:"execution_payload_header"`
+				} `json:"body"`
+			} `json:"message"`
+		}
+		if err := json.Unmarshal(body, &block); err != nil {
+			return blindedBlock{}, err
+		}
+		blockHash, err := parseHash(block.Message.Body.ExecutionPayloadHeader.BlockHash)
+		if err != nil {
+			return blindedBlock{}, err
+		}
+		proposerIndex, err := strconv.ParseUint(block.Message.ProposerIndex, 10, 64)
+		if err != nil {
+			return blindedBlock{}, errors.Wrap(err, "invalid proposer index")
+		}
+		return blindedBlock{blockHash: blockHash, proposerIndex: phase0.ValidatorIndex(proposerIndex)}, nil
+	}
+
+	switch version := header.Get("Eth-Consensus-Version"); strings.ToLower(version) {
+	case "bellatrix":
+		block := &apiv1bellatrix.SignedBlindedBeaconBlock{}
+		if err := block.UnmarshalSSZ(body); err != nil {
+			return blindedBlock{}, err
+		}
+		return blindedBlock{blockHash: block.Message.Body.ExecutionPayloadHeader.BlockHash, proposerIndex: block.Message.ProposerIndex}, nil
+	case "capella":
+		block := &apiv1capella.SignedBlindedBeaconBlock{}
+		if err := block.UnmarshalSSZ(body); err != nil {
+			return blindedBlock{}, err
+		}
+		return blindedBlock{blockHash: block.Message.Body.ExecutionPayloadHeader.BlockHash, proposerIndex: block.Message.ProposerIndex}, nil
+	case "deneb":
+		block := &apiv1deneb.SignedBlindedBeaconBlock{}
+		if err := block.UnmarshalSSZ(body); err != nil {
+			return blindedBlock{}, err
+		}
+		return blindedBlock{blockHash: block.Message.Body.ExecutionPayloadHeader.BlockHash, proposerIndex: block.Message.ProposerIndex}, nil
+	default:
+		return blindedBlock{}, errors.Errorf("unsupported consensus version %q", version)
+	}
+}
+
+// usesRelay returns whether the given relay may be used for the validator.
+func (s *Service) usesRelay(pubKey phase0.BLSPubKey, relay *Relay) bool {
+	if s.proposerSettings == nil {
+		return true
+	}
+	return s.proposerSettings.ProposerSettings(pubKey).UsesRelay(relay.Name())
+}
+
+// forEachRelay calls f with each relay and its index in parallel, and returns the errors by relay index.
+func forEachRelay(relays []*Relay, f func(i int, relay *Relay) error) []error {
+	errs := make([]error, len(relays))
+	var wg sync.WaitGroup
+	for i, relay := range relays {
+		wg.Add(1)
+		go func(i int, relay *Relay) {
+			defer wg.Done()
+			errs[i] = f(i, relay)
+		}(i, relay)
+	}
+	wg.Wait()
+	return errs
+}
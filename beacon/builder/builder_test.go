@@ -0,0 +1,345 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+var testForkVersion = phase0.Version{0x01, 0x01, 0x70, 0x00}
+
+// relayStub is a local relay which bids a fixed value and unblinds blocks only if it has bid.
+type relayStub struct {
+	*httptest.Server
+
+	secretKey *bls.SecretKey
+	pubKey    phase0.BLSPubKey
+	value     string // empty for no bid
+	blockHash phase0.Hash32
+	// signer, if set, signs the relay's bids instead of its own key.
+	signer *bls.SecretKey
+
+	mu            sync.Mutex
+	registrations int
+	unblinded     int
+}
+
+func newRelayStub(t *testing.T, value string, blockHash byte) *relayStub {
+	threshold.Init()
+	relay := &relayStub{value: value, secretKey: &bls.SecretKey{}}
+	relay.secretKey.SetByCSPRNG()
+	copy(relay.pubKey[:], relay.secretKey.GetPublicKey().Serialize())
+	relay.blockHash[0] = blockHash
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/builder/validators", func(w http.ResponseWriter, r *http.Request) {
+		var registrations []*eth2apiv1.SignedValidatorRegistration
+		if err := json.NewDecoder(r.Body).Decode(&registrations); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		relay.mu.Lock()
+		relay.registrations += len(registrations)
+		relay.mu.Unlock()
+	})
+	mux.HandleFunc("/eth/v1/builder/header/", func(w http.ResponseWriter, r *http.Request) {
+		if relay.value == "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		parts := strings.Split(r.URL.Path, "/")
+		parentHash, err := parseHash(parts[len(parts)-2])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp, err := relay.signedBid(parentHash)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(resp)
+	})
+	mux.HandleFunc("/eth/v1/builder/blinded_blocks", func(w http.ResponseWriter, r *http.Request) {
+		if relay.value == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		relay.mu.Lock()
+		relay.unblinded++
+		relay.mu.Unlock()
+		_, _ = fmt.Fprintf(w, `{"version":"deneb","data":{"block_hash":"%#x"}}`, relay.blockHash)
+	})
+	mux.HandleFunc("/eth/v1/builder/status", func(w http.ResponseWriter, r *http.Request) {})
+
+	relay.Server = httptest.NewServer(mux)
+	t.Cleanup(relay.Close)
+	return relay
+}
+
+// signedBid returns the relay's signed Deneb bid on top of the given parent.
+func (r *relayStub) signedBid(parentHash phase0.Hash32) ([]byte, error) {
+	header := &deneb.ExecutionPayloadHeader{
+		ParentHash:    parentHash,
+		BlockHash:     r.blockHash,
+		BaseFeePerGas: uint256.NewInt(7),
+		GasLimit:      30000000,
+	}
+	value, err := uint256.FromDecimal(r.value)
+	if err != nil {
+		return nil, err
+	}
+	commitments := []deneb.KZGCommitment{{1}, {2}}
+	domain, err := Domain(testForkVersion)
+	if err != nil {
+		return nil, err
+	}
+	root, err := (&builderBid{header: header, blobKZGCommitments: commitments, deneb: true, value: value, pubKey: r.pubKey}).HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	signingRoot, err := (&phase0.SigningData{ObjectRoot: root, Domain: domain}).HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	signer := r.secretKey
+	if r.signer != nil {
+		signer = r.signer
+	}
+	return json.Marshal(map[string]interface{}{
+		"version": "deneb",
+		"data": map[string]interface{}{
+			"message": map[string]interface{}{
+				"header":               header,
+				"blob_kzg_commitments": commitments,
+				"value":                r.value,
+				"pubkey":               fmt.Sprintf("%#x", r.pubKey),
+			},
+			"signature": fmt.Sprintf("%#x", signer.SignByte(signingRoot[:]).Serialize()),
+		},
+	})
+}
+
+// urlWithPubKey returns the relay's URL with its public key as the user.
+func (r *relayStub) urlWithPubKey() string {
+	return strings.Replace(r.URL, "http://", fmt.Sprintf("http://%#x@", r.pubKey), 1)
+}
+
+func TestBestBid(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ctx := context.Background()
+	parentHash := phase0.Hash32{0xaa}
+
+	low := newRelayStub(t, "1000000000000000", 1)   // 0.001 ETH
+	high := newRelayStub(t, "30000000000000000", 2) // 0.03 ETH
+	none := newRelayStub(t, "", 3)
+	down := newRelayStub(t, "50000000000000000", 4)
+	down.Close()
+
+	t.Run("highest bid", func(t *testing.T) {
+		s, err := New(logger, Config{Relays: strings.Join([]string{low.urlWithPubKey(), high.urlWithPubKey(), none.urlWithPubKey(), down.urlWithPubKey()}, ";")}, testForkVersion, nil, nil)
+		require.NoError(t, err)
+
+		bid := s.BestBid(ctx, 100, parentHash, phase0.BLSPubKey{})
+		require.NotNil(t, bid)
+		require.Equal(t, high.blockHash, bid.BlockHash)
+		require.Equal(t, "deneb", bid.Version)
+		require.Equal(t, uint256.NewInt(30000000000000000), bid.Value)
+	})
+
+	t.Run("minimum bid", func(t *testing.T) {
+		s, err := New(logger, Config{Relays: low.urlWithPubKey() + ";" + high.urlWithPubKey(), MinBid: "0.01"}, testForkVersion, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, high.blockHash, s.BestBid(ctx, 100, parentHash, phase0.BLSPubKey{}).BlockHash)
+
+		s, err = New(logger, Config{Relays: low.urlWithPubKey() + ";" + high.urlWithPubKey(), MinBid: "0.05"}, testForkVersion, nil, nil)
+		require.NoError(t, err)
+		require.Nil(t, s.BestBid(ctx, 100, parentHash, phase0.BLSPubKey{}))
+	})
+
+	t.Run("relay public key", func(t *testing.T) {
+		s, err := New(logger, Config{Relays: low.urlWithPubKey()}, testForkVersion, nil, nil)
+		require.NoError(t, err)
+		require.NotNil(t, s.BestBid(ctx, 100, parentHash, phase0.BLSPubKey{}))
+
+		// a relay's public key is required.
+		_, err = New(logger, Config{Relays: low.URL}, testForkVersion, nil, nil)
+		require.Error(t, err)
+
+		// a bid of another key is ignored.
+		impostor := strings.Replace(high.urlWithPubKey(), fmt.Sprintf("%#x", high.pubKey), fmt.Sprintf("%#x", low.pubKey), 1)
+		s, err = New(logger, Config{Relays: impostor}, testForkVersion, nil, nil)
+		require.NoError(t, err)
+		require.Nil(t, s.BestBid(ctx, 100, parentHash, phase0.BLSPubKey{}))
+
+		// a bid which isn't signed by the relay's key is ignored.
+		forger := newRelayStub(t, "90000000000000000", 5)
+		forger.signer = low.secretKey
+		s, err = New(logger, Config{Relays: forger.urlWithPubKey()}, testForkVersion, nil, nil)
+		require.NoError(t, err)
+		require.Nil(t, s.BestBid(ctx, 100, parentHash, phase0.BLSPubKey{}))
+
+		// a bid signed in another network is ignored.
+		s, err = New(logger, Config{Relays: low.urlWithPubKey()}, phase0.Version{}, nil, nil)
+		require.NoError(t, err)
+		require.Nil(t, s.BestBid(ctx, 100, parentHash, phase0.BLSPubKey{}))
+	})
+
+	t.Run("validator relays", func(t *testing.T) {
+		restricted := phase0.BLSPubKey{1}
+		proposerSettings := beaconprotocol.NewProposerConfigManager(beaconprotocol.ProposerSettings{}, nil)
+		require.NoError(t, proposerSettings.SetProposerConfig(&beaconprotocol.ProposerConfig{
+			Proposers: map[string]beaconprotocol.ProposerOptions{
+				fmt.Sprintf("%#x", restricted): {Builder: beaconprotocol.BuilderOptions{Relays: []string{low.URL}}},
+			},
+		}))
+		s, err := New(logger, Config{Relays: low.urlWithPubKey() + ";" + high.urlWithPubKey()}, testForkVersion, proposerSettings, nil)
+		require.NoError(t, err)
+		require.Equal(t, high.blockHash, s.BestBid(ctx, 100, parentHash, phase0.BLSPubKey{}).BlockHash)
+		require.Equal(t, low.blockHash, s.BestBid(ctx, 100, parentHash, restricted).BlockHash)
+	})
+}
+
+func TestBuilderAPI(t *testing.T) {
+	logger := logging.TestLogger(t)
+	bidder := newRelayStub(t, "1000", 1)
+	other := newRelayStub(t, "", 2)
+	outbid := newRelayStub(t, "500", 3)
+
+	s, err := New(logger, Config{Relays: strings.Join([]string{bidder.urlWithPubKey(), other.urlWithPubKey(), outbid.urlWithPubKey()}, ";")}, testForkVersion, nil, nil)
+	require.NoError(t, err)
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	t.Run("register validators directly", func(t *testing.T) {
+		registrations := []*api.VersionedSignedValidatorRegistration{
+			{Version: spec.BuilderVersionV1, V1: &eth2apiv1.SignedValidatorRegistration{Message: &eth2apiv1.ValidatorRegistration{}}},
+			{Version: spec.BuilderVersionV1, V1: &eth2apiv1.SignedValidatorRegistration{Message: &eth2apiv1.ValidatorRegistration{}}},
+		}
+		require.NoError(t, s.SubmitValidatorRegistrations(context.Background(), registrations))
+		require.Equal(t, 2, bidder.registrations)
+		require.Equal(t, 2, other.registrations)
+		require.Equal(t, 2, outbid.registrations)
+	})
+
+	t.Run("get header", func(t *testing.T) {
+		resp, err := http.Get(fmt.Sprintf("%s/eth/v1/builder/header/1/%#x/%#x", server.URL, phase0.Hash32{}, phase0.BLSPubKey{}))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "deneb", resp.Header.Get("Eth-Consensus-Version"))
+
+		resp, err = http.Get(server.URL + "/eth/v1/builder/header/1/0x00/0x00")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("submit blinded block", func(t *testing.T) {
+		// the block is unblinded only by the relay whose bid was served.
+		block := fmt.Sprintf(`{"message":{"proposer_index":"1","body":{"execution_payload_header":{"block_hash":"%#x"}}}}`, bidder.blockHash)
+		resp, err := http.Post(server.URL+"/eth/v1/builder/blinded_blocks", "application/json", strings.NewReader(block))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), fmt.Sprintf("%#x", bidder.blockHash))
+		require.Equal(t, 1, bidder.unblinded)
+		require.Zero(t, outbid.unblinded)
+
+		// a block built on a bid which this node didn't serve, such as another operator's, is sent to all relays.
+		block = fmt.Sprintf(`{"message":{"proposer_index":"1","body":{"execution_payload_header":{"block_hash":"%#x"}}}}`, outbid.blockHash)
+		resp, err = http.Post(server.URL+"/eth/v1/builder/blinded_blocks", "application/json", strings.NewReader(block))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, 2, bidder.unblinded)
+		require.Equal(t, 1, outbid.unblinded)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/eth/v1/builder/status")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestSubmitUnservedBlindedBlock(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ctx := context.Background()
+	allowed := newRelayStub(t, "1000", 1)
+	disallowed := newRelayStub(t, "1000", 2)
+	relays := allowed.urlWithPubKey() + ";" + disallowed.urlWithPubKey()
+
+	validator := phase0.BLSPubKey{1}
+	proposerSettings := beaconprotocol.NewProposerConfigManager(beaconprotocol.ProposerSettings{}, nil)
+	require.NoError(t, proposerSettings.SetProposerConfig(&beaconprotocol.ProposerConfig{
+		Proposers: map[string]beaconprotocol.ProposerOptions{
+			fmt.Sprintf("%#x", validator): {Builder: beaconprotocol.BuilderOptions{Relays: []string{allowed.URL}}},
+		},
+	}))
+	pubKeyOf := func(index phase0.ValidatorIndex) (phase0.BLSPubKey, bool) {
+		return validator, index == 7
+	}
+	s, err := New(logger, Config{Relays: relays}, testForkVersion, proposerSettings, pubKeyOf)
+	require.NoError(t, err)
+
+	// the block hash was never served by this node, so the block is sent to the relays which its proposer may use.
+	block := fmt.Sprintf(`{"message":{"proposer_index":"7","body":{"execution_payload_header":{"block_hash":"%#x"}}}}`, phase0.Hash32{0xbb})
+	resp, err := s.SubmitBlindedBlock(ctx, []byte(block), http.Header{"Content-Type": []string{"application/json"}})
+	require.NoError(t, err)
+	require.Contains(t, string(resp), fmt.Sprintf("%#x", allowed.blockHash))
+	require.Equal(t, 1, allowed.unblinded)
+	require.Zero(t, disallowed.unblinded)
+
+	// the relays of an unknown proposer can't be determined.
+	block = fmt.Sprintf(`{"message":{"proposer_index":"8","body":{"execution_payload_header":{"block_hash":"%#x"}}}}`, phase0.Hash32{0xbb})
+	_, err = s.SubmitBlindedBlock(ctx, []byte(block), http.Header{"Content-Type": []string{"application/json"}})
+	require.Error(t, err)
+	require.Equal(t, 1, allowed.unblinded)
+
+	// no relay could unblind the block.
+	none := newRelayStub(t, "", 3)
+	s, err = New(logger, Config{Relays: none.urlWithPubKey()}, testForkVersion, nil, nil)
+	require.NoError(t, err)
+	_, err = s.SubmitBlindedBlock(ctx, []byte(block), http.Header{"Content-Type": []string{"application/json"}})
+	require.Error(t, err)
+}
+
+func TestParseEther(t *testing.T) {
+	value, err := ParseEther("0.05")
+	require.NoError(t, err)
+	require.Equal(t, uint256.NewInt(50000000000000000), value)
+
+	value, err = ParseEther("")
+	require.NoError(t, err)
+	require.True(t, value.IsZero())
+
+	_, err = ParseEther("-1")
+	require.Error(t, err)
+	_, err = ParseEther("0.0000000000000000001")
+	require.Error(t, err)
+	_, err = ParseEther("eth")
+	require.Error(t, err)
+}
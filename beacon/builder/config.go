@@ -0,0 +1,62 @@
+package builder
+
+import (
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultHeaderTimeout is the default timeout for getting a header from a relay,
+	// which has to leave the beacon node enough time to fall back to a local block.
+	DefaultHeaderTimeout = 950 * time.Millisecond
+	// DefaultRequestTimeout is the default timeout for the rest of the relay requests.
+	DefaultRequestTimeout = 4 * time.Second
+)
+
+// Config is the configuration of the relay client.
+type Config struct {
+	Relays        string        `yaml:"Relays" env:"BUILDER_RELAYS" env-description:"MEV relay URLs to get bids from directly, separated with ';'. The relay's public key, which its bids are verified with, must be given as the URL user, e.g. https://0xabc...@relay.example"`
+	MinBid        string        `yaml:"MinBid" env:"BUILDER_MIN_BID" env-default:"0" env-description:"Minimum bid value (in ETH) for a builder block to be preferred over a local block"`
+	ListenAddress string        `yaml:"ListenAddress" env:"BUILDER_LISTEN_ADDRESS" env-default:"127.0.0.1:18550" env-description:"Address to serve the builder API on, to be used as the beacon node's builder endpoint"`
+	HeaderTimeout time.Duration `yaml:"HeaderTimeout" env:"BUILDER_HEADER_TIMEOUT" env-description:"Timeout for getting a header from a relay"`
+}
+
+// Enabled returns whether any relays are configured.
+func (c Config) Enabled() bool {
+	return len(c.relayURLs()) > 0
+}
+
+func (c Config) relayURLs() []string {
+	var urls []string
+	for _, u := range strings.Split(c.Relays, ";") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// ParseEther parses a decimal amount of ETH (such as "0.05") into wei.
+func ParseEther(s string) (*uint256.Int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return uint256.NewInt(0), nil
+	}
+	eth, ok := new(big.Rat).SetString(s)
+	if !ok || eth.Sign() < 0 {
+		return nil, errors.Errorf("invalid ETH amount %q", s)
+	}
+	wei := new(big.Rat).Mul(eth, new(big.Rat).SetInt(big.NewInt(1e18)))
+	if !wei.IsInt() {
+		return nil, errors.Errorf("ETH amount %q is more precise than wei", s)
+	}
+	value, overflow := uint256.FromBig(wei.Num())
+	if overflow {
+		return nil, errors.Errorf("ETH amount %q is too large", s)
+	}
+	return value, nil
+}
@@ -0,0 +1,208 @@
+package builder
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+)
+
+// Bid is a builder's offer for a slot, as returned by a relay.
+type Bid struct {
+	Relay     string
+	Version   string
+	Value     *uint256.Int
+	BlockHash phase0.Hash32
+	// Raw is the relay's response, which is served to the beacon node as is.
+	Raw []byte
+}
+
+// bidResponse is the part of a relay's getHeader response needed to choose between bids.
+type bidResponse struct {
+	Version string `json:"version"`
+	Data    struct {
+		Message struct {
+			Header struct {
+				ParentHash string `json:"parent_hash"`
+				BlockHash  string `json:"block_hash"`
+			} `json:"header"`
+			Value string `json:"value"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+// Relay is a client of a single relay's builder API.
+type Relay struct {
+	name   string
+	url    *url.URL
+	pubKey phase0.BLSPubKey
+	domain phase0.Domain
+	client *http.Client
+}
+
+// NewRelay creates a new Relay from its URL, with the relay's public key as the URL user.
+// The relay's bids must be signed by its public key in the given builder domain.
+func NewRelay(rawURL string, domain phase0.Domain) (*Relay, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse relay URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("relay URL %q must be http or https", rawURL)
+	}
+	if u.User == nil {
+		return nil, errors.Errorf("relay URL of %s must have the relay's public key as its user", u.Host)
+	}
+	pubKey, err := parsePubKey(u.User.Username())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key of relay %s", u.Host)
+	}
+	u.User = nil
+	return &Relay{
+		name:   u.Host,
+		url:    u,
+		pubKey: pubKey,
+		domain: domain,
+		client: &http.Client{},
+	}, nil
+}
+
+// Name returns the relay's host, which is safe to log.
+func (r *Relay) Name() string {
+	return r.name
+}
+
+// Status checks that the relay is up.
+func (r *Relay) Status(ctx context.Context) error {
+	_, err := r.do(ctx, http.MethodGet, "/eth/v1/builder/status", nil, nil)
+	return err
+}
+
+// RegisterValidators submits validator registrations to the relay.
+func (r *Relay) RegisterValidators(ctx context.Context, registrations []*eth2apiv1.SignedValidatorRegistration) error {
+	body, err := json.Marshal(registrations)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal registrations")
+	}
+	_, err = r.do(ctx, http.MethodPost, "/eth/v1/builder/validators", body, http.Header{"Content-Type": []string{"application/json"}})
+	return err
+}
+
+// GetHeader gets the relay's bid for the given slot, or nil if it has none.
+func (r *Relay) GetHeader(ctx context.Context, slot phase0.Slot, parentHash phase0.Hash32, pubKey phase0.BLSPubKey) (*Bid, error) {
+	path := fmt.Sprintf("/eth/v1/builder/header/%d/%#x/%#x", slot, parentHash, pubKey)
+	raw, err := r.do(ctx, http.MethodGet, path, nil, http.Header{"Accept": []string{"application/json"}})
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var resp bidResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal bid")
+	}
+	msg := resp.Data.Message
+	value, err := uint256.FromDecimal(msg.Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid bid value")
+	}
+	bidParentHash, err := parseHash(msg.Header.ParentHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid bid parent hash")
+	}
+	if bidParentHash != parentHash {
+		return nil, errors.Errorf("bid parent hash %#x doesn't match %#x", bidParentHash, parentHash)
+	}
+	blockHash, err := parseHash(msg.Header.BlockHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid bid block hash")
+	}
+	if err := verifyBidSignature(raw, r.domain, r.pubKey); err != nil {
+		return nil, err
+	}
+	return &Bid{
+		Relay:     r.name,
+		Version:   resp.Version,
+		Value:     value,
+		BlockHash: blockHash,
+		Raw:       raw,
+	}, nil
+}
+
+// SubmitBlindedBlock submits a signed blinded block to the relay, and returns the relay's response with the unblinded payload.
+func (r *Relay) SubmitBlindedBlock(ctx context.Context, body []byte, header http.Header) ([]byte, error) {
+	return r.do(ctx, http.MethodPost, "/eth/v1/builder/blinded_blocks", body, header)
+}
+
+// do sends a request to the relay, and returns the response body or nil if the relay had no content.
+func (r *Relay) do(ctx context.Context, method, path string, body []byte, header http.Header) ([]byte, error) {
+	u := *r.url
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create request")
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "request to relay %s failed", r.name)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read response of relay %s", r.name)
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("relay %s responded with status %d: %s", r.name, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return respBody, nil
+}
+
+func parseHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+func parseHash(s string) (phase0.Hash32, error) {
+	var hash phase0.Hash32
+	b, err := parseHex(s)
+	if err != nil {
+		return hash, err
+	}
+	if len(b) != len(hash) {
+		return hash, errors.Errorf("invalid length %d", len(b))
+	}
+	copy(hash[:], b)
+	return hash, nil
+}
+
+func parsePubKey(s string) (phase0.BLSPubKey, error) {
+	var pubKey phase0.BLSPubKey
+	b, err := parseHex(s)
+	if err != nil {
+		return pubKey, err
+	}
+	if len(b) != len(pubKey) {
+		return pubKey, errors.Errorf("invalid length %d", len(b))
+	}
+	copy(pubKey[:], b)
+	return pubKey, nil
+}
@@ -0,0 +1,115 @@
+package builder
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+)
+
+// Handler returns the builder API served to the beacon node, which should use it as its builder endpoint.
+//
+// Validator registrations are acknowledged without being forwarded,
+// since the node registers its validators with the relays directly.
+func (s *Service) Handler() http.Handler {
+	router := chi.NewRouter()
+	router.Use(middleware.Recoverer)
+
+	router.Get("/eth/v1/builder/status", s.handleStatus)
+	router.Post("/eth/v1/builder/validators", s.handleRegisterValidators)
+	router.Get("/eth/v1/builder/header/{slot}/{parent_hash}/{pubkey}", s.handleGetHeader)
+	router.Post("/eth/v1/builder/blinded_blocks", s.handleSubmitBlindedBlock)
+	return router
+}
+
+// ListenAndServe serves the builder API on the configured address.
+func (s *Service) ListenAndServe() error {
+	s.logger.Info("serving builder API", zap.String("addr", s.listenAddress), zap.Int("relays", len(s.relays)))
+
+	server := &http.Server{
+		Addr:              s.listenAddress,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return server.ListenAndServe()
+}
+
+func (s *Service) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if err := s.Status(r.Context()); err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Service) handleRegisterValidators(w http.ResponseWriter, r *http.Request) {
+	_, _ = io.Copy(io.Discard, r.Body)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Service) handleGetHeader(w http.ResponseWriter, r *http.Request) {
+	slot, err := strconv.ParseUint(chi.URLParam(r, "slot"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid slot")
+		return
+	}
+	parentHash, err := parseHash(chi.URLParam(r, "parent_hash"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid parent hash")
+		return
+	}
+	pubKey, err := parsePubKey(chi.URLParam(r, "pubkey"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid public key")
+		return
+	}
+
+	bid := s.BestBid(r.Context(), phase0.Slot(slot), parentHash, pubKey)
+	if bid == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Eth-Consensus-Version", bid.Version)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(bid.Raw)
+}
+
+func (s *Service) handleSubmitBlindedBlock(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "could not read request")
+		return
+	}
+	header := http.Header{"Accept": []string{"application/json"}}
+	for _, key := range []string{"Content-Type", "Eth-Consensus-Version"} {
+		if v := r.Header.Get(key); v != "" {
+			header.Set(key, v)
+		}
+	}
+
+	resp, err := s.SubmitBlindedBlock(r.Context(), body, header)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
+// writeError writes an error in the builder API's format.
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{code, message})
+}
@@ -12,6 +12,9 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
 )
 
 // AttesterDuties returns attester duties for a given epoch.
@@ -30,22 +33,55 @@ func (gc *goClient) AttesterDuties(ctx context.Context, epoch phase0.Epoch, vali
 	return resp.Data, nil
 }
 
+// GetAttestationData returns the attestation data of the given slot and committee index,
+// requesting it from the beacon node once for all the validators attesting in that committee.
 func (gc *goClient) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (ssz.Marshaler, spec.DataVersion, error) {
+	data, err := gc.attestationDataCache.get(slot, committeeIndex, func() (*phase0.AttestationData, error) {
+		return gc.fetchAttestationData(slot, committeeIndex)
+	})
+	if err != nil {
+		return nil, DataVersionNil, err
+	}
+	return data, spec.DataVersionPhase0, nil
+}
+
+func (gc *goClient) fetchAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error) {
 	attDataReqStart := time.Now()
 	resp, err := gc.client.AttestationData(gc.ctx, &api.AttestationDataOpts{
 		Slot:           slot,
 		CommitteeIndex: committeeIndex,
 	})
 	if err != nil {
-		return nil, DataVersionNil, fmt.Errorf("failed to get attestation data: %w", err)
+		return nil, fmt.Errorf("failed to get attestation data: %w", err)
 	}
-	if resp == nil {
-		return nil, DataVersionNil, fmt.Errorf("attestation data response is nil")
+	if resp == nil || resp.Data == nil {
+		return nil, fmt.Errorf("attestation data response is nil")
 	}
 
 	metricsAttesterDataRequest.Observe(time.Since(attDataReqStart).Seconds())
 
-	return resp.Data, spec.DataVersionPhase0, nil
+	return resp.Data, nil
+}
+
+// PrefetchAttestationData requests the attestation data of the given committees in the background,
+// so that it's ready by the time attester duties ask for it.
+func (gc *goClient) PrefetchAttestationData(slot phase0.Slot, committeeIndices []phase0.CommitteeIndex) {
+	for _, committeeIndex := range committeeIndices {
+		committeeIndex := committeeIndex
+		go func() {
+			if _, _, err := gc.GetAttestationData(slot, committeeIndex); err != nil {
+				gc.log.Debug("failed to prefetch attestation data",
+					fields.Slot(slot),
+					zap.Uint64("committee_index", uint64(committeeIndex)),
+					zap.Error(err))
+			}
+		}()
+	}
+}
+
+// InvalidateAttestationData drops the attestation data of the given slot onwards, such as after a reorg.
+func (gc *goClient) InvalidateAttestationData(fromSlot phase0.Slot) {
+	gc.attestationDataCache.invalidate(fromSlot)
 }
 
 // SubmitAttestation implements Beacon interface
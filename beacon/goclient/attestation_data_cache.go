@@ -0,0 +1,107 @@
+package goclient
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"golang.org/x/sync/singleflight"
+)
+
+// attestationDataCacheSlots is how many slots attestation data is kept for.
+const attestationDataCacheSlots = 32
+
+// attestationDataCache shares attestation data between the attester duties of a slot,
+// so that the beacon node is requested once per slot and committee index, however many validators attest.
+type attestationDataCache struct {
+	mu sync.Mutex
+	// generation is incremented on invalidation, so that requests started before it are neither cached nor joined.
+	generation uint64
+	data       map[phase0.Slot]map[phase0.CommitteeIndex]*phase0.AttestationData
+	requests   singleflight.Group
+}
+
+func newAttestationDataCache() *attestationDataCache {
+	return &attestationDataCache{
+		data: map[phase0.Slot]map[phase0.CommitteeIndex]*phase0.AttestationData{},
+	}
+}
+
+// get returns the cached attestation data, or fetches it once for all concurrent callers.
+func (c *attestationDataCache) get(
+	slot phase0.Slot,
+	committeeIndex phase0.CommitteeIndex,
+	fetch func() (*phase0.AttestationData, error),
+) (*phase0.AttestationData, error) {
+	c.mu.Lock()
+	if data, ok := c.data[slot][committeeIndex]; ok {
+		c.mu.Unlock()
+		metricsAttestationDataCacheHits.Inc()
+		return copyAttestationData(data), nil
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	result, err, _ := c.requests.Do(attestationDataKey(generation, slot, committeeIndex), func() (interface{}, error) {
+		data, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		c.store(generation, slot, committeeIndex, data)
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyAttestationData(result.(*phase0.AttestationData)), nil
+}
+
+func (c *attestationDataCache) store(generation uint64, slot phase0.Slot, committeeIndex phase0.CommitteeIndex, data *phase0.AttestationData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if c.data[slot] == nil {
+		c.data[slot] = map[phase0.CommitteeIndex]*phase0.AttestationData{}
+	}
+	c.data[slot][committeeIndex] = data
+
+	for cachedSlot := range c.data {
+		if cachedSlot+attestationDataCacheSlots < slot {
+			delete(c.data, cachedSlot)
+		}
+	}
+}
+
+// invalidate drops the attestation data of the given slot onwards, including requests in flight.
+func (c *attestationDataCache) invalidate(fromSlot phase0.Slot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for slot := range c.data {
+		if slot >= fromSlot {
+			delete(c.data, slot)
+		}
+	}
+}
+
+func attestationDataKey(generation uint64, slot phase0.Slot, committeeIndex phase0.CommitteeIndex) string {
+	return fmt.Sprintf("%d/%d/%d", generation, slot, committeeIndex)
+}
+
+// copyAttestationData copies cached attestation data, so that callers can't modify the cache.
+func copyAttestationData(data *phase0.AttestationData) *phase0.AttestationData {
+	cpy := *data
+	if data.Source != nil {
+		source := *data.Source
+		cpy.Source = &source
+	}
+	if data.Target != nil {
+		target := *data.Target
+		cpy.Target = &target
+	}
+	return &cpy
+}
@@ -0,0 +1,118 @@
+package goclient
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationDataCache(t *testing.T) {
+	var fetches atomic.Int32
+	var startOnce sync.Once
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fetch := func() (*phase0.AttestationData, error) {
+		fetches.Add(1)
+		startOnce.Do(func() { close(started) })
+		<-release
+		return &phase0.AttestationData{
+			Slot:   10,
+			Index:  1,
+			Source: &phase0.Checkpoint{Epoch: 1},
+			Target: &phase0.Checkpoint{Epoch: 2},
+		}, nil
+	}
+
+	cache := newAttestationDataCache()
+
+	// Concurrent requests share a single fetch.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := cache.get(10, 1, fetch)
+			require.NoError(t, err)
+			require.Equal(t, phase0.Slot(10), data.Slot)
+		}()
+	}
+	<-started
+	close(release)
+	wg.Wait()
+	require.LessOrEqual(t, fetches.Load(), int32(2))
+	fetchesBefore := fetches.Load()
+
+	// Cached data is served without fetching, and can't be modified by callers.
+	data, err := cache.get(10, 1, fetch)
+	require.NoError(t, err)
+	require.Equal(t, fetchesBefore, fetches.Load())
+	data.Target.Epoch = 100
+	data, err = cache.get(10, 1, fetch)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Epoch(2), data.Target.Epoch)
+
+	// Other committees are fetched separately.
+	_, err = cache.get(10, 2, fetch)
+	require.NoError(t, err)
+	require.Equal(t, fetchesBefore+1, fetches.Load())
+
+	// Invalidation drops the given slot onwards.
+	_, err = cache.get(9, 1, fetch)
+	require.NoError(t, err)
+	cache.invalidate(10)
+	_, err = cache.get(9, 1, fetch)
+	require.NoError(t, err)
+	require.Equal(t, fetchesBefore+2, fetches.Load())
+	_, err = cache.get(10, 1, fetch)
+	require.NoError(t, err)
+	require.Equal(t, fetchesBefore+3, fetches.Load())
+}
+
+func TestAttestationDataCache_Errors(t *testing.T) {
+	cache := newAttestationDataCache()
+
+	_, err := cache.get(10, 1, func() (*phase0.AttestationData, error) {
+		return nil, errors.New("beacon node unavailable")
+	})
+	require.ErrorContains(t, err, "beacon node unavailable")
+
+	// Errors aren't cached.
+	data, err := cache.get(10, 1, func() (*phase0.AttestationData, error) {
+		return &phase0.AttestationData{Slot: 10}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(10), data.Slot)
+}
+
+func TestAttestationDataCache_InvalidateInFlight(t *testing.T) {
+	cache := newAttestationDataCache()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := cache.get(10, 1, func() (*phase0.AttestationData, error) {
+			close(started)
+			<-release
+			return &phase0.AttestationData{Slot: 10, BeaconBlockRoot: phase0.Root{1}}, nil
+		})
+		require.NoError(t, err)
+	}()
+	<-started
+
+	// Data requested before a reorg isn't cached.
+	cache.invalidate(10)
+	close(release)
+	<-done
+
+	data, err := cache.get(10, 1, func() (*phase0.AttestationData, error) {
+		return &phase0.AttestationData{Slot: 10, BeaconBlockRoot: phase0.Root{2}}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, phase0.Root{2}, data.BeaconBlockRoot)
+}
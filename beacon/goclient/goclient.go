@@ -41,6 +41,7 @@ var (
 	allMetrics = []prometheus.Collector{
 		metricsBeaconNodeStatus,
 		metricsBeaconDataRequest,
+		metricsAttestationDataCacheHits,
 	}
 	metricsBeaconNodeStatus = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "ssv_beacon_status",
@@ -54,6 +55,11 @@ var (
 		Buckets: []float64{0.02, 0.05, 0.1, 0.2, 0.5, 1, 5},
 	}, []string{"role"})
 
+	metricsAttestationDataCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv_beacon_attestation_data_cache_hits",
+		Help: "Attestation data requests served from the cache",
+	})
+
 	metricsAttesterDataRequest                  = metricsBeaconDataRequest.WithLabelValues(spectypes.BNRoleAttester.String())
 	metricsAggregatorDataRequest                = metricsBeaconDataRequest.WithLabelValues(spectypes.BNRoleAggregator.String())
 	metricsProposerDataRequest                  = metricsBeaconDataRequest.WithLabelValues(spectypes.BNRoleProposer.String())
@@ -136,6 +142,8 @@ type Client interface {
 
 type NodeClientProvider interface {
 	NodeClient() NodeClient
+	// NodeVersion returns the version string of the Beacon node, such as "Lighthouse/v4.5.0/x86_64-linux".
+	NodeVersion() string
 }
 
 var _ NodeClientProvider = (*goClient)(nil)
@@ -154,8 +162,11 @@ type goClient struct {
 	registrationMu       sync.Mutex
 	registrationLastSlot phase0.Slot
 	registrationCache    map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration
+	builderRelays        eth2client.ValidatorRegistrationsSubmitter
+	proposerSettings     beaconprotocol.ProposerSettingsProvider
 	commonTimeout        time.Duration
 	longTimeout          time.Duration
+	attestationDataCache *attestationDataCache
 }
 
 // New init new client and go-client instance
@@ -188,16 +199,19 @@ func New(
 	}
 
 	client := &goClient{
-		log:               logger,
-		ctx:               opt.Context,
-		network:           opt.Network,
-		client:            httpClient.(*eth2clienthttp.Service),
-		graffiti:          opt.Graffiti,
-		gasLimit:          opt.GasLimit,
-		operatorDataStore: operatorDataStore,
-		registrationCache: map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
-		commonTimeout:     commonTimeout,
-		longTimeout:       longTimeout,
+		log:                  logger,
+		ctx:                  opt.Context,
+		network:              opt.Network,
+		client:               httpClient.(*eth2clienthttp.Service),
+		graffiti:             opt.Graffiti,
+		gasLimit:             opt.GasLimit,
+		operatorDataStore:    operatorDataStore,
+		registrationCache:    map[phase0.BLSPubKey]*api.VersionedSignedValidatorRegistration{},
+		builderRelays:        opt.BuilderRelays,
+		proposerSettings:     opt.ProposerSettings,
+		commonTimeout:        commonTimeout,
+		longTimeout:          longTimeout,
+		attestationDataCache: newAttestationDataCache(),
 	}
 
 	nodeVersionResp, err := client.client.NodeVersion(opt.Context, &api.NodeVersionOpts{})
@@ -226,6 +240,10 @@ func (gc *goClient) NodeClient() NodeClient {
 	return gc.nodeClient
 }
 
+func (gc *goClient) NodeVersion() string {
+	return gc.nodeVersion
+}
+
 // Healthy returns if beacon node is currently healthy: responds to requests, not in the syncing state, not optimistic
 // (for optimistic see https://github.com/ethereum/consensus-specs/blob/dev/sync/optimistic.md#block-production).
 func (gc *goClient) Healthy(ctx context.Context) error {
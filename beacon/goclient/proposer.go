@@ -363,12 +363,18 @@ func (gc *goClient) createValidatorRegistration(pubkey []byte, feeRecipient bell
 	pk := phase0.BLSPubKey{}
 	copy(pk[:], pubkey)
 
+	// The gas limit must be the one which the validator registration runner signed.
+	gasLimit := gc.gasLimit
+	if gc.proposerSettings != nil {
+		gasLimit = gc.proposerSettings.ProposerSettings(pk).GasLimit
+	}
+
 	signedReg := &api.VersionedSignedValidatorRegistration{
 		Version: spec.BuilderVersionV1,
 		V1: &eth2apiv1.SignedValidatorRegistration{
 			Message: &eth2apiv1.ValidatorRegistration{
 				FeeRecipient: feeRecipient,
-				GasLimit:     gc.gasLimit,
+				GasLimit:     gasLimit,
 				Timestamp:    gc.network.GetSlotStartTime(gc.network.GetEpochFirstSlot(gc.network.EstimatedCurrentEpoch())),
 				Pubkey:       pk,
 			},
@@ -449,6 +455,15 @@ func (gc *goClient) submitBatchedRegistrations(slot phase0.Slot, registrations [
 			bs = len(registrations)
 		}
 
+		if gc.builderRelays != nil {
+			// Relays are registered with directly, in addition to the beacon node.
+			if err := gc.builderRelays.SubmitValidatorRegistrations(gc.ctx, registrations[0:bs]); err != nil {
+				gc.log.Warn("failed to submit batched validator registrations to relays",
+					fields.Slot(slot),
+					zap.Error(err))
+			}
+		}
+
 		if err := gc.client.SubmitValidatorRegistrations(gc.ctx, registrations[0:bs]); err != nil {
 			return err
 		}
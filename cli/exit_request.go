@@ -0,0 +1,261 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+// createExitRequestCmd signs a validator exit request with the owner's key, to be stored offline until it's submitted.
+var createExitRequestCmd = &cobra.Command{
+	Use:   "create-exit-request",
+	Short: "Signs a validator exit request with the owner's key",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := logging.SetGlobalLogger("debug", "capital", "console", nil); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named(logging.NameExitRequest)
+
+		networkName, _ := cmd.Flags().GetString("network")
+		validatorPubKey, _ := cmd.Flags().GetString("validator")
+		ownerKeyFilePath, _ := cmd.Flags().GetString("owner-key-file")
+		validFor, _ := cmd.Flags().GetDuration("valid-for")
+		outputPath, _ := cmd.Flags().GetString("output")
+		preSign, _ := cmd.Flags().GetBool("presign")
+		epoch, _ := cmd.Flags().GetUint64("epoch")
+
+		network, err := networkconfig.GetNetworkConfigByName(networkName)
+		if err != nil {
+			logger.Fatal("failed to get network config", zap.Error(err))
+		}
+
+		pubKey, err := hexutil.Decode(validatorPubKey)
+		if err != nil {
+			logger.Fatal("failed to decode validator public key", zap.Error(err))
+		}
+
+		ownerKey, err := readOwnerKey(ownerKeyFilePath)
+		if err != nil {
+			logger.Fatal("failed to read owner key", zap.Error(err))
+		}
+
+		request := &types.ExitRequest{
+			ValidatorPubKey: pubKey,
+			Epoch:           phase0.Epoch(epoch),
+			PreSign:         preSign,
+			Deadline:        uint64(time.Now().Add(validFor).Unix()),
+		}
+		if err := request.Sign(network, ownerKey); err != nil {
+			logger.Fatal("failed to sign exit request", zap.Error(err))
+		}
+
+		data, err := json.MarshalIndent(request, "", "  ")
+		if err != nil {
+			logger.Fatal("failed to encode exit request", zap.Error(err))
+		}
+		if err := writeFile(outputPath, data); err != nil {
+			logger.Fatal("failed to save exit request", zap.Error(err))
+		}
+		logger.Info("exit request signed",
+			zap.String("owner", request.Owner.String()),
+			zap.Time("deadline", time.Unix(int64(request.Deadline), 0)),
+			zap.String("path", outputPath),
+		)
+	},
+}
+
+// submitExitRequestCmd submits a signed validator exit request to the API of one of the validator's operators.
+var submitExitRequestCmd = &cobra.Command{
+	Use:   "submit-exit-request",
+	Short: "Submits a signed validator exit request to an operator",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := logging.SetGlobalLogger("debug", "capital", "console", nil); err != nil {
+			log.Fatal(err)
+		}
+		logger := zap.L().Named(logging.NameExitRequest)
+
+		requestPath, _ := cmd.Flags().GetString("request-file")
+		nodeAPI, _ := cmd.Flags().GetString("node-api")
+		outputDir, _ := cmd.Flags().GetString("output-dir")
+		wait, _ := cmd.Flags().GetDuration("wait")
+		networkName, _ := cmd.Flags().GetString("network")
+		ownerKeyFilePath, _ := cmd.Flags().GetString("owner-key-file")
+		operatorID, _ := cmd.Flags().GetUint64("operator-id")
+
+		data, err := readFile(requestPath)
+		if err != nil {
+			logger.Fatal("failed to read exit request", zap.Error(err))
+		}
+		request := &types.ExitRequest{}
+		if err := json.Unmarshal(data, request); err != nil {
+			logger.Fatal("failed to decode exit request", zap.Error(err))
+		}
+		submittedAt := time.Now()
+		if err := submitExitRequest(nodeAPI, data); err != nil {
+			logger.Fatal("failed to submit exit request", zap.Error(err))
+		}
+		logger.Info("exit request submitted", zap.String("node_api", nodeAPI))
+
+		if !request.PreSign || outputDir == "" {
+			return
+		}
+
+		// Wait for the committee to sign the exit, and save it for the owner to submit whenever they wish.
+		if ownerKeyFilePath == "" || operatorID == 0 {
+			logger.Fatal("claiming the pre-signed exit requires the owner key file and the operator ID")
+		}
+		network, err := networkconfig.GetNetworkConfigByName(networkName)
+		if err != nil {
+			logger.Fatal("failed to get network config", zap.Error(err))
+		}
+		ownerKey, err := readOwnerKey(ownerKeyFilePath)
+		if err != nil {
+			logger.Fatal("failed to read owner key", zap.Error(err))
+		}
+		logger.Info("waiting for the pre-signed exit", zap.Duration("wait", wait))
+		claimant := &exitClaimant{network: network, ownerKey: ownerKey, operatorID: operatorID}
+		signedExit, err := waitForPreSignedExit(nodeAPI, request, claimant, submittedAt, wait)
+		if err != nil {
+			logger.Fatal("failed to get pre-signed exit", zap.Error(err))
+		}
+		signedExitData, err := json.MarshalIndent(signedExit.Exit, "", "  ")
+		if err != nil {
+			logger.Fatal("failed to encode pre-signed exit", zap.Error(err))
+		}
+		path := filepath.Join(outputDir, fmt.Sprintf("exit_%x_%d.json", []byte(request.ValidatorPubKey), signedExit.Exit.Message.Epoch))
+		if err := os.MkdirAll(outputDir, 0700); err != nil {
+			logger.Fatal("failed to create output directory", zap.Error(err))
+		}
+		if err := writeFile(path, signedExitData); err != nil {
+			logger.Fatal("failed to save pre-signed exit", zap.Error(err))
+		}
+		logger.Info("pre-signed exit saved", zap.String("path", path))
+	},
+}
+
+// exitClaimant signs the owner's claims of pre-signed exits from an operator.
+type exitClaimant struct {
+	network    networkconfig.NetworkConfig
+	ownerKey   *ecdsa.PrivateKey
+	operatorID spectypes.OperatorID
+}
+
+// claim returns a fresh claim of the validator's signed exits, since each claim is accepted only once.
+func (c *exitClaimant) claim(validatorPubKey []byte) ([]byte, error) {
+	claim, err := types.NewExitClaim(validatorPubKey, c.operatorID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := claim.Sign(c.network, c.ownerKey); err != nil {
+		return nil, err
+	}
+	return json.Marshal(claim)
+}
+
+// waitForPreSignedExit polls the operator's API until the requested exit is signed by the committee,
+// claiming it with a claim signed by the owner for each poll.
+func waitForPreSignedExit(nodeAPI string, request *types.ExitRequest, claimant *exitClaimant, submittedAt time.Time, wait time.Duration) (*registrystorage.SignedExit, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	url := strings.TrimSuffix(nodeAPI, "/") + "/v1/validators/exits/claim"
+	deadline := time.Now().Add(wait)
+	for time.Now().Before(deadline) {
+		time.Sleep(12 * time.Second)
+
+		claimData, err := claimant.claim(request.ValidatorPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not sign exit claim: %w", err)
+		}
+		resp, err := client.Post(url, "application/json", bytes.NewReader(claimData))
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		var response struct {
+			Data []*registrystorage.SignedExit `json:"data"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, signedExit := range response.Data {
+			if !signedExit.PreSigned || signedExit.SignedAt.Before(submittedAt) {
+				continue
+			}
+			if request.Epoch != 0 && signedExit.Exit.Message.Epoch != request.Epoch {
+				continue
+			}
+			return signedExit, nil
+		}
+	}
+	return nil, fmt.Errorf("exit wasn't signed within %s", wait)
+}
+
+// readOwnerKey reads the hex encoded private key of a validator's owner.
+func readOwnerKey(path string) (*ecdsa.PrivateKey, error) {
+	keyBytes, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(string(keyBytes)), "0x"))
+}
+
+func submitExitRequest(nodeAPI string, data []byte) error {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(strings.TrimSuffix(nodeAPI, "/")+"/v1/validators/exit", "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func init() {
+	createExitRequestCmd.Flags().String("network", "mainnet", "Network of the validator")
+	createExitRequestCmd.Flags().String("validator", "", "Hex encoded public key of the validator to exit")
+	createExitRequestCmd.Flags().String("owner-key-file", "", "File path to the hex encoded private key of the validator's owner")
+	createExitRequestCmd.Flags().Duration("valid-for", 365*24*time.Hour, "How long the exit request remains valid")
+	createExitRequestCmd.Flags().String("output", "exit_request.json", "File path to save the signed exit request to")
+	createExitRequestCmd.Flags().Bool("presign", false, "Have the exit signed and returned rather than submitted to the beacon chain")
+	createExitRequestCmd.Flags().Uint64("epoch", 0, "Epoch of a pre-signed exit, defaults to the epoch it's signed at")
+	_ = createExitRequestCmd.MarkFlagRequired("validator")
+	_ = createExitRequestCmd.MarkFlagRequired("owner-key-file")
+	RootCmd.AddCommand(createExitRequestCmd)
+
+	submitExitRequestCmd.Flags().String("request-file", "exit_request.json", "File path to the signed exit request")
+	submitExitRequestCmd.Flags().String("node-api", "http://localhost:16000", "URL of the operator node's SSV API")
+	submitExitRequestCmd.Flags().String("output-dir", "", "Directory to save the pre-signed exit to, once the committee signs it")
+	submitExitRequestCmd.Flags().Duration("wait", 5*time.Minute, "How long to wait for the pre-signed exit")
+	submitExitRequestCmd.Flags().String("network", "mainnet", "Network of the validator, to claim the pre-signed exit on")
+	submitExitRequestCmd.Flags().String("owner-key-file", "", "File path to the hex encoded private key of the validator's owner, to claim the pre-signed exit with")
+	submitExitRequestCmd.Flags().Uint64("operator-id", 0, "ID of the operator whose node API is used, to claim the pre-signed exit from")
+	RootCmd.AddCommand(submitExitRequestCmd)
+}
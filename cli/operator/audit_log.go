@@ -0,0 +1,67 @@
+package operator
+
+import (
+	"errors"
+	"log"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/auditlog"
+	global_config "github.com/bloxapp/ssv/cli/config"
+)
+
+// VerifyAuditLogCmd verifies the hash chain of the node's audit log of signatures.
+var VerifyAuditLogCmd = &cobra.Command{
+	Use:   "verify-audit-log",
+	Short: "Verifies that the node's audit log of signatures wasn't modified",
+	Long: `Verifies the hash chain of the audit log of the signatures produced by the node, across its rotated files,
+and reports the first record which was modified, removed or reordered.
+The log is read from the configured audit_log directory, unless --dir is given. It may be verified while the node is running.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		dir, _ := cmd.Flags().GetString("dir")
+		if dir == "" {
+			dir = cfg.AuditLog.Dir
+		}
+		if dir == "" {
+			logger.Fatal("no audit log directory is configured")
+		}
+
+		result, err := auditlog.Verify(dir)
+		if err != nil {
+			var verifyErr *auditlog.VerifyError
+			if errors.As(err, &verifyErr) {
+				logger.Fatal("audit log is invalid",
+					zap.String("file", verifyErr.File),
+					zap.Int("line", verifyErr.Line),
+					zap.Uint64("valid_records", result.Records),
+					zap.Error(verifyErr.Err))
+			}
+			logger.Fatal("could not verify audit log", zap.Error(err))
+		}
+		if result.Records == 0 {
+			logger.Warn("audit log is empty", zap.String("dir", dir))
+			return
+		}
+		if result.Pruned {
+			logger.Warn("audit log's oldest files were removed by rotation, verified from its oldest remaining record",
+				zap.Uint64("first_seq", result.FirstSeq))
+		}
+		logger.Info("audit log is valid",
+			zap.Int("files", result.Files),
+			zap.Uint64("records", result.Records),
+			zap.Uint64("first_seq", result.FirstSeq),
+			zap.Uint64("last_seq", result.LastSeq),
+			zap.String("last_hash", result.LastHash))
+	},
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, VerifyAuditLogCmd)
+	VerifyAuditLogCmd.Flags().String("dir", "", "Directory of the audit log, overriding the config's")
+}
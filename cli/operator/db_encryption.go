@@ -0,0 +1,118 @@
+package operator
+
+import (
+	"crypto/sha256"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/operator/keys"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+// dbEncryptionKeyMessage is signed by the operator key to derive the database's encryption key from.
+// Signatures are deterministic, so the key is stable, and only the operator key's holder can derive it.
+const dbEncryptionKeyMessage = "ssv node database encryption key"
+
+// setupDBEncryption sets the key encrypting the node's database, and migrates the database to it
+// from the previous key or password it was encrypted with, or from being unencrypted.
+// If encryption isn't enabled, a database encrypted with the configured keys is decrypted.
+func setupDBEncryption(logger *zap.Logger, operatorPrivKey, previousPrivKey keys.OperatorPrivateKey) {
+	key, previousKeys, err := dbEncryptionKeys(operatorPrivKey, previousPrivKey, false)
+	if err != nil {
+		logger.Fatal("could not derive database encryption key", zap.Error(err))
+	}
+	cfg.DBOptions.EncryptionKey = nil
+	if cfg.DBOptions.Encrypt {
+		cfg.DBOptions.EncryptionKey = key
+	} else {
+		previousKeys = append(previousKeys, key)
+	}
+
+	migrated, err := kv.MigrateEncryption(logger, cfg.DBOptions, previousKeys...)
+	if errors.Is(err, kv.ErrUnknownEncryptionKey) {
+		logger.Fatal("database is encrypted with a key which can't be derived from the configured operator keys or passwords")
+	}
+	if err != nil {
+		logger.Fatal("could not migrate database encryption", zap.Error(err))
+	}
+	if migrated {
+		logger.Info("migrated database encryption", zap.Bool("encrypted", cfg.DBOptions.Encrypt))
+	}
+}
+
+// useDBEncryptionKey sets the key which the node's existing database is currently encrypted with,
+// for commands which shouldn't modify the database. Unlike setupDBEncryption, it neither migrates
+// the database nor creates it or its password salt.
+func useDBEncryptionKey(logger *zap.Logger, operatorPrivKey, previousPrivKey keys.OperatorPrivateKey) {
+	key, previousKeys, err := dbEncryptionKeys(operatorPrivKey, previousPrivKey, true)
+	if err != nil {
+		logger.Fatal("could not derive database encryption key", zap.Error(err))
+	}
+	cfg.DBOptions.EncryptionKey, err = kv.EncryptionKey(cfg.DBOptions.Path, append([][]byte{key}, previousKeys...)...)
+	if errors.Is(err, kv.ErrDatabaseNotFound) {
+		logger.Fatal("node database doesn't exist", zap.String("path", cfg.DBOptions.Path))
+	}
+	if err != nil {
+		logger.Fatal("could not find the database's encryption key", zap.Error(err))
+	}
+}
+
+// dbEncryptionKeys returns the database's encryption key, derived from the password if one is configured
+// or from the operator key otherwise, and its previous keys. If readOnly is true, passwords are only
+// derived with the database's existing salt.
+func dbEncryptionKeys(operatorPrivKey, previousPrivKey keys.OperatorPrivateKey, readOnly bool) ([]byte, [][]byte, error) {
+	operatorKey, err := dbEncryptionKeyFromOperatorKey(operatorPrivKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var previousKeys [][]byte
+	if previousPrivKey != nil {
+		previousKey, err := dbEncryptionKeyFromOperatorKey(previousPrivKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		previousKeys = append(previousKeys, previousKey)
+	}
+	if cfg.DBOptions.PreviousEncryptionPasswordFile != "" {
+		previousKey, err := dbEncryptionKeyFromPasswordFile(cfg.DBOptions.PreviousEncryptionPasswordFile, readOnly)
+		if err != nil {
+			return nil, nil, err
+		}
+		previousKeys = append(previousKeys, previousKey)
+	}
+
+	if cfg.DBOptions.EncryptionPasswordFile == "" {
+		return operatorKey, previousKeys, nil
+	}
+	key, err := dbEncryptionKeyFromPasswordFile(cfg.DBOptions.EncryptionPasswordFile, readOnly)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The database may have been encrypted with the operator key before the password was configured.
+	return key, append(previousKeys, operatorKey), nil
+}
+
+func dbEncryptionKeyFromOperatorKey(privKey keys.OperatorPrivateKey) ([]byte, error) {
+	signature, err := privKey.Sign([]byte(dbEncryptionKeyMessage))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign with operator key")
+	}
+	key := sha256.Sum256(signature)
+	return key[:], nil
+}
+
+func dbEncryptionKeyFromPasswordFile(passwordFile string, readOnly bool) ([]byte, error) {
+	password, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read password file")
+	}
+	trimmed := []byte(strings.TrimSpace(string(password)))
+	if readOnly {
+		return kv.ReadKeyFromPassword(cfg.DBOptions.Path, trimmed)
+	}
+	return kv.KeyFromPassword(cfg.DBOptions.Path, trimmed)
+}
@@ -0,0 +1,294 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/ekm"
+	"github.com/bloxapp/ssv/eth/contract"
+	"github.com/bloxapp/ssv/eth/eventhandler"
+	"github.com/bloxapp/ssv/eth/eventparser"
+	"github.com/bloxapp/ssv/eth/executionclient"
+	ibftstorage "github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/logging/fields"
+	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
+	"github.com/bloxapp/ssv/operator/keys"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+// EventsCmd groups the commands for debugging the registry contract's events.
+var EventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Debugging tools for the registry contract's events",
+}
+
+// replayEventsCmd replays the registry contract's events of a block range against a scratch database,
+// and diffs the resulting registry data with the node's database.
+var replayEventsCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replays registry events of a block range against a scratch database and diffs it with the node's database",
+	Long: `Replays the registry contract's events of a block range against a scratch in-memory database,
+prints the resulting operators, shares and recipients, and diffs them against the node's database.
+The node's database is only read, but the node must be stopped for it to be opened.
+Events are fetched from the configured execution client, or read from a JSON file of logs (see --save-logs).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		fromBlock, _ := cmd.Flags().GetUint64("from")
+		toBlock, _ := cmd.Flags().GetUint64("to")
+		logsFile, _ := cmd.Flags().GetString("logs-file")
+		saveLogsFile, _ := cmd.Flags().GetString("save-logs")
+		outputFile, _ := cmd.Flags().GetString("output")
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+		operatorPrivKey, _ := loadOperatorPrivateKey(logger)
+		previousPrivKey, _ := loadPreviousOperatorPrivateKey(logger)
+		if previousPrivKey != nil {
+			operatorPrivKey = keys.WithPreviousKeys(operatorPrivKey, previousPrivKey)
+		}
+
+		// Open the node's database without migrations, which would write to it.
+		cfg.DBOptions.Ctx = cmd.Context()
+		useDBEncryptionKey(logger, operatorPrivKey, previousPrivKey)
+		liveDB, err := kv.New(logger, cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("could not open the node's database, is the node running?", zap.Error(err))
+		}
+		defer liveDB.Close()
+		liveStorage, err := operatorstorage.NewNodeStorage(logger, liveDB)
+		if err != nil {
+			logger.Fatal("failed to create node storage", zap.Error(err))
+		}
+
+		if fromBlock == 0 {
+			fromBlock = networkConfig.RegistrySyncOffset.Uint64()
+		}
+		if toBlock == 0 {
+			lastProcessedBlock, found, err := liveStorage.GetLastProcessedBlock(nil)
+			if err != nil || !found {
+				logger.Fatal("could not get the node's last processed block, set --to instead", zap.Error(err))
+			}
+			toBlock = lastProcessedBlock.Uint64()
+		}
+		if fromBlock > toBlock {
+			logger.Fatal("empty block range", fields.FromBlock(fromBlock), fields.ToBlock(toBlock))
+		}
+
+		// Replay as this operator, so that its shares are decrypted and stored like the node does.
+		encodedPubKey, err := operatorPrivKey.Public().Base64()
+		if err != nil {
+			logger.Fatal("could not encode public key", zap.Error(err))
+		}
+		operatorData, found, err := liveStorage.GetOperatorDataByPubKey(nil, encodedPubKey)
+		if err != nil {
+			logger.Fatal("could not get operator data by public key", zap.Error(err))
+		}
+		if !found {
+			operatorData = &registrystorage.OperatorData{PublicKey: encodedPubKey}
+		}
+
+		scratchDB, err := kv.NewInMemory(logger, basedb.Options{Ctx: cmd.Context()})
+		if err != nil {
+			logger.Fatal("could not create scratch database", zap.Error(err))
+		}
+		defer scratchDB.Close()
+		scratchStorage, err := operatorstorage.NewNodeStorage(logger, scratchDB)
+		if err != nil {
+			logger.Fatal("failed to create scratch storage", zap.Error(err))
+		}
+		ekmHashedKey, err := operatorPrivKey.EKMHash()
+		if err != nil {
+			logger.Fatal("could not get operator private key hash", zap.Error(err))
+		}
+		keyManager, err := ekm.NewETHKeyManagerSigner(logger, scratchDB, networkConfig, true, ekmHashedKey)
+		if err != nil {
+			logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
+		}
+
+		contractAddr := ethcommon.HexToAddress(networkConfig.RegistryContractAddr)
+		var (
+			logStream <-chan executionclient.BlockLogs
+			fetchErrs <-chan error
+			filterer  *contract.ContractFilterer
+		)
+		if logsFile != "" {
+			logStream, err = readLogsFile(logsFile, fromBlock, toBlock)
+			if err != nil {
+				logger.Fatal("could not read logs file", zap.Error(err))
+			}
+			// Parsing logs requires the contract's ABI only.
+			filterer, err = contract.NewContractFilterer(contractAddr, nil)
+		} else {
+			var executionClient *executionclient.ExecutionClient
+			executionClient, err = executionclient.New(
+				cmd.Context(),
+				cfg.ExecutionClient.Addr,
+				contractAddr,
+				executionclient.WithLogger(logger),
+				executionclient.WithConnectionTimeout(cfg.ExecutionClient.ConnectionTimeout),
+			)
+			if err != nil {
+				logger.Fatal("could not connect to execution client", zap.Error(err))
+			}
+			defer executionClient.Close()
+			logStream, fetchErrs = executionClient.FetchLogs(cmd.Context(), fromBlock, toBlock)
+			filterer, err = executionClient.Filterer()
+		}
+		if err != nil {
+			logger.Fatal("failed to set up event filterer", zap.Error(err))
+		}
+
+		var savedLogs []ethtypes.Log
+		if saveLogsFile != "" {
+			logStream = teeLogs(cmd.Context(), logStream, &savedLogs)
+		}
+
+		eventHandler, err := eventhandler.New(
+			scratchStorage,
+			eventparser.New(filterer),
+			nil, // tasks aren't executed
+			networkConfig,
+			operatordatastore.New(operatorData),
+			operatorPrivKey,
+			keyManager,
+			nil,
+			ibftstorage.NewStores(),
+			eventhandler.WithFullNode(),
+			eventhandler.WithLogger(logger),
+		)
+		if err != nil {
+			logger.Fatal("failed to setup event data handler", zap.Error(err))
+		}
+
+		lastProcessedBlock, err := eventHandler.HandleBlockEventsStream(logStream, false)
+		if err != nil {
+			logger.Fatal("failed to replay events", zap.Error(err))
+		}
+		if fetchErrs != nil {
+			if err := <-fetchErrs; err != nil {
+				logger.Fatal("failed to fetch events", zap.Error(err))
+			}
+		}
+		logger.Info("replayed events",
+			fields.FromBlock(fromBlock),
+			fields.ToBlock(toBlock),
+			zap.Uint64("last_processed_block", lastProcessedBlock))
+
+		if saveLogsFile != "" {
+			if err := writeLogsFile(saveLogsFile, savedLogs); err != nil {
+				logger.Fatal("could not save logs", zap.Error(err))
+			}
+			logger.Info("saved logs", zap.String("path", saveLogsFile), fields.Count(len(savedLogs)))
+		}
+
+		replayed, err := operatorstorage.LoadRegistryState(scratchStorage)
+		if err != nil {
+			logger.Fatal("could not load replayed registry", zap.Error(err))
+		}
+		replayedJSON, err := json.MarshalIndent(replayed, "", "  ")
+		if err != nil {
+			logger.Fatal("could not encode replayed registry", zap.Error(err))
+		}
+		if outputFile != "" {
+			if err := os.WriteFile(filepath.Clean(outputFile), replayedJSON, 0600); err != nil {
+				logger.Fatal("could not save replayed registry", zap.Error(err))
+			}
+		} else {
+			fmt.Println(string(replayedJSON))
+		}
+
+		live, err := operatorstorage.LoadRegistryState(liveStorage)
+		if err != nil {
+			logger.Fatal("could not load the node's registry", zap.Error(err))
+		}
+		diff := live.Diff(replayed)
+		logger.Info("compared replayed registry with the node's database",
+			zap.Int("operators", len(replayed.Operators)),
+			zap.Int("shares", len(replayed.Shares)),
+			zap.Int("recipients", len(replayed.Recipients)),
+			zap.Int("differences", len(diff)))
+		for _, line := range diff {
+			fmt.Println(line)
+		}
+	},
+}
+
+// readLogsFile streams the logs of the given block range from a JSON file of logs.
+func readLogsFile(path string, fromBlock, toBlock uint64) (<-chan executionclient.BlockLogs, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	var logs []ethtypes.Log
+	if err := json.Unmarshal(data, &logs); err != nil {
+		return nil, fmt.Errorf("could not decode logs: %w", err)
+	}
+
+	inRange := make([]ethtypes.Log, 0, len(logs))
+	for _, log := range logs {
+		if log.BlockNumber >= fromBlock && log.BlockNumber <= toBlock && !log.Removed {
+			inRange = append(inRange, log)
+		}
+	}
+	blocks := executionclient.PackLogs(inRange)
+	stream := make(chan executionclient.BlockLogs, len(blocks))
+	for _, blockLogs := range blocks {
+		stream <- blockLogs
+	}
+	close(stream)
+	return stream, nil
+}
+
+// teeLogs forwards the given logs, collecting them as well.
+func teeLogs(ctx context.Context, in <-chan executionclient.BlockLogs, collected *[]ethtypes.Log) <-chan executionclient.BlockLogs {
+	out := make(chan executionclient.BlockLogs)
+	go func() {
+		defer close(out)
+		for blockLogs := range in {
+			*collected = append(*collected, blockLogs.Logs...)
+			select {
+			case out <- blockLogs:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func writeLogsFile(path string, logs []ethtypes.Log) error {
+	data, err := json.Marshal(logs)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Clean(path), data, 0600)
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, replayEventsCmd)
+	replayEventsCmd.Flags().Uint64("from", 0, "First block to replay, defaults to the network's registry sync offset")
+	replayEventsCmd.Flags().Uint64("to", 0, "Last block to replay, defaults to the node's last processed block")
+	replayEventsCmd.Flags().String("logs-file", "", "JSON file of logs to replay instead of fetching them from the execution client")
+	replayEventsCmd.Flags().String("save-logs", "", "File to save the replayed logs to, to be replayed with --logs-file")
+	replayEventsCmd.Flags().String("output", "", "File to save the replayed registry to as JSON, rather than printing it")
+	EventsCmd.AddCommand(replayEventsCmd)
+}
@@ -8,12 +8,17 @@ import (
 	"math/big"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/bloxapp/ssv/operator/keystore"
+	"github.com/bloxapp/ssv/protocol/v2/message"
 
 	"github.com/bloxapp/ssv/network"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ilyakaznacheev/cleanenv"
@@ -23,6 +28,8 @@ import (
 
 	"github.com/bloxapp/ssv/api/handlers"
 	apiserver "github.com/bloxapp/ssv/api/server"
+	"github.com/bloxapp/ssv/auditlog"
+	"github.com/bloxapp/ssv/beacon/builder"
 	"github.com/bloxapp/ssv/beacon/goclient"
 	global_config "github.com/bloxapp/ssv/cli/config"
 	"github.com/bloxapp/ssv/ekm"
@@ -42,8 +49,10 @@ import (
 	"github.com/bloxapp/ssv/monitoring/metrics"
 	"github.com/bloxapp/ssv/monitoring/metricsreporter"
 	p2pv1 "github.com/bloxapp/ssv/network/p2p"
+	networkpeers "github.com/bloxapp/ssv/network/peers"
 	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/bloxapp/ssv/nodeprobe"
+	"github.com/bloxapp/ssv/notifications"
 	"github.com/bloxapp/ssv/operator"
 	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
 	"github.com/bloxapp/ssv/operator/duties/dutystore"
@@ -67,6 +76,13 @@ type KeyStore struct {
 	PasswordFile   string `yaml:"PasswordFile" env:"PASSWORD_FILE" env-description:"Password for operator private key file decryption"`
 }
 
+type PKCS11 struct {
+	Module  string `yaml:"Module" env:"PKCS11_MODULE" env-description:"Path to the PKCS#11 module of the HSM holding the operator private key, which is used instead of OperatorPrivateKey and KeyStore"`
+	Slot    uint   `yaml:"Slot" env:"PKCS11_SLOT" env-description:"ID of the slot of the HSM token holding the operator private key"`
+	Label   string `yaml:"Label" env:"PKCS11_KEY_LABEL" env-description:"Label of the operator private key in the HSM"`
+	PINFile string `yaml:"PINFile" env:"PKCS11_PIN_FILE" env-description:"File containing the user PIN of the HSM token"`
+}
+
 type config struct {
 	global_config.GlobalConfig `yaml:"global"`
 	DBOptions                  basedb.Options                   `yaml:"db"`
@@ -74,15 +90,29 @@ type config struct {
 	ExecutionClient            executionclient.ExecutionOptions `yaml:"eth1"` // TODO: execution_client in yaml
 	ConsensusClient            beaconprotocol.Options           `yaml:"eth2"` // TODO: consensus_client in yaml
 	P2pNetworkConfig           p2pv1.Config                     `yaml:"p2p"`
+	Builder                    builder.Config                   `yaml:"builder"`
 	KeyStore                   KeyStore                         `yaml:"KeyStore"`
+	PKCS11                     PKCS11                           `yaml:"PKCS11"`
 	OperatorPrivateKey         string                           `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
+	PreviousKeyStore           KeyStore                         `yaml:"PreviousKeyStore" env-prefix:"PREVIOUS_"`
+	PreviousOperatorPrivateKey string                           `yaml:"PreviousOperatorPrivateKey" env:"PREVIOUS_OPERATOR_KEY" env-description:"Operator private key before it was rotated, used to migrate the node's database to the current key and to decrypt shares encrypted to it"`
 	MetricsAPIPort             int                              `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"Port to listen on for the metrics API."`
 	EnableProfile              bool                             `yaml:"EnableProfile" env:"ENABLE_PROFILE" env-description:"flag that indicates whether go profiling tools are enabled"`
 	NetworkPrivateKey          string                           `yaml:"NetworkPrivateKey" env:"NETWORK_PRIVATE_KEY" env-description:"private key for network identity"`
 	WsAPIPort                  int                              `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-description:"Port to listen on for the websocket API."`
 	WithPing                   bool                             `yaml:"WithPing" env:"WITH_PING" env-description:"Whether to send websocket ping messages'"`
 	SSVAPIPort                 int                              `yaml:"SSVAPIPort" env:"SSV_API_PORT" env-description:"Port to listen on for the SSV API."`
+	SSVAdminAPIPort            int                              `yaml:"SSVAdminAPIPort" env:"SSV_ADMIN_API_PORT" env-description:"Port to listen on localhost for the SSV admin API, which changes the node's state."`
 	LocalEventsPath            string                           `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"path to local events"`
+	ValidationPolicyFile       string                           `yaml:"ValidationPolicyFile" env:"VALIDATION_POLICY_FILE" env-description:"Path to a YAML message validation policy, reloaded on SIGHUP"`
+	SignatureBatchWindow       time.Duration                    `yaml:"SignatureBatchWindow" env:"SIGNATURE_BATCH_WINDOW" env-description:"Window for collecting the BLS signatures of incoming messages to verify them in batches, disabled if zero"`
+	SignatureBatchSize         int                              `yaml:"SignatureBatchSize" env:"SIGNATURE_BATCH_SIZE" env-default:"128" env-description:"Maximum number of BLS signatures verified in a batch"`
+	ProposerConfigFile         string                           `yaml:"ProposerConfigFile" env:"PROPOSER_CONFIG_FILE" env-description:"Path to a YAML proposer config with per-validator or per-owner builder and gas limit options, reloaded on SIGHUP"`
+	RegistrySnapshotFile       string                           `yaml:"RegistrySnapshotFile" env:"REGISTRY_SNAPSHOT_FILE" env-description:"Path to a registry snapshot to import instead of syncing the full history of events, if the node hasn't synced any"`
+	RegistrySnapshotSigner     string                           `yaml:"RegistrySnapshotSigner" env:"REGISTRY_SNAPSHOT_SIGNER" env-description:"Base64 public key of the operator trusted to sign the imported registry snapshot"`
+	Notifications              notifications.Config             `yaml:"notifications"`
+	AuditLog                   auditlog.Config                  `yaml:"audit_log"`
+	RunwayWarningThreshold     time.Duration                    `yaml:"RunwayWarningThreshold" env:"CLUSTER_RUNWAY_WARNING_THRESHOLD" env-description:"Warn when the estimated time until one of the operator's clusters can be liquidated drops below this threshold, defaults to the contract's liquidation threshold period if zero"`
 }
 
 var cfg config
@@ -115,49 +145,36 @@ var StartNodeCmd = &cobra.Command{
 		if err != nil {
 			logger.Fatal("could not setup network", zap.Error(err))
 		}
+		operatorPrivKey, operatorPrivKeyText := loadOperatorPrivateKey(logger)
+		previousPrivKey, previousPrivKeyText := loadPreviousOperatorPrivateKey(logger)
+
 		cfg.DBOptions.Ctx = cmd.Context()
+		setupDBEncryption(logger, operatorPrivKey, previousPrivKey)
 		db, err := setupDB(logger, networkConfig.Beacon.GetNetwork())
 		if err != nil {
 			logger.Fatal("could not setup db", zap.Error(err))
 		}
 
-		var operatorPrivKey keys.OperatorPrivateKey
-		var operatorPrivKeyText string
-		if cfg.KeyStore.PrivateKeyFile != "" {
-			// nolint: gosec
-			encryptedJSON, err := os.ReadFile(cfg.KeyStore.PrivateKeyFile)
-			if err != nil {
-				logger.Fatal("could not read PEM file", zap.Error(err))
-			}
+		notificationService, notifier := setupNotifications(cmd.Context(), logger, db)
 
-			// nolint: gosec
-			keyStorePassword, err := os.ReadFile(cfg.KeyStore.PasswordFile)
-			if err != nil {
-				logger.Fatal("could not read password file", zap.Error(err))
-			}
-
-			decryptedKeystore, err := keystore.DecryptKeystore(encryptedJSON, string(keyStorePassword))
-			if err != nil {
-				logger.Fatal("could not decrypt operator private key keystore", zap.Error(err))
-			}
-			operatorPrivKey, err = keys.PrivateKeyFromBytes(decryptedKeystore)
-			if err != nil {
-				logger.Fatal("could not extract operator private key from file", zap.Error(err))
-			}
+		nodeStorage, operatorData := setupOperatorStorage(logger, db, networkConfig, operatorPrivKey, operatorPrivKeyText, previousPrivKey, previousPrivKeyText)
+		operatorDataStore := operatordatastore.New(operatorData)
+		if previousPrivKey != nil {
+			operatorPrivKey = keys.WithPreviousKeys(operatorPrivKey, previousPrivKey)
+		}
 
-			operatorPrivKeyText = base64.StdEncoding.EncodeToString(decryptedKeystore)
-		} else {
-			operatorPrivKey, err = keys.PrivateKeyFromString(cfg.OperatorPrivateKey)
+		ekmOptions := []ekm.Option{ekm.WithNotifier(notifier)}
+		if cfg.AuditLog.Enabled() {
+			auditLog := setupAuditLog(logger)
+			defer auditLog.Close()
+			operatorPrivKey, err = auditlog.OperatorKey(operatorPrivKey, auditLog)
 			if err != nil {
-				logger.Fatal("could not decode operator private key", zap.Error(err))
+				logger.Fatal("could not audit operator key", zap.Error(err))
 			}
-			operatorPrivKeyText = cfg.OperatorPrivateKey
+			ekmOptions = append(ekmOptions, ekm.WithAuditLog(auditLog))
 		}
 		cfg.P2pNetworkConfig.OperatorSigner = operatorPrivKey
 
-		nodeStorage, operatorData := setupOperatorStorage(logger, db, operatorPrivKey, operatorPrivKeyText)
-		operatorDataStore := operatordatastore.New(operatorData)
-
 		usingLocalEvents := len(cfg.LocalEventsPath) != 0
 
 		verifyConfig(logger, nodeStorage, networkConfig.Name, usingLocalEvents)
@@ -167,7 +184,9 @@ var StartNodeCmd = &cobra.Command{
 			logger.Fatal("could not get operator private key hash", zap.Error(err))
 		}
 
-		keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, cfg.SSVOptions.ValidatorOptions.BuilderProposals, ekmHashedKey)
+		// with a proposer config, builder proposals may be enabled for any validator at runtime.
+		builderProposals := cfg.SSVOptions.ValidatorOptions.BuilderProposals || cfg.ProposerConfigFile != ""
+		keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, builderProposals, ekmHashedKey, ekmOptions...)
 		if err != nil {
 			logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
 		}
@@ -191,6 +210,26 @@ var StartNodeCmd = &cobra.Command{
 		cfg.ConsensusClient.GasLimit = spectypes.DefaultGasLimit
 		cfg.ConsensusClient.Network = networkConfig.Beacon.GetNetwork()
 
+		var proposerConfigManager *beaconprotocol.ProposerConfigManager
+		reloadProposerConfig := func() error {
+			return errors.New("no proposer config file is configured")
+		}
+		if cfg.ProposerConfigFile != "" || cfg.ConsensusClient.GraffitiTemplate != "" {
+			proposerConfigManager = setupProposerConfig(logger, nodeStorage)
+			if cfg.ProposerConfigFile != "" {
+				reloadProposerConfig = func() error {
+					return reloadProposerConfigFile(logger, proposerConfigManager)
+				}
+				go reloadOnSignal(cmd.Context(), logger, reloadProposerConfig)
+			}
+
+			cfg.ConsensusClient.ProposerSettings = proposerConfigManager
+			cfg.SSVOptions.ValidatorOptions.ProposerSettings = proposerConfigManager
+		}
+		if cfg.Builder.Enabled() {
+			cfg.ConsensusClient.BuilderRelays = setupBuilder(logger, networkConfig, nodeStorage, cfg.SSVOptions.ValidatorOptions.ProposerSettings)
+		}
+
 		consensusClient := setupConsensusClient(logger, operatorDataStore, slotTickerProvider)
 
 		executionClient, err := executionclient.New(
@@ -208,8 +247,27 @@ var StartNodeCmd = &cobra.Command{
 			logger.Fatal("could not connect to execution client", zap.Error(err))
 		}
 
+		executionNode, err := executionClient.ClientVersion(cmd.Context())
+		if err != nil {
+			logger.Warn("could not get execution client version", zap.Error(err))
+		}
+		var consensusNode string
+		if nodeClientProvider, ok := consensusClient.(goclient.NodeClientProvider); ok {
+			consensusNode = nodeClientProvider.NodeVersion()
+		} else {
+			logger.Warn("could not get consensus client version")
+		}
+		cfg.P2pNetworkConfig.ExecutionNode = executionNode
+		cfg.P2pNetworkConfig.ConsensusNode = consensusNode
+		cfg.SSVOptions.ValidatorOptions.GraffitiData = beaconprotocol.GraffitiData{
+			NodeVersion:     commons.GetNodeVersion(),
+			ExecutionClient: beaconprotocol.ClientName(executionNode),
+			ConsensusClient: beaconprotocol.ClientName(consensusNode),
+		}
+
 		cfg.P2pNetworkConfig.Permissioned = permissioned
 		cfg.P2pNetworkConfig.NodeStorage = nodeStorage
+		cfg.P2pNetworkConfig.PeerStore = networkpeers.NewPeerStore(logger, db)
 		cfg.P2pNetworkConfig.OperatorPubKeyHash = format.OperatorID(operatorData.PublicKey)
 		cfg.P2pNetworkConfig.OperatorDataStore = operatorDataStore
 		cfg.P2pNetworkConfig.FullNode = cfg.SSVOptions.ValidatorOptions.FullNode
@@ -220,14 +278,34 @@ var StartNodeCmd = &cobra.Command{
 		dutyStore := dutystore.New()
 		cfg.SSVOptions.DutyStore = dutyStore
 
-		messageValidator := validation.NewMessageValidator(
-			networkConfig,
+		validationPolicy := validation.DefaultPolicy()
+		if cfg.ValidationPolicyFile != "" {
+			validationPolicy, err = validation.LoadPolicy(cfg.ValidationPolicyFile)
+			if err != nil {
+				logger.Fatal("could not load message validation policy", zap.Error(err))
+			}
+		}
+
+		messageValidatorOptions := []validation.Option{
 			validation.WithNodeStorage(nodeStorage),
 			validation.WithLogger(logger),
 			validation.WithMetrics(metricsReporter),
 			validation.WithDutyStore(dutyStore),
 			validation.WithOwnOperatorID(operatorDataStore),
-		)
+			validation.WithPolicy(validationPolicy),
+		}
+		if cfg.SignatureBatchWindow > 0 {
+			messageValidatorOptions = append(messageValidatorOptions,
+				validation.WithSignatureBatching(cmd.Context(), cfg.SignatureBatchWindow, cfg.SignatureBatchSize))
+		}
+		messageValidator := validation.NewMessageValidator(networkConfig, messageValidatorOptions...)
+		validationPolicyManager := messageValidator.(validation.PolicyManager)
+		reloadValidationPolicy := func() error {
+			return reloadMessageValidationPolicy(logger, validationPolicyManager)
+		}
+		if cfg.ValidationPolicyFile != "" {
+			go reloadOnSignal(cmd.Context(), logger, reloadValidationPolicy)
+		}
 
 		cfg.P2pNetworkConfig.Metrics = metricsReporter
 		cfg.P2pNetworkConfig.MessageValidator = messageValidator
@@ -242,6 +320,7 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.Network = networkConfig
 		cfg.SSVOptions.P2PNetwork = p2pNetwork
 		cfg.SSVOptions.ValidatorOptions.BeaconNetwork = networkConfig.Beacon.GetNetwork()
+		cfg.SSVOptions.ValidatorOptions.NetworkConfig = networkConfig
 		cfg.SSVOptions.ValidatorOptions.Context = cmd.Context()
 		cfg.SSVOptions.ValidatorOptions.DB = db
 		cfg.SSVOptions.ValidatorOptions.Network = p2pNetwork
@@ -271,15 +350,17 @@ var StartNodeCmd = &cobra.Command{
 			spectypes.BNRoleSyncCommitteeContribution,
 			spectypes.BNRoleValidatorRegistration,
 			spectypes.BNRoleVoluntaryExit,
+			message.BNRoleCommittee,
 		}
 		storageMap := ibftstorage.NewStores()
 
 		for _, storageRole := range storageRoles {
-			storageMap.Add(storageRole, ibftstorage.New(cfg.SSVOptions.ValidatorOptions.DB, storageRole.String()))
+			storageMap.Add(storageRole, ibftstorage.New(cfg.SSVOptions.ValidatorOptions.DB, message.RoleToString(storageRole)))
 		}
 
 		cfg.SSVOptions.ValidatorOptions.StorageMap = storageMap
 		cfg.SSVOptions.ValidatorOptions.Metrics = metricsReporter
+		cfg.SSVOptions.ValidatorOptions.Notifier = notifier
 		cfg.SSVOptions.Metrics = metricsReporter
 
 		validatorCtrl := validator.NewController(logger, cfg.SSVOptions.ValidatorOptions)
@@ -294,6 +375,11 @@ var StartNodeCmd = &cobra.Command{
 		nodeProber := nodeprobe.NewProber(
 			logger,
 			func() {
+				if notificationService != nil {
+					ctx, cancel := context.WithTimeout(cmd.Context(), cfg.Notifications.Timeout)
+					notificationService.Flush(ctx)
+					cancel()
+				}
 				logger.Fatal("ethereum node(s) are either out of sync or down. Ensure the nodes are healthy to resume.")
 			},
 			map[string]nodeprobe.Node{
@@ -306,6 +392,7 @@ var StartNodeCmd = &cobra.Command{
 			},
 		)
 
+		nodeProber.SetNotifier(notifier)
 		nodeProber.Start(cmd.Context())
 		nodeProber.Wait()
 		logger.Info("ethereum node(s) are healthy")
@@ -323,6 +410,7 @@ var StartNodeCmd = &cobra.Command{
 			nodeStorage,
 			operatorDataStore,
 			operatorPrivKey,
+			notifier,
 		)
 		nodeProber.AddNode("event syncer", eventSyncer)
 
@@ -336,10 +424,11 @@ var StartNodeCmd = &cobra.Command{
 			logger.Fatal("failed to start network", zap.Error(err))
 		}
 
-		if cfg.SSVAPIPort > 0 {
+		if cfg.SSVAPIPort > 0 || cfg.SSVAdminAPIPort > 0 {
 			apiServer := apiserver.New(
 				logger,
 				fmt.Sprintf(":%d", cfg.SSVAPIPort),
+				fmt.Sprintf("127.0.0.1:%d", cfg.SSVAdminAPIPort),
 				&handlers.Node{
 					// TODO: replace with narrower interface! (instead of accessing the entire PeersIndex)
 					ListenAddresses: []string{fmt.Sprintf("tcp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.TCPPort), fmt.Sprintf("udp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.UDPPort)},
@@ -349,15 +438,40 @@ var StartNodeCmd = &cobra.Command{
 					NodeProber:      nodeProber,
 				},
 				&handlers.Validators{
-					Shares: nodeStorage.Shares(),
+					Shares:      nodeStorage.Shares(),
+					Exits:       validatorCtrl,
+					SignedExits: nodeStorage.SignedExits(),
+				},
+				&handlers.Validation{
+					Policy:       validationPolicyManager,
+					ReloadPolicy: reloadValidationPolicy,
+				},
+				&handlers.Proposer{
+					Config:       proposerConfigManager,
+					ReloadConfig: reloadProposerConfig,
+				},
+				&handlers.Registry{
+					Storage:    nodeStorage,
+					OperatorID: operatorDataStore.GetOperatorID,
+					BlockTime:  networkConfig.SlotDurationSec(),
 				},
 			)
-			go func() {
-				err := apiServer.Run()
-				if err != nil {
-					logger.Fatal("failed to start API server", zap.Error(err))
-				}
-			}()
+			if cfg.SSVAPIPort > 0 {
+				go func() {
+					err := apiServer.Run()
+					if err != nil {
+						logger.Fatal("failed to start API server", zap.Error(err))
+					}
+				}()
+			}
+			if cfg.SSVAdminAPIPort > 0 {
+				go func() {
+					err := apiServer.RunAdmin()
+					if err != nil {
+						logger.Fatal("failed to start admin API server", zap.Error(err))
+					}
+				}()
+			}
 		}
 
 		if err := operatorNode.Start(logger); err != nil {
@@ -423,6 +537,103 @@ func setupGlobal() (*zap.Logger, error) {
 	return zap.L(), nil
 }
 
+// loadOperatorPrivateKey loads the operator's private key from the configured keystore or text,
+// returning it alongside its text form.
+func loadOperatorPrivateKey(logger *zap.Logger) (keys.OperatorPrivateKey, string) {
+	if cfg.PKCS11.Module != "" {
+		return loadPKCS11PrivateKey(logger), ""
+	}
+	return loadPrivateKey(logger, cfg.OperatorPrivateKey, cfg.KeyStore)
+}
+
+// loadPKCS11PrivateKey loads the operator private key held by an HSM, which signs and decrypts with it.
+func loadPKCS11PrivateKey(logger *zap.Logger) keys.OperatorPrivateKey {
+	// nolint: gosec
+	pin, err := os.ReadFile(cfg.PKCS11.PINFile)
+	if err != nil {
+		logger.Fatal("could not read PKCS#11 PIN file", zap.Error(err))
+	}
+	operatorPrivKey, err := keys.PrivateKeyFromPKCS11(keys.PKCS11Options{
+		Module: cfg.PKCS11.Module,
+		Slot:   cfg.PKCS11.Slot,
+		PIN:    strings.TrimSpace(string(pin)),
+		Label:  cfg.PKCS11.Label,
+	})
+	if err != nil {
+		logger.Fatal("could not load operator private key from HSM", zap.Error(err))
+	}
+	logger.Info("loaded operator private key from HSM",
+		zap.String("module", cfg.PKCS11.Module),
+		zap.Uint("slot", cfg.PKCS11.Slot),
+		zap.String("label", cfg.PKCS11.Label))
+	return operatorPrivKey
+}
+
+// loadPreviousOperatorPrivateKey loads the operator's key from before it was rotated, if it's configured.
+func loadPreviousOperatorPrivateKey(logger *zap.Logger) (keys.OperatorPrivateKey, string) {
+	if cfg.PreviousOperatorPrivateKey == "" && cfg.PreviousKeyStore.PrivateKeyFile == "" {
+		return nil, ""
+	}
+	return loadPrivateKey(logger.With(zap.String("key", "previous")), cfg.PreviousOperatorPrivateKey, cfg.PreviousKeyStore)
+}
+
+func loadPrivateKey(logger *zap.Logger, privateKey string, keyStore KeyStore) (keys.OperatorPrivateKey, string) {
+	if keyStore.PrivateKeyFile == "" {
+		operatorPrivKey, err := keys.PrivateKeyFromString(privateKey)
+		if err != nil {
+			logger.Fatal("could not decode operator private key", zap.Error(err))
+		}
+		return operatorPrivKey, privateKey
+	}
+
+	// nolint: gosec
+	encryptedJSON, err := os.ReadFile(keyStore.PrivateKeyFile)
+	if err != nil {
+		logger.Fatal("could not read PEM file", zap.Error(err))
+	}
+
+	// nolint: gosec
+	keyStorePassword, err := os.ReadFile(keyStore.PasswordFile)
+	if err != nil {
+		logger.Fatal("could not read password file", zap.Error(err))
+	}
+
+	decryptedKeystore, err := keystore.DecryptKeystore(encryptedJSON, string(keyStorePassword))
+	if err != nil {
+		logger.Fatal("could not decrypt operator private key keystore", zap.Error(err))
+	}
+	operatorPrivKey, err := keys.PrivateKeyFromBytes(decryptedKeystore)
+	if err != nil {
+		logger.Fatal("could not extract operator private key from file", zap.Error(err))
+	}
+	return operatorPrivKey, base64.StdEncoding.EncodeToString(decryptedKeystore)
+}
+
+// setupNotifications starts sending notifications of node events to the configured sinks.
+// Without sinks, events are discarded and no service is returned.
+func setupNotifications(ctx context.Context, logger *zap.Logger, db basedb.Database) (*notifications.Service, notifications.Notifier) {
+	if len(cfg.Notifications.Sinks) == 0 {
+		return nil, notifications.NopNotifier{}
+	}
+	service, err := notifications.New(logger, db, cfg.Notifications)
+	if err != nil {
+		logger.Fatal("could not setup notifications", zap.Error(err))
+	}
+	service.Start(ctx)
+	logger.Info("sending notifications", fields.Count(len(cfg.Notifications.Sinks)))
+	return service, service
+}
+
+// setupAuditLog opens the audit log of the signatures produced by the node.
+func setupAuditLog(logger *zap.Logger) *auditlog.Log {
+	auditLog, err := auditlog.Open(logger.Named(logging.NameAuditLog), cfg.AuditLog)
+	if err != nil {
+		logger.Fatal("could not open signing audit log", zap.Error(err))
+	}
+	logger.Info("recording signatures in audit log", zap.String("dir", cfg.AuditLog.Dir))
+	return auditLog
+}
+
 func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.BadgerDB, error) {
 	db, err := kv.New(logger, cfg.DBOptions)
 	if err != nil {
@@ -474,7 +685,15 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 	return db, nil
 }
 
-func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey keys.OperatorPrivateKey, configPrivKeyText string) (operatorstorage.Storage, *registrystorage.OperatorData) {
+func setupOperatorStorage(
+	logger *zap.Logger,
+	db basedb.Database,
+	networkConfig networkconfig.NetworkConfig,
+	configPrivKey keys.OperatorPrivateKey,
+	configPrivKeyText string,
+	previousPrivKey keys.OperatorPrivateKey,
+	previousPrivKeyText string,
+) (operatorstorage.Storage, *registrystorage.OperatorData) {
 	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
 	if err != nil {
 		logger.Fatal("failed to create node storage", zap.Error(err))
@@ -490,25 +709,19 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey
 		logger.Fatal("could not hash private key", zap.Error(err))
 	}
 
-	// Backwards compatibility for the old hashing method,
-	// which was hashing the text from the configuration directly,
-	// whereas StorageHash re-encodes with PEM format.
-	cliPrivKeyDecoded, err := base64.StdEncoding.DecodeString(configPrivKeyText)
-	if err != nil {
-		logger.Fatal("could not decode private key", zap.Error(err))
-	}
-	configStoragePrivKeyLegacyHash, err := rsaencryption.HashRsaKey(cliPrivKeyDecoded)
-	if err != nil {
-		logger.Fatal("could not hash private key", zap.Error(err))
-	}
-
 	if !found {
-		if err := nodeStorage.SavePrivateKeyHash(configStoragePrivKeyHash); err != nil {
+		if err := nodeStorage.SavePrivateKeyHash(nil, configStoragePrivKeyHash); err != nil {
 			logger.Fatal("could not save hashed private key", zap.Error(err))
 		}
-	} else if configStoragePrivKeyHash != storedPrivKeyHash &&
-		configStoragePrivKeyLegacyHash != storedPrivKeyHash {
-		logger.Fatal("operator private key is not matching the one encrypted the storage")
+	} else if !privateKeyMatchesHash(logger, configPrivKey, configPrivKeyText, storedPrivKeyHash) {
+		if previousPrivKey == nil || !privateKeyMatchesHash(logger, previousPrivKey, previousPrivKeyText, storedPrivKeyHash) {
+			logger.Fatal("operator private key is not matching the one encrypted the storage")
+		}
+		signerStorage := ekm.NewSignerStorage(db, networkConfig.Beacon, logger)
+		if err := rotateOperatorKey(db, nodeStorage, signerStorage, previousPrivKey, configPrivKey); err != nil {
+			logger.Fatal("could not rotate operator private key", zap.Error(err))
+		}
+		logger.Info("rotated operator private key, the previous key is only used to decrypt shares encrypted to it")
 	}
 
 	encodedPubKey, err := configPrivKey.Public().Base64()
@@ -534,6 +747,140 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey
 	return nodeStorage, operatorData
 }
 
+// privateKeyMatchesHash returns whether the key is the one whose hash is stored in the node's database.
+func privateKeyMatchesHash(logger *zap.Logger, privKey keys.OperatorPrivateKey, privKeyText string, storedHash string) bool {
+	hash, err := privKey.StorageHash()
+	if err != nil {
+		logger.Fatal("could not hash private key", zap.Error(err))
+	}
+	if hash == storedHash {
+		return true
+	}
+
+	// Backwards compatibility for the old hashing method,
+	// which was hashing the text from the configuration directly,
+	// whereas StorageHash re-encodes with PEM format.
+	privKeyDecoded, err := base64.StdEncoding.DecodeString(privKeyText)
+	if err != nil {
+		logger.Fatal("could not decode private key", zap.Error(err))
+	}
+	legacyHash, err := rsaencryption.HashRsaKey(privKeyDecoded)
+	if err != nil {
+		logger.Fatal("could not hash private key", zap.Error(err))
+	}
+	return legacyHash == storedHash
+}
+
+// rotateOperatorKey re-encrypts the key manager's accounts from the previous operator key to the new one,
+// and replaces the hash of the operator key stored in the node's database, in a single transaction.
+func rotateOperatorKey(
+	db basedb.Database,
+	nodeStorage operatorstorage.Storage,
+	signerStorage ekm.Storage,
+	previousPrivKey keys.OperatorPrivateKey,
+	newPrivKey keys.OperatorPrivateKey,
+) error {
+	previousEncryptionKey, err := previousPrivKey.EKMHash()
+	if err != nil {
+		return errors.Wrap(err, "could not hash previous private key")
+	}
+	newEncryptionKey, err := newPrivKey.EKMHash()
+	if err != nil {
+		return errors.Wrap(err, "could not hash private key")
+	}
+	newStorageHash, err := newPrivKey.StorageHash()
+	if err != nil {
+		return errors.Wrap(err, "could not hash private key")
+	}
+	if err := signerStorage.SetEncryptionKey(previousEncryptionKey); err != nil {
+		return err
+	}
+
+	return db.Update(func(txn basedb.Txn) error {
+		if err := signerStorage.RotateEncryptionKeyTxn(txn, newEncryptionKey); err != nil {
+			return err
+		}
+		return nodeStorage.SavePrivateKeyHash(txn, newStorageHash)
+	})
+}
+
+// reloadMessageValidationPolicy reloads the message validation policy from the configured file.
+func reloadMessageValidationPolicy(logger *zap.Logger, policyManager validation.PolicyManager) error {
+	if cfg.ValidationPolicyFile == "" {
+		return errors.New("no message validation policy file is configured")
+	}
+	policy, err := validation.LoadPolicy(cfg.ValidationPolicyFile)
+	if err != nil {
+		return err
+	}
+	if err := policyManager.SetPolicy(policy); err != nil {
+		return err
+	}
+	logger.Info("reloaded message validation policy", zap.String("path", cfg.ValidationPolicyFile))
+	return nil
+}
+
+// setupProposerConfig loads the configured proposer config, resolving owner options from the validators' shares.
+func setupProposerConfig(logger *zap.Logger, nodeStorage operatorstorage.Storage) *beaconprotocol.ProposerConfigManager {
+	defaults := beaconprotocol.ProposerSettings{
+		BuilderEnabled: cfg.SSVOptions.ValidatorOptions.BuilderProposals,
+		GasLimit:       cfg.ConsensusClient.GasLimit,
+	}
+	if cfg.ConsensusClient.GraffitiTemplate != "" {
+		graffiti, err := beaconprotocol.ParseGraffiti(cfg.ConsensusClient.GraffitiTemplate)
+		if err != nil {
+			logger.Fatal("invalid graffiti template", zap.Error(err))
+		}
+		defaults.Graffiti = graffiti
+	}
+	ownerOf := func(pubKey phase0.BLSPubKey) (ethcommon.Address, bool) {
+		share := nodeStorage.Shares().Get(nil, pubKey[:])
+		if share == nil {
+			return ethcommon.Address{}, false
+		}
+		return share.OwnerAddress, true
+	}
+	manager := beaconprotocol.NewProposerConfigManager(defaults, ownerOf)
+	if cfg.ProposerConfigFile == "" {
+		return manager
+	}
+	if err := reloadProposerConfigFile(logger, manager); err != nil {
+		logger.Fatal("could not load proposer config", zap.Error(err))
+	}
+	return manager
+}
+
+// reloadProposerConfigFile reloads the proposer config from the configured file.
+func reloadProposerConfigFile(logger *zap.Logger, manager *beaconprotocol.ProposerConfigManager) error {
+	config, err := beaconprotocol.LoadProposerConfig(cfg.ProposerConfigFile)
+	if err != nil {
+		return err
+	}
+	if err := manager.SetProposerConfig(config); err != nil {
+		return err
+	}
+	logger.Info("reloaded proposer config", zap.String("path", cfg.ProposerConfigFile))
+	return nil
+}
+
+// reloadOnSignal calls reload whenever the process receives SIGHUP, until the context is done.
+func reloadOnSignal(ctx context.Context, logger *zap.Logger, reload func() error) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			if err := reload(); err != nil {
+				logger.Error("could not reload on SIGHUP", zap.Error(err))
+			}
+		}
+	}
+}
+
 func setupSSVNetwork(logger *zap.Logger) (networkconfig.NetworkConfig, error) {
 	networkConfig, err := networkconfig.GetNetworkConfigByName(cfg.SSVOptions.NetworkName)
 	if err != nil {
@@ -575,6 +922,37 @@ func setupP2P(logger *zap.Logger, db basedb.Database, mr metricsreporter.Metrics
 	return p2pv1.New(logger, &cfg.P2pNetworkConfig, mr)
 }
 
+func setupBuilder(
+	logger *zap.Logger,
+	networkConfig networkconfig.NetworkConfig,
+	nodeStorage operatorstorage.Storage,
+	proposerSettings beaconprotocol.ProposerSettingsProvider,
+) *builder.Service {
+	pubKeyOf := func(index phase0.ValidatorIndex) (phase0.BLSPubKey, bool) {
+		var pubKey phase0.BLSPubKey
+		found := false
+		nodeStorage.Shares().Range(nil, func(share *types.SSVShare) bool {
+			if share.HasBeaconMetadata() && share.BeaconMetadata.Index == index {
+				copy(pubKey[:], share.ValidatorPubKey)
+				found = true
+				return false
+			}
+			return true
+		})
+		return pubKey, found
+	}
+	svc, err := builder.New(logger, cfg.Builder, networkConfig.ForkVersion(), proposerSettings, pubKeyOf)
+	if err != nil {
+		logger.Fatal("failed to create builder relay client", zap.Error(err))
+	}
+	go func() {
+		if err := svc.ListenAndServe(); err != nil {
+			logger.Fatal("failed to start builder API server", zap.Error(err))
+		}
+	}()
+	return svc
+}
+
 func setupConsensusClient(
 	logger *zap.Logger,
 	operatorDataStore operatordatastore.OperatorDataStore,
@@ -600,6 +978,7 @@ func setupEventHandling(
 	nodeStorage operatorstorage.Storage,
 	operatorDataStore operatordatastore.OperatorDataStore,
 	operatorDecrypter keys.OperatorDecrypter,
+	notifier notifications.Notifier,
 ) *eventsyncer.EventSyncer {
 	eventFilterer, err := executionClient.Filterer()
 	if err != nil {
@@ -621,6 +1000,8 @@ func setupEventHandling(
 		eventhandler.WithFullNode(),
 		eventhandler.WithLogger(logger),
 		eventhandler.WithMetrics(metricsReporter),
+		eventhandler.WithRunwayWarningThreshold(cfg.RunwayWarningThreshold),
+		eventhandler.WithNotifier(notifier),
 	)
 	if err != nil {
 		logger.Fatal("failed to setup event data handler", zap.Error(err))
@@ -634,6 +1015,10 @@ func setupEventHandling(
 		eventsyncer.WithMetrics(metricsReporter),
 	)
 
+	if cfg.RegistrySnapshotFile != "" && len(cfg.LocalEventsPath) == 0 {
+		importRegistrySnapshot(ctx, logger, executionClient, eventHandler, networkConfig, nodeStorage, operatorDataStore)
+	}
+
 	fromBlock, found, err := nodeStorage.GetLastProcessedBlock(nil)
 	if err != nil {
 		logger.Fatal("syncing registry contract events failed, could not get last processed block", zap.Error(err))
@@ -1,16 +1,22 @@
 package operator
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/bloxapp/ssv/ekm"
 	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator/keys"
 	operatorstorage "github.com/bloxapp/ssv/operator/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/storage/kv"
+	"github.com/bloxapp/ssv/utils/threshold"
 )
 
 func Test_verifyConfig(t *testing.T) {
@@ -136,3 +142,137 @@ func Test_verifyConfig(t *testing.T) {
 		require.NoError(t, nodeStorage.DeleteConfig(nil))
 	})
 }
+
+func Test_setupOperatorStorageRotation(t *testing.T) {
+	threshold.Init()
+	logger := zap.New(zapcore.NewNopCore(), zap.WithFatalHook(zapcore.WriteThenPanic))
+
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+
+	previousKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	newKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	// The node's database is locked to the previous key, which encrypts the key manager's shares.
+	setupOperatorStorage(logger, db, networkconfig.TestNetwork, previousKey, string(previousKey.Base64()), nil, "")
+	previousEncryptionKey, err := previousKey.EKMHash()
+	require.NoError(t, err)
+	km, err := ekm.NewETHKeyManagerSigner(logger, db, networkconfig.TestNetwork, false, previousEncryptionKey)
+	require.NoError(t, err)
+	share := &bls.SecretKey{}
+	share.SetByCSPRNG()
+	require.NoError(t, km.AddShare(share))
+
+	// A different key can't be used without the previous one.
+	require.PanicsWithValue(t, "operator private key is not matching the one encrypted the storage", func() {
+		setupOperatorStorage(logger, db, networkconfig.TestNetwork, newKey, string(newKey.Base64()), nil, "")
+	})
+	require.PanicsWithValue(t, "operator private key is not matching the one encrypted the storage", func() {
+		setupOperatorStorage(logger, db, networkconfig.TestNetwork, newKey, string(newKey.Base64()), otherKey, string(otherKey.Base64()))
+	})
+
+	// Rotating moves the database's lock and the shares' encryption to the new key.
+	nodeStorage, _ := setupOperatorStorage(logger, db, networkconfig.TestNetwork, newKey, string(newKey.Base64()), previousKey, string(previousKey.Base64()))
+	storedHash, found, err := nodeStorage.GetPrivateKeyHash()
+	require.NoError(t, err)
+	require.True(t, found)
+	newHash, err := newKey.StorageHash()
+	require.NoError(t, err)
+	require.Equal(t, newHash, storedHash)
+
+	newEncryptionKey, err := newKey.EKMHash()
+	require.NoError(t, err)
+	km, err = ekm.NewETHKeyManagerSigner(logger, db, networkconfig.TestNetwork, false, newEncryptionKey)
+	require.NoError(t, err)
+	exported, err := km.(ekm.ShareExporter).ExportShare(share.GetPublicKey().SerializeToHexStr())
+	require.NoError(t, err)
+	require.True(t, share.IsEqual(exported))
+
+	// Once rotated, the previous key may remain configured.
+	setupOperatorStorage(logger, db, networkconfig.TestNetwork, newKey, string(newKey.Base64()), previousKey, string(previousKey.Base64()))
+}
+
+func Test_setupDBEncryption(t *testing.T) {
+	logger := zap.New(zapcore.NewNopCore(), zap.WithFatalHook(zapcore.WriteThenPanic))
+	dir := t.TempDir()
+	path := filepath.Join(dir, "db")
+	defer func(options basedb.Options) { cfg.DBOptions = options }(cfg.DBOptions)
+
+	previousKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	newKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	requireData := func() {
+		db, err := kv.New(logger, cfg.DBOptions)
+		require.NoError(t, err)
+		defer db.Close()
+		obj, found, err := db.Get([]byte("prefix"), []byte("key"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte("value"), obj.Value)
+	}
+
+	// Commands which don't migrate the database don't create it.
+	cfg.DBOptions = basedb.Options{Path: path}
+	require.PanicsWithValue(t, "node database doesn't exist", func() {
+		useDBEncryptionKey(logger, previousKey, nil)
+	})
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	db, err := kv.New(logger, basedb.Options{Path: path})
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("prefix"), []byte("key"), []byte("value")))
+	require.NoError(t, db.Close())
+
+	// An existing database is encrypted with a key derived from the operator key.
+	cfg.DBOptions = basedb.Options{Path: path, Encrypt: true}
+	setupDBEncryption(logger, previousKey, nil)
+	require.Len(t, cfg.DBOptions.EncryptionKey, kv.EncryptionKeySize)
+	requireData()
+	_, err = kv.New(logger, basedb.Options{Path: path})
+	require.Error(t, err)
+
+	// Its key is rotated with the operator key, which requires the previous operator key.
+	require.PanicsWithValue(t, "database is encrypted with a key which can't be derived from the configured operator keys or passwords", func() {
+		setupDBEncryption(logger, newKey, nil)
+	})
+	setupDBEncryption(logger, newKey, previousKey)
+	requireData()
+	setupDBEncryption(logger, newKey, nil)
+	requireData()
+
+	// A password replaces the operator key.
+	passwordFile := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0600))
+	cfg.DBOptions.EncryptionPasswordFile = passwordFile
+	require.PanicsWithValue(t, "could not derive database encryption key", func() {
+		useDBEncryptionKey(logger, newKey, nil)
+	})
+	_, err = kv.ReadKeyFromPassword(path, []byte("password"))
+	require.Error(t, err)
+	setupDBEncryption(logger, newKey, nil)
+	requireData()
+	passwordKey, err := kv.KeyFromPassword(path, []byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, passwordKey, cfg.DBOptions.EncryptionKey)
+
+	// Commands which don't migrate the database use its current key.
+	cfg.DBOptions = basedb.Options{Path: path}
+	require.PanicsWithValue(t, "could not find the database's encryption key", func() {
+		useDBEncryptionKey(logger, newKey, nil)
+	})
+	cfg.DBOptions.EncryptionPasswordFile = passwordFile
+	useDBEncryptionKey(logger, newKey, nil)
+	require.Equal(t, passwordKey, cfg.DBOptions.EncryptionKey)
+
+	// Disabling encryption decrypts the database.
+	setupDBEncryption(logger, newKey, nil)
+	require.Empty(t, cfg.DBOptions.EncryptionKey)
+	requireData()
+}
@@ -0,0 +1,226 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/eth/contract"
+	"github.com/bloxapp/ssv/eth/eventhandler"
+	"github.com/bloxapp/ssv/eth/executionclient"
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/networkconfig"
+	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
+	operatorstorage "github.com/bloxapp/ssv/operator/storage"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+// ExportRegistrySnapshotCmd exports a signed snapshot of a synced node's registry,
+// which fresh nodes can import instead of syncing the full history of events.
+var ExportRegistrySnapshotCmd = &cobra.Command{
+	Use:   "export-registry-snapshot",
+	Short: "Exports a signed snapshot of the node's registry for fresh nodes to import",
+	Long: `Exports the operators, shares and recipients of the node's registry at its last processed block,
+signed with the operator's key. Fresh nodes can import it with RegistrySnapshotFile, trusting the signer with
+RegistrySnapshotSigner, and then sync only the events after the snapshot's block.
+The node must be stopped for its database to be opened.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger", err)
+		}
+
+		outputFile, _ := cmd.Flags().GetString("output")
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+		operatorPrivKey, _ := loadOperatorPrivateKey(logger)
+		previousPrivKey, _ := loadPreviousOperatorPrivateKey(logger)
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		useDBEncryptionKey(logger, operatorPrivKey, previousPrivKey)
+		db, err := kv.New(logger, cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("could not open the node's database, is the node running?", zap.Error(err))
+		}
+		defer db.Close()
+		nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+		if err != nil {
+			logger.Fatal("failed to create node storage", zap.Error(err))
+		}
+
+		lastProcessedBlock, found, err := nodeStorage.GetLastProcessedBlock(nil)
+		if err != nil || !found {
+			logger.Fatal("could not get the node's last processed block, is it synced?", zap.Error(err))
+		}
+
+		executionClient, err := executionclient.New(
+			cmd.Context(),
+			cfg.ExecutionClient.Addr,
+			ethcommon.HexToAddress(networkConfig.RegistryContractAddr),
+			executionclient.WithLogger(logger),
+			executionclient.WithConnectionTimeout(cfg.ExecutionClient.ConnectionTimeout),
+		)
+		if err != nil {
+			logger.Fatal("could not connect to execution client", zap.Error(err))
+		}
+		defer executionClient.Close()
+
+		header, err := executionClient.HeaderByNumber(cmd.Context(), lastProcessedBlock)
+		if err != nil {
+			logger.Fatal("could not get the header of the last processed block", zap.Error(err))
+		}
+		journals, err := nodeStorage.ListBlockJournals(nil)
+		if err != nil {
+			logger.Fatal("could not list block journals", zap.Error(err))
+		}
+		for _, journal := range journals {
+			if journal.BlockNumber == header.Number.Uint64() && journal.BlockHash != (ethcommon.Hash{}) && journal.BlockHash != header.Hash() {
+				logger.Fatal("the last processed block was reorganized, start the node to roll it back",
+					fields.BlockNumber(journal.BlockNumber))
+			}
+		}
+
+		snapshot, err := operatorstorage.NewRegistrySnapshot(nodeStorage, networkConfig.Name, header)
+		if err != nil {
+			logger.Fatal("could not create registry snapshot", zap.Error(err))
+		}
+		if err := snapshot.Sign(operatorPrivKey); err != nil {
+			logger.Fatal("could not sign registry snapshot", zap.Error(err))
+		}
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			logger.Fatal("could not encode registry snapshot", zap.Error(err))
+		}
+		if err := os.WriteFile(filepath.Clean(outputFile), data, 0600); err != nil {
+			logger.Fatal("could not save registry snapshot", zap.Error(err))
+		}
+
+		logger.Info("exported registry snapshot",
+			zap.String("path", outputFile),
+			fields.BlockNumber(snapshot.BlockNumber),
+			zap.String("block_hash", snapshot.BlockHash.Hex()),
+			zap.Int("operators", len(snapshot.Operators)),
+			zap.Int("shares", len(snapshot.Shares)),
+			zap.Int("recipients", len(snapshot.Recipients)),
+			zap.String("signer", snapshot.Signer))
+	},
+}
+
+// importRegistrySnapshot imports the configured registry snapshot if the node hasn't processed any events yet,
+// and adds the keys of the operator's own shares, decrypting them from their ValidatorAdded events.
+func importRegistrySnapshot(
+	ctx context.Context,
+	logger *zap.Logger,
+	executionClient *executionclient.ExecutionClient,
+	eventHandler *eventhandler.EventHandler,
+	networkConfig networkconfig.NetworkConfig,
+	nodeStorage operatorstorage.Storage,
+	operatorDataStore operatordatastore.OperatorDataStore,
+) {
+	logger = logger.With(zap.String("path", cfg.RegistrySnapshotFile))
+
+	if _, found, err := nodeStorage.GetLastProcessedBlock(nil); err != nil {
+		logger.Fatal("could not get last processed block", zap.Error(err))
+	} else if found {
+		logger.Info("registry has already synced events, not importing the registry snapshot")
+		return
+	}
+	if cfg.RegistrySnapshotSigner == "" {
+		logger.Fatal("importing a registry snapshot requires its trusted signer (RegistrySnapshotSigner)")
+	}
+
+	data, err := os.ReadFile(filepath.Clean(cfg.RegistrySnapshotFile))
+	if err != nil {
+		logger.Fatal("could not read registry snapshot", zap.Error(err))
+	}
+	snapshot := &operatorstorage.RegistrySnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		logger.Fatal("could not decode registry snapshot", zap.Error(err))
+	}
+	if err := snapshot.Verify(networkConfig.Name, cfg.RegistrySnapshotSigner); err != nil {
+		logger.Fatal("could not verify registry snapshot", zap.Error(err))
+	}
+	header, err := executionClient.HeaderByNumber(ctx, new(big.Int).SetUint64(snapshot.BlockNumber))
+	if err != nil {
+		logger.Fatal("could not get the header of the registry snapshot's block", zap.Error(err))
+	}
+	if err := snapshot.VerifyHeader(header); err != nil {
+		logger.Fatal("registry snapshot isn't of the canonical chain", zap.Error(err))
+	}
+
+	// Fetch the ValidatorAdded events of the owners of own shares before importing,
+	// so that the import isn't left without the shares' keys if fetching fails.
+	ownPublicKey := operatorDataStore.GetOperatorData().PublicKey
+	var ownOperatorID uint64
+	for _, od := range snapshot.Operators {
+		if bytes.Equal(od.PublicKey, ownPublicKey) {
+			ownOperatorID = od.ID
+		}
+	}
+	var ownerTopics []ethcommon.Hash
+	owners := make(map[ethcommon.Address]bool)
+	for _, share := range operatorstorage.OperatorShares(snapshot.Shares, ownOperatorID) {
+		if ownOperatorID != 0 && share.BelongsToOperator(ownOperatorID) && !owners[share.OwnerAddress] {
+			owners[share.OwnerAddress] = true
+			ownerTopics = append(ownerTopics, ethcommon.BytesToHash(share.OwnerAddress.Bytes()))
+		}
+	}
+	var ownLogs []ethtypes.Log
+	if len(ownerTopics) > 0 {
+		contractABI, err := contract.ContractMetaData.GetAbi()
+		if err != nil {
+			logger.Fatal("could not get contract ABI", zap.Error(err))
+		}
+		validatorAddedID := contractABI.Events[eventhandler.ValidatorAdded].ID
+		ownLogs, err = executionClient.FilterLogs(
+			ctx,
+			networkConfig.RegistrySyncOffset.Uint64(),
+			snapshot.BlockNumber,
+			[][]ethcommon.Hash{{validatorAddedID}, ownerTopics},
+		)
+		if err != nil {
+			logger.Fatal("could not fetch the ValidatorAdded events of own shares", zap.Error(err))
+		}
+	}
+
+	if err := operatorstorage.ImportRegistrySnapshot(nodeStorage, snapshot, ownOperatorID); err != nil {
+		logger.Fatal("could not import registry snapshot", zap.Error(err))
+	}
+	if ownOperatorID != 0 {
+		od, found, err := nodeStorage.GetOperatorData(nil, ownOperatorID)
+		if err != nil || !found {
+			logger.Fatal("could not get own operator data", zap.Error(err))
+		}
+		operatorDataStore.SetOperatorData(od)
+	}
+	importedKeys, err := eventHandler.ImportShareKeys(ownLogs)
+	if err != nil {
+		logger.Fatal("could not import the keys of own shares", zap.Error(err))
+	}
+
+	logger.Info("imported registry snapshot",
+		fields.BlockNumber(snapshot.BlockNumber),
+		zap.String("block_hash", snapshot.BlockHash.Hex()),
+		zap.Int("operators", len(snapshot.Operators)),
+		zap.Int("shares", len(snapshot.Shares)),
+		zap.Int("recipients", len(snapshot.Recipients)),
+		zap.Int("own_share_keys", importedKeys))
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, ExportRegistrySnapshotCmd)
+	ExportRegistrySnapshotCmd.Flags().String("output", "registry-snapshot.json", "File to save the registry snapshot to")
+}
@@ -0,0 +1,132 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/auditlog"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+)
+
+// WithAuditLog records the signatures produced by the key manager in the audit log.
+// A signature is only returned once it's recorded.
+func WithAuditLog(log *auditlog.Log) Option {
+	return func(km *ethKeyManagerSigner) {
+		km.auditLog = log
+	}
+}
+
+// domainRoles are the duties which sign beacon objects of each domain.
+var domainRoles = map[phase0.DomainType]spectypes.BeaconRole{
+	spectypes.DomainAttester:                    spectypes.BNRoleAttester,
+	spectypes.DomainProposer:                    spectypes.BNRoleProposer,
+	spectypes.DomainRandao:                      spectypes.BNRoleProposer,
+	spectypes.DomainAggregateAndProof:           spectypes.BNRoleAggregator,
+	spectypes.DomainSelectionProof:              spectypes.BNRoleAggregator,
+	spectypes.DomainSyncCommittee:               spectypes.BNRoleSyncCommittee,
+	spectypes.DomainSyncCommitteeSelectionProof: spectypes.BNRoleSyncCommitteeContribution,
+	spectypes.DomainContributionAndProof:        spectypes.BNRoleSyncCommitteeContribution,
+	spectypes.DomainVoluntaryExit:               spectypes.BNRoleVoluntaryExit,
+	spectypes.DomainApplicationBuilder:          spectypes.BNRoleValidatorRegistration,
+}
+
+func (km *ethKeyManagerSigner) auditBeaconObject(obj interface{}, pk []byte, domainType phase0.DomainType, root []byte) error {
+	record := &auditlog.Record{
+		Signer:      auditlog.SignerValidator,
+		PubKey:      hex.EncodeToString(pk),
+		DomainType:  hex.EncodeToString(domainType[:]),
+		ObjectType:  objectType(obj),
+		SigningRoot: hex.EncodeToString(root),
+	}
+
+	var slot *phase0.Slot
+	switch v := obj.(type) {
+	case *phase0.AttestationData:
+		slot = &v.Slot
+	case *capella.BeaconBlock:
+		slot = &v.Slot
+	case *deneb.BeaconBlock:
+		slot = &v.Slot
+	case *apiv1capella.BlindedBeaconBlock:
+		slot = &v.Slot
+	case *apiv1deneb.BlindedBeaconBlock:
+		slot = &v.Slot
+	case *phase0.AggregateAndProof:
+		slot = &v.Aggregate.Data.Slot
+	case *altair.SyncAggregatorSelectionData:
+		slot = &v.Slot
+	case *altair.ContributionAndProof:
+		slot = &v.Contribution.Slot
+	case *phase0.VoluntaryExit:
+		record.Epoch = uint64Ptr(uint64(v.Epoch))
+	case spectypes.SSZUint64:
+		if domainType == spectypes.DomainRandao {
+			record.Epoch = uint64Ptr(uint64(v))
+		} else {
+			s := phase0.Slot(v)
+			slot = &s
+		}
+	case *eth2apiv1.ValidatorRegistration:
+	}
+	if slot != nil {
+		km.setAuditSlot(record, *slot, domainRoles[domainType])
+	}
+	return km.appendAuditRecord(record)
+}
+
+func (km *ethKeyManagerSigner) auditRoot(data spectypes.Root, sigType spectypes.SignatureType, pk []byte, root [32]byte) error {
+	record := &auditlog.Record{
+		Signer:      auditlog.SignerValidator,
+		PubKey:      hex.EncodeToString(pk),
+		DomainType:  hex.EncodeToString(sigType[:]),
+		ObjectType:  objectType(data),
+		SigningRoot: hex.EncodeToString(root[:]),
+	}
+	switch v := data.(type) {
+	case *specqbft.Message:
+		if len(v.Identifier) >= len(spectypes.MessageID{}) {
+			role := spectypes.MessageIDFromBytes(v.Identifier).GetRoleType()
+			km.setAuditSlot(record, phase0.Slot(v.Height), role)
+		}
+	case *spectypes.PartialSignatureMessages:
+		record.Slot = uint64Ptr(uint64(v.Slot))
+		record.Epoch = uint64Ptr(uint64(km.beaconNetwork.EstimatedEpochAtSlot(v.Slot)))
+	}
+	return km.appendAuditRecord(record)
+}
+
+// setAuditSlot sets the slot and epoch of the record, and the ID of the duty in the
+// format of the node's logs, excluding the validator index which the key manager doesn't know.
+func (km *ethKeyManagerSigner) setAuditSlot(record *auditlog.Record, slot phase0.Slot, role spectypes.BeaconRole) {
+	epoch := km.beaconNetwork.EstimatedEpochAtSlot(slot)
+	record.Slot = uint64Ptr(uint64(slot))
+	record.Epoch = uint64Ptr(uint64(epoch))
+	record.DutyID = fmt.Sprintf("%v-e%v-s%v", message.RoleToString(role), epoch, slot)
+}
+
+func (km *ethKeyManagerSigner) appendAuditRecord(record *auditlog.Record) error {
+	if err := km.auditLog.Append(record); err != nil {
+		return errors.Wrap(err, "could not record signature in audit log")
+	}
+	return nil
+}
+
+func objectType(obj interface{}) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", obj), "*")
+}
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
@@ -3,6 +3,7 @@ package ekm
 import (
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/attestantio/go-eth2-client/api"
@@ -25,7 +26,10 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/auditlog"
 	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/notifications"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/storage/basedb"
 )
 
@@ -48,6 +52,19 @@ type ethKeyManagerSigner struct {
 	domain            spectypes.DomainType
 	slashingProtector core.SlashingProtector
 	builderProposals  bool
+	beaconNetwork     beacon.BeaconNetwork
+	notifier          notifications.Notifier
+	auditLog          *auditlog.Log
+}
+
+// Option defines a key manager configuration option.
+type Option func(*ethKeyManagerSigner)
+
+// WithNotifier enables notifications about signatures refused by slashing protection.
+func WithNotifier(notifier notifications.Notifier) Option {
+	return func(km *ethKeyManagerSigner) {
+		km.notifier = notifier
+	}
 }
 
 // StorageProvider provides the underlying KeyManager storage.
@@ -58,8 +75,13 @@ type StorageProvider interface {
 	BumpSlashingProtection(pubKey []byte) error
 }
 
+// ShareExporter exports the secret keys of shares, such as to add them back after they're removed.
+type ShareExporter interface {
+	ExportShare(pubKey string) (*bls.SecretKey, error)
+}
+
 // NewETHKeyManagerSigner returns a new instance of ethKeyManagerSigner
-func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network networkconfig.NetworkConfig, builderProposals bool, encryptionKey string) (spectypes.KeyManager, error) {
+func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network networkconfig.NetworkConfig, builderProposals bool, encryptionKey string, opts ...Option) (spectypes.KeyManager, error) {
 	signerStore := NewSignerStorage(db, network.Beacon, logger)
 	if encryptionKey != "" {
 		err := signerStore.SetEncryptionKey(encryptionKey)
@@ -89,7 +111,7 @@ func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network netw
 	slashingProtector := slashingprotection.NewNormalProtection(signerStore)
 	beaconSigner := signer.NewSimpleSigner(wallet, slashingProtector, core.Network(network.Beacon.GetBeaconNetwork()))
 
-	return &ethKeyManagerSigner{
+	km := &ethKeyManagerSigner{
 		wallet:            wallet,
 		walletLock:        &sync.RWMutex{},
 		signer:            beaconSigner,
@@ -97,7 +119,13 @@ func NewETHKeyManagerSigner(logger *zap.Logger, db basedb.Database, network netw
 		domain:            network.Domain,
 		slashingProtector: slashingProtector,
 		builderProposals:  builderProposals,
-	}, nil
+		beaconNetwork:     network.Beacon,
+		notifier:          notifications.NopNotifier{},
+	}
+	for _, opt := range opts {
+		opt(km)
+	}
+	return km, nil
 }
 
 func (km *ethKeyManagerSigner) ListAccounts() ([]core.ValidatorAccount, error) {
@@ -115,8 +143,17 @@ func (km *ethKeyManagerSigner) RetrieveHighestProposal(pubKey []byte) (phase0.Sl
 func (km *ethKeyManagerSigner) SignBeaconObject(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
 	sig, rootSlice, err := km.signBeaconObject(obj, domain, pk, domainType)
 	if err != nil {
+		// The signer refuses slashable attestations and proposals with these errors.
+		if strings.HasPrefix(err.Error(), "slashable ") {
+			km.notifySlashingRejected(pk, err)
+		}
 		return nil, [32]byte{}, err
 	}
+	if km.auditLog != nil {
+		if err := km.auditBeaconObject(obj, pk, domainType, rootSlice); err != nil {
+			return nil, [32]byte{}, err
+		}
+	}
 	var root [32]byte
 	copy(root[:], rootSlice)
 	return sig, root, nil
@@ -235,7 +272,9 @@ func (km *ethKeyManagerSigner) IsAttestationSlashable(pk []byte, data *phase0.At
 		if err != nil {
 			return err
 		}
-		return errors.Errorf("slashable attestation (%s), not signing", val.Status)
+		err := errors.Errorf("slashable attestation (%s), not signing", val.Status)
+		km.notifySlashingRejected(pk, err)
+		return err
 	}
 	return nil
 }
@@ -246,12 +285,21 @@ func (km *ethKeyManagerSigner) IsBeaconBlockSlashable(pk []byte, slot phase0.Slo
 		return err
 	}
 	if status.Status != core.ValidProposal {
-		return errors.Errorf("slashable proposal (%s), not signing", status.Status)
+		err := errors.Errorf("slashable proposal (%s), not signing", status.Status)
+		km.notifySlashingRejected(pk, err)
+		return err
 	}
 
 	return nil
 }
 
+func (km *ethKeyManagerSigner) notifySlashingRejected(pk []byte, err error) {
+	km.notifier.Notify(notifications.NewEvent(notifications.EventSlashingRejected, "slashing protection refused to sign", map[string]string{
+		"share": "0x" + hex.EncodeToString(pk),
+		"error": err.Error(),
+	}))
+}
+
 func (km *ethKeyManagerSigner) SignRoot(data spectypes.Root, sigType spectypes.SignatureType, pk []byte) (spectypes.Signature, error) {
 	km.walletLock.RLock()
 	defer km.walletLock.RUnlock()
@@ -270,6 +318,11 @@ func (km *ethKeyManagerSigner) SignRoot(data spectypes.Root, sigType spectypes.S
 	if err != nil {
 		return nil, errors.Wrap(err, "could not sign message")
 	}
+	if km.auditLog != nil {
+		if err := km.auditRoot(data, sigType, pk, root); err != nil {
+			return nil, err
+		}
+	}
 
 	return sig, nil
 }
@@ -316,10 +369,33 @@ func (km *ethKeyManagerSigner) RemoveShare(pubKey string) error {
 		if err := km.wallet.DeleteAccountByPublicKey(pubKey); err != nil {
 			return errors.Wrap(err, "could not delete share")
 		}
+		if err := km.storage.RemoveShareKey(pkDecoded); err != nil {
+			return errors.Wrap(err, "could not remove share key")
+		}
 	}
 	return nil
 }
 
+// ExportShare returns the secret key of the share with the given hex encoded public key.
+// Shares saved before their secret keys were recorded can't be exported.
+func (km *ethKeyManagerSigner) ExportShare(pubKey string) (*bls.SecretKey, error) {
+	km.walletLock.RLock()
+	defer km.walletLock.RUnlock()
+
+	pkDecoded, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hex decode share public key")
+	}
+	shareKey, found, err := km.storage.ShareKey(pkDecoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get share key")
+	}
+	if !found {
+		return nil, errors.New("share key not found")
+	}
+	return shareKey, nil
+}
+
 // BumpSlashingProtection updates the slashing protection data for a given public key.
 func (km *ethKeyManagerSigner) BumpSlashingProtection(pubKey []byte) error {
 	currentSlot := km.storage.BeaconNetwork().EstimatedCurrentSlot()
@@ -425,5 +501,8 @@ func (km *ethKeyManagerSigner) saveShare(shareKey *bls.SecretKey) error {
 	if err := km.wallet.AddValidatorAccount(account); err != nil {
 		return errors.Wrap(err, "could not save new account")
 	}
+	if err := km.storage.SaveShareKey(shareKey); err != nil {
+		return errors.Wrap(err, "could not save share key")
+	}
 	return nil
 }
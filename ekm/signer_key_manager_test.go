@@ -2,6 +2,11 @@ package ekm
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/attestantio/go-eth2-client/spec/altair"
@@ -18,8 +23,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/bloxapp/ssv/auditlog"
 	"github.com/bloxapp/ssv/logging"
 	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/notifications"
 	"github.com/bloxapp/ssv/operator/keys"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/utils"
@@ -63,6 +70,18 @@ func testKeyManager(t *testing.T, network *networkconfig.NetworkConfig) spectype
 	return km
 }
 
+func TestExportShare(t *testing.T) {
+	km := testKeyManager(t, nil)
+
+	shareKey, err := km.(ShareExporter).ExportShare(pk1Str)
+	require.NoError(t, err)
+	require.Equal(t, sk1Str, shareKey.SerializeToHexStr())
+
+	require.NoError(t, km.RemoveShare(pk1Str))
+	_, err = km.(ShareExporter).ExportShare(pk1Str)
+	require.Error(t, err)
+}
+
 func TestEncryptedKeyManager(t *testing.T) {
 	// Generate key 1.
 	privateKey, err := keys.GeneratePrivateKey()
@@ -128,8 +147,18 @@ func TestEncryptedKeyManager(t *testing.T) {
 	require.NoError(t, err)
 }
 
+type recordingNotifier struct {
+	events []*notifications.Event
+}
+
+func (n *recordingNotifier) Notify(event *notifications.Event) {
+	n.events = append(n.events, event)
+}
+
 func TestSlashing(t *testing.T) {
 	km := testKeyManager(t, nil)
+	notifier := &recordingNotifier{}
+	WithNotifier(notifier)(km.(*ethKeyManagerSigner))
 
 	sk1 := &bls.SecretKey{}
 	require.NoError(t, sk1.SetHexString(sk1Str))
@@ -279,6 +308,14 @@ func TestSlashing(t *testing.T) {
 		require.EqualError(t, err, "slashable proposal (HighestProposalVote), not signing")
 		require.Equal(t, [32]byte{}, sig)
 	})
+	t.Run("slashable signs notified", func(t *testing.T) {
+		require.Len(t, notifier.events, 3)
+		for _, event := range notifier.events {
+			require.Equal(t, notifications.EventSlashingRejected, event.Type)
+			require.Equal(t, "0x"+sk1.GetPublicKey().SerializeToHexStr(), event.Fields["share"])
+		}
+		require.Equal(t, "slashable attestation (HighestAttestationVote), not signing", notifier.events[0].Fields["error"])
+	})
 }
 
 func TestSlashing_Attestation(t *testing.T) {
@@ -453,3 +490,123 @@ func TestSignRoot(t *testing.T) {
 		// require.True(t, res)
 	})
 }
+
+func TestAuditLog(t *testing.T) {
+	require.NoError(t, bls.Init(bls.BLS12_381))
+
+	km := testKeyManager(t, nil)
+	dir := t.TempDir()
+	auditLog, err := auditlog.Open(logging.TestLogger(t), auditlog.Config{Dir: dir})
+	require.NoError(t, err)
+	WithAuditLog(auditLog)(km.(*ethKeyManagerSigner))
+
+	pk := _byteArray(pk1Str)
+	currentSlot := km.(*ethKeyManagerSigner).storage.Network().EstimatedCurrentSlot()
+	currentEpoch := km.(*ethKeyManagerSigner).storage.Network().EstimatedEpochAtSlot(currentSlot)
+	attestationData := &phase0.AttestationData{
+		Slot:   currentSlot,
+		Source: &phase0.Checkpoint{Epoch: currentEpoch - 1},
+		Target: &phase0.Checkpoint{Epoch: currentEpoch},
+	}
+	_, root, err := km.SignBeaconObject(attestationData, phase0.Domain{}, pk, spectypes.DomainAttester)
+	require.NoError(t, err)
+
+	msgID := spectypes.NewMsgID(networkconfig.TestNetwork.Domain, pk, spectypes.BNRoleProposer)
+	msg := &specqbft.Message{
+		MsgType:    specqbft.CommitMsgType,
+		Height:     specqbft.Height(currentSlot),
+		Identifier: msgID[:],
+	}
+	_, err = km.SignRoot(msg, spectypes.QBFTSignatureType, pk)
+	require.NoError(t, err)
+
+	// Signatures refused by slashing protection aren't recorded.
+	_, _, err = km.SignBeaconObject(attestationData, phase0.Domain{1}, pk, spectypes.DomainAttester)
+	require.Error(t, err)
+
+	require.NoError(t, auditLog.Close())
+	result, err := auditlog.Verify(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), result.Records)
+
+	data, err := os.ReadFile(filepath.Join(dir, "signatures.log"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var attestationRecord, messageRecord auditlog.Record
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &attestationRecord))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &messageRecord))
+
+	require.Equal(t, auditlog.SignerValidator, attestationRecord.Signer)
+	require.Equal(t, pk1Str, attestationRecord.PubKey)
+	require.Equal(t, "01000000", attestationRecord.DomainType)
+	require.Equal(t, "phase0.AttestationData", attestationRecord.ObjectType)
+	require.Equal(t, hex.EncodeToString(root[:]), attestationRecord.SigningRoot)
+	require.Equal(t, uint64(currentSlot), *attestationRecord.Slot)
+	require.Equal(t, uint64(currentEpoch), *attestationRecord.Epoch)
+	require.Equal(t, fmt.Sprintf("ATTESTER-e%d-s%d", currentEpoch, currentSlot), attestationRecord.DutyID)
+
+	require.Equal(t, "qbft.Message", messageRecord.ObjectType)
+	require.Equal(t, hex.EncodeToString(spectypes.QBFTSignatureType[:]), messageRecord.DomainType)
+	require.Equal(t, fmt.Sprintf("PROPOSER-e%d-s%d", currentEpoch, currentSlot), messageRecord.DutyID)
+}
+
+func TestRotateEncryptionKey(t *testing.T) {
+	threshold.Init()
+	logger := logging.TestLogger(t)
+	db, err := getBaseStorage(logger)
+	require.NoError(t, err)
+	defer db.Close()
+
+	oldKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	oldEncryptionKey, err := oldKey.EKMHash()
+	require.NoError(t, err)
+	newKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	newEncryptionKey, err := newKey.EKMHash()
+	require.NoError(t, err)
+
+	signerStorage := NewSignerStorage(db, networkconfig.TestNetwork.Beacon.GetNetwork(), logger)
+	require.NoError(t, signerStorage.SetEncryptionKey(oldEncryptionKey))
+	hdwallet := hd.NewWallet(&core.WalletContext{Storage: signerStorage})
+	require.NoError(t, signerStorage.SaveWallet(hdwallet))
+	sk := bls.SecretKey{}
+	sk.SetByCSPRNG()
+	index := 0
+	account, err := hdwallet.CreateValidatorAccountFromPrivateKey(sk.Serialize(), &index)
+	require.NoError(t, err)
+	require.NoError(t, signerStorage.SaveShareKey(&sk))
+
+	// A failed transaction leaves the accounts encrypted with the old key.
+	err = db.Update(func(txn basedb.Txn) error {
+		require.NoError(t, signerStorage.RotateEncryptionKeyTxn(txn, newEncryptionKey))
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+	require.NoError(t, signerStorage.SetEncryptionKey(oldEncryptionKey))
+	_, err = signerStorage.OpenAccount(account.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, db.Update(func(txn basedb.Txn) error {
+		return signerStorage.RotateEncryptionKeyTxn(txn, newEncryptionKey)
+	}))
+
+	// The accounts are only decrypted with the new key.
+	reopened := NewSignerStorage(db, networkconfig.TestNetwork.Beacon.GetNetwork(), logger)
+	require.NoError(t, reopened.SetEncryptionKey(newEncryptionKey))
+	retrieved, err := reopened.OpenAccount(account.ID())
+	require.NoError(t, err)
+	require.Equal(t, account.ValidatorPublicKey(), retrieved.ValidatorPublicKey())
+	shareKey, found, err := reopened.ShareKey(account.ValidatorPublicKey())
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, sk.IsEqual(shareKey))
+
+	require.NoError(t, reopened.SetEncryptionKey(oldEncryptionKey))
+	_, err = reopened.OpenAccount(account.ID())
+	require.True(t, errors.Is(err, ErrCantDecrypt))
+	_, _, err = reopened.ShareKey(account.ValidatorPublicKey())
+	require.True(t, errors.Is(err, ErrCantDecrypt))
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/bloxapp/eth2-key-manager/wallets/hd"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
@@ -34,6 +35,7 @@ const (
 	accountsPath          = "accounts_%s"
 	highestAttPrefix      = prefix + "highest_att-"
 	highestProposalPrefix = prefix + "highest_prop-"
+	shareKeysPrefix       = prefix + "share_keys-"
 )
 
 // Storage represents the interface for ssv node storage
@@ -44,7 +46,11 @@ type Storage interface {
 
 	RemoveHighestAttestation(pubKey []byte) error
 	RemoveHighestProposal(pubKey []byte) error
+	SaveShareKey(shareKey *bls.SecretKey) error
+	ShareKey(pubKey []byte) (*bls.SecretKey, bool, error)
+	RemoveShareKey(pubKey []byte) error
 	SetEncryptionKey(newKey string) error
+	RotateEncryptionKeyTxn(rw basedb.ReadWriter, newKey string) error
 	ListAccountsTxn(r basedb.Reader) ([]core.ValidatorAccount, error)
 	SaveAccountTxn(rw basedb.ReadWriter, account core.ValidatorAccount) error
 
@@ -84,8 +90,49 @@ func (s *storage) SetEncryptionKey(newKey string) error {
 	return nil
 }
 
+// RotateEncryptionKeyTxn re-encrypts the stored accounts with the new key, which is used from then on.
+// The accounts are read with the current key, so it must be set first.
+func (s *storage) RotateEncryptionKeyTxn(rw basedb.ReadWriter, newKey string) error {
+	accounts, err := s.ListAccountsTxn(rw)
+	if err != nil {
+		return errors.Wrap(err, "could not list accounts with the current encryption key")
+	}
+	shareKeys, err := s.listShareKeysTxn(rw)
+	if err != nil {
+		return errors.Wrap(err, "could not list share keys with the current encryption key")
+	}
+
+	s.lock.RLock()
+	currentKey := s.encryptionKey
+	s.lock.RUnlock()
+
+	if err := s.SetEncryptionKey(newKey); err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		if err := s.SaveAccountTxn(rw, account); err != nil {
+			s.lock.Lock()
+			s.encryptionKey = currentKey
+			s.lock.Unlock()
+			return errors.Wrapf(err, "could not re-encrypt account %s", account.ID())
+		}
+	}
+	for _, shareKey := range shareKeys {
+		if err := s.saveShareKeyTxn(rw, shareKey); err != nil {
+			s.lock.Lock()
+			s.encryptionKey = currentKey
+			s.lock.Unlock()
+			return errors.Wrapf(err, "could not re-encrypt share key %s", shareKey.GetPublicKey().SerializeToHexStr())
+		}
+	}
+	return nil
+}
+
 func (s *storage) DropRegistryData() error {
-	return s.db.DropPrefix(s.objPrefix(accountsPrefix))
+	if err := s.db.DropPrefix(s.objPrefix(accountsPrefix)); err != nil {
+		return err
+	}
+	return s.db.DropPrefix(s.objPrefix(shareKeysPrefix))
 }
 
 func (s *storage) objPrefix(obj string) []byte {
@@ -349,6 +396,77 @@ func (s *storage) RemoveHighestProposal(pubKey []byte) error {
 	return s.db.Delete(s.objPrefix(highestProposalPrefix), pubKey)
 }
 
+// SaveShareKey saves the secret key of a share, so that it can be exported.
+func (s *storage) SaveShareKey(shareKey *bls.SecretKey) error {
+	return s.saveShareKeyTxn(nil, shareKey)
+}
+
+func (s *storage) saveShareKeyTxn(rw basedb.ReadWriter, shareKey *bls.SecretKey) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	encryptedValue, err := s.encryptData(shareKey.Serialize())
+	if err != nil {
+		return err
+	}
+	return s.db.Using(rw).Set(s.objPrefix(shareKeysPrefix), shareKey.GetPublicKey().Serialize(), encryptedValue)
+}
+
+// ShareKey returns the secret key of the share with the given public key.
+func (s *storage) ShareKey(pubKey []byte) (*bls.SecretKey, bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	obj, found, err := s.db.Get(s.objPrefix(shareKeysPrefix), pubKey)
+	if err != nil {
+		return nil, found, err
+	}
+	if !found {
+		return nil, found, nil
+	}
+	shareKey, err := s.decodeShareKey(obj.Value)
+	if err != nil {
+		return nil, found, err
+	}
+	return shareKey, found, nil
+}
+
+// RemoveShareKey removes the secret key of the share with the given public key.
+func (s *storage) RemoveShareKey(pubKey []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Delete(s.objPrefix(shareKeysPrefix), pubKey)
+}
+
+func (s *storage) listShareKeysTxn(r basedb.Reader) ([]*bls.SecretKey, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var ret []*bls.SecretKey
+	err := s.db.UsingReader(r).GetAll(s.objPrefix(shareKeysPrefix), func(i int, obj basedb.Obj) error {
+		shareKey, err := s.decodeShareKey(obj.Value)
+		if err != nil {
+			return err
+		}
+		ret = append(ret, shareKey)
+		return nil
+	})
+	return ret, err
+}
+
+func (s *storage) decodeShareKey(value []byte) (*bls.SecretKey, error) {
+	decryptedData, err := s.decryptData(value)
+	if err != nil {
+		return nil, errors.Wrap(ErrCantDecrypt, err.Error())
+	}
+	shareKey := &bls.SecretKey{}
+	if err := shareKey.Deserialize(decryptedData); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize share key")
+	}
+	return shareKey, nil
+}
+
 func (s *storage) decryptData(objectValue []byte) ([]byte, error) {
 	if s.encryptionKey == nil || len(s.encryptionKey) == 0 {
 		return objectValue, nil
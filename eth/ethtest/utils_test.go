@@ -258,7 +258,7 @@ func setupOperatorStorage(
 		logger.Fatal("failed to encode operator private key", zap.Error(err))
 	}
 
-	if err := nodeStorage.SavePrivateKeyHash(privKeyHash); err != nil {
+	if err := nodeStorage.SavePrivateKeyHash(nil, privKeyHash); err != nil {
 		logger.Fatal("couldn't setup operator private key", zap.Error(err))
 	}
 
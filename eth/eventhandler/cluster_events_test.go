@@ -0,0 +1,295 @@
+package eventhandler
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/bloxapp/ssv/eth/contract"
+	"github.com/bloxapp/ssv/eth/executionclient"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+func TestHandleFeeAndClusterEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	core, recorded := observer.New(zap.DebugLevel)
+	logger := zap.New(core)
+
+	ops, err := createOperators(4, 0)
+	require.NoError(t, err)
+	eh, _, err := setupEventHandler(t, ctx, logger, nil, ops[0], false)
+	require.NoError(t, err)
+
+	for _, op := range ops {
+		encodedPubKey, err := op.privateKey.Public().Base64()
+		require.NoError(t, err)
+		_, err = eh.nodeStorage.SaveOperatorData(nil, &registrystorage.OperatorData{
+			ID:           op.id,
+			PublicKey:    encodedPubKey,
+			OwnerAddress: testAddr,
+		})
+		require.NoError(t, err)
+	}
+
+	handleBlock := func(blockNumber uint64, executeTasks bool, logs ...ethtypes.Log) {
+		eventsCh := make(chan executionclient.BlockLogs, 1)
+		eventsCh <- executionclient.BlockLogs{BlockNumber: blockNumber, Logs: logs}
+		close(eventsCh)
+		lastProcessedBlock, err := eh.HandleBlockEventsStream(eventsCh, executeTasks)
+		require.NoError(t, err)
+		require.Equal(t, blockNumber, lastProcessedBlock)
+	}
+	operatorIDs := []uint64{4, 3, 2, 1}
+	cluster := contract.ISSVNetworkCoreCluster{
+		ValidatorCount: 1,
+		Active:         true,
+		Balance:        big.NewInt(5000),
+	}
+
+	// Block 1: the network fee, liquidation parameters and operators' fees are set.
+	block1 := []ethtypes.Log{
+		packEventLog(t, NetworkFeeUpdated, 1, big.NewInt(0), big.NewInt(10)),
+		packEventLog(t, LiquidationThresholdPeriodUpdated, 1, uint64(100)),
+		packEventLog(t, MinimumLiquidationCollateralUpdated, 1, big.NewInt(1000)),
+	}
+	for _, op := range ops {
+		block1 = append(block1, packEventLog(t, OperatorFeeExecuted, 1, testAddr, op.id, big.NewInt(1), big.NewInt(5)))
+	}
+	handleBlock(1, false, block1...)
+
+	networkFees, err := eh.nodeStorage.Clusters().GetNetworkFees(nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, networkFees.NetworkFee.Int64())
+	require.EqualValues(t, 100, networkFees.LiquidationThresholdPeriod)
+	require.EqualValues(t, 1000, networkFees.MinimumLiquidationCollateral.Int64())
+	require.EqualValues(t, 5, eh.operatorDataStore.GetOperatorData().Fee.Int64())
+
+	// Block 2: a cluster is deposited to, and the own operator declares a fee and a whitelisted address.
+	handleBlock(2, false,
+		packEventLog(t, ClusterDeposited, 2, testAddr, operatorIDs, big.NewInt(5000), cluster),
+		packEventLog(t, OperatorFeeDeclared, 2, testAddr, uint64(1), big.NewInt(2), big.NewInt(7)),
+		packEventLog(t, OperatorWhitelistUpdated, 2, uint64(1), testAddr),
+		// Events of unknown operators are malformed and skipped.
+		packEventLog(t, OperatorFeeDeclared, 2, testAddr, uint64(5), big.NewInt(2), big.NewInt(7)),
+	)
+
+	savedCluster, found, err := eh.nodeStorage.Clusters().GetCluster(nil, testAddr, operatorIDs)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []uint64{1, 2, 3, 4}, savedCluster.OperatorIDs)
+	require.EqualValues(t, 5000, savedCluster.Balance.Int64())
+	require.EqualValues(t, 2, savedCluster.UpdatedBlock)
+	require.True(t, savedCluster.Active)
+
+	od, found, err := eh.nodeStorage.GetOperatorData(nil, 1)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 5, od.Fee.Int64())
+	require.EqualValues(t, 7, od.DeclaredFee.Int64())
+	require.Equal(t, testAddr, od.WhitelistedAddress)
+	require.Equal(t, testAddr, eh.operatorDataStore.GetOperatorData().WhitelistedAddress)
+
+	// The cluster pays (10 + 4*5) per block, and can be liquidated below max(30*100, 1000).
+	burnRate, err := registrystorage.ClusterBurnRate(nil, eh.nodeStorage, savedCluster, networkFees)
+	require.NoError(t, err)
+	require.EqualValues(t, 30, burnRate.Int64())
+	blocks, ok := savedCluster.BlocksToLiquidation(2, burnRate, networkFees)
+	require.True(t, ok)
+	require.EqualValues(t, (5000-3000)/30, blocks)
+
+	// Block 60: the cluster is within the liquidation threshold period of liquidation and is warned about once.
+	handleBlock(60, true)
+	handleBlock(61, true)
+	require.Equal(t, 1, recorded.FilterMessage("cluster is approaching liquidation, its owner should deposit to it").Len())
+
+	// Block 62: the declared fee is executed.
+	handleBlock(62, false, packEventLog(t, OperatorFeeExecuted, 62, testAddr, uint64(1), big.NewInt(62), big.NewInt(7)))
+	od, _, err = eh.nodeStorage.GetOperatorData(nil, 1)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, od.Fee.Int64())
+	require.Nil(t, od.DeclaredFee)
+
+	// Rolling back undoes the changes of each block.
+	require.NoError(t, eh.RollbackBlocks(2, false))
+	od, _, err = eh.nodeStorage.GetOperatorData(nil, 1)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, od.Fee.Int64())
+	require.EqualValues(t, 7, od.DeclaredFee.Int64())
+
+	require.NoError(t, eh.RollbackBlocks(1, false))
+	_, found, err = eh.nodeStorage.Clusters().GetCluster(nil, testAddr, operatorIDs)
+	require.NoError(t, err)
+	require.False(t, found)
+	od, _, err = eh.nodeStorage.GetOperatorData(nil, 1)
+	require.NoError(t, err)
+	require.Nil(t, od.DeclaredFee)
+	require.Equal(t, ethcommon.Address{}, od.WhitelistedAddress)
+	require.Equal(t, ethcommon.Address{}, eh.operatorDataStore.GetOperatorData().WhitelistedAddress)
+
+	require.NoError(t, eh.RollbackBlocks(0, false))
+	networkFees, err = eh.nodeStorage.Clusters().GetNetworkFees(nil)
+	require.NoError(t, err)
+	require.Zero(t, networkFees.NetworkFee.Sign())
+	require.Zero(t, networkFees.LiquidationThresholdPeriod)
+	od, _, err = eh.nodeStorage.GetOperatorData(nil, 1)
+	require.NoError(t, err)
+	require.Nil(t, od.Fee)
+}
+
+// packEventLog packs the given arguments of a contract event into a log, as the contract would emit it.
+func packEventLog(t *testing.T, name string, blockNumber uint64, args ...interface{}) ethtypes.Log {
+	contractABI, err := contract.ContractMetaData.GetAbi()
+	require.NoError(t, err)
+	event, ok := contractABI.Events[name]
+	require.True(t, ok)
+	require.Len(t, args, len(event.Inputs))
+
+	topics := []ethcommon.Hash{event.ID}
+	var nonIndexed []interface{}
+	for i, input := range event.Inputs {
+		if !input.Indexed {
+			nonIndexed = append(nonIndexed, args[i])
+			continue
+		}
+		topic, err := abi.MakeTopics([]interface{}{args[i]})
+		require.NoError(t, err)
+		topics = append(topics, topic[0][0])
+	}
+	data, err := event.Inputs.NonIndexed().Pack(nonIndexed...)
+	require.NoError(t, err)
+
+	return ethtypes.Log{
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: blockNumber,
+	}
+}
+
+type runwayMetrics struct {
+	nopMetrics
+	runwayBlocks map[string]uint64
+	runwayDays   map[string]float64
+}
+
+func (m *runwayMetrics) ClusterRunway(owner ethcommon.Address, operatorIDs []uint64, _ float64, blocks uint64, days float64) {
+	id := string((&registrystorage.Cluster{Owner: owner, OperatorIDs: operatorIDs}).ID())
+	m.runwayBlocks[id] = blocks
+	m.runwayDays[id] = days
+}
+
+func (m *runwayMetrics) ClusterRunwayRemoved(owner ethcommon.Address, operatorIDs []uint64) {
+	id := string((&registrystorage.Cluster{Owner: owner, OperatorIDs: operatorIDs}).ID())
+	delete(m.runwayBlocks, id)
+	delete(m.runwayDays, id)
+}
+
+func TestMonitorClusterRunway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	core, recorded := observer.New(zap.DebugLevel)
+	logger := zap.New(core)
+
+	ops, err := createOperators(4, 0)
+	require.NoError(t, err)
+	eh, _, err := setupEventHandler(t, ctx, logger, nil, ops[0], false)
+	require.NoError(t, err)
+
+	metrics := &runwayMetrics{runwayBlocks: map[string]uint64{}, runwayDays: map[string]float64{}}
+	eh.metrics = metrics
+	// The runway is warned about below 50 blocks.
+	blockTime := eh.networkConfig.SlotDurationSec()
+	WithRunwayWarningThreshold(50 * blockTime)(eh)
+
+	for _, op := range ops {
+		encodedPubKey, err := op.privateKey.Public().Base64()
+		require.NoError(t, err)
+		_, err = eh.nodeStorage.SaveOperatorData(nil, &registrystorage.OperatorData{
+			ID:           op.id,
+			PublicKey:    encodedPubKey,
+			OwnerAddress: testAddr,
+			Fee:          big.NewInt(5),
+		})
+		require.NoError(t, err)
+	}
+
+	handleBlock := func(blockNumber uint64, logs ...ethtypes.Log) {
+		eventsCh := make(chan executionclient.BlockLogs, 1)
+		eventsCh <- executionclient.BlockLogs{BlockNumber: blockNumber, Logs: logs}
+		close(eventsCh)
+		_, err := eh.HandleBlockEventsStream(eventsCh, true)
+		require.NoError(t, err)
+	}
+	operatorIDs := []uint64{1, 2, 3, 4}
+	clusterID := string((&registrystorage.Cluster{Owner: testAddr, OperatorIDs: operatorIDs}).ID())
+	cluster := contract.ISSVNetworkCoreCluster{
+		ValidatorCount: 1,
+		Active:         true,
+		Balance:        big.NewInt(5000),
+	}
+
+	// The cluster pays 30 per block and can be liquidated below 3000.
+	handleBlock(1,
+		packEventLog(t, NetworkFeeUpdated, 1, big.NewInt(0), big.NewInt(10)),
+		packEventLog(t, LiquidationThresholdPeriodUpdated, 1, uint64(100)),
+		packEventLog(t, ClusterDeposited, 1, testAddr, operatorIDs, big.NewInt(5000), cluster),
+	)
+	require.EqualValues(t, 2000/30, metrics.runwayBlocks[clusterID])
+
+	// Block 10: the runway is above the threshold.
+	handleBlock(10)
+	require.EqualValues(t, (5000-9*30-3000)/30, metrics.runwayBlocks[clusterID])
+	require.InDelta(t, registrystorage.RunwayDays((5000-9*30-3000)/30, blockTime), metrics.runwayDays[clusterID], 1e-9)
+	require.Zero(t, recorded.FilterMessage("cluster is approaching liquidation, its owner should deposit to it").Len())
+
+	// Block 20: the runway drops below the threshold, and is warned about once.
+	handleBlock(20)
+	handleBlock(21)
+	require.EqualValues(t, (5000-20*30-3000)/30, metrics.runwayBlocks[clusterID])
+	require.Equal(t, 1, recorded.FilterMessage("cluster is approaching liquidation, its owner should deposit to it").Len())
+
+	// Block 22: a deposit brings the runway back above the threshold, so it's warned about again once it drops below.
+	cluster.Balance = big.NewInt(5000)
+	handleBlock(22, packEventLog(t, ClusterDeposited, 22, testAddr, operatorIDs, big.NewInt(1000), cluster))
+	require.EqualValues(t, 2000/30, metrics.runwayBlocks[clusterID])
+	handleBlock(40)
+	require.Equal(t, 2, recorded.FilterMessage("cluster is approaching liquidation, its owner should deposit to it").Len())
+
+	// Block 41: the cluster is liquidated, and its runway is no longer reported.
+	cluster.Active = false
+	cluster.Balance = big.NewInt(0)
+	handleBlock(41, packEventLog(t, ClusterLiquidated, 41, testAddr, operatorIDs, cluster))
+	require.NotContains(t, metrics.runwayBlocks, clusterID)
+
+	// Block 42: clusters without the operator or without validators aren't monitored.
+	otherCluster := contract.ISSVNetworkCoreCluster{ValidatorCount: 1, Active: true, Balance: big.NewInt(5000)}
+	emptyCluster := contract.ISSVNetworkCoreCluster{ValidatorCount: 0, Active: true, Balance: big.NewInt(5000)}
+	handleBlock(42,
+		packEventLog(t, ClusterDeposited, 42, testAddr, []uint64{2, 3, 4}, big.NewInt(5000), otherCluster),
+		packEventLog(t, ClusterDeposited, 42, testAddr, []uint64{1, 2, 3}, big.NewInt(5000), emptyCluster),
+	)
+	require.Len(t, eh.monitoredClusters, 1)
+	require.Empty(t, metrics.runwayBlocks)
+
+	// Rolling back the liquidation reports the cluster's runway again.
+	require.NoError(t, eh.RollbackBlocks(40, false))
+	handleBlock(41)
+	require.EqualValues(t, (5000-19*30-3000)/30, metrics.runwayBlocks[clusterID])
+	require.Len(t, eh.monitoredClusters, 1)
+
+	// Block 42: the cluster's last validator is removed, and it's no longer monitored.
+	cluster.ValidatorCount = 0
+	cluster.Balance = big.NewInt(5000 - 20*30)
+	handleBlock(42, packEventLog(t, ClusterWithdrawn, 42, testAddr, operatorIDs, big.NewInt(0), cluster))
+	require.Empty(t, eh.monitoredClusters)
+	require.Empty(t, metrics.runwayBlocks)
+}
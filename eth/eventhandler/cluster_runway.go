@@ -0,0 +1,166 @@
+package eventhandler
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+// monitoredCluster is a cluster of the operator with validators, whose runway is reported.
+type monitoredCluster struct {
+	cluster *registrystorage.Cluster
+	// reported is true while the cluster's runway is reported in metrics.
+	reported bool
+	// warned is true once the cluster's runway was warned about, until it's back above the warning threshold.
+	warned bool
+}
+
+// monitorClusters reports the runway of the operator's active clusters at the given block, which is the estimated
+// time until they can be liquidated, and warns about the clusters whose runway drops below the warning threshold.
+// The clusters are loaded from storage once, and then updated from the events which change them.
+func (eh *EventHandler) monitorClusters(blockNumber uint64) error {
+	operatorID := eh.operatorDataStore.GetOperatorID()
+	if operatorID == 0 {
+		return nil
+	}
+	if !eh.monitoredClustersLoaded {
+		if err := eh.loadMonitoredClusters(operatorID); err != nil {
+			return err
+		}
+	}
+
+	networkFees, err := eh.nodeStorage.Clusters().GetNetworkFees(nil)
+	if err != nil {
+		return fmt.Errorf("could not get network fees: %w", err)
+	}
+
+	blockTime := eh.networkConfig.SlotDurationSec()
+	warningBlocks := networkFees.LiquidationThresholdPeriod
+	if eh.runwayWarningThreshold > 0 && blockTime > 0 {
+		warningBlocks = uint64(eh.runwayWarningThreshold / blockTime)
+	}
+
+	for _, mc := range eh.monitoredClusters {
+		cluster := mc.cluster
+		if !cluster.Active {
+			eh.stopReportingCluster(mc)
+			continue
+		}
+		burnRate, err := registrystorage.ClusterBurnRate(nil, eh.nodeStorage, cluster, networkFees)
+		if err != nil {
+			return err
+		}
+		blocks, ok := cluster.BlocksToLiquidation(blockNumber, burnRate, networkFees)
+		if !ok {
+			// The cluster doesn't pay fees.
+			eh.stopReportingCluster(mc)
+			continue
+		}
+
+		balance := cluster.BalanceAt(blockNumber, burnRate)
+		days := registrystorage.RunwayDays(blocks, blockTime)
+		eh.metrics.ClusterRunway(cluster.Owner, cluster.OperatorIDs, registrystorage.BalanceInSSV(balance), blocks, days)
+		mc.reported = true
+
+		if blocks > 0 && blocks >= warningBlocks {
+			mc.warned = false
+			continue
+		}
+		if mc.warned {
+			continue
+		}
+		mc.warned = true
+		eh.logger.Warn("cluster is approaching liquidation, its owner should deposit to it",
+			fields.BlockNumber(blockNumber),
+			fields.Owner(cluster.Owner),
+			fields.OperatorIDs(cluster.OperatorIDs),
+			zap.Stringer("balance", balance),
+			zap.Stringer("liquidation_collateral", networkFees.LiquidationCollateral(burnRate)),
+			zap.Uint64("runway_blocks", blocks),
+			zap.Float64("runway_days", days))
+	}
+	return nil
+}
+
+// loadMonitoredClusters replaces the monitored clusters with the operator's clusters with validators in storage.
+func (eh *EventHandler) loadMonitoredClusters(operatorID uint64) error {
+	clusters, err := eh.nodeStorage.Clusters().ListClusters(nil)
+	if err != nil {
+		return fmt.Errorf("could not list clusters: %w", err)
+	}
+
+	monitored := make(map[string]*monitoredCluster)
+	for _, cluster := range clusters {
+		if !hasLocalValidators(cluster, operatorID) {
+			continue
+		}
+		id := hex.EncodeToString(cluster.ID())
+		mc, ok := eh.monitoredClusters[id]
+		if !ok {
+			mc = &monitoredCluster{}
+		}
+		mc.cluster = cluster
+		monitored[id] = mc
+	}
+	for id, mc := range eh.monitoredClusters {
+		if _, ok := monitored[id]; !ok {
+			eh.stopReportingCluster(mc)
+		}
+	}
+	eh.monitoredClusters = monitored
+	eh.monitoredClustersLoaded = true
+	return nil
+}
+
+// updateMonitoredClusters applies the clusters changed by the events of a committed block to the monitored clusters.
+func (eh *EventHandler) updateMonitoredClusters(changed []*registrystorage.Cluster) {
+	operatorID := eh.operatorDataStore.GetOperatorID()
+	if !eh.monitoredClustersLoaded || operatorID == 0 {
+		// The clusters are loaded with the changes once they're monitored.
+		return
+	}
+	for _, cluster := range changed {
+		id := hex.EncodeToString(cluster.ID())
+		mc, ok := eh.monitoredClusters[id]
+		switch {
+		case hasLocalValidators(cluster, operatorID) && ok:
+			mc.cluster = cluster
+		case hasLocalValidators(cluster, operatorID):
+			eh.monitoredClusters[id] = &monitoredCluster{cluster: cluster}
+		case ok:
+			eh.stopReportingCluster(mc)
+			delete(eh.monitoredClusters, id)
+		}
+	}
+}
+
+// reloadMonitoredClusters makes the monitored clusters be loaded from storage again,
+// after it was changed without events, such as by a rollback.
+func (eh *EventHandler) reloadMonitoredClusters() {
+	eh.monitoredClustersLoaded = false
+}
+
+func (eh *EventHandler) stopReportingCluster(mc *monitoredCluster) {
+	if mc.reported {
+		eh.metrics.ClusterRunwayRemoved(mc.cluster.Owner, mc.cluster.OperatorIDs)
+		mc.reported = false
+	}
+}
+
+// hasLocalValidators returns whether the operator is in the given cluster,
+// and so runs its validators, if it has any.
+func hasLocalValidators(cluster *registrystorage.Cluster, operatorID uint64) bool {
+	if cluster.ValidatorCount == 0 {
+		return false
+	}
+	for _, id := range cluster.OperatorIDs {
+		if id == operatorID {
+			return true
+		}
+	}
+	return false
+}
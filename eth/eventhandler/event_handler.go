@@ -21,11 +21,13 @@ import (
 	qbftstorage "github.com/bloxapp/ssv/ibft/storage"
 	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/notifications"
 	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
 	"github.com/bloxapp/ssv/operator/keys"
 	nodestorage "github.com/bloxapp/ssv/operator/storage"
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 )
 
@@ -39,6 +41,16 @@ const (
 	ClusterReactivated         = "ClusterReactivated"
 	FeeRecipientAddressUpdated = "FeeRecipientAddressUpdated"
 	ValidatorExited            = "ValidatorExited"
+
+	ClusterDeposited                    = "ClusterDeposited"
+	ClusterWithdrawn                    = "ClusterWithdrawn"
+	OperatorFeeDeclared                 = "OperatorFeeDeclared"
+	OperatorFeeDeclarationCancelled     = "OperatorFeeDeclarationCancelled"
+	OperatorFeeExecuted                 = "OperatorFeeExecuted"
+	OperatorWhitelistUpdated            = "OperatorWhitelistUpdated"
+	NetworkFeeUpdated                   = "NetworkFeeUpdated"
+	LiquidationThresholdPeriodUpdated   = "LiquidationThresholdPeriodUpdated"
+	MinimumLiquidationCollateralUpdated = "MinimumLiquidationCollateralUpdated"
 )
 
 var (
@@ -67,9 +79,18 @@ type EventHandler struct {
 	beacon            beaconprotocol.BeaconNode
 	storageMap        *qbftstorage.QBFTStores
 
+	// runwayWarningThreshold is the runway of own clusters below which they're warned about, see WithRunwayWarningThreshold.
+	runwayWarningThreshold time.Duration
+	// monitoredClusters are the operator's clusters with validators whose runway is reported, by cluster ID.
+	monitoredClusters       map[string]*monitoredCluster
+	monitoredClustersLoaded bool
+	// changedClusters are the clusters changed by the events of the block being processed.
+	changedClusters []*registrystorage.Cluster
+
 	fullNode bool
 	logger   *zap.Logger
 	metrics  metrics
+	notifier notifications.Notifier
 }
 
 func New(
@@ -96,6 +117,7 @@ func New(
 		storageMap:        storageMap,
 		logger:            zap.NewNop(),
 		metrics:           nopMetrics{},
+		notifier:          notifications.NopNotifier{},
 	}
 
 	for _, opt := range opts {
@@ -121,30 +143,43 @@ func (eh *EventHandler) HandleBlockEventsStream(logs <-chan executionclient.Bloc
 		}
 
 		lastProcessedBlock = blockLogs.BlockNumber
-		if !executeTasks || len(tasks) == 0 {
+		if !executeTasks {
 			continue
 		}
+		eh.executeTasks(logger, tasks)
 
-		logger.Debug("executing tasks", fields.Count(len(tasks)))
-
-		for _, task := range tasks {
-			logger = logger.With(fields.Type(task))
-			logger.Debug("executing task")
-			if err := task.Execute(); err != nil {
-				// TODO: We log failed task until we discuss how we want to handle this case. We likely need to crash the node in this case.
-				logger.Error("failed to execute task", zap.Error(err))
-			} else {
-				logger.Debug("executed task")
-			}
+		if err := eh.monitorClusters(blockLogs.BlockNumber); err != nil {
+			logger.Warn("could not monitor the runway of clusters", zap.Error(err))
 		}
 	}
 
 	return
 }
 
+func (eh *EventHandler) executeTasks(logger *zap.Logger, tasks []Task) {
+	if len(tasks) == 0 {
+		return
+	}
+
+	logger.Debug("executing tasks", fields.Count(len(tasks)))
+
+	for _, task := range tasks {
+		logger := logger.With(fields.Type(task))
+		logger.Debug("executing task")
+		if err := task.Execute(); err != nil {
+			// TODO: We log failed task until we discuss how we want to handle this case. We likely need to crash the node in this case.
+			logger.Error("failed to execute task", zap.Error(err))
+		} else {
+			logger.Debug("executed task")
+			eh.notifyTask(task)
+		}
+	}
+}
+
 func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]Task, error) {
 	txn := eh.nodeStorage.Begin()
 	defer txn.Discard()
+	eh.changedClusters = nil
 
 	lastProcessedBlock, found, err := eh.nodeStorage.GetLastProcessedBlock(txn)
 	if err != nil {
@@ -160,14 +195,18 @@ func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]T
 		// Returning an error to signal that we should stop processing and
 		// investigate the issue.
 		//
-		// TODO: this may happen during reorgs, and we should either
-		// implement reorg support or only process finalized blocks.
+		// Reorgs don't cause this, since EventSyncer rolls back
+		// the reorganized blocks before processing the canonical ones.
 		return nil, ErrInferiorBlock
 	}
 
+	journal := &nodestorage.BlockJournal{
+		BlockNumber: block.BlockNumber,
+		BlockHash:   block.BlockHash,
+	}
 	var tasks []Task
 	for _, log := range block.Logs {
-		task, err := eh.processEvent(txn, log)
+		task, err := eh.processEvent(txn, journal, log)
 		if err != nil {
 			return nil, err
 		}
@@ -176,6 +215,10 @@ func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]T
 		}
 	}
 
+	if err := eh.saveBlockJournal(txn, journal); err != nil {
+		return nil, fmt.Errorf("save block journal: %w", err)
+	}
+
 	if err := eh.nodeStorage.SaveLastProcessedBlock(txn, new(big.Int).SetUint64(block.BlockNumber)); err != nil {
 		return nil, fmt.Errorf("set last processed block: %w", err)
 	}
@@ -183,11 +226,12 @@ func (eh *EventHandler) processBlockEvents(block executionclient.BlockLogs) ([]T
 	if err := txn.Commit(); err != nil {
 		return nil, fmt.Errorf("commit transaction: %w", err)
 	}
+	eh.updateMonitoredClusters(eh.changedClusters)
 
 	return tasks, nil
 }
 
-func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task, error) {
+func (eh *EventHandler) processEvent(txn basedb.Txn, journal *nodestorage.BlockJournal, event ethtypes.Log) (Task, error) {
 	abiEvent, err := eh.eventParser.EventByID(event.Topics[0])
 	if err != nil {
 		eh.logger.Error("failed to find event by ID", zap.String("hash", event.Topics[0].String()))
@@ -205,7 +249,7 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 			return nil, nil
 		}
 
-		if err := eh.handleOperatorAdded(txn, operatorAddedEvent); err != nil {
+		if err := eh.handleOperatorAdded(txn, journal, operatorAddedEvent); err != nil {
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
 
 			var malformedEventError *MalformedEventError
@@ -251,7 +295,7 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 			return nil, nil
 		}
 
-		share, err := eh.handleValidatorAdded(txn, validatorAddedEvent)
+		share, err := eh.handleValidatorAdded(txn, journal, validatorAddedEvent)
 		if err != nil {
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
 
@@ -282,7 +326,7 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 			return nil, nil
 		}
 
-		validatorPubKey, err := eh.handleValidatorRemoved(txn, validatorRemovedEvent)
+		validatorPubKey, err := eh.handleValidatorRemoved(txn, journal, validatorRemovedEvent)
 		if err != nil {
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
 
@@ -311,7 +355,7 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 			return nil, nil
 		}
 
-		sharesToLiquidate, err := eh.handleClusterLiquidated(txn, clusterLiquidatedEvent)
+		sharesToLiquidate, err := eh.handleClusterLiquidated(txn, journal, clusterLiquidatedEvent)
 		if err != nil {
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
 
@@ -342,7 +386,7 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 			return nil, nil
 		}
 
-		sharesToReactivate, err := eh.handleClusterReactivated(txn, clusterReactivatedEvent)
+		sharesToReactivate, err := eh.handleClusterReactivated(txn, journal, clusterReactivatedEvent)
 		if err != nil {
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
 
@@ -373,7 +417,7 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 			return nil, nil
 		}
 
-		updated, err := eh.handleFeeRecipientAddressUpdated(txn, feeRecipientAddressUpdatedEvent)
+		updated, err := eh.handleFeeRecipientAddressUpdated(txn, journal, feeRecipientAddressUpdatedEvent)
 		if err != nil {
 			eh.metrics.EventProcessingFailed(abiEvent.Name)
 
@@ -423,6 +467,213 @@ func (eh *EventHandler) processEvent(txn basedb.Txn, event ethtypes.Log) (Task,
 		task := NewExitValidatorTask(eh.taskExecutor, exitDescriptor.PubKey, exitDescriptor.BlockNumber, exitDescriptor.ValidatorIndex)
 		return task, nil
 
+	case ClusterDeposited:
+		clusterDepositedEvent, err := eh.eventParser.ParseClusterDeposited(event)
+		if err != nil {
+			eh.logger.Warn("could not parse event",
+				fields.EventName(abiEvent.Name),
+				zap.Error(err))
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+			return nil, nil
+		}
+
+		if err := eh.handleClusterDeposited(txn, journal, clusterDepositedEvent); err != nil {
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+
+			var malformedEventError *MalformedEventError
+			if errors.As(err, &malformedEventError) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("handle ClusterDeposited: %w", err)
+		}
+
+		eh.metrics.EventProcessed(abiEvent.Name)
+		return nil, nil
+
+	case ClusterWithdrawn:
+		clusterWithdrawnEvent, err := eh.eventParser.ParseClusterWithdrawn(event)
+		if err != nil {
+			eh.logger.Warn("could not parse event",
+				fields.EventName(abiEvent.Name),
+				zap.Error(err))
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+			return nil, nil
+		}
+
+		if err := eh.handleClusterWithdrawn(txn, journal, clusterWithdrawnEvent); err != nil {
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+
+			var malformedEventError *MalformedEventError
+			if errors.As(err, &malformedEventError) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("handle ClusterWithdrawn: %w", err)
+		}
+
+		eh.metrics.EventProcessed(abiEvent.Name)
+		return nil, nil
+
+	case OperatorFeeDeclared:
+		operatorFeeDeclaredEvent, err := eh.eventParser.ParseOperatorFeeDeclared(event)
+		if err != nil {
+			eh.logger.Warn("could not parse event",
+				fields.EventName(abiEvent.Name),
+				zap.Error(err))
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+			return nil, nil
+		}
+
+		if err := eh.handleOperatorFeeDeclared(txn, journal, operatorFeeDeclaredEvent); err != nil {
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+
+			var malformedEventError *MalformedEventError
+			if errors.As(err, &malformedEventError) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("handle OperatorFeeDeclared: %w", err)
+		}
+
+		eh.metrics.EventProcessed(abiEvent.Name)
+		return nil, nil
+
+	case OperatorFeeDeclarationCancelled:
+		operatorFeeDeclarationCancelledEvent, err := eh.eventParser.ParseOperatorFeeDeclarationCancelled(event)
+		if err != nil {
+			eh.logger.Warn("could not parse event",
+				fields.EventName(abiEvent.Name),
+				zap.Error(err))
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+			return nil, nil
+		}
+
+		if err := eh.handleOperatorFeeDeclarationCancelled(txn, journal, operatorFeeDeclarationCancelledEvent); err != nil {
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+
+			var malformedEventError *MalformedEventError
+			if errors.As(err, &malformedEventError) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("handle OperatorFeeDeclarationCancelled: %w", err)
+		}
+
+		eh.metrics.EventProcessed(abiEvent.Name)
+		return nil, nil
+
+	case OperatorFeeExecuted:
+		operatorFeeExecutedEvent, err := eh.eventParser.ParseOperatorFeeExecuted(event)
+		if err != nil {
+			eh.logger.Warn("could not parse event",
+				fields.EventName(abiEvent.Name),
+				zap.Error(err))
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+			return nil, nil
+		}
+
+		if err := eh.handleOperatorFeeExecuted(txn, journal, operatorFeeExecutedEvent); err != nil {
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+
+			var malformedEventError *MalformedEventError
+			if errors.As(err, &malformedEventError) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("handle OperatorFeeExecuted: %w", err)
+		}
+
+		eh.metrics.EventProcessed(abiEvent.Name)
+		return nil, nil
+
+	case OperatorWhitelistUpdated:
+		operatorWhitelistUpdatedEvent, err := eh.eventParser.ParseOperatorWhitelistUpdated(event)
+		if err != nil {
+			eh.logger.Warn("could not parse event",
+				fields.EventName(abiEvent.Name),
+				zap.Error(err))
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+			return nil, nil
+		}
+
+		if err := eh.handleOperatorWhitelistUpdated(txn, journal, operatorWhitelistUpdatedEvent); err != nil {
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+
+			var malformedEventError *MalformedEventError
+			if errors.As(err, &malformedEventError) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("handle OperatorWhitelistUpdated: %w", err)
+		}
+
+		eh.metrics.EventProcessed(abiEvent.Name)
+		return nil, nil
+
+	case NetworkFeeUpdated:
+		networkFeeUpdatedEvent, err := eh.eventParser.ParseNetworkFeeUpdated(event)
+		if err != nil {
+			eh.logger.Warn("could not parse event",
+				fields.EventName(abiEvent.Name),
+				zap.Error(err))
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+			return nil, nil
+		}
+
+		if err := eh.handleNetworkFeeUpdated(txn, journal, networkFeeUpdatedEvent); err != nil {
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+
+			var malformedEventError *MalformedEventError
+			if errors.As(err, &malformedEventError) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("handle NetworkFeeUpdated: %w", err)
+		}
+
+		eh.metrics.EventProcessed(abiEvent.Name)
+		return nil, nil
+
+	case LiquidationThresholdPeriodUpdated:
+		liquidationThresholdPeriodUpdatedEvent, err := eh.eventParser.ParseLiquidationThresholdPeriodUpdated(event)
+		if err != nil {
+			eh.logger.Warn("could not parse event",
+				fields.EventName(abiEvent.Name),
+				zap.Error(err))
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+			return nil, nil
+		}
+
+		if err := eh.handleLiquidationThresholdPeriodUpdated(txn, journal, liquidationThresholdPeriodUpdatedEvent); err != nil {
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+
+			var malformedEventError *MalformedEventError
+			if errors.As(err, &malformedEventError) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("handle LiquidationThresholdPeriodUpdated: %w", err)
+		}
+
+		eh.metrics.EventProcessed(abiEvent.Name)
+		return nil, nil
+
+	case MinimumLiquidationCollateralUpdated:
+		minimumLiquidationCollateralUpdatedEvent, err := eh.eventParser.ParseMinimumLiquidationCollateralUpdated(event)
+		if err != nil {
+			eh.logger.Warn("could not parse event",
+				fields.EventName(abiEvent.Name),
+				zap.Error(err))
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+			return nil, nil
+		}
+
+		if err := eh.handleMinimumLiquidationCollateralUpdated(txn, journal, minimumLiquidationCollateralUpdatedEvent); err != nil {
+			eh.metrics.EventProcessingFailed(abiEvent.Name)
+
+			var malformedEventError *MalformedEventError
+			if errors.As(err, &malformedEventError) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("handle MinimumLiquidationCollateralUpdated: %w", err)
+		}
+
+		eh.metrics.EventProcessed(abiEvent.Name)
+		return nil, nil
+
 	default:
 		eh.logger.Warn("unknown event name", fields.Name(abiEvent.Name))
 		return nil, nil
@@ -450,7 +701,7 @@ func (eh *EventHandler) processLocalEvent(txn basedb.Txn, event localevents.Even
 	switch event.Name {
 	case OperatorAdded:
 		data := event.Data.(contract.ContractOperatorAdded)
-		if err := eh.handleOperatorAdded(txn, &data); err != nil {
+		if err := eh.handleOperatorAdded(txn, nil, &data); err != nil {
 			return fmt.Errorf("handle OperatorAdded: %w", err)
 		}
 		return nil
@@ -462,33 +713,33 @@ func (eh *EventHandler) processLocalEvent(txn basedb.Txn, event localevents.Even
 		return nil
 	case ValidatorAdded:
 		data := event.Data.(contract.ContractValidatorAdded)
-		if _, err := eh.handleValidatorAdded(txn, &data); err != nil {
+		if _, err := eh.handleValidatorAdded(txn, nil, &data); err != nil {
 			return fmt.Errorf("handle ValidatorAdded: %w", err)
 		}
 		return nil
 	case ValidatorRemoved:
 		data := event.Data.(contract.ContractValidatorRemoved)
-		if _, err := eh.handleValidatorRemoved(txn, &data); err != nil {
+		if _, err := eh.handleValidatorRemoved(txn, nil, &data); err != nil {
 			return fmt.Errorf("handle ValidatorRemoved: %w", err)
 		}
 		return nil
 	case ClusterLiquidated:
 		data := event.Data.(contract.ContractClusterLiquidated)
-		_, err := eh.handleClusterLiquidated(txn, &data)
+		_, err := eh.handleClusterLiquidated(txn, nil, &data)
 		if err != nil {
 			return fmt.Errorf("handle ClusterLiquidated: %w", err)
 		}
 		return nil
 	case ClusterReactivated:
 		data := event.Data.(contract.ContractClusterReactivated)
-		_, err := eh.handleClusterReactivated(txn, &data)
+		_, err := eh.handleClusterReactivated(txn, nil, &data)
 		if err != nil {
 			return fmt.Errorf("handle ClusterReactivated: %w", err)
 		}
 		return nil
 	case FeeRecipientAddressUpdated:
 		data := event.Data.(contract.ContractFeeRecipientAddressUpdated)
-		_, err := eh.handleFeeRecipientAddressUpdated(txn, &data)
+		_, err := eh.handleFeeRecipientAddressUpdated(txn, nil, &data)
 		if err != nil {
 			return fmt.Errorf("handle FeeRecipientAddressUpdated: %w", err)
 		}
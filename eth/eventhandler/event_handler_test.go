@@ -1271,6 +1271,174 @@ func TestHandleBlockEventsStream(t *testing.T) {
 			require.False(t, share.Liquidated)
 		})
 	})
+	t.Run("test RollbackBlocks undoes the changes of reorganized blocks", func(t *testing.T) {
+		processNextBlock := func() {
+			sim.Commit()
+			block := <-logs
+			require.NotEmpty(t, block.Logs)
+			require.NotEqual(t, ethcommon.Hash{}, block.BlockHash)
+
+			eventsCh := make(chan executionclient.BlockLogs)
+			go func() {
+				defer close(eventsCh)
+				eventsCh <- block
+			}()
+
+			lastProcessedBlock, err := eh.HandleBlockEventsStream(eventsCh, false)
+			require.NoError(t, err)
+			require.Equal(t, blockNum+1, lastProcessedBlock)
+			blockNum++
+		}
+
+		accounts, err := eh.keyManager.(ekm.StorageProvider).ListAccounts()
+		require.NoError(t, err)
+		accountsBefore := len(accounts)
+		nonceBefore, err := eh.nodeStorage.GetNextNonce(nil, testAddr)
+		require.NoError(t, err)
+		blockBefore := blockNum
+
+		// Block 1: an own validator is added.
+		validatorData5, err := createNewValidator(ops)
+		require.NoError(t, err)
+		sharesData5, err := generateSharesData(validatorData5, ops, testAddr, int(nonceBefore))
+		require.NoError(t, err)
+		valPubKey := validatorData5.masterPubKey.Serialize()
+		cluster := simcontract.CallableCluster{
+			ValidatorCount:  1,
+			NetworkFeeIndex: 1,
+			Index:           2,
+			Active:          true,
+			Balance:         big.NewInt(100_000_000),
+		}
+		_, err = boundContract.SimcontractTransactor.RegisterValidator(auth, valPubKey, []uint64{1, 2, 3, 4}, sharesData5, big.NewInt(100_000_000), cluster)
+		require.NoError(t, err)
+		processNextBlock()
+		blockAdded := blockNum
+		require.NotNil(t, eh.nodeStorage.Shares().Get(nil, valPubKey))
+		requireKeyManagerDataToExist(t, eh, accountsBefore+1, validatorData5)
+
+		// Block 2: the validator is removed.
+		_, err = boundContract.SimcontractTransactor.RemoveValidator(auth, valPubKey, []uint64{1, 2, 3, 4}, cluster)
+		require.NoError(t, err)
+		processNextBlock()
+		blockRemoved := blockNum
+		require.Nil(t, eh.nodeStorage.Shares().Get(nil, valPubKey))
+		requireKeyManagerDataToNotExist(t, eh, accountsBefore, validatorData5)
+
+		// Block 3: the cluster of validator 2 is liquidated.
+		_, err = boundContract.SimcontractTransactor.Liquidate(auth, testAddr, []uint64{1, 2, 3, 4}, cluster)
+		require.NoError(t, err)
+		processNextBlock()
+		liquidatedPubKey := validatorData2.masterPubKey.Serialize()
+		require.True(t, eh.nodeStorage.Shares().Get(nil, liquidatedPubKey).Liquidated)
+
+		// Rolling back block 3 reactivates the cluster.
+		require.NoError(t, eh.RollbackBlocks(blockRemoved, false))
+		require.False(t, eh.nodeStorage.Shares().Get(nil, liquidatedPubKey).Liquidated)
+		lastProcessedBlock, found, err := eh.nodeStorage.GetLastProcessedBlock(nil)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, blockRemoved, lastProcessedBlock.Uint64())
+
+		// Rolling back block 2 adds the share back, with its secret key.
+		require.NoError(t, eh.RollbackBlocks(blockAdded, false))
+		require.NotNil(t, eh.nodeStorage.Shares().Get(nil, valPubKey))
+		requireKeyManagerDataToExist(t, eh, accountsBefore+1, validatorData5)
+
+		// Rolling back block 1 removes the share and restores the nonce.
+		require.NoError(t, eh.RollbackBlocks(blockBefore, false))
+		require.Nil(t, eh.nodeStorage.Shares().Get(nil, valPubKey))
+		requireKeyManagerDataToNotExist(t, eh, accountsBefore, validatorData5)
+		nonce, err := eh.nodeStorage.GetNextNonce(nil, testAddr)
+		require.NoError(t, err)
+		require.Equal(t, nonceBefore, nonce)
+
+		journals, err := eh.nodeStorage.ListBlockJournals(nil)
+		require.NoError(t, err)
+		for _, journal := range journals {
+			require.LessOrEqual(t, journal.BlockNumber, blockBefore)
+		}
+	})
+	t.Run("test ImportShareKeys adds the keys of own shares from their events", func(t *testing.T) {
+		valPubKey := validatorData2.masterPubKey.Serialize()
+		require.NotNil(t, eh.nodeStorage.Shares().Get(nil, valPubKey))
+
+		accounts, err := eh.keyManager.(ekm.StorageProvider).ListAccounts()
+		require.NoError(t, err)
+		sharePubKey := validatorData2.operatorsShares[0].sec.GetPublicKey().SerializeToHexStr()
+		require.NoError(t, eh.keyManager.RemoveShare(sharePubKey))
+		requireKeyManagerDataToNotExist(t, eh, len(accounts)-1, validatorData2)
+
+		contractABI, err := contract.ContractMetaData.GetAbi()
+		require.NoError(t, err)
+		validatorAddedLogs, err := client.FilterLogs(ctx, 0, sim.Blockchain.CurrentBlock().Number.Uint64(), [][]ethcommon.Hash{
+			{contractABI.Events[ValidatorAdded].ID},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, validatorAddedLogs)
+
+		ownShares := 0
+		for _, share := range eh.nodeStorage.Shares().List(nil) {
+			if share.BelongsToOperator(eh.operatorDataStore.GetOperatorID()) {
+				ownShares++
+			}
+		}
+
+		imported, err := eh.ImportShareKeys(validatorAddedLogs)
+		require.NoError(t, err)
+		require.Equal(t, ownShares, imported)
+		requireKeyManagerDataToExist(t, eh, len(accounts), validatorData2)
+	})
+	t.Run("test another operator imports a registry snapshot and the keys of its shares", func(t *testing.T) {
+		lastProcessedBlock, found, err := eh.nodeStorage.GetLastProcessedBlock(nil)
+		require.NoError(t, err)
+		require.True(t, found)
+		header, err := client.HeaderByNumber(ctx, lastProcessedBlock)
+		require.NoError(t, err)
+		snapshot, err := operatorstorage.NewRegistrySnapshot(eh.nodeStorage, "testnet", header)
+		require.NoError(t, err)
+
+		eh2, _, err := setupEventHandler(t, ctx, logger, mockNetworkConfig, ops[1], false)
+		require.NoError(t, err)
+		ownPublicKey := eh2.operatorDataStore.GetOperatorData().PublicKey
+		var ownOperatorData *registrystorage.OperatorData
+		for i, od := range snapshot.Operators {
+			if bytes.Equal(od.PublicKey, ownPublicKey) {
+				ownOperatorData = &snapshot.Operators[i]
+			}
+		}
+		require.NotNil(t, ownOperatorData)
+		require.NoError(t, operatorstorage.ImportRegistrySnapshot(eh2.nodeStorage, snapshot, ownOperatorData.ID))
+		eh2.operatorDataStore.SetOperatorData(ownOperatorData)
+
+		share := eh2.nodeStorage.Shares().Get(nil, validatorData2.masterPubKey.Serialize())
+		require.NotNil(t, share)
+		require.True(t, share.BelongsToOperator(ownOperatorData.ID))
+		require.Equal(t, validatorData2.operatorsShares[1].pub.Serialize(), share.SharePubKey)
+
+		ownShares := 0
+		for _, share := range eh2.nodeStorage.Shares().List(nil) {
+			if share.BelongsToOperator(ownOperatorData.ID) {
+				ownShares++
+			}
+		}
+		require.NotZero(t, ownShares)
+
+		contractABI, err := contract.ContractMetaData.GetAbi()
+		require.NoError(t, err)
+		validatorAddedLogs, err := client.FilterLogs(ctx, 0, lastProcessedBlock.Uint64(), [][]ethcommon.Hash{
+			{contractABI.Events[ValidatorAdded].ID},
+		})
+		require.NoError(t, err)
+
+		imported, err := eh2.ImportShareKeys(validatorAddedLogs)
+		require.NoError(t, err)
+		require.Equal(t, ownShares, imported)
+		accounts, err := eh2.keyManager.(ekm.StorageProvider).ListAccounts()
+		require.NoError(t, err)
+		require.Len(t, accounts, ownShares)
+		require.True(t, shareExist(accounts, validatorData2.operatorsShares[1].pub.Serialize()))
+	})
 }
 
 func setupEventHandler(t *testing.T, ctx context.Context, logger *zap.Logger, network *networkconfig.NetworkConfig, operator *testOperator, useMockCtrl bool) (*EventHandler, *mocks.MockController, error) {
@@ -1382,7 +1550,7 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, operator *test
 		logger.Fatal("failed to encode operator public key", zap.Error(err))
 	}
 
-	if err := nodeStorage.SavePrivateKeyHash(encodedPrivKey); err != nil {
+	if err := nodeStorage.SavePrivateKeyHash(nil, encodedPrivKey); err != nil {
 		logger.Fatal("couldn't setup operator private key", zap.Error(err))
 	}
 
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
@@ -16,6 +17,7 @@ import (
 	"github.com/bloxapp/ssv/eth/contract"
 	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/operator/duties"
+	nodestorage "github.com/bloxapp/ssv/operator/storage"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
 	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
@@ -37,7 +39,7 @@ var (
 // TODO: make sure all handlers are tested properly:
 // set up a mock DB where we test that after running the handler we check that the DB state is as expected
 
-func (eh *EventHandler) handleOperatorAdded(txn basedb.Txn, event *contract.ContractOperatorAdded) error {
+func (eh *EventHandler) handleOperatorAdded(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractOperatorAdded) error {
 	logger := eh.logger.With(
 		fields.EventName(OperatorAdded),
 		fields.TxHash(event.Raw.TxHash),
@@ -51,6 +53,7 @@ func (eh *EventHandler) handleOperatorAdded(txn basedb.Txn, event *contract.Cont
 		PublicKey:    event.PublicKey,
 		OwnerAddress: event.Owner,
 		ID:           event.OperatorId,
+		Fee:          event.Fee,
 	}
 
 	// throw an error if there is an existing operator with the same public key and different operator id
@@ -70,6 +73,7 @@ func (eh *EventHandler) handleOperatorAdded(txn basedb.Txn, event *contract.Cont
 		logger.Debug("operator data already exists")
 		return nil
 	}
+	journal.OperatorAdded(od.ID)
 
 	if bytes.Equal(event.PublicKey, operatorData.PublicKey) {
 		eh.operatorDataStore.SetOperatorData(od)
@@ -121,7 +125,7 @@ func (eh *EventHandler) handleOperatorRemoved(txn basedb.Txn, event *contract.Co
 	return nil
 }
 
-func (eh *EventHandler) handleValidatorAdded(txn basedb.Txn, event *contract.ContractValidatorAdded) (ownShare *ssvtypes.SSVShare, err error) {
+func (eh *EventHandler) handleValidatorAdded(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractValidatorAdded) (ownShare *ssvtypes.SSVShare, err error) {
 	logger := eh.logger.With(
 		fields.EventName(ValidatorAdded),
 		fields.TxHash(event.Raw.TxHash),
@@ -130,6 +134,11 @@ func (eh *EventHandler) handleValidatorAdded(txn basedb.Txn, event *contract.Con
 		fields.Validator(event.PublicKey),
 	)
 
+	// The contract updates the cluster even if the event is malformed for the node.
+	if err := eh.saveCluster(txn, journal, event.Owner, event.OperatorIds, event.Cluster, event.Raw.BlockNumber); err != nil {
+		return nil, err
+	}
+
 	// Get the expected nonce.
 	nonce, nonceErr := eh.nodeStorage.GetNextNonce(txn, event.Owner)
 	if nonceErr != nil {
@@ -138,6 +147,9 @@ func (eh *EventHandler) handleValidatorAdded(txn basedb.Txn, event *contract.Con
 
 	// Bump nonce. This transaction would be reverted later if the handling fails,
 	// unless the failure is due to a malformed event.
+	if err := eh.journalRecipient(txn, journal, event.Owner); err != nil {
+		return nil, err
+	}
 	if err := eh.nodeStorage.BumpNonce(txn, event.Owner); err != nil {
 		return nil, err
 	}
@@ -146,25 +158,15 @@ func (eh *EventHandler) handleValidatorAdded(txn basedb.Txn, event *contract.Con
 		return nil, &MalformedEventError{Err: err}
 	}
 
-	// Calculate the expected length of constructed shares based on the number of operator IDs,
-	// signature length, public key length, and encrypted key length.
-	operatorCount := len(event.OperatorIds)
-	signatureOffset := phase0.SignatureLength
-	pubKeysOffset := phase0.PublicKeyLength*operatorCount + signatureOffset
-	sharesExpectedLength := encryptedKeyLength*operatorCount + pubKeysOffset
-
-	if sharesExpectedLength != len(event.Shares) {
+	signature, sharePublicKeys, encryptedKeys, ok := splitShares(event)
+	if !ok {
 		logger.Warn("malformed event: event shares length is not correct",
-			zap.Int("expected", sharesExpectedLength),
+			zap.Int("expected", sharesExpectedLength(len(event.OperatorIds))),
 			zap.Int("got", len(event.Shares)))
 
 		return nil, &MalformedEventError{Err: ErrIncorrectSharesLength}
 	}
 
-	signature := event.Shares[:signatureOffset]
-	sharePublicKeys := splitBytes(event.Shares[signatureOffset:pubKeysOffset], phase0.PublicKeyLength)
-	encryptedKeys := splitBytes(event.Shares[pubKeysOffset:], len(event.Shares[pubKeysOffset:])/operatorCount)
-
 	// verify sig
 	if err := verifySignature(signature, event.Owner, event.PublicKey, nonce); err != nil {
 		logger.Warn("malformed event: failed to verify signature",
@@ -179,7 +181,7 @@ func (eh *EventHandler) handleValidatorAdded(txn basedb.Txn, event *contract.Con
 	validatorShare := eh.nodeStorage.Shares().Get(txn, event.PublicKey)
 
 	if validatorShare == nil {
-		shareCreated, err := eh.handleShareCreation(txn, event, sharePublicKeys, encryptedKeys)
+		shareCreated, err := eh.handleShareCreation(txn, journal, event, sharePublicKeys, encryptedKeys)
 		if err != nil {
 			var malformedEventError *MalformedEventError
 			if errors.As(err, &malformedEventError) {
@@ -216,9 +218,33 @@ func (eh *EventHandler) handleValidatorAdded(txn basedb.Txn, event *contract.Con
 	return
 }
 
+// sharesExpectedLength calculates the expected length of constructed shares based on the number of operator IDs,
+// signature length, public key length, and encrypted key length.
+func sharesExpectedLength(operatorCount int) int {
+	return phase0.SignatureLength + phase0.PublicKeyLength*operatorCount + encryptedKeyLength*operatorCount
+}
+
+// splitShares splits the shares of a ValidatorAdded event into the owner's signature,
+// the share public keys and the encrypted share keys, or returns false if their length isn't correct.
+func splitShares(event *contract.ContractValidatorAdded) (signature []byte, sharePublicKeys [][]byte, encryptedKeys [][]byte, ok bool) {
+	operatorCount := len(event.OperatorIds)
+	if operatorCount == 0 || sharesExpectedLength(operatorCount) != len(event.Shares) {
+		return nil, nil, nil, false
+	}
+
+	signatureOffset := phase0.SignatureLength
+	pubKeysOffset := phase0.PublicKeyLength*operatorCount + signatureOffset
+
+	signature = event.Shares[:signatureOffset]
+	sharePublicKeys = splitBytes(event.Shares[signatureOffset:pubKeysOffset], phase0.PublicKeyLength)
+	encryptedKeys = splitBytes(event.Shares[pubKeysOffset:], len(event.Shares[pubKeysOffset:])/operatorCount)
+	return signature, sharePublicKeys, encryptedKeys, true
+}
+
 // handleShareCreation is called when a validator was added/updated during registry sync
 func (eh *EventHandler) handleShareCreation(
 	txn basedb.Txn,
+	journal *nodestorage.BlockJournal,
 	validatorEvent *contract.ContractValidatorAdded,
 	sharePublicKeys [][]byte,
 	encryptedKeys [][]byte,
@@ -244,6 +270,9 @@ func (eh *EventHandler) handleShareCreation(
 	}
 
 	// Save share.
+	if err := journal.ShareChanged(share.ValidatorPubKey, nil); err != nil {
+		return nil, fmt.Errorf("could not journal validator share: %w", err)
+	}
 	if err := eh.nodeStorage.Shares().Save(txn, share); err != nil {
 		return nil, fmt.Errorf("could not save validator share: %w", err)
 	}
@@ -312,7 +341,7 @@ func (eh *EventHandler) validatorAddedEventToShare(
 	return &validatorShare, shareSecret, nil
 }
 
-func (eh *EventHandler) handleValidatorRemoved(txn basedb.Txn, event *contract.ContractValidatorRemoved) (spectypes.ValidatorPK, error) {
+func (eh *EventHandler) handleValidatorRemoved(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractValidatorRemoved) (spectypes.ValidatorPK, error) {
 	logger := eh.logger.With(
 		fields.EventName(ValidatorRemoved),
 		fields.TxHash(event.Raw.TxHash),
@@ -322,6 +351,10 @@ func (eh *EventHandler) handleValidatorRemoved(txn basedb.Txn, event *contract.C
 	)
 	logger.Debug("processing event")
 
+	if err := eh.saveCluster(txn, journal, event.Owner, event.OperatorIds, event.Cluster, event.Raw.BlockNumber); err != nil {
+		return nil, err
+	}
+
 	// TODO: handle metrics
 	share := eh.nodeStorage.Shares().Get(txn, event.PublicKey)
 	if share == nil {
@@ -351,6 +384,9 @@ func (eh *EventHandler) handleValidatorRemoved(txn basedb.Txn, event *contract.C
 		return nil, fmt.Errorf("could not clean all decided messages: %w", err)
 	}
 
+	if err := journal.ShareChanged(share.ValidatorPubKey, share); err != nil {
+		return nil, fmt.Errorf("could not journal validator share: %w", err)
+	}
 	if err := eh.nodeStorage.Shares().Delete(txn, share.ValidatorPubKey); err != nil {
 		return nil, fmt.Errorf("could not remove validator share: %w", err)
 	}
@@ -360,6 +396,9 @@ func (eh *EventHandler) handleValidatorRemoved(txn basedb.Txn, event *contract.C
 		logger = logger.With(zap.String("validator_pubkey", hex.EncodeToString(share.ValidatorPubKey)))
 	}
 	if isOperatorShare {
+		if err := eh.journalShareKey(journal, share); err != nil {
+			return nil, fmt.Errorf("could not journal share key: %w", err)
+		}
 		err = eh.keyManager.RemoveShare(hex.EncodeToString(share.SharePubKey))
 		if err != nil {
 			return nil, fmt.Errorf("could not remove share from ekm storage: %w", err)
@@ -374,7 +413,7 @@ func (eh *EventHandler) handleValidatorRemoved(txn basedb.Txn, event *contract.C
 	return nil, nil
 }
 
-func (eh *EventHandler) handleClusterLiquidated(txn basedb.Txn, event *contract.ContractClusterLiquidated) ([]*ssvtypes.SSVShare, error) {
+func (eh *EventHandler) handleClusterLiquidated(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractClusterLiquidated) ([]*ssvtypes.SSVShare, error) {
 	logger := eh.logger.With(
 		fields.EventName(ClusterLiquidated),
 		fields.TxHash(event.Raw.TxHash),
@@ -383,7 +422,11 @@ func (eh *EventHandler) handleClusterLiquidated(txn basedb.Txn, event *contract.
 	)
 	logger.Debug("processing event")
 
-	toLiquidate, liquidatedPubKeys, err := eh.processClusterEvent(txn, event.Owner, event.OperatorIds, true)
+	if err := eh.saveCluster(txn, journal, event.Owner, event.OperatorIds, event.Cluster, event.Raw.BlockNumber); err != nil {
+		return nil, err
+	}
+
+	toLiquidate, liquidatedPubKeys, err := eh.processClusterEvent(txn, journal, event.Owner, event.OperatorIds, true)
 	if err != nil {
 		return nil, fmt.Errorf("could not process cluster event: %w", err)
 	}
@@ -396,7 +439,7 @@ func (eh *EventHandler) handleClusterLiquidated(txn basedb.Txn, event *contract.
 	return toLiquidate, nil
 }
 
-func (eh *EventHandler) handleClusterReactivated(txn basedb.Txn, event *contract.ContractClusterReactivated) ([]*ssvtypes.SSVShare, error) {
+func (eh *EventHandler) handleClusterReactivated(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractClusterReactivated) ([]*ssvtypes.SSVShare, error) {
 	logger := eh.logger.With(
 		fields.EventName(ClusterReactivated),
 		fields.TxHash(event.Raw.TxHash),
@@ -405,7 +448,11 @@ func (eh *EventHandler) handleClusterReactivated(txn basedb.Txn, event *contract
 	)
 	logger.Debug("processing event")
 
-	toReactivate, enabledPubKeys, err := eh.processClusterEvent(txn, event.Owner, event.OperatorIds, false)
+	if err := eh.saveCluster(txn, journal, event.Owner, event.OperatorIds, event.Cluster, event.Raw.BlockNumber); err != nil {
+		return nil, err
+	}
+
+	toReactivate, enabledPubKeys, err := eh.processClusterEvent(txn, journal, event.Owner, event.OperatorIds, false)
 	if err != nil {
 		return nil, fmt.Errorf("could not process cluster event: %w", err)
 	}
@@ -425,7 +472,7 @@ func (eh *EventHandler) handleClusterReactivated(txn basedb.Txn, event *contract
 	return toReactivate, nil
 }
 
-func (eh *EventHandler) handleFeeRecipientAddressUpdated(txn basedb.Txn, event *contract.ContractFeeRecipientAddressUpdated) (bool, error) {
+func (eh *EventHandler) handleFeeRecipientAddressUpdated(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractFeeRecipientAddressUpdated) (bool, error) {
 	logger := eh.logger.With(
 		fields.EventName(FeeRecipientAddressUpdated),
 		fields.TxHash(event.Raw.TxHash),
@@ -440,9 +487,12 @@ func (eh *EventHandler) handleFeeRecipientAddressUpdated(txn basedb.Txn, event *
 	}
 
 	if !found || recipientData == nil {
+		journal.RecipientChanged(event.Owner, nil)
 		recipientData = &registrystorage.RecipientData{
 			Owner: event.Owner,
 		}
+	} else {
+		journal.RecipientChanged(event.Owner, recipientData)
 	}
 
 	copy(recipientData.FeeRecipient[:], event.RecipientAddress.Bytes())
@@ -500,6 +550,272 @@ func (eh *EventHandler) handleValidatorExited(txn basedb.Txn, event *contract.Co
 	return ed, nil
 }
 
+func (eh *EventHandler) handleClusterDeposited(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractClusterDeposited) error {
+	logger := eh.logger.With(
+		fields.EventName(ClusterDeposited),
+		fields.TxHash(event.Raw.TxHash),
+		fields.Owner(event.Owner),
+		fields.OperatorIDs(event.OperatorIds),
+		zap.Stringer("value", event.Value),
+	)
+	logger.Debug("processing event")
+
+	if err := eh.saveCluster(txn, journal, event.Owner, event.OperatorIds, event.Cluster, event.Raw.BlockNumber); err != nil {
+		return err
+	}
+
+	logger.Debug("processed event")
+	return nil
+}
+
+func (eh *EventHandler) handleClusterWithdrawn(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractClusterWithdrawn) error {
+	logger := eh.logger.With(
+		fields.EventName(ClusterWithdrawn),
+		fields.TxHash(event.Raw.TxHash),
+		fields.Owner(event.Owner),
+		fields.OperatorIDs(event.OperatorIds),
+		zap.Stringer("value", event.Value),
+	)
+	logger.Debug("processing event")
+
+	if err := eh.saveCluster(txn, journal, event.Owner, event.OperatorIds, event.Cluster, event.Raw.BlockNumber); err != nil {
+		return err
+	}
+
+	logger.Debug("processed event")
+	return nil
+}
+
+func (eh *EventHandler) handleOperatorFeeDeclared(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractOperatorFeeDeclared) error {
+	logger := eh.logger.With(
+		fields.EventName(OperatorFeeDeclared),
+		fields.TxHash(event.Raw.TxHash),
+		fields.OperatorID(event.OperatorId),
+		zap.Stringer("fee", event.Fee),
+	)
+	logger.Debug("processing event")
+
+	err := eh.updateOperatorData(txn, journal, logger, event.OperatorId, func(od *registrystorage.OperatorData) {
+		od.DeclaredFee = event.Fee
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("processed event")
+	return nil
+}
+
+func (eh *EventHandler) handleOperatorFeeDeclarationCancelled(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractOperatorFeeDeclarationCancelled) error {
+	logger := eh.logger.With(
+		fields.EventName(OperatorFeeDeclarationCancelled),
+		fields.TxHash(event.Raw.TxHash),
+		fields.OperatorID(event.OperatorId),
+	)
+	logger.Debug("processing event")
+
+	err := eh.updateOperatorData(txn, journal, logger, event.OperatorId, func(od *registrystorage.OperatorData) {
+		od.DeclaredFee = nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("processed event")
+	return nil
+}
+
+func (eh *EventHandler) handleOperatorFeeExecuted(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractOperatorFeeExecuted) error {
+	logger := eh.logger.With(
+		fields.EventName(OperatorFeeExecuted),
+		fields.TxHash(event.Raw.TxHash),
+		fields.OperatorID(event.OperatorId),
+		zap.Stringer("fee", event.Fee),
+	)
+	logger.Debug("processing event")
+
+	err := eh.updateOperatorData(txn, journal, logger, event.OperatorId, func(od *registrystorage.OperatorData) {
+		od.Fee = event.Fee
+		od.DeclaredFee = nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("processed event")
+	return nil
+}
+
+func (eh *EventHandler) handleOperatorWhitelistUpdated(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractOperatorWhitelistUpdated) error {
+	logger := eh.logger.With(
+		fields.EventName(OperatorWhitelistUpdated),
+		fields.TxHash(event.Raw.TxHash),
+		fields.OperatorID(event.OperatorId),
+		zap.Stringer("whitelisted", event.Whitelisted),
+	)
+	logger.Debug("processing event")
+
+	err := eh.updateOperatorData(txn, journal, logger, event.OperatorId, func(od *registrystorage.OperatorData) {
+		od.WhitelistedAddress = event.Whitelisted
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("processed event")
+	return nil
+}
+
+func (eh *EventHandler) handleNetworkFeeUpdated(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractNetworkFeeUpdated) error {
+	logger := eh.logger.With(
+		fields.EventName(NetworkFeeUpdated),
+		fields.TxHash(event.Raw.TxHash),
+		zap.Stringer("old_fee", event.OldFee),
+		zap.Stringer("new_fee", event.NewFee),
+	)
+	logger.Debug("processing event")
+
+	err := eh.updateNetworkFees(txn, journal, func(networkFees *registrystorage.NetworkFees) {
+		networkFees.NetworkFee = event.NewFee
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("processed event")
+	return nil
+}
+
+func (eh *EventHandler) handleLiquidationThresholdPeriodUpdated(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractLiquidationThresholdPeriodUpdated) error {
+	logger := eh.logger.With(
+		fields.EventName(LiquidationThresholdPeriodUpdated),
+		fields.TxHash(event.Raw.TxHash),
+		zap.Uint64("value", event.Value),
+	)
+	logger.Debug("processing event")
+
+	err := eh.updateNetworkFees(txn, journal, func(networkFees *registrystorage.NetworkFees) {
+		networkFees.LiquidationThresholdPeriod = event.Value
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("processed event")
+	return nil
+}
+
+func (eh *EventHandler) handleMinimumLiquidationCollateralUpdated(txn basedb.Txn, journal *nodestorage.BlockJournal, event *contract.ContractMinimumLiquidationCollateralUpdated) error {
+	logger := eh.logger.With(
+		fields.EventName(MinimumLiquidationCollateralUpdated),
+		fields.TxHash(event.Raw.TxHash),
+		zap.Stringer("value", event.Value),
+	)
+	logger.Debug("processing event")
+
+	err := eh.updateNetworkFees(txn, journal, func(networkFees *registrystorage.NetworkFees) {
+		networkFees.MinimumLiquidationCollateral = event.Value
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("processed event")
+	return nil
+}
+
+// saveCluster saves the state of a cluster as emitted by an event which changed it.
+func (eh *EventHandler) saveCluster(
+	txn basedb.Txn,
+	journal *nodestorage.BlockJournal,
+	owner ethcommon.Address,
+	operatorIDs []uint64,
+	cluster contract.ISSVNetworkCoreCluster,
+	blockNumber uint64,
+) error {
+	if cluster.Balance == nil {
+		// Local events don't carry the cluster's state.
+		return nil
+	}
+
+	previous, found, err := eh.nodeStorage.Clusters().GetCluster(txn, owner, operatorIDs)
+	if err != nil {
+		return fmt.Errorf("could not get cluster: %w", err)
+	}
+	if !found {
+		previous = nil
+	}
+	journal.ClusterChanged(owner, operatorIDs, previous)
+
+	sortedOperatorIDs := append([]uint64{}, operatorIDs...)
+	sort.Slice(sortedOperatorIDs, func(i, j int) bool {
+		return sortedOperatorIDs[i] < sortedOperatorIDs[j]
+	})
+	saved := &registrystorage.Cluster{
+		Owner:           owner,
+		OperatorIDs:     sortedOperatorIDs,
+		ValidatorCount:  cluster.ValidatorCount,
+		NetworkFeeIndex: cluster.NetworkFeeIndex,
+		Index:           cluster.Index,
+		Active:          cluster.Active,
+		Balance:         cluster.Balance,
+		UpdatedBlock:    blockNumber,
+	}
+	if err := eh.nodeStorage.Clusters().SaveCluster(txn, saved); err != nil {
+		return fmt.Errorf("could not save cluster: %w", err)
+	}
+	eh.changedClusters = append(eh.changedClusters, saved)
+	return nil
+}
+
+// updateOperatorData applies the given update to the data of an operator and saves it.
+func (eh *EventHandler) updateOperatorData(
+	txn basedb.Txn,
+	journal *nodestorage.BlockJournal,
+	logger *zap.Logger,
+	operatorID uint64,
+	update func(od *registrystorage.OperatorData),
+) error {
+	od, found, err := eh.nodeStorage.GetOperatorData(txn, operatorID)
+	if err != nil {
+		return fmt.Errorf("could not get operator data: %w", err)
+	}
+	if !found || od == nil {
+		logger.Warn("malformed event: could not find operator data")
+		return &MalformedEventError{Err: ErrOperatorDataNotFound}
+	}
+
+	journal.OperatorChanged(od)
+	update(od)
+	if err := eh.nodeStorage.UpdateOperatorData(txn, od); err != nil {
+		return fmt.Errorf("could not save operator data: %w", err)
+	}
+
+	if od.ID == eh.operatorDataStore.GetOperatorID() {
+		eh.operatorDataStore.SetOperatorData(od)
+	}
+	return nil
+}
+
+// updateNetworkFees applies the given update to the network fees and saves them.
+func (eh *EventHandler) updateNetworkFees(
+	txn basedb.Txn,
+	journal *nodestorage.BlockJournal,
+	update func(networkFees *registrystorage.NetworkFees),
+) error {
+	networkFees, err := eh.nodeStorage.Clusters().GetNetworkFees(txn)
+	if err != nil {
+		return fmt.Errorf("could not get network fees: %w", err)
+	}
+
+	journal.NetworkFeesChanged(networkFees)
+	update(networkFees)
+	if err := eh.nodeStorage.Clusters().SaveNetworkFees(txn, networkFees); err != nil {
+		return fmt.Errorf("could not save network fees: %w", err)
+	}
+	return nil
+}
+
 func splitBytes(buf []byte, lim int) [][]byte {
 	var chunk []byte
 	chunks := make([][]byte, 0, len(buf)/lim+1)
@@ -516,6 +832,7 @@ func splitBytes(buf []byte, lim int) [][]byte {
 // processClusterEvent handles registry contract event for cluster
 func (eh *EventHandler) processClusterEvent(
 	txn basedb.Txn,
+	journal *nodestorage.BlockJournal,
 	owner ethcommon.Address,
 	operatorIDs []uint64,
 	toLiquidate bool,
@@ -531,6 +848,9 @@ func (eh *EventHandler) processClusterEvent(
 			updatedPubKeys = append(updatedPubKeys, hex.EncodeToString(share.ValidatorPubKey))
 		}
 		if isOperatorShare {
+			if err := journal.ShareChanged(share.ValidatorPubKey, share); err != nil {
+				return nil, nil, fmt.Errorf("could not journal validator share: %w", err)
+			}
 			share.Liquidated = toLiquidate
 			toUpdate = append(toUpdate, share)
 		}
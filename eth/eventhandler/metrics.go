@@ -2,6 +2,7 @@ package eventhandler
 
 import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 )
 
 type metrics interface {
@@ -11,14 +12,18 @@ type metrics interface {
 	ValidatorRemoved(publicKey []byte)
 	EventProcessed(eventName string)
 	EventProcessingFailed(eventName string)
+	ClusterRunway(owner ethcommon.Address, operatorIDs []uint64, balance float64, blocks uint64, days float64)
+	ClusterRunwayRemoved(owner ethcommon.Address, operatorIDs []uint64)
 }
 
 // nopMetrics is no-op metrics.
 type nopMetrics struct{}
 
-func (n nopMetrics) OperatorPublicKey(spectypes.OperatorID, []byte) {}
-func (n nopMetrics) ValidatorInactive([]byte)                       {}
-func (n nopMetrics) ValidatorError([]byte)                          {}
-func (n nopMetrics) ValidatorRemoved([]byte)                        {}
-func (n nopMetrics) EventProcessed(string)                          {}
-func (n nopMetrics) EventProcessingFailed(string)                   {}
+func (n nopMetrics) OperatorPublicKey(spectypes.OperatorID, []byte)                      {}
+func (n nopMetrics) ValidatorInactive([]byte)                                            {}
+func (n nopMetrics) ValidatorError([]byte)                                               {}
+func (n nopMetrics) ValidatorRemoved([]byte)                                             {}
+func (n nopMetrics) EventProcessed(string)                                               {}
+func (n nopMetrics) EventProcessingFailed(string)                                        {}
+func (n nopMetrics) ClusterRunway(ethcommon.Address, []uint64, float64, uint64, float64) {}
+func (n nopMetrics) ClusterRunwayRemoved(ethcommon.Address, []uint64)                    {}
@@ -0,0 +1,47 @@
+package eventhandler
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/bloxapp/ssv/notifications"
+)
+
+// notifyTask notifies about the change to the operator's validators made by an executed task.
+func (eh *EventHandler) notifyTask(task Task) {
+	switch t := task.(type) {
+	case *StartValidatorTask:
+		operatorIDs := make([]uint64, 0, len(t.share.Committee))
+		for _, operator := range t.share.Committee {
+			operatorIDs = append(operatorIDs, operator.OperatorID)
+		}
+		eh.notifier.Notify(notifications.NewEvent(notifications.EventValidatorAdded, "validator was added to the operator", map[string]string{
+			"validator": "0x" + hex.EncodeToString(t.share.ValidatorPubKey),
+			"owner":     t.share.OwnerAddress.Hex(),
+			"operators": formatOperatorIDs(operatorIDs),
+		}))
+	case *StopValidatorTask:
+		eh.notifier.Notify(notifications.NewEvent(notifications.EventValidatorRemoved, "validator was removed from the operator", map[string]string{
+			"validator": "0x" + hex.EncodeToString(t.pubKey),
+		}))
+	case *LiquidateClusterTask:
+		validators := make([]string, 0, len(t.toLiquidate))
+		for _, share := range t.toLiquidate {
+			validators = append(validators, "0x"+hex.EncodeToString(share.ValidatorPubKey))
+		}
+		eh.notifier.Notify(notifications.NewEvent(notifications.EventValidatorLiquidated, "cluster was liquidated, its validators were stopped", map[string]string{
+			"validators": strings.Join(validators, ","),
+			"owner":      t.owner.Hex(),
+			"operators":  formatOperatorIDs(t.operatorIDs),
+		}))
+	}
+}
+
+func formatOperatorIDs(operatorIDs []uint64) string {
+	ids := make([]string, 0, len(operatorIDs))
+	for _, id := range operatorIDs {
+		ids = append(ids, strconv.FormatUint(id, 10))
+	}
+	return strings.Join(ids, ",")
+}
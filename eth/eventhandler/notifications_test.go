@@ -0,0 +1,47 @@
+package eventhandler
+
+import (
+	"testing"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/notifications"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+type recordingNotifier struct {
+	events []*notifications.Event
+}
+
+func (n *recordingNotifier) Notify(event *notifications.Event) {
+	n.events = append(n.events, event)
+}
+
+func TestNotifyTask(t *testing.T) {
+	notifier := &recordingNotifier{}
+	eh := &EventHandler{notifier: notifier}
+
+	share := &ssvtypes.SSVShare{
+		Share: spectypes.Share{
+			ValidatorPubKey: []byte{1, 2},
+			Committee:       []*spectypes.Operator{{OperatorID: 1}, {OperatorID: 2}, {OperatorID: 3}, {OperatorID: 4}},
+		},
+		Metadata: ssvtypes.Metadata{OwnerAddress: testAddr},
+	}
+	eh.notifyTask(NewStartValidatorTask(nil, share))
+	eh.notifyTask(NewStopValidatorTask(nil, []byte{1, 2}))
+	eh.notifyTask(NewLiquidateClusterTask(nil, testAddr, []uint64{1, 2, 3, 4}, []*ssvtypes.SSVShare{share, share}))
+	// Other tasks aren't notified about.
+	eh.notifyTask(NewUpdateFeeRecipientTask(nil, testAddr, ethcommon.Address{}))
+
+	require.Len(t, notifier.events, 3)
+	require.Equal(t, notifications.EventValidatorAdded, notifier.events[0].Type)
+	require.Equal(t, map[string]string{"validator": "0x0102", "owner": testAddr.Hex(), "operators": "1,2,3,4"}, notifier.events[0].Fields)
+	require.Equal(t, notifications.EventValidatorRemoved, notifier.events[1].Type)
+	require.Equal(t, "0x0102", notifier.events[1].Fields["validator"])
+	require.Equal(t, notifications.EventValidatorLiquidated, notifier.events[2].Type)
+	require.Equal(t, notifications.SeverityWarning, notifier.events[2].Severity)
+	require.Equal(t, "0x0102,0x0102", notifier.events[2].Fields["validators"])
+}
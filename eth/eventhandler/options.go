@@ -1,7 +1,10 @@
 package eventhandler
 
 import (
+	"time"
+
 	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/notifications"
 	"go.uber.org/zap"
 )
 
@@ -22,9 +25,24 @@ func WithMetrics(metrics metrics) Option {
 	}
 }
 
+// WithNotifier enables notifications about changes to the operator's validators.
+func WithNotifier(notifier notifications.Notifier) Option {
+	return func(eh *EventHandler) {
+		eh.notifier = notifier
+	}
+}
+
 // WithFullNode signals that node works in a full node state.
 func WithFullNode() Option {
 	return func(eh *EventHandler) {
 		eh.fullNode = true
 	}
 }
+
+// WithRunwayWarningThreshold sets the runway of own clusters below which the node warns about their approaching liquidation.
+// If zero, the contract's liquidation threshold period is used.
+func WithRunwayWarningThreshold(threshold time.Duration) Option {
+	return func(eh *EventHandler) {
+		eh.runwayWarningThreshold = threshold
+	}
+}
@@ -0,0 +1,334 @@
+package eventhandler
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/ekm"
+	"github.com/bloxapp/ssv/logging/fields"
+	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
+	"github.com/bloxapp/ssv/operator/keys"
+	nodestorage "github.com/bloxapp/ssv/operator/storage"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// RollbackBlocks undoes the registry changes of the processed blocks after the given block
+// using their journals, and sets the given block as the last processed block.
+// If executeTasks is true, validators are stopped, started and updated according to the undone changes.
+func (eh *EventHandler) RollbackBlocks(toBlock uint64, executeTasks bool) error {
+	logger := eh.logger.With(fields.ToBlock(toBlock))
+
+	txn := eh.nodeStorage.Begin()
+	defer txn.Discard()
+
+	journals, err := eh.nodeStorage.ListBlockJournals(txn)
+	if err != nil {
+		return fmt.Errorf("list block journals: %w", err)
+	}
+
+	rollback := &rollbackChanges{
+		operatorID: eh.operatorDataStore.GetOperatorID(),
+		shares:     make(map[string]*ssvtypes.SSVShare),
+		recipients: make(map[ethcommon.Address]*registrystorage.RecipientData),
+	}
+	for i := len(journals) - 1; i >= 0 && journals[i].BlockNumber > toBlock; i-- {
+		journal := journals[i]
+		for j := len(journal.Entries) - 1; j >= 0; j-- {
+			if err := eh.undoJournalEntry(txn, rollback, journal.Entries[j]); err != nil {
+				return fmt.Errorf("undo block %d: %w", journal.BlockNumber, err)
+			}
+		}
+		if err := eh.nodeStorage.DeleteBlockJournal(txn, journal.BlockNumber); err != nil {
+			return fmt.Errorf("delete block journal: %w", err)
+		}
+		logger.Info("rolled back block",
+			fields.BlockNumber(journal.BlockNumber),
+			zap.String("block_hash", journal.BlockHash.Hex()),
+			fields.Count(len(journal.Entries)))
+	}
+
+	if err := eh.nodeStorage.SaveLastProcessedBlock(txn, new(big.Int).SetUint64(toBlock)); err != nil {
+		return fmt.Errorf("set last processed block: %w", err)
+	}
+
+	tasks, err := eh.rollbackTasks(txn, rollback)
+	if err != nil {
+		return err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	// The operator's data is only updated once the rolled back registry is committed.
+	if rollback.operatorData != nil {
+		eh.operatorDataStore.SetOperatorData(rollback.operatorData)
+	}
+	eh.reloadMonitoredClusters()
+
+	if executeTasks {
+		eh.executeTasks(logger, tasks)
+	}
+	return nil
+}
+
+// rollbackChanges holds the shares and recipients as they were before a rollback,
+// in the order in which they were undone, and the operator's data as it's after the rollback, if it changed.
+type rollbackChanges struct {
+	operatorID      spectypes.OperatorID
+	operatorData    *registrystorage.OperatorData
+	shares          map[string]*ssvtypes.SSVShare
+	sharesOrder     []string
+	recipients      map[ethcommon.Address]*registrystorage.RecipientData
+	recipientsOrder []ethcommon.Address
+}
+
+// currentOperatorData returns the operator's data as rolled back so far.
+func (r *rollbackChanges) currentOperatorData(store operatordatastore.OperatorDataStore) *registrystorage.OperatorData {
+	if r.operatorData != nil {
+		return r.operatorData
+	}
+	return store.GetOperatorData()
+}
+
+func (eh *EventHandler) undoJournalEntry(txn basedb.Txn, rollback *rollbackChanges, entry nodestorage.JournalEntry) error {
+	switch {
+	case entry.AddedOperatorID != 0:
+		if err := eh.nodeStorage.DeleteOperatorData(txn, entry.AddedOperatorID); err != nil {
+			return fmt.Errorf("could not delete operator data: %w", err)
+		}
+		if od := rollback.currentOperatorData(eh.operatorDataStore); od.ID == entry.AddedOperatorID {
+			rollback.operatorData = &registrystorage.OperatorData{PublicKey: od.PublicKey}
+		}
+
+	case entry.PreviousOperator != nil:
+		if err := eh.nodeStorage.UpdateOperatorData(txn, entry.PreviousOperator); err != nil {
+			return fmt.Errorf("could not save operator data: %w", err)
+		}
+		if od := rollback.currentOperatorData(eh.operatorDataStore); od.ID == entry.PreviousOperator.ID {
+			rollback.operatorData = entry.PreviousOperator
+		}
+
+	case entry.ClusterOwner != nil:
+		if entry.PreviousCluster == nil {
+			if err := eh.nodeStorage.Clusters().DeleteCluster(txn, *entry.ClusterOwner, entry.ClusterOperatorIDs); err != nil {
+				return fmt.Errorf("could not delete cluster: %w", err)
+			}
+			return nil
+		}
+		if err := eh.nodeStorage.Clusters().SaveCluster(txn, entry.PreviousCluster); err != nil {
+			return fmt.Errorf("could not save cluster: %w", err)
+		}
+
+	case entry.PreviousNetworkFees != nil:
+		if err := eh.nodeStorage.Clusters().SaveNetworkFees(txn, entry.PreviousNetworkFees); err != nil {
+			return fmt.Errorf("could not save network fees: %w", err)
+		}
+
+	case entry.RecipientOwner != nil:
+		owner := *entry.RecipientOwner
+		if _, ok := rollback.recipients[owner]; !ok {
+			current, found, err := eh.nodeStorage.GetRecipientData(txn, owner)
+			if err != nil {
+				return fmt.Errorf("could not get recipient data: %w", err)
+			}
+			if !found {
+				current = nil
+			}
+			rollback.recipients[owner] = current
+			rollback.recipientsOrder = append(rollback.recipientsOrder, owner)
+		}
+		if err := eh.nodeStorage.DeleteRecipientData(txn, owner); err != nil {
+			return fmt.Errorf("could not delete recipient data: %w", err)
+		}
+		if entry.PreviousRecipient != nil {
+			if _, err := eh.nodeStorage.SaveRecipientData(txn, entry.PreviousRecipient); err != nil {
+				return fmt.Errorf("could not save recipient data: %w", err)
+			}
+		}
+
+	case entry.EncryptedShareKey != nil:
+		decrypted, err := eh.operatorDecrypter.Decrypt(entry.EncryptedShareKey)
+		if err != nil {
+			return fmt.Errorf("could not decrypt share key: %w", err)
+		}
+		shareKey := &bls.SecretKey{}
+		if err := shareKey.SetHexString(string(decrypted)); err != nil {
+			return fmt.Errorf("could not decode share key: %w", err)
+		}
+		if err := eh.keyManager.AddShare(shareKey); err != nil {
+			return fmt.Errorf("could not add share secret to key manager: %w", err)
+		}
+
+	case entry.ValidatorPubKey != nil:
+		pubKey := hex.EncodeToString(entry.ValidatorPubKey)
+		current := eh.nodeStorage.Shares().Get(txn, entry.ValidatorPubKey)
+		if _, ok := rollback.shares[pubKey]; !ok {
+			rollback.shares[pubKey] = current
+			rollback.sharesOrder = append(rollback.sharesOrder, pubKey)
+		}
+
+		if entry.PreviousShare == nil {
+			if current == nil {
+				return nil
+			}
+			if err := eh.nodeStorage.Shares().Delete(txn, entry.ValidatorPubKey); err != nil {
+				return fmt.Errorf("could not remove validator share: %w", err)
+			}
+			if current.BelongsToOperator(rollback.operatorID) {
+				if err := eh.keyManager.RemoveShare(hex.EncodeToString(current.SharePubKey)); err != nil {
+					return fmt.Errorf("could not remove share from ekm storage: %w", err)
+				}
+			}
+			return nil
+		}
+
+		previous := &ssvtypes.SSVShare{}
+		if err := previous.Decode(entry.PreviousShare); err != nil {
+			return fmt.Errorf("could not decode validator share: %w", err)
+		}
+		if current != nil {
+			// Keep the beacon metadata, which isn't changed by events.
+			previous.BeaconMetadata = current.BeaconMetadata
+		}
+		if err := eh.nodeStorage.Shares().Save(txn, previous); err != nil {
+			return fmt.Errorf("could not save validator share: %w", err)
+		}
+	}
+	return nil
+}
+
+// rollbackTasks returns the tasks which bring the validators in line with the rolled back registry.
+func (eh *EventHandler) rollbackTasks(txn basedb.Txn, rollback *rollbackChanges) ([]Task, error) {
+	var tasks []Task
+	for _, pubKey := range rollback.sharesOrder {
+		before := rollback.shares[pubKey]
+		validatorPubKey, err := hex.DecodeString(pubKey)
+		if err != nil {
+			return nil, err
+		}
+		after := eh.nodeStorage.Shares().Get(txn, validatorPubKey)
+
+		ownBefore := before != nil && before.BelongsToOperator(rollback.operatorID)
+		ownAfter := after != nil && after.BelongsToOperator(rollback.operatorID)
+		switch {
+		case ownBefore && !ownAfter:
+			tasks = append(tasks, NewStopValidatorTask(eh.taskExecutor, before.ValidatorPubKey))
+		case !ownBefore && ownAfter:
+			tasks = append(tasks, NewStartValidatorTask(eh.taskExecutor, after))
+		case ownBefore && ownAfter && before.Liquidated != after.Liquidated:
+			operatorIDs := make([]uint64, 0, len(after.Committee))
+			for _, operator := range after.Committee {
+				operatorIDs = append(operatorIDs, operator.OperatorID)
+			}
+			if after.Liquidated {
+				tasks = append(tasks, NewLiquidateClusterTask(eh.taskExecutor, after.OwnerAddress, operatorIDs, []*ssvtypes.SSVShare{after}))
+				continue
+			}
+			if err := eh.keyManager.(ekm.StorageProvider).BumpSlashingProtection(after.SharePubKey); err != nil {
+				return nil, fmt.Errorf("could not bump slashing protection: %w", err)
+			}
+			tasks = append(tasks, NewReactivateClusterTask(eh.taskExecutor, after.OwnerAddress, operatorIDs, []*ssvtypes.SSVShare{after}))
+		}
+	}
+
+	for _, owner := range rollback.recipientsOrder {
+		after, found, err := eh.nodeStorage.GetRecipientData(txn, owner)
+		if err != nil {
+			return nil, fmt.Errorf("could not get recipient data: %w", err)
+		}
+		if !found {
+			after = nil
+		}
+		if feeRecipient(owner, rollback.recipients[owner]) != feeRecipient(owner, after) {
+			tasks = append(tasks, NewUpdateFeeRecipientTask(eh.taskExecutor, owner, feeRecipient(owner, after)))
+		}
+	}
+	return tasks, nil
+}
+
+// feeRecipient returns the fee recipient of the given owner's validators, which defaults to the owner.
+func feeRecipient(owner ethcommon.Address, recipientData *registrystorage.RecipientData) ethcommon.Address {
+	if recipientData == nil {
+		return owner
+	}
+	return ethcommon.Address(recipientData.FeeRecipient)
+}
+
+// saveBlockJournal saves the journal of a processed block, and deletes the journals
+// which are too far behind it to be rolled back.
+func (eh *EventHandler) saveBlockJournal(txn basedb.Txn, journal *nodestorage.BlockJournal) error {
+	if err := eh.nodeStorage.SaveBlockJournal(txn, journal); err != nil {
+		return err
+	}
+	if journal.BlockNumber < nodestorage.BlockJournalDepth {
+		return nil
+	}
+	journals, err := eh.nodeStorage.ListBlockJournals(txn)
+	if err != nil {
+		return err
+	}
+	for _, j := range journals {
+		if j.BlockNumber > journal.BlockNumber-nodestorage.BlockJournalDepth {
+			break
+		}
+		if err := eh.nodeStorage.DeleteBlockJournal(txn, j.BlockNumber); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// journalRecipient records the owner's recipient data before it's changed.
+func (eh *EventHandler) journalRecipient(txn basedb.Txn, journal *nodestorage.BlockJournal, owner ethcommon.Address) error {
+	if journal == nil {
+		return nil
+	}
+	recipientData, found, err := eh.nodeStorage.GetRecipientData(txn, owner)
+	if err != nil {
+		return fmt.Errorf("could not get recipient data: %w", err)
+	}
+	if !found {
+		recipientData = nil
+	}
+	journal.RecipientChanged(owner, recipientData)
+	return nil
+}
+
+// journalShareKey records the secret key of an own share before it's removed from the key manager,
+// encrypted with the operator's public key, so that it can be added back if the removal is rolled back.
+func (eh *EventHandler) journalShareKey(journal *nodestorage.BlockJournal, share *ssvtypes.SSVShare) error {
+	if journal == nil {
+		return nil
+	}
+	exporter, ok := eh.keyManager.(ekm.ShareExporter)
+	if !ok {
+		return nil
+	}
+	shareKey, err := exporter.ExportShare(hex.EncodeToString(share.SharePubKey))
+	if err != nil {
+		// The share may not be in the key manager, in which case there is nothing to add back.
+		eh.logger.Warn("could not export share key, it won't be added back if the block is reorganized",
+			fields.PubKey(share.ValidatorPubKey),
+			zap.Error(err))
+		return nil
+	}
+	operatorPubKey, err := keys.PublicKeyFromString(string(eh.operatorDataStore.GetOperatorData().PublicKey))
+	if err != nil {
+		return fmt.Errorf("could not decode operator public key: %w", err)
+	}
+	encryptedKey, err := operatorPubKey.Encrypt([]byte(shareKey.SerializeToHexStr()))
+	if err != nil {
+		return fmt.Errorf("could not encrypt share key: %w", err)
+	}
+	journal.ShareKeyRemoved(share.ValidatorPubKey, encryptedKey)
+	return nil
+}
@@ -0,0 +1,62 @@
+package eventhandler
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+)
+
+// ImportShareKeys adds the secret keys of the operator's own shares to the key manager,
+// decrypting them from the given ValidatorAdded logs, ordered by block.
+// It's used after importing a registry snapshot, which has the shares but not their keys.
+// Returns the number of own shares whose keys were added.
+func (eh *EventHandler) ImportShareKeys(logs []ethtypes.Log) (int, error) {
+	operatorID := eh.operatorDataStore.GetOperatorID()
+	imported := make(map[string]bool)
+
+	// Walk the logs from the latest, since a validator which was removed and added again has new shares.
+	for i := len(logs) - 1; i >= 0; i-- {
+		event, err := eh.eventParser.ParseValidatorAdded(logs[i])
+		if err != nil {
+			return len(imported), fmt.Errorf("could not parse ValidatorAdded event: %w", err)
+		}
+		share := eh.nodeStorage.Shares().Get(nil, event.PublicKey)
+		if share == nil || !share.BelongsToOperator(operatorID) || imported[hex.EncodeToString(share.ValidatorPubKey)] {
+			continue
+		}
+
+		_, sharePublicKeys, encryptedKeys, ok := splitShares(event)
+		if !ok {
+			continue
+		}
+		_, shareSecret, err := eh.validatorAddedEventToShare(event, sharePublicKeys, encryptedKeys)
+		if err != nil || shareSecret == nil {
+			eh.logger.Warn("could not decrypt share key from event",
+				fields.TxHash(event.Raw.TxHash),
+				fields.PubKey(event.PublicKey),
+				zap.Error(err))
+			continue
+		}
+		if !bytes.Equal(shareSecret.GetPublicKey().Serialize(), share.SharePubKey) {
+			// The event is of an earlier registration of the validator.
+			continue
+		}
+
+		if err := eh.keyManager.AddShare(shareSecret); err != nil {
+			return len(imported), fmt.Errorf("could not add share secret to key manager: %w", err)
+		}
+		imported[hex.EncodeToString(share.ValidatorPubKey)] = true
+	}
+
+	for _, share := range eh.nodeStorage.Shares().List(nil) {
+		if share.BelongsToOperator(operatorID) && !imported[hex.EncodeToString(share.ValidatorPubKey)] {
+			eh.logger.Warn("could not find the key of own share", fields.PubKey(share.ValidatorPubKey))
+		}
+	}
+	return len(imported), nil
+}
@@ -32,6 +32,15 @@ type eventFilterer interface {
 	ParseClusterReactivated(log ethtypes.Log) (*contract.ContractClusterReactivated, error)
 	ParseFeeRecipientAddressUpdated(log ethtypes.Log) (*contract.ContractFeeRecipientAddressUpdated, error)
 	ParseValidatorExited(log ethtypes.Log) (*contract.ContractValidatorExited, error)
+	ParseClusterDeposited(log ethtypes.Log) (*contract.ContractClusterDeposited, error)
+	ParseClusterWithdrawn(log ethtypes.Log) (*contract.ContractClusterWithdrawn, error)
+	ParseOperatorFeeDeclared(log ethtypes.Log) (*contract.ContractOperatorFeeDeclared, error)
+	ParseOperatorFeeDeclarationCancelled(log ethtypes.Log) (*contract.ContractOperatorFeeDeclarationCancelled, error)
+	ParseOperatorFeeExecuted(log ethtypes.Log) (*contract.ContractOperatorFeeExecuted, error)
+	ParseOperatorWhitelistUpdated(log ethtypes.Log) (*contract.ContractOperatorWhitelistUpdated, error)
+	ParseNetworkFeeUpdated(log ethtypes.Log) (*contract.ContractNetworkFeeUpdated, error)
+	ParseLiquidationThresholdPeriodUpdated(log ethtypes.Log) (*contract.ContractLiquidationThresholdPeriodUpdated, error)
+	ParseMinimumLiquidationCollateralUpdated(log ethtypes.Log) (*contract.ContractMinimumLiquidationCollateralUpdated, error)
 }
 
 type eventByIDGetter interface {
@@ -6,8 +6,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/eth/executionclient"
@@ -21,15 +24,21 @@ import (
 var (
 	// ErrNodeNotReady is returned when node is not ready.
 	ErrNodeNotReady = fmt.Errorf("node not ready")
+
+	// ErrReorgTooDeep is returned when a reorganized block is behind the range of the block journals,
+	// so the reorg can't be rolled back.
+	ErrReorgTooDeep = errors.New("reorg is deeper than the block journals")
 )
 
 type ExecutionClient interface {
 	FetchHistoricalLogs(ctx context.Context, fromBlock uint64) (logs <-chan executionclient.BlockLogs, errors <-chan error, err error)
 	StreamLogs(ctx context.Context, fromBlock uint64) <-chan executionclient.BlockLogs
+	HeaderByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Header, error)
 }
 
 type EventHandler interface {
 	HandleBlockEventsStream(logs <-chan executionclient.BlockLogs, executeTasks bool) (uint64, error)
+	RollbackBlocks(toBlock uint64, executeTasks bool) error
 }
 
 // EventSyncer syncs registry contract events from the given ExecutionClient
@@ -86,9 +95,25 @@ func (es *EventSyncer) Healthy(ctx context.Context) error {
 }
 
 // SyncHistory reads and processes historical events since the given fromBlock.
+// Blocks which were processed before and have been reorganized since are rolled back first.
 func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastProcessedBlock uint64, err error) {
+	ancestor, reorged, err := es.findReorg(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check for reorgs: %w", err)
+	}
+	if reorged {
+		if err := es.eventHandler.RollbackBlocks(ancestor, false); err != nil {
+			return 0, fmt.Errorf("failed to roll back reorganized blocks: %w", err)
+		}
+		fromBlock = ancestor + 1
+	}
+
 	fetchLogs, fetchError, err := es.executionClient.FetchHistoricalLogs(ctx, fromBlock)
 	if errors.Is(err, executionclient.ErrNothingToSync) {
+		if reorged {
+			// Ongoing sync should continue after the rolled back blocks.
+			return ancestor, nil
+		}
 		// Nothing to sync, should keep ongoing sync from the given fromBlock.
 		return 0, executionclient.ErrNothingToSync
 	}
@@ -120,10 +145,139 @@ func (es *EventSyncer) SyncHistory(ctx context.Context, fromBlock uint64) (lastP
 }
 
 // SyncOngoing streams and processes ongoing events as they come since the given fromBlock.
+// When processed blocks are reorganized, they're rolled back and streaming restarts after the common ancestor.
 func (es *EventSyncer) SyncOngoing(ctx context.Context, fromBlock uint64) error {
-	es.logger.Info("subscribing to ongoing registry events", fields.FromBlock(fromBlock))
+	for {
+		es.logger.Info("subscribing to ongoing registry events", fields.FromBlock(fromBlock))
+
+		streamCtx, cancel := context.WithCancel(ctx)
+		logs := es.executionClient.StreamLogs(streamCtx, fromBlock)
+		checked, reorg := es.checkReorgs(streamCtx, logs)
+		_, err := es.eventHandler.HandleBlockEventsStream(checked, true)
+		cancel()
+		for range logs {
+			// Drain the stream until it's closed, so it doesn't block.
+		}
+		if err != nil {
+			return err
+		}
+
+		r := <-reorg
+		if r.err != nil {
+			return r.err
+		}
+		if !r.reorged {
+			return nil
+		}
+		if err := es.eventHandler.RollbackBlocks(r.ancestor, true); err != nil {
+			return fmt.Errorf("failed to roll back reorganized blocks: %w", err)
+		}
+		fromBlock = r.ancestor + 1
+	}
+}
 
-	logs := es.executionClient.StreamLogs(ctx, fromBlock)
-	_, err := es.eventHandler.HandleBlockEventsStream(logs, true)
-	return err
+type reorgResult struct {
+	ancestor uint64
+	reorged  bool
+	err      error
+}
+
+// checkReorgs forwards the given logs, checking that the processed blocks are still canonical before each block.
+// The forwarded logs are closed once a reorg is found, after which the result has the common ancestor to roll back to.
+func (es *EventSyncer) checkReorgs(ctx context.Context, logs <-chan executionclient.BlockLogs) (<-chan executionclient.BlockLogs, <-chan reorgResult) {
+	checked := make(chan executionclient.BlockLogs)
+	result := make(chan reorgResult, 1)
+	go func() {
+		defer close(checked)
+		for blockLogs := range logs {
+			blockLogs := blockLogs
+			ancestor, reorged, err := es.findReorg(ctx, &blockLogs)
+			if err != nil || reorged {
+				result <- reorgResult{ancestor: ancestor, reorged: reorged, err: err}
+				return
+			}
+			checked <- blockLogs
+		}
+		result <- reorgResult{}
+	}()
+	return checked, result
+}
+
+// findReorg checks that the tip of the block journals is still canonical, by the parent hash of the next block
+// if it directly follows the tip, or otherwise by the tip's own hash. Only if the tip was reorganized,
+// it compares the hashes of the older journaled blocks with the canonical chain, newest first,
+// and returns the last journaled block which is still canonical, or the block before the journals' range if none is.
+func (es *EventSyncer) findReorg(ctx context.Context, next *executionclient.BlockLogs) (ancestor uint64, reorged bool, err error) {
+	journals, err := es.nodeStorage.ListBlockJournals(nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to list block journals: %w", err)
+	}
+
+	// Blocks of unknown hashes, such as for logs read without their block hashes, can't be checked.
+	tip := len(journals) - 1
+	for tip >= 0 && journals[tip].BlockHash == (ethcommon.Hash{}) {
+		tip--
+	}
+	if tip < 0 {
+		return 0, false, nil
+	}
+	canonical, err := es.canonicalTip(ctx, journals[tip], next)
+	if err != nil {
+		return 0, false, err
+	}
+	if canonical {
+		return 0, false, nil
+	}
+
+	for i := tip - 1; i >= 0; i-- {
+		journal := journals[i]
+		if journal.BlockHash == (ethcommon.Hash{}) {
+			// The hash is unknown, such as for logs read without their block hashes.
+			continue
+		}
+		header, err := es.executionClient.HeaderByNumber(ctx, new(big.Int).SetUint64(journal.BlockNumber))
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to get header of block %d: %w", journal.BlockNumber, err)
+		}
+		if header.Hash() == journal.BlockHash {
+			es.logger.Warn("detected a reorg of processed blocks",
+				zap.Uint64("common_ancestor", journal.BlockNumber),
+				zap.Uint64("last_processed_block", journals[len(journals)-1].BlockNumber))
+			return journal.BlockNumber, true, nil
+		}
+	}
+
+	// None of the journaled blocks is canonical, but every processed block within
+	// the journals' range has a journal, so it's safe to roll back all of them.
+	lastProcessedBlock := journals[len(journals)-1].BlockNumber
+	if lastProcessedBlock >= nodestorage.BlockJournalDepth {
+		ancestor = lastProcessedBlock - nodestorage.BlockJournalDepth
+	}
+	if journals[0].BlockNumber <= ancestor {
+		return 0, false, ErrReorgTooDeep
+	}
+	es.logger.Warn("detected a reorg of all the journaled blocks",
+		zap.Uint64("common_ancestor", ancestor),
+		zap.Uint64("last_processed_block", lastProcessedBlock))
+	return ancestor, true, nil
+}
+
+// canonicalTip returns whether the journaled tip block is still canonical, by the parent hash of the next block
+// if it directly follows the tip and it's still canonical itself, or otherwise by the tip's hash.
+func (es *EventSyncer) canonicalTip(ctx context.Context, tip *nodestorage.BlockJournal, next *executionclient.BlockLogs) (bool, error) {
+	if next != nil && next.BlockNumber == tip.BlockNumber+1 && next.BlockHash != (ethcommon.Hash{}) {
+		header, err := es.executionClient.HeaderByNumber(ctx, new(big.Int).SetUint64(next.BlockNumber))
+		if err != nil {
+			return false, fmt.Errorf("failed to get header of block %d: %w", next.BlockNumber, err)
+		}
+		if header.Hash() == next.BlockHash {
+			return header.ParentHash == tip.BlockHash, nil
+		}
+	}
+
+	header, err := es.executionClient.HeaderByNumber(ctx, new(big.Int).SetUint64(tip.BlockNumber))
+	if err != nil {
+		return false, fmt.Errorf("failed to get header of block %d: %w", tip.BlockNumber, err)
+	}
+	return header.Hash() == tip.BlockHash, nil
 }
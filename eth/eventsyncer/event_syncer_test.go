@@ -1,6 +1,7 @@
 package eventsyncer
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"math/big"
@@ -117,9 +118,9 @@ func TestEventSyncer(t *testing.T) {
 		require.Equal(t, uint64(0x1), receipt.Status)
 	}
 
-	eh := setupEventHandler(t, ctx, logger)
+	eh, nodeStorage := setupEventHandler(t, ctx, logger)
 	eventSyncer := New(
-		nil,
+		nodeStorage,
 		client,
 		eh,
 		WithLogger(logger),
@@ -131,7 +132,115 @@ func TestEventSyncer(t *testing.T) {
 	require.NoError(t, eventSyncer.SyncOngoing(ctx, lastProcessedBlock+1))
 }
 
-func setupEventHandler(t *testing.T, ctx context.Context, logger *zap.Logger) *eventhandler.EventHandler {
+// TestEventSyncerReorg checks that registry events of reorganized blocks are rolled back,
+// and the events of the canonical chain are processed instead.
+func TestEventSyncerReorg(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sim := simTestBackend(testAddr)
+
+	rpcServer, _ := sim.Node.RPCHandler()
+	httpSrv := httptest.NewServer(rpcServer.WebsocketHandler([]string{"*"}))
+	defer rpcServer.Stop()
+	defer httpSrv.Close()
+
+	parsed, _ := abi.JSON(strings.NewReader(simcontract.SimcontractMetaData.ABI))
+	auth, _ := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	contractAddr, _, _, err := bind.DeployContract(auth, parsed, ethcommon.FromHex(simcontract.SimcontractMetaData.Bin), sim)
+	require.NoError(t, err)
+	sim.Commit()
+
+	boundContract, err := simcontract.NewSimcontract(contractAddr, sim)
+	require.NoError(t, err)
+
+	addr := "ws:" + strings.TrimPrefix(httpSrv.URL, "http:")
+	client, err := executionclient.New(ctx, addr, contractAddr, executionclient.WithLogger(logger), executionclient.WithFollowDistance(0))
+	require.NoError(t, err)
+	defer client.Close()
+
+	eh, nodeStorage := setupEventHandler(t, ctx, logger)
+	eventSyncer := New(nodeStorage, client, eh, WithLogger(logger))
+
+	registerOperator := func() []byte {
+		opPubKey, _, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		encodedPubKey := []byte(base64.StdEncoding.EncodeToString(opPubKey))
+		packed, err := eventparser.PackOperatorPublicKey(encodedPubKey)
+		require.NoError(t, err)
+		_, err = boundContract.SimcontractTransactor.RegisterOperator(auth, packed, big.NewInt(100_000_000))
+		require.NoError(t, err)
+		return encodedPubKey
+	}
+	requireOperator := func(id uint64, publicKey []byte) {
+		od, found, err := nodeStorage.GetOperatorData(nil, id)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, publicKey, od.PublicKey)
+	}
+
+	// Operator 1 is registered in a block which is reorganized later.
+	parent := sim.Blockchain.CurrentBlock()
+	reorgedPubKey := registerOperator()
+	sim.Commit()
+
+	lastProcessedBlock, err := eventSyncer.SyncHistory(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, parent.Number.Uint64()+1, lastProcessedBlock)
+	requireOperator(1, reorgedPubKey)
+
+	// A longer side chain registers a different operator 1.
+	require.NoError(t, sim.Fork(ctx, parent.Hash()))
+	canonicalPubKey := registerOperator()
+	sim.Commit()
+	sim.Commit()
+	sim.Commit()
+
+	lastProcessedBlock, err = eventSyncer.SyncHistory(ctx, lastProcessedBlock+1)
+	require.NoError(t, err)
+	require.Equal(t, parent.Number.Uint64()+1, lastProcessedBlock)
+	requireOperator(1, canonicalPubKey)
+	operators, err := nodeStorage.ListOperators(nil, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, operators, 1)
+
+	// The same happens during ongoing sync.
+	syncErr := make(chan error, 1)
+	go func() {
+		syncErr <- eventSyncer.SyncOngoing(ctx, lastProcessedBlock+1)
+	}()
+
+	parent = sim.Blockchain.CurrentBlock()
+	reorgedPubKey = registerOperator()
+	sim.Commit()
+	require.Eventually(t, func() bool {
+		od, found, err := nodeStorage.GetOperatorData(nil, 2)
+		return err == nil && found && bytes.Equal(od.PublicKey, reorgedPubKey)
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, sim.Fork(ctx, parent.Hash()))
+	sim.Commit()
+	canonicalPubKey = registerOperator()
+	sim.Commit()
+	sim.Commit()
+	require.Eventually(t, func() bool {
+		// Keep producing blocks, since the stream is resumed on the next head.
+		sim.Commit()
+		od, found, err := nodeStorage.GetOperatorData(nil, 2)
+		return err == nil && found && bytes.Equal(od.PublicKey, canonicalPubKey)
+	}, 5*time.Second, 100*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-syncErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ongoing sync didn't stop")
+	}
+}
+
+func setupEventHandler(t *testing.T, ctx context.Context, logger *zap.Logger) (*eventhandler.EventHandler, operatorstorage.Storage) {
 	db, err := kv.NewInMemory(logger, basedb.Options{
 		Ctx: ctx,
 	})
@@ -186,7 +295,7 @@ func setupEventHandler(t *testing.T, ctx context.Context, logger *zap.Logger) *e
 	if err != nil {
 		t.Fatal(err)
 	}
-	return eh
+	return eh, nodeStorage
 }
 
 func simTestBackend(testAddr ethcommon.Address) *simulator.SimulatedBackend {
@@ -213,7 +322,7 @@ func setupOperatorStorage(logger *zap.Logger, db basedb.Database, privKey keys.O
 		logger.Fatal("failed to encode operator public key", zap.Error(err))
 	}
 
-	if err := nodeStorage.SavePrivateKeyHash(privKeyHash); err != nil {
+	if err := nodeStorage.SavePrivateKeyHash(nil, privKeyHash); err != nil {
 		logger.Fatal("could not setup operator private key", zap.Error(err))
 	}
 
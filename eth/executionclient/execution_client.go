@@ -95,6 +95,38 @@ func (ec *ExecutionClient) FetchHistoricalLogs(ctx context.Context, fromBlock ui
 	return
 }
 
+// FetchLogs retrieves the logs emitted by the contract within the given block range, inclusive.
+func (ec *ExecutionClient) FetchLogs(ctx context.Context, fromBlock, toBlock uint64) (logs <-chan BlockLogs, errors <-chan error) {
+	return ec.fetchLogsInBatches(ctx, fromBlock, toBlock)
+}
+
+// FilterLogs retrieves the logs emitted by the contract within the given block range, inclusive,
+// which match the given topics. Unlike FetchLogs, it's meant for sparse logs, which are returned at once.
+func (ec *ExecutionClient) FilterLogs(ctx context.Context, fromBlock, toBlock uint64, topics [][]ethcommon.Hash) ([]ethtypes.Log, error) {
+	var logs []ethtypes.Log
+	for batchStart := fromBlock; batchStart <= toBlock; batchStart += ec.logBatchSize {
+		batchEnd := batchStart + ec.logBatchSize - 1
+		if batchEnd > toBlock {
+			batchEnd = toBlock
+		}
+		results, err := ec.client.FilterLogs(ctx, ethereum.FilterQuery{
+			Addresses: []ethcommon.Address{ec.contractAddress},
+			FromBlock: new(big.Int).SetUint64(batchStart),
+			ToBlock:   new(big.Int).SetUint64(batchEnd),
+			Topics:    topics,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range results {
+			if !log.Removed {
+				logs = append(logs, log)
+			}
+		}
+	}
+	return logs, nil
+}
+
 // Calls FilterLogs multiple times and batches results to avoid fetching enormous amount of events
 func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, endBlock uint64) (<-chan BlockLogs, <-chan error) {
 	logs := make(chan BlockLogs, defaultLogBuf)
@@ -148,7 +180,8 @@ func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, e
 				validLogs := make([]ethtypes.Log, 0, len(results))
 				for _, log := range results {
 					if log.Removed {
-						// This shouldn't happen unless there was a reorg!
+						// This shouldn't happen unless there was a reorg,
+						// which EventSyncer detects by the hashes of processed blocks.
 						ec.logger.Warn("log is removed",
 							zap.String("block_hash", log.BlockHash.Hex()),
 							fields.TxHash(log.TxHash),
@@ -158,8 +191,14 @@ func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, e
 					validLogs = append(validLogs, log)
 				}
 				if len(validLogs) == 0 {
-					// Emit empty block logs to indicate that we have advanced to this block.
-					logs <- BlockLogs{BlockNumber: toBlock}
+					// Emit empty block logs to indicate that we have advanced to this block,
+					// with its hash to detect if it's reorganized later.
+					header, err := ec.client.HeaderByNumber(ctx, new(big.Int).SetUint64(toBlock))
+					if err != nil {
+						errors <- fmt.Errorf("failed to get header of block %d: %w", toBlock, err)
+						return
+					}
+					logs <- BlockLogs{BlockNumber: toBlock, BlockHash: header.Hash()}
 				} else {
 					for _, blockLogs := range PackLogs(validLogs) {
 						logs <- blockLogs
@@ -244,6 +283,20 @@ func (ec *ExecutionClient) Healthy(ctx context.Context) error {
 	return nil
 }
 
+// ClientVersion returns the version string of the execution client, such as "Geth/v1.13.4-stable/linux-amd64/go1.21.3".
+func (ec *ExecutionClient) ClientVersion(ctx context.Context) (string, error) {
+	var version string
+	if err := ec.client.Client().CallContext(ctx, &version, "web3_clientVersion"); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// HeaderByNumber returns the header of the canonical block with the given number, or the latest block if nil.
+func (ec *ExecutionClient) HeaderByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Header, error) {
+	return ec.client.HeaderByNumber(ctx, blockNumber)
+}
+
 func (ec *ExecutionClient) BlockByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Block, error) {
 	return ec.client.BlockByNumber(ctx, blockNumber)
 }
@@ -113,6 +113,18 @@ func TestFetchHistoricalLogs(t *testing.T) {
 		require.Fail(t, "timeout")
 	}
 
+	// Fetch the logs of a block range, regardless of the follow distance.
+	var rangeLogs []ethtypes.Log
+	logs, fetchErrCh = client.FetchLogs(ctx, 2, 4)
+	for block := range logs {
+		rangeLogs = append(rangeLogs, block.Logs...)
+	}
+	require.NoError(t, <-fetchErrCh)
+	require.Len(t, rangeLogs, 3)
+	for _, log := range rangeLogs {
+		require.True(t, log.BlockNumber >= 2 && log.BlockNumber <= 4)
+	}
+
 	require.NoError(t, client.Close())
 	require.NoError(t, sim.Close())
 }
@@ -282,6 +294,18 @@ func TestFetchLogsInBatches(t *testing.T) {
 		}
 	})
 
+	t.Run("FilterLogs by topics", func(t *testing.T) {
+		logs, err := client.FilterLogs(ctx, 3, 11, [][]ethcommon.Hash{{parsed.Events["Called"].ID}})
+		require.NoError(t, err)
+		require.Len(t, logs, 9)
+		require.EqualValues(t, 3, logs[0].BlockNumber)
+		require.EqualValues(t, 11, logs[8].BlockNumber)
+
+		logs, err = client.FilterLogs(ctx, 3, 11, [][]ethcommon.Hash{{ethcommon.Hash{1}}})
+		require.NoError(t, err)
+		require.Empty(t, logs)
+	})
+
 	t.Run("context is canceled", func(t *testing.T) {
 		canceledCtx, cancel := context.WithCancel(ctx)
 		cancel()
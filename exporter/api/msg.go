@@ -7,6 +7,8 @@ import (
 
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	"github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/protocol/v2/message"
 )
 
 // Message represents an exporter message
@@ -47,7 +49,7 @@ func NewDecidedAPIMsg(msgs ...*specqbft.SignedMessage) Message {
 			PublicKey: hex.EncodeToString(pkv),
 			From:      uint64(msgs[0].Message.Height),
 			To:        uint64(msgs[len(msgs)-1].Message.Height),
-			Role:      role.String(),
+			Role:      message.RoleToString(role),
 		},
 		Data: data,
 	}
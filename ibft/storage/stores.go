@@ -4,6 +4,7 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/cornelk/hashmap"
 
+	"github.com/bloxapp/ssv/protocol/v2/message"
 	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 )
@@ -22,7 +23,7 @@ func NewStores() *QBFTStores {
 func NewStoresFromRoles(db basedb.Database, roles ...spectypes.BeaconRole) *QBFTStores {
 	stores := NewStores()
 	for _, role := range roles {
-		stores.Add(role, New(db, role.String()))
+		stores.Add(role, New(db, message.RoleToString(role)))
 	}
 	return stores
 }
@@ -0,0 +1,42 @@
+package simulation
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
+)
+
+// submitFunc is called whenever an operator submits a duty's result to its beacon node.
+type submitFunc func(operatorID spectypes.OperatorID, validatorIndex phase0.ValidatorIndex, role spectypes.BeaconRole, slot phase0.Slot, root phase0.Root)
+
+// beaconNode is the beacon node of an operator's validator, which reports the submitted duties.
+type beaconNode struct {
+	*spectestingutils.TestingBeaconNode
+	operatorID     spectypes.OperatorID
+	validatorIndex phase0.ValidatorIndex
+	submit         submitFunc
+}
+
+func newBeaconNode(operatorID spectypes.OperatorID, validatorIndex phase0.ValidatorIndex, submit submitFunc) *beaconNode {
+	return &beaconNode{
+		TestingBeaconNode: spectestingutils.NewTestingBeaconNode(),
+		operatorID:        operatorID,
+		validatorIndex:    validatorIndex,
+		submit:            submit,
+	}
+}
+
+func (bn *beaconNode) SubmitAttestation(attestation *phase0.Attestation) error {
+	root, err := attestation.Data.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	bn.submit(bn.operatorID, bn.validatorIndex, spectypes.BNRoleAttester, attestation.Data.Slot, root)
+	return bn.TestingBeaconNode.SubmitAttestation(attestation)
+}
+
+func (bn *beaconNode) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
+	bn.submit(bn.operatorID, bn.validatorIndex, spectypes.BNRoleSyncCommittee, msg.Slot, msg.BeaconBlockRoot)
+	return bn.TestingBeaconNode.SubmitSyncMessage(msg)
+}
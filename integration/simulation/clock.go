@@ -0,0 +1,187 @@
+package simulation
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/bloxapp/ssv/operator/slotticker"
+	"github.com/bloxapp/ssv/protocol/v2/qbft/roundtimer"
+)
+
+// VirtualClock is a deterministic clock which only advances when stepped.
+// Timers fire in the order of their deadlines, and timers sharing a deadline fire in the order they were set.
+// VirtualClock implements both roundtimer.Clock and slotticker.Clock.
+type VirtualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	seq    uint64
+	events eventHeap
+}
+
+// NewVirtualClock returns a VirtualClock set to the given time.
+func NewVirtualClock(now time.Time) *VirtualClock {
+	return &VirtualClock{now: now}
+}
+
+// Now returns the current virtual time.
+func (c *VirtualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// AfterFunc calls f once the clock advanced by d.
+func (c *VirtualClock) AfterFunc(d time.Duration, f func()) roundtimer.ClockTimer {
+	return &funcTimer{clock: c, event: c.schedule(d, f)}
+}
+
+// NewTimer returns a timer which sends the virtual time on its channel once the clock advanced by d.
+func (c *VirtualClock) NewTimer(d time.Duration) slotticker.Timer {
+	t := &chanTimer{clock: c, ch: make(chan time.Time, 1)}
+	t.event = c.schedule(d, t.fire)
+	return t
+}
+
+// Step advances the clock to the earliest pending timer and fires it.
+// It returns false if there are no pending timers.
+func (c *VirtualClock) Step() bool {
+	c.mu.Lock()
+	if len(c.events) == 0 {
+		c.mu.Unlock()
+		return false
+	}
+	e := heap.Pop(&c.events).(*event)
+	if e.at.After(c.now) {
+		c.now = e.at
+	}
+	c.mu.Unlock()
+
+	// f is called without holding the lock, so that it may set new timers.
+	e.f()
+	return true
+}
+
+// Next returns the deadline of the earliest pending timer, if there is one.
+func (c *VirtualClock) Next() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.events) == 0 {
+		return time.Time{}, false
+	}
+	return c.events[0].at, true
+}
+
+// Pending returns the number of pending timers.
+func (c *VirtualClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.events)
+}
+
+func (c *VirtualClock) schedule(d time.Duration, f func()) *event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if d < 0 {
+		d = 0
+	}
+	c.seq++
+	e := &event{at: c.now.Add(d), seq: c.seq, f: f}
+	heap.Push(&c.events, e)
+	return e
+}
+
+func (c *VirtualClock) cancel(e *event) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e.index < 0 {
+		return false
+	}
+	heap.Remove(&c.events, e.index)
+	return true
+}
+
+// funcTimer is a timer created by VirtualClock.AfterFunc.
+type funcTimer struct {
+	clock *VirtualClock
+	event *event
+}
+
+func (t *funcTimer) Stop() bool {
+	return t.clock.cancel(t.event)
+}
+
+// chanTimer is a timer created by VirtualClock.NewTimer.
+type chanTimer struct {
+	clock *VirtualClock
+	event *event
+	ch    chan time.Time
+}
+
+func (t *chanTimer) fire() {
+	// Like time.Timer, never block on a full channel.
+	select {
+	case t.ch <- t.clock.Now():
+	default:
+	}
+}
+
+func (t *chanTimer) Stop() bool {
+	return t.clock.cancel(t.event)
+}
+
+func (t *chanTimer) Reset(d time.Duration) bool {
+	active := t.Stop()
+	t.event = t.clock.schedule(d, t.fire)
+	return active
+}
+
+func (t *chanTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+type event struct {
+	at    time.Time
+	seq   uint64
+	f     func()
+	index int
+}
+
+// eventHeap is a min-heap of events ordered by deadline and then by sequence.
+type eventHeap []*event
+
+func (h eventHeap) Len() int { return len(h) }
+
+func (h eventHeap) Less(i, j int) bool {
+	if h[i].at.Equal(h[j].at) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].at.Before(h[j].at)
+}
+
+func (h eventHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *eventHeap) Push(x any) {
+	e := x.(*event)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *eventHeap) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*h = old[:n-1]
+	return e
+}
@@ -0,0 +1,260 @@
+package simulation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"math/rand"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network"
+	protocolp2p "github.com/bloxapp/ssv/protocol/v2/p2p"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
+)
+
+// NetworkConditions control how the simulated network delivers messages between operators.
+type NetworkConditions struct {
+	// MinLatency and MaxLatency bound the uniformly distributed delivery latency.
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// LossRate is the probability of a message being dropped on its way to a peer.
+	LossRate float64
+	// ReorderRate is the probability of a message being held back for another MaxLatency,
+	// letting messages which were sent after it overtake it.
+	ReorderRate float64
+}
+
+// NetworkStats counts the messages passed through the simulated network.
+type NetworkStats struct {
+	Broadcasts int
+	Delivered  int
+	Dropped    int
+}
+
+// BroadcastObserver is notified of every message broadcasted to the simulated network.
+type BroadcastObserver func(from spectypes.OperatorID, msg *queue.DecodedSSVMessage)
+
+// Network is an in-memory network between simulated operators.
+// Messages are delivered on a VirtualClock, according to NetworkConditions and a seeded random source,
+// so that a given seed always produces the same deliveries.
+type Network struct {
+	clock      *VirtualClock
+	rand       *rand.Rand
+	conditions NetworkConditions
+	nodes      []*Node
+	observers  []BroadcastObserver
+	stats      NetworkStats
+	trace      hash.Hash
+}
+
+// NewNetwork creates a new Network.
+func NewNetwork(clock *VirtualClock, rand *rand.Rand, conditions NetworkConditions) *Network {
+	return &Network{
+		clock:      clock,
+		rand:       rand,
+		conditions: conditions,
+		trace:      sha256.New(),
+	}
+}
+
+// AddNode adds an operator's node to the network.
+func (n *Network) AddNode(logger *zap.Logger, operatorID spectypes.OperatorID) *Node {
+	node := &Node{
+		net:        n,
+		logger:     logger,
+		operatorID: operatorID,
+		online:     true,
+		subscribed: make(map[string]bool),
+	}
+	n.nodes = append(n.nodes, node)
+	return node
+}
+
+// Observe registers an observer of broadcasted messages.
+func (n *Network) Observe(observer BroadcastObserver) {
+	n.observers = append(n.observers, observer)
+}
+
+// Stats returns the message counters of the network.
+func (n *Network) Stats() NetworkStats {
+	return n.stats
+}
+
+// Trace returns a digest of every message delivered so far, including its time and recipient.
+func (n *Network) Trace() [32]byte {
+	var digest [32]byte
+	copy(digest[:], n.trace.Sum(nil))
+	return digest
+}
+
+func (n *Network) broadcast(from *Node, msg *spectypes.SSVMessage) error {
+	if !from.online {
+		return nil
+	}
+	n.stats.Broadcasts++
+
+	// Encode the message so that recipients can't observe later changes made by the sender.
+	data, err := msg.Encode()
+	if err != nil {
+		return errors.Wrap(err, "could not encode message")
+	}
+
+	decoded, err := decode(data)
+	if err != nil {
+		return err
+	}
+	for _, observer := range n.observers {
+		observer(from.operatorID, decoded)
+	}
+
+	topic := hex.EncodeToString(msg.MsgID.GetPubKey())
+	for _, to := range n.nodes {
+		if !to.subscribed[topic] {
+			continue
+		}
+		if to == from {
+			// Messages are delivered to the sender immediately, just like in pubsub.
+			n.deliver(to, data, 0)
+			continue
+		}
+		if !to.online {
+			continue
+		}
+		if n.rand.Float64() < n.conditions.LossRate {
+			n.stats.Dropped++
+			continue
+		}
+		latency := n.conditions.MinLatency
+		if spread := n.conditions.MaxLatency - n.conditions.MinLatency; spread > 0 {
+			latency += time.Duration(n.rand.Int63n(int64(spread)))
+		}
+		if n.rand.Float64() < n.conditions.ReorderRate {
+			latency += n.conditions.MaxLatency
+		}
+		n.deliver(to, data, latency)
+	}
+	return nil
+}
+
+func (n *Network) deliver(to *Node, data []byte, latency time.Duration) {
+	n.clock.AfterFunc(latency, func() {
+		if !to.online || to.router == nil {
+			n.stats.Dropped++
+			return
+		}
+		msg, err := decode(data)
+		if err != nil {
+			to.logger.Error("could not decode delivered message", zap.Error(err))
+			return
+		}
+		n.stats.Delivered++
+		n.traceDelivery(to, data)
+		to.router.Route(context.Background(), msg)
+	})
+}
+
+func (n *Network) traceDelivery(to *Node, data []byte) {
+	var header [16]byte
+	binary.BigEndian.PutUint64(header[:8], uint64(n.clock.Now().UnixNano()))
+	binary.BigEndian.PutUint64(header[8:], uint64(to.operatorID))
+	_, _ = n.trace.Write(header[:])
+	_, _ = n.trace.Write(data)
+}
+
+func decode(data []byte) (*queue.DecodedSSVMessage, error) {
+	msg := &spectypes.SSVMessage{}
+	if err := msg.Decode(data); err != nil {
+		return nil, errors.Wrap(err, "could not decode message")
+	}
+	decoded, err := queue.DecodeSSVMessage(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode ssv message")
+	}
+	return decoded, nil
+}
+
+// Node is an operator's endpoint to the simulated Network, implementing network.P2PNetwork.
+type Node struct {
+	net        *Network
+	logger     *zap.Logger
+	operatorID spectypes.OperatorID
+	online     bool
+	subscribed map[string]bool
+	router     network.MessageRouter
+}
+
+var _ network.P2PNetwork = (*Node)(nil)
+
+// SetOnline connects or disconnects the node. Offline nodes neither send nor receive messages.
+func (n *Node) SetOnline(online bool) {
+	n.online = online
+}
+
+// PeerID returns the simulated peer ID of the node.
+func (n *Node) PeerID() peer.ID {
+	return peer.ID(fmt.Sprintf("operator-%d", n.operatorID))
+}
+
+func (n *Node) Close() error {
+	return nil
+}
+
+func (n *Node) Setup(logger *zap.Logger) error {
+	return nil
+}
+
+func (n *Node) Start(logger *zap.Logger) error {
+	return nil
+}
+
+func (n *Node) UpdateSubnets(logger *zap.Logger) {}
+
+func (n *Node) SubscribeAll(logger *zap.Logger) error {
+	return nil
+}
+
+func (n *Node) SubscribeRandoms(logger *zap.Logger, numSubnets int) error {
+	return nil
+}
+
+func (n *Node) UseMessageRouter(router network.MessageRouter) {
+	n.router = router
+}
+
+func (n *Node) Subscribe(pk spectypes.ValidatorPK) error {
+	n.subscribed[hex.EncodeToString(pk)] = true
+	return nil
+}
+
+func (n *Node) Unsubscribe(logger *zap.Logger, pk spectypes.ValidatorPK) error {
+	delete(n.subscribed, hex.EncodeToString(pk))
+	return nil
+}
+
+func (n *Node) Peers(pk spectypes.ValidatorPK) ([]peer.ID, error) {
+	topic := hex.EncodeToString(pk)
+	var peers []peer.ID
+	for _, node := range n.net.nodes {
+		if node != n && node.online && node.subscribed[topic] {
+			peers = append(peers, node.PeerID())
+		}
+	}
+	return peers, nil
+}
+
+func (n *Node) Broadcast(msg *spectypes.SSVMessage) error {
+	return n.net.broadcast(n, msg)
+}
+
+func (n *Node) ReportValidation(logger *zap.Logger, message *spectypes.SSVMessage, res protocolp2p.MsgValidationResult) {
+}
+
+func (n *Node) RegisterHandlers(logger *zap.Logger, handlers ...*protocolp2p.SyncHandler) {}
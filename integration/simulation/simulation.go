@@ -0,0 +1,532 @@
+// Package simulation runs deterministic, in-process simulations of SSV committees.
+//
+// A simulation wires the real duty runners and QBFT controllers of every operator's validators
+// over an in-memory network, and drives them with a virtual clock instead of wall-clock time.
+// Every random choice (keys, latencies, losses and reorderings) derives from a single seed,
+// so that a run can be reproduced exactly, and its safety and liveness checked over many slots.
+package simulation
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	qbftstorage "github.com/bloxapp/ssv/ibft/storage"
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator/slotticker"
+	"github.com/bloxapp/ssv/operator/validator"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
+	protocolvalidator "github.com/bloxapp/ssv/protocol/v2/ssv/validator"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+const queueSize = 1024
+
+var allRoles = []spectypes.BeaconRole{
+	spectypes.BNRoleAttester,
+	spectypes.BNRoleProposer,
+	spectypes.BNRoleAggregator,
+	spectypes.BNRoleSyncCommittee,
+	spectypes.BNRoleSyncCommitteeContribution,
+	spectypes.BNRoleValidatorRegistration,
+	spectypes.BNRoleVoluntaryExit,
+}
+
+// Config configures a Simulation.
+type Config struct {
+	// Seed drives every random choice of the simulation.
+	Seed int64
+	// Operators is the size of the committee which all validators are shared with (3f+1).
+	Operators int
+	// Validators is the number of validators run by the committee.
+	Validators int
+	// Slots is the number of simulated slots.
+	Slots int
+	// StartSlot is the first simulated slot, defaults to the first slot of the second epoch.
+	StartSlot phase0.Slot
+	// Roles are the duties which every validator has in every slot, defaults to attestations.
+	// Only BNRoleAttester and BNRoleSyncCommittee are supported.
+	Roles []spectypes.BeaconRole
+	// DutyPeriod is the number of slots between the duties of each validator, which are spread across
+	// the period by validator index, such as an epoch for attestations. Defaults to a duty every slot.
+	DutyPeriod int
+	// Conditions control the delivery of messages between operators.
+	Conditions NetworkConditions
+	// Offline operators neither send nor receive messages.
+	Offline []spectypes.OperatorID
+	// DutyDeadline is the time since the start of a duty's slot by which
+	// it must be submitted, defaults to a slot.
+	DutyDeadline time.Duration
+}
+
+// Duty identifies a validator's duty in a slot.
+type Duty struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Role           spectypes.BeaconRole
+	Slot           phase0.Slot
+}
+
+func (d Duty) String() string {
+	return fmt.Sprintf("%s(validator=%d, slot=%d)", d.Role, d.ValidatorIndex, d.Slot)
+}
+
+// Result summarizes a simulation run.
+type Result struct {
+	// Duties is the number of duties assigned to the committee.
+	Duties int
+	// Submitted is the number of duties submitted by any operator before their deadline.
+	Submitted int
+	// Missed are the duties which no operator submitted before their deadline.
+	Missed []Duty
+	// Decided is the number of decided QBFT instances.
+	Decided int
+	// SafetyViolations describe conflicting decisions or submissions.
+	SafetyViolations []string
+	// Network counts the messages passed through the network.
+	Network NetworkStats
+	// Trace is a digest of all message deliveries, which is equal for runs with equal configs.
+	Trace [32]byte
+}
+
+// CheckSafety returns an error if any safety invariant was violated.
+func (r *Result) CheckSafety() error {
+	if len(r.SafetyViolations) > 0 {
+		return fmt.Errorf("%d safety violations, first: %s", len(r.SafetyViolations), r.SafetyViolations[0])
+	}
+	return nil
+}
+
+// CheckLiveness returns an error if any duty wasn't submitted before its deadline.
+func (r *Result) CheckLiveness() error {
+	if len(r.Missed) > 0 {
+		return fmt.Errorf("%d/%d duties missed, first: %s", len(r.Missed), r.Duties, r.Missed[0])
+	}
+	return nil
+}
+
+type decisionKey struct {
+	msgID  spectypes.MessageID
+	height specqbft.Height
+}
+
+type submission struct {
+	root phase0.Root
+	at   time.Time
+}
+
+// Simulation is a deterministic simulation of a committee of operators running validators.
+type Simulation struct {
+	logger        *zap.Logger
+	cfg           Config
+	ctx           context.Context
+	cancel        context.CancelFunc
+	clock         *VirtualClock
+	network       *Network
+	beaconNetwork beacon.BeaconNetwork
+	quorum        uint64
+	validators    []*simulatedValidator
+	operators     []*simulatedOperator
+
+	duties      []Duty
+	decisions   map[decisionKey][32]byte
+	submissions map[Duty]submission
+	violations  []string
+}
+
+type simulatedValidator struct {
+	index  phase0.ValidatorIndex
+	pubKey phase0.BLSPubKey
+	shares map[spectypes.OperatorID]*bls.SecretKey
+}
+
+type simulatedOperator struct {
+	id         spectypes.OperatorID
+	logger     *zap.Logger
+	node       *Node
+	db         basedb.Database
+	validators []*protocolvalidator.Validator
+	byPubKey   map[string]*protocolvalidator.Validator
+}
+
+// Route implements network.MessageRouter by pushing messages to the queue of their validator.
+func (o *simulatedOperator) Route(_ context.Context, msg *queue.DecodedSSVMessage) {
+	if v, ok := o.byPubKey[hex.EncodeToString(msg.MsgID.GetPubKey())]; ok {
+		v.HandleMessage(o.logger, msg)
+	}
+}
+
+// New creates a new Simulation.
+func New(logger *zap.Logger, cfg Config) (*Simulation, error) {
+	if cfg.Operators < 4 || (cfg.Operators-1)%3 != 0 {
+		return nil, errors.Errorf("committee size must be 3f+1, got %d", cfg.Operators)
+	}
+	if cfg.Validators < 1 {
+		return nil, errors.New("at least one validator is required")
+	}
+	if len(cfg.Roles) == 0 {
+		cfg.Roles = []spectypes.BeaconRole{spectypes.BNRoleAttester}
+	}
+	if cfg.DutyPeriod < 0 {
+		return nil, errors.Errorf("duty period must not be negative, got %d", cfg.DutyPeriod)
+	}
+	if cfg.DutyPeriod == 0 {
+		cfg.DutyPeriod = 1
+	}
+	for _, role := range cfg.Roles {
+		if role != spectypes.BNRoleAttester && role != spectypes.BNRoleSyncCommittee {
+			return nil, errors.Errorf("unsupported role %s", role)
+		}
+	}
+
+	beaconNetwork := networkconfig.TestNetwork.Beacon
+	if cfg.StartSlot == 0 {
+		cfg.StartSlot = phase0.Slot(beaconNetwork.SlotsPerEpoch())
+	}
+	if cfg.DutyDeadline == 0 {
+		cfg.DutyDeadline = beaconNetwork.SlotDurationSec()
+	}
+
+	threshold.Init()
+
+	rng := rand.New(rand.NewSource(cfg.Seed)) // #nosec G404 -- simulations must be reproducible
+	clock := NewVirtualClock(beaconNetwork.GetSlotStartTime(cfg.StartSlot - 1))
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Simulation{
+		logger:        logger,
+		cfg:           cfg,
+		ctx:           ctx,
+		cancel:        cancel,
+		clock:         clock,
+		network:       NewNetwork(clock, rng, cfg.Conditions),
+		beaconNetwork: beaconNetwork,
+		quorum:        uint64(2*(cfg.Operators-1)/3 + 1),
+		decisions:     make(map[decisionKey][32]byte),
+		submissions:   make(map[Duty]submission),
+	}
+	s.network.Observe(s.observeBroadcast)
+
+	for i := 0; i < cfg.Validators; i++ {
+		v, err := s.generateValidator(rng, phase0.ValidatorIndex(i+1))
+		if err != nil {
+			s.Close()
+			return nil, errors.Wrap(err, "could not generate validator")
+		}
+		s.validators = append(s.validators, v)
+	}
+
+	offline := make(map[spectypes.OperatorID]bool)
+	for _, id := range cfg.Offline {
+		offline[id] = true
+	}
+	for i := 1; i <= cfg.Operators; i++ {
+		o, err := s.setupOperator(spectypes.OperatorID(i))
+		if err != nil {
+			s.Close()
+			return nil, errors.Wrapf(err, "could not setup operator %d", i)
+		}
+		o.node.SetOnline(!offline[o.id])
+		s.operators = append(s.operators, o)
+	}
+
+	return s, nil
+}
+
+// generateValidator creates a validator key and splits it between the operators.
+func (s *Simulation) generateValidator(rng *rand.Rand, index phase0.ValidatorIndex) (*simulatedValidator, error) {
+	// Polynomial of degree quorum-1, where the first coefficient is the validator key.
+	poly := make([]bls.SecretKey, s.quorum)
+	for i := range poly {
+		buf := make([]byte, 32)
+		_, _ = rng.Read(buf)
+		if err := poly[i].SetLittleEndianMod(buf); err != nil {
+			return nil, err
+		}
+	}
+
+	v := &simulatedValidator{
+		index:  index,
+		shares: make(map[spectypes.OperatorID]*bls.SecretKey),
+	}
+	copy(v.pubKey[:], poly[0].GetPublicKey().Serialize())
+
+	km := spectestingutils.NewTestingKeyManager()
+	for i := 1; i <= s.cfg.Operators; i++ {
+		id := bls.ID{}
+		if err := id.SetDecString(fmt.Sprint(i)); err != nil {
+			return nil, err
+		}
+		share := &bls.SecretKey{}
+		if err := share.Set(poly, &id); err != nil {
+			return nil, err
+		}
+		if err := km.AddShare(share); err != nil {
+			return nil, err
+		}
+		v.shares[spectypes.OperatorID(i)] = share
+	}
+	return v, nil
+}
+
+func (s *Simulation) setupOperator(id spectypes.OperatorID) (*simulatedOperator, error) {
+	logger := s.logger.With(fields.OperatorID(id))
+
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create db")
+	}
+
+	o := &simulatedOperator{
+		id:       id,
+		logger:   logger,
+		node:     s.network.AddNode(logger, id),
+		db:       db,
+		byPubKey: make(map[string]*protocolvalidator.Validator),
+	}
+	o.node.UseMessageRouter(o)
+
+	committee := make([]*spectypes.Operator, 0, s.cfg.Operators)
+	stores := qbftstorage.NewStoresFromRoles(db, allRoles...)
+	for _, sv := range s.validators {
+		committee = committee[:0]
+		for i := 1; i <= s.cfg.Operators; i++ {
+			committee = append(committee, &spectypes.Operator{
+				OperatorID: spectypes.OperatorID(i),
+				PubKey:     sv.shares[spectypes.OperatorID(i)].GetPublicKey().Serialize(),
+			})
+		}
+
+		share := &types.SSVShare{
+			Share: spectypes.Share{
+				OperatorID:      id,
+				ValidatorPubKey: append([]byte(nil), sv.pubKey[:]...), // BLS (cgo) requires a standalone allocation
+				SharePubKey:     sv.shares[id].GetPublicKey().Serialize(),
+				Committee:       append([]*spectypes.Operator(nil), committee...),
+				Quorum:          s.quorum,
+				PartialQuorum:   uint64((s.cfg.Operators-1)/3 + 1),
+				DomainType:      types.GetDefaultDomain(),
+			},
+			Metadata: types.Metadata{
+				BeaconMetadata: &beacon.ValidatorMetadata{
+					Index: sv.index,
+				},
+				OwnerAddress: common.HexToAddress("0x0"),
+			},
+		}
+
+		ctx, cancel := context.WithCancel(s.ctx)
+		options := protocolvalidator.Options{
+			Network:       o.node,
+			Beacon:        newBeaconNode(id, sv.index, s.onSubmit),
+			BeaconNetwork: s.beaconNetwork,
+			Storage:       stores,
+			SSVShare:      share,
+			Signer:        spectestingutils.NewTestingKeyManager(),
+			QueueSize:     queueSize,
+			Clock:         s.clock,
+		}
+		options.DutyRunners = validator.SetupRunners(ctx, logger, options)
+		v := protocolvalidator.NewValidator(ctx, cancel, options)
+		if _, err := v.StartWithoutConsumers(logger); err != nil {
+			return nil, errors.Wrap(err, "could not start validator")
+		}
+		o.validators = append(o.validators, v)
+		o.byPubKey[hex.EncodeToString(sv.pubKey[:])] = v
+	}
+	return o, nil
+}
+
+// Run simulates the configured slots, and returns the result once all of their duties are due.
+func (s *Simulation) Run() (*Result, error) {
+	ticker := slotticker.NewWithClock(s.logger, slotticker.Config{
+		SlotDuration: s.beaconNetwork.SlotDurationSec(),
+		GenesisTime:  s.beaconNetwork.GetSlotStartTime(0),
+	}, s.clock)
+
+	endSlot := s.cfg.StartSlot + phase0.Slot(s.cfg.Slots)
+	endTime := s.beaconNetwork.GetSlotStartTime(endSlot - 1).Add(s.cfg.DutyDeadline)
+
+	next := ticker.Next()
+	for {
+		select {
+		case <-next:
+			if slot := ticker.Slot(); slot < endSlot {
+				if err := s.scheduleDuties(slot); err != nil {
+					return nil, err
+				}
+			}
+			next = ticker.Next()
+			continue
+		default:
+		}
+
+		if at, ok := s.clock.Next(); !ok || at.After(endTime) {
+			break
+		}
+		s.clock.Step()
+		s.processQueues()
+	}
+
+	return s.result(), nil
+}
+
+// Close stops the validators and releases the resources of the simulation.
+func (s *Simulation) Close() {
+	s.cancel()
+	for _, o := range s.operators {
+		for _, v := range o.validators {
+			v.Stop()
+		}
+		if err := o.db.Close(); err != nil {
+			s.logger.Debug("could not close db", zap.Error(err))
+		}
+	}
+}
+
+// scheduleDuties executes the duties of the validators which have duties in the slot at a third of it,
+// which is when the duty scheduler executes attestations and sync committee messages.
+func (s *Simulation) scheduleDuties(slot phase0.Slot) error {
+	for _, role := range s.cfg.Roles {
+		for _, sv := range s.validators {
+			if (uint64(slot)+uint64(sv.index))%uint64(s.cfg.DutyPeriod) != 0 {
+				continue
+			}
+			duty := newDuty(sv, role, slot)
+			msg, err := validator.CreateDutyExecuteMsg(duty, sv.pubKey, types.GetDefaultDomain())
+			if err != nil {
+				return errors.Wrap(err, "could not create duty execute message")
+			}
+			s.duties = append(s.duties, Duty{ValidatorIndex: sv.index, Role: role, Slot: slot})
+
+			pubKey := hex.EncodeToString(sv.pubKey[:])
+			s.clock.AfterFunc(s.beaconNetwork.SlotDurationSec()/3, func() {
+				for _, o := range s.operators {
+					if !o.node.online {
+						continue
+					}
+					dec, err := queue.DecodeSSVMessage(msg)
+					if err != nil {
+						s.logger.Error("could not decode duty execute message", zap.Error(err))
+						return
+					}
+					o.byPubKey[pubKey].HandleMessage(o.logger, dec)
+				}
+			})
+		}
+	}
+	return nil
+}
+
+// processQueues handles the pending messages of every validator, in a fixed order.
+func (s *Simulation) processQueues() {
+	for _, o := range s.operators {
+		for i, v := range o.validators {
+			for _, role := range s.cfg.Roles {
+				msgID := spectypes.NewMsgID(types.GetDefaultDomain(), s.validators[i].pubKey[:], role)
+				if _, err := v.ProcessPendingMessages(o.logger, msgID, v.ProcessMessage); err != nil {
+					o.logger.Error("could not process pending messages", zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// observeBroadcast checks that all decided messages of an instance agree on its value.
+func (s *Simulation) observeBroadcast(from spectypes.OperatorID, msg *queue.DecodedSSVMessage) {
+	sm, ok := msg.Body.(*specqbft.SignedMessage)
+	if !ok || sm.Message.MsgType != specqbft.CommitMsgType || uint64(len(sm.Signers)) < s.quorum {
+		return
+	}
+
+	key := decisionKey{msgID: msg.MsgID, height: sm.Message.Height}
+	decided, ok := s.decisions[key]
+	if !ok {
+		s.decisions[key] = sm.Message.Root
+		return
+	}
+	if decided != sm.Message.Root {
+		s.violations = append(s.violations, fmt.Sprintf(
+			"operator %d broadcasted a decision on %x for %s at height %d, which was already decided on %x",
+			from, sm.Message.Root, msg.MsgID, sm.Message.Height, decided,
+		))
+	}
+}
+
+// onSubmit checks that all operators submit the same result for a duty.
+func (s *Simulation) onSubmit(operatorID spectypes.OperatorID, validatorIndex phase0.ValidatorIndex, role spectypes.BeaconRole, slot phase0.Slot, root phase0.Root) {
+	duty := Duty{ValidatorIndex: validatorIndex, Role: role, Slot: slot}
+	submitted, ok := s.submissions[duty]
+	if !ok {
+		s.submissions[duty] = submission{root: root, at: s.clock.Now()}
+		return
+	}
+	if submitted.root != root {
+		s.violations = append(s.violations, fmt.Sprintf(
+			"operator %d submitted %x for %s, which was already submitted as %x",
+			operatorID, root, duty, submitted.root,
+		))
+	}
+}
+
+func (s *Simulation) result() *Result {
+	result := &Result{
+		Duties:           len(s.duties),
+		Decided:          len(s.decisions),
+		SafetyViolations: s.violations,
+		Network:          s.network.Stats(),
+		Trace:            s.network.Trace(),
+	}
+	for _, duty := range s.duties {
+		deadline := s.beaconNetwork.GetSlotStartTime(duty.Slot).Add(s.cfg.DutyDeadline)
+		if submitted, ok := s.submissions[duty]; ok && !submitted.at.After(deadline) {
+			result.Submitted++
+		} else {
+			result.Missed = append(result.Missed, duty)
+		}
+	}
+	sort.Slice(result.Missed, func(i, j int) bool {
+		if result.Missed[i].Slot != result.Missed[j].Slot {
+			return result.Missed[i].Slot < result.Missed[j].Slot
+		}
+		return result.Missed[i].ValidatorIndex < result.Missed[j].ValidatorIndex
+	})
+	return result
+}
+
+func newDuty(sv *simulatedValidator, role spectypes.BeaconRole, slot phase0.Slot) *spectypes.Duty {
+	var template spectypes.Duty
+	switch role {
+	case spectypes.BNRoleAttester:
+		template = spectestingutils.TestingAttesterDuty
+	case spectypes.BNRoleSyncCommittee:
+		template = spectestingutils.TestingSyncCommitteeDuty
+	}
+
+	return &spectypes.Duty{
+		Type:                          role,
+		PubKey:                        sv.pubKey,
+		Slot:                          slot,
+		ValidatorIndex:                sv.index,
+		CommitteeIndex:                template.CommitteeIndex,
+		CommitteesAtSlot:              template.CommitteesAtSlot,
+		CommitteeLength:               template.CommitteeLength,
+		ValidatorCommitteeIndex:       template.ValidatorCommitteeIndex,
+		ValidatorSyncCommitteeIndices: template.ValidatorSyncCommitteeIndices,
+	}
+}
@@ -0,0 +1,181 @@
+package simulation
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+var (
+	slots     = flag.Int("simulation.slots", 64, "number of slots to simulate")
+	longSlots = flag.Int("simulation.long-slots", 4096, "number of slots of the long simulation, with a duty per validator per epoch")
+	seed      = flag.Int64("simulation.seed", 1, "seed of the simulations")
+)
+
+var defaultConditions = NetworkConditions{
+	MinLatency:  10 * time.Millisecond,
+	MaxLatency:  300 * time.Millisecond,
+	ReorderRate: 0.05,
+}
+
+func run(t *testing.T, cfg Config) *Result {
+	types.SetDefaultDomain(spectestingutils.TestingSSVDomainType)
+	logger := logging.TestLogger(t).WithOptions(zap.IncreaseLevel(zap.ErrorLevel))
+
+	s, err := New(logger, cfg)
+	require.NoError(t, err)
+	defer s.Close()
+
+	result, err := s.Run()
+	require.NoError(t, err)
+	roles := len(cfg.Roles)
+	if roles == 0 {
+		roles = 1
+	}
+	dutyPeriod := cfg.DutyPeriod
+	if dutyPeriod == 0 {
+		dutyPeriod = 1
+	}
+	startSlot := int(cfg.StartSlot)
+	if startSlot == 0 {
+		startSlot = int(networkconfig.TestNetwork.Beacon.SlotsPerEpoch())
+	}
+	duties := 0
+	for slot := startSlot; slot < startSlot+cfg.Slots; slot++ {
+		for index := 1; index <= cfg.Validators; index++ {
+			if (slot+index)%dutyPeriod == 0 {
+				duties += roles
+			}
+		}
+	}
+	require.Equal(t, duties, result.Duties)
+	t.Logf("submitted %d/%d duties, decided %d instances, network: %+v",
+		result.Submitted, result.Duties, result.Decided, result.Network)
+	return result
+}
+
+func TestSimulation(t *testing.T) {
+	result := run(t, Config{
+		Seed:       *seed,
+		Operators:  4,
+		Validators: 2,
+		Slots:      *slots,
+		Roles:      []spectypes.BeaconRole{spectypes.BNRoleAttester, spectypes.BNRoleSyncCommittee},
+		Conditions: defaultConditions,
+	})
+	require.NoError(t, result.CheckSafety())
+	require.NoError(t, result.CheckLiveness())
+}
+
+func TestLongSimulation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long simulation")
+	}
+	slotsPerEpoch := int(networkconfig.TestNetwork.Beacon.SlotsPerEpoch())
+	result := run(t, Config{
+		Seed:       *seed,
+		Operators:  4,
+		Validators: 2,
+		Slots:      *longSlots,
+		DutyPeriod: slotsPerEpoch,
+		Conditions: defaultConditions,
+	})
+	require.NoError(t, result.CheckSafety())
+	require.NoError(t, result.CheckLiveness())
+	require.Equal(t, *longSlots/slotsPerEpoch*2, result.Duties)
+}
+
+func TestSimulationWithMessageLoss(t *testing.T) {
+	// Lost messages may cost a few duties their quorum, but must never break safety.
+	conditions := defaultConditions
+	conditions.LossRate = 0.02
+	result := run(t, Config{
+		Seed:       *seed,
+		Operators:  4,
+		Validators: 2,
+		Slots:      *slots,
+		Conditions: conditions,
+	})
+	require.NoError(t, result.CheckSafety())
+	require.GreaterOrEqual(t, float64(result.Submitted)/float64(result.Duties), 0.9)
+}
+
+func TestSimulationWithOfflineOperator(t *testing.T) {
+	// The first operator is offline, so every slot whose leader is the first operator needs a round change.
+	result := run(t, Config{
+		Seed:       *seed,
+		Operators:  4,
+		Validators: 1,
+		Slots:      *slots / 4,
+		Conditions: defaultConditions,
+		Offline:    []spectypes.OperatorID{1},
+	})
+	require.NoError(t, result.CheckSafety())
+	require.NoError(t, result.CheckLiveness())
+}
+
+func TestSimulationWithoutQuorum(t *testing.T) {
+	result := run(t, Config{
+		Seed:       *seed,
+		Operators:  4,
+		Validators: 1,
+		Slots:      4,
+		Offline:    []spectypes.OperatorID{1, 2},
+	})
+	require.NoError(t, result.CheckSafety())
+	require.Error(t, result.CheckLiveness())
+	require.Zero(t, result.Submitted)
+	require.Zero(t, result.Decided)
+}
+
+func TestSimulationIsDeterministic(t *testing.T) {
+	cfg := Config{
+		Seed:       *seed,
+		Operators:  4,
+		Validators: 1,
+		Slots:      8,
+		Conditions: defaultConditions,
+	}
+	first := run(t, cfg)
+	second := run(t, cfg)
+	require.Equal(t, first, second)
+
+	cfg.Seed++
+	third := run(t, cfg)
+	require.NotEqual(t, first.Trace, third.Trace)
+}
+
+func TestVirtualClock(t *testing.T) {
+	start := time.Unix(1000, 0)
+	clock := NewVirtualClock(start)
+
+	var fired []int
+	clock.AfterFunc(2*time.Second, func() { fired = append(fired, 2) })
+	clock.AfterFunc(time.Second, func() { fired = append(fired, 1) })
+	stopped := clock.AfterFunc(time.Second, func() { fired = append(fired, -1) })
+	clock.AfterFunc(time.Second, func() {
+		fired = append(fired, 11)
+		clock.AfterFunc(0, func() { fired = append(fired, 12) })
+	})
+	require.True(t, stopped.Stop())
+	require.False(t, stopped.Stop())
+
+	timer := clock.NewTimer(time.Second)
+	require.True(t, timer.Reset(3*time.Second))
+
+	for clock.Step() {
+	}
+	require.Equal(t, []int{1, 11, 12, 2}, fired)
+	require.Equal(t, start.Add(3*time.Second), clock.Now())
+	require.Equal(t, start.Add(3*time.Second), <-timer.C())
+	require.False(t, timer.Stop())
+}
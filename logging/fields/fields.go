@@ -214,7 +214,7 @@ func Round(round specqbft.Round) zap.Field {
 }
 
 func Role(val spectypes.BeaconRole) zap.Field {
-	return zap.Stringer(FieldRole, val)
+	return zap.String(FieldRole, message.RoleToString(val))
 }
 
 func MessageID(val spectypes.MessageID) zap.Field {
@@ -322,7 +322,7 @@ func BuilderProposals(v bool) zap.Field {
 }
 
 func FormatDutyID(epoch phase0.Epoch, duty *spectypes.Duty) string {
-	return fmt.Sprintf("%v-e%v-s%v-v%v", duty.Type.String(), epoch, duty.Slot, duty.ValidatorIndex)
+	return fmt.Sprintf("%v-e%v-s%v-v%v", message.RoleToString(duty.Type), epoch, duty.Slot, duty.ValidatorIndex)
 }
 
 func Duties(epoch phase0.Epoch, duties []*spectypes.Duty) zap.Field {
@@ -24,4 +24,7 @@ const (
 	NameScoreInspector    = "ScoreInspector"
 	NameEventHandler      = "EventHandler"
 	NameDutyFetcher       = "DutyFetcher"
+	NameExitRequest       = "ExitRequest"
+	NameNotifications     = "Notifications"
+	NameAuditLog          = "AuditLog"
 )
@@ -0,0 +1,78 @@
+package validation
+
+// committee_validation.go contains functions for validating batched committee partial signatures
+
+import (
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	ssvmessage "github.com/bloxapp/ssv/protocol/v2/message"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// maxCommitteePartialSignaturesMsgSize fits the partial signatures of a committee's attester
+// and sync committee duties, which grow with the number of its validators.
+const maxCommitteePartialSignaturesMsgSize = maxMessageSize
+
+func (mv *messageValidator) validateCommitteePartialSignatures(
+	share *ssvtypes.SSVShare,
+	msg *ssvtypes.CommitteePartialSignatures,
+	msgID spectypes.MessageID,
+	receivedAt time.Time,
+	signatureVerifier func() error,
+) (phase0.Slot, error) {
+	if msgID.GetRoleType() != ssvmessage.BNRoleCommittee {
+		e := ErrInvalidRole
+		e.got = ssvmessage.RoleToString(msgID.GetRoleType())
+		e.want = ssvmessage.RoleToString(ssvmessage.BNRoleCommittee)
+		return msg.Slot, e
+	}
+
+	if err := msg.Validate(); err != nil {
+		e := ErrInvalidCommitteePartialSignatures
+		e.innerErr = err
+		return msg.Slot, e
+	}
+
+	if err := mv.commonSignerValidation(msg.Signer, share); err != nil {
+		return msg.Slot, err
+	}
+
+	for _, partialSig := range msg.Messages {
+		if err := mv.validateSignatureFormat(partialSig.Message.PartialSignature); err != nil {
+			return msg.Slot, err
+		}
+	}
+
+	if err := mv.validateSlotTime(msg.Slot, msgID.GetRoleType(), receivedAt); err != nil {
+		return msg.Slot, err
+	}
+
+	state := mv.consensusState(msgID)
+	signerState := state.GetSignerState(msg.Signer)
+	if signerState != nil && msg.Slot < signerState.Slot {
+		err := ErrSlotAlreadyAdvanced
+		err.want = signerState.Slot
+		err.got = msg.Slot
+		return msg.Slot, err
+	}
+
+	if signatureVerifier != nil {
+		if err := signatureVerifier(); err != nil {
+			return msg.Slot, err
+		}
+	}
+
+	if signerState == nil {
+		signerState = state.CreateSignerState(msg.Signer)
+	}
+	if msg.Slot > signerState.Slot {
+		newEpoch := mv.netCfg.Beacon.EstimatedEpochAtSlot(msg.Slot) > mv.netCfg.Beacon.EstimatedEpochAtSlot(signerState.Slot)
+		signerState.ResetSlot(msg.Slot, specqbft.FirstRound, newEpoch)
+	}
+
+	return msg.Slot, nil
+}
@@ -12,6 +12,7 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"golang.org/x/exp/slices"
 
+	ssvmessage "github.com/bloxapp/ssv/protocol/v2/message"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/instance"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/roundtimer"
 	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
@@ -90,7 +91,7 @@ func (mv *messageValidator) validateConsensusMessage(
 
 	// TODO: lowestAllowed is not supported yet because first round is non-deterministic now
 	lowestAllowed := /*estimatedRound - allowedRoundsInPast*/ specqbft.FirstRound
-	highestAllowed := estimatedRound + allowedRoundsInFuture
+	highestAllowed := estimatedRound + mv.policy().AllowedRoundsInFuture
 
 	if msgRound < lowestAllowed || msgRound > highestAllowed {
 		err := ErrEstimatedRoundTooFar
@@ -250,7 +251,7 @@ func (mv *messageValidator) validateSignerBehaviorConsensus(
 			return ErrDuplicatedProposalWithDifferentData
 		}
 
-		limits := maxMessageCounts(len(share.Committee))
+		limits := mv.policy().messageCounts(len(share.Committee))
 		if err := signerState.MessageCounts.ValidateConsensusMessage(signedMsg, limits); err != nil {
 			return err
 		}
@@ -266,6 +267,7 @@ func (mv *messageValidator) validateDutyCount(
 ) error {
 	switch msgID.GetRoleType() {
 	case spectypes.BNRoleAttester, spectypes.BNRoleAggregator, spectypes.BNRoleValidatorRegistration, spectypes.BNRoleVoluntaryExit:
+		maxDutiesPerEpoch := mv.policy().MaxDutiesPerEpoch
 		limit := maxDutiesPerEpoch
 
 		if sameSlot := !newDutyInSameEpoch; sameSlot {
@@ -330,16 +332,7 @@ func (mv *messageValidator) isDecidedMessage(signedMsg *specqbft.SignedMessage)
 }
 
 func (mv *messageValidator) maxRound(role spectypes.BeaconRole) specqbft.Round {
-	switch role {
-	case spectypes.BNRoleAttester, spectypes.BNRoleAggregator: // TODO: check if value for aggregator is correct as there are messages on stage exceeding the limit
-		return 12 // TODO: consider calculating based on quick timeout and slow timeout
-	case spectypes.BNRoleProposer, spectypes.BNRoleSyncCommittee, spectypes.BNRoleSyncCommitteeContribution:
-		return 6
-	case spectypes.BNRoleValidatorRegistration, spectypes.BNRoleVoluntaryExit:
-		return 0
-	default:
-		panic("unknown role")
-	}
+	return mv.policy().role(role).MaxRound
 }
 
 func (mv *messageValidator) currentEstimatedRound(sinceSlotStart time.Duration) specqbft.Round {
@@ -354,7 +347,7 @@ func (mv *messageValidator) currentEstimatedRound(sinceSlotStart time.Duration)
 
 func (mv *messageValidator) waitAfterSlotStart(role spectypes.BeaconRole) time.Duration {
 	switch role {
-	case spectypes.BNRoleAttester, spectypes.BNRoleSyncCommittee:
+	case spectypes.BNRoleAttester, spectypes.BNRoleSyncCommittee, ssvmessage.BNRoleCommittee:
 		return mv.netCfg.Beacon.SlotDurationSec() / 3
 	case spectypes.BNRoleAggregator, spectypes.BNRoleSyncCommitteeContribution:
 		return mv.netCfg.Beacon.SlotDurationSec() / 3 * 2
@@ -373,7 +366,8 @@ func (mv *messageValidator) validRole(roleType spectypes.BeaconRole) bool {
 		spectypes.BNRoleSyncCommittee,
 		spectypes.BNRoleSyncCommitteeContribution,
 		spectypes.BNRoleValidatorRegistration,
-		spectypes.BNRoleVoluntaryExit:
+		spectypes.BNRoleVoluntaryExit,
+		ssvmessage.BNRoleCommittee:
 		return true
 	}
 	return false
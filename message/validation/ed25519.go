@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/operator/keys"
+)
+
+// maxCertificateValidityEpochs bounds the expiry of operator key certificates,
+// allowing an epoch of clock difference with their signer.
+const maxCertificateValidityEpochs = keys.Ed25519KeyValidityEpochs + 1
+
+func (mv *messageValidator) verifyEd25519Signature(messageData []byte, certificate *keys.OperatorKeyCertificate, signature []byte, epoch phase0.Epoch) error {
+	if err := mv.verifyCertificate(certificate, epoch); err != nil {
+		return err
+	}
+
+	mv.metrics.MessageValidationEd25519Verifications()
+	if err := certificate.VerifySignature(messageData, signature); err != nil {
+		e := ErrSignatureVerification
+		e.innerErr = fmt.Errorf("verify opid: %v signature: %w", certificate.OperatorID, err)
+		return e
+	}
+
+	return nil
+}
+
+// verifyCertificate verifies that the certificate is valid at the epoch and signed by its operator's RSA key.
+// The last certificate verified of each operator is cached, since operators replace their keys rarely.
+func (mv *messageValidator) verifyCertificate(certificate *keys.OperatorKeyCertificate, epoch phase0.Epoch) error {
+	if uint64(epoch) > certificate.ExpiryEpoch {
+		e := ErrOperatorKeyCertificateExpired
+		e.got = certificate.ExpiryEpoch
+		e.want = epoch
+		return e
+	}
+	if certificate.ExpiryEpoch > uint64(epoch)+maxCertificateValidityEpochs {
+		e := ErrOperatorKeyCertificateTooLong
+		e.got = certificate.ExpiryEpoch
+		e.want = uint64(epoch) + maxCertificateValidityEpochs
+		return e
+	}
+
+	operatorID := spectypes.OperatorID(certificate.OperatorID)
+	if cached, ok := mv.operatorIDToCertificateCache.Get(operatorID); ok &&
+		cached.ExpiryEpoch == certificate.ExpiryEpoch && bytes.Equal(cached.PublicKey, certificate.PublicKey) {
+		return nil
+	}
+
+	operatorPubKey, err := mv.operatorPublicKey(operatorID)
+	if err != nil {
+		return err
+	}
+
+	mv.metrics.MessageValidationRSAVerifications()
+	if err := certificate.Verify(mv.netCfg.Domain, operatorPubKey); err != nil {
+		e := ErrSignatureVerification
+		e.innerErr = fmt.Errorf("verify opid: %v certificate: %w", operatorID, err)
+		return e
+	}
+
+	// The certificate is copied since it references the message's data.
+	mv.operatorIDToCertificateCache.Set(operatorID, &keys.OperatorKeyCertificate{
+		OperatorID:  certificate.OperatorID,
+		ExpiryEpoch: certificate.ExpiryEpoch,
+		PublicKey:   bytes.Clone(certificate.PublicKey),
+	})
+	return nil
+}
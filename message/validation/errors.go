@@ -99,4 +99,46 @@ var (
 	ErrDeserializePublicKey                = Error{text: "deserialize public key", reject: true}
 	ErrNoPartialMessages                   = Error{text: "no partial messages", reject: true}
 	ErrDuplicatedPartialSignatureMessage   = Error{text: "duplicated partial signature message", reject: true}
+	ErrInvalidExitRequest                  = Error{text: "invalid exit request", reject: true}
+	ErrExitRequestValidatorMismatch        = Error{text: "exit request validator doesn't match message ID", reject: true}
+	ErrExitRequestNotByOwner               = Error{text: "exit request is not by the validator's owner", reject: true}
+	ErrExitRequestSlotOutOfRange           = Error{text: "exit request slot is out of range"}
+	ErrInvalidCommitteePartialSignatures   = Error{text: "invalid committee partial signatures", reject: true}
+	ErrEd25519SignaturesNotAccepted        = Error{text: "Ed25519 signatures aren't accepted yet"}
+	ErrRSASignaturesNotAccepted            = Error{text: "RSA signatures are no longer accepted"}
+	ErrOperatorKeyCertificateExpired       = Error{text: "operator key certificate expired", reject: true}
+	ErrOperatorKeyCertificateTooLong       = Error{text: "operator key certificate expires too far in the future", reject: true}
+	ErrCommitteeConsensusNotAccepted       = Error{text: "committee consensus isn't accepted yet"}
 )
+
+// errorsByText indexes the validation errors by their text.
+var errorsByText = func() map[string]Error {
+	m := make(map[string]Error)
+	for _, err := range []Error{
+		ErrEmptyData, ErrWrongDomain, ErrNoShareMetadata,
+		ErrUnknownValidator, ErrValidatorLiquidated, ErrValidatorNotAttesting,
+		ErrSlotAlreadyAdvanced, ErrRoundAlreadyAdvanced, ErrRoundTooHigh,
+		ErrEarlyMessage, ErrLateMessage, ErrTooManySameTypeMessagesPerRound,
+		ErrSignatureVerification, ErrOperatorNotFound, ErrPubSubMessageHasNoData,
+		ErrPubSubDataTooBig, ErrMalformedPubSubMessage, ErrEmptyPubSubMessage,
+		ErrTopicNotFound, ErrSSVDataTooBig, ErrInvalidRole,
+		ErrUnexpectedConsensusMessage, ErrNoSigners, ErrWrongSignatureSize,
+		ErrZeroSignature, ErrZeroSigner, ErrSignerNotInCommittee,
+		ErrDuplicatedSigner, ErrSignerNotLeader, ErrSignersNotSorted,
+		ErrUnexpectedSigner, ErrInvalidHash, ErrEstimatedRoundTooFar,
+		ErrMalformedMessage, ErrMalformedSignedMessage, ErrUnknownSSVMessageType,
+		ErrUnknownQBFTMessageType, ErrUnknownPartialMessageType, ErrPartialSignatureTypeRoleMismatch,
+		ErrNonDecidedWithMultipleSigners, ErrWrongSignersLength, ErrDuplicatedProposalWithDifferentData,
+		ErrEventMessage, ErrDKGMessage, ErrMalformedPrepareJustifications,
+		ErrUnexpectedPrepareJustifications, ErrMalformedRoundChangeJustifications, ErrUnexpectedRoundChangeJustifications,
+		ErrInvalidJustifications, ErrTooManyDutiesPerEpoch, ErrNoDuty,
+		ErrNoDutyIgnored, ErrDeserializePublicKey, ErrNoPartialMessages,
+		ErrDuplicatedPartialSignatureMessage, ErrInvalidExitRequest, ErrExitRequestValidatorMismatch,
+		ErrExitRequestNotByOwner, ErrExitRequestSlotOutOfRange, ErrInvalidCommitteePartialSignatures,
+		ErrEd25519SignaturesNotAccepted, ErrRSASignaturesNotAccepted, ErrOperatorKeyCertificateExpired,
+		ErrOperatorKeyCertificateTooLong, ErrCommitteeConsensusNotAccepted,
+	} {
+		m[err.text] = err
+	}
+	return m
+}()
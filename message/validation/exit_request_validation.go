@@ -0,0 +1,79 @@
+package validation
+
+// exit_request_validation.go contains functions for validating owners' exit requests
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+const (
+	maxExitRequestMsgSize = 1024
+	// exitRequestSlotWindow is how far the slot of an exit request may be from the current slot.
+	exitRequestSlotWindow = phase0.Slot(32)
+)
+
+func (mv *messageValidator) validateExitRequestMessage(
+	share *ssvtypes.SSVShare,
+	msg *ssvtypes.ExitRequestMessage,
+	msgID spectypes.MessageID,
+	receivedAt time.Time,
+	signer spectypes.OperatorID,
+	signatureVerifier func() error,
+) error {
+	if msgID.GetRoleType() != spectypes.BNRoleVoluntaryExit {
+		e := ErrInvalidRole
+		e.got = msgID.GetRoleType()
+		e.want = spectypes.BNRoleVoluntaryExit
+		return e
+	}
+
+	if !bytes.Equal(msg.Request.ValidatorPubKey, msgID.GetPubKey()) {
+		return ErrExitRequestValidatorMismatch
+	}
+
+	if msg.Request.Owner != share.OwnerAddress {
+		e := ErrExitRequestNotByOwner
+		e.got = msg.Request.Owner.String()
+		e.want = share.OwnerAddress.String()
+		return e
+	}
+
+	if err := msg.Request.Verify(mv.netCfg, receivedAt); err != nil {
+		e := ErrInvalidExitRequest
+		e.innerErr = err
+		return e
+	}
+
+	// Only the committee may schedule the exit, or anyone could relay the request at slots of their choosing.
+	if err := mv.commonSignerValidation(msg.Operator, share); err != nil {
+		return err
+	}
+	if signer != 0 && signer != msg.Operator {
+		e := ErrUnexpectedSigner
+		e.got = signer
+		e.want = msg.Operator
+		return e
+	}
+
+	if signatureVerifier != nil {
+		if err := signatureVerifier(); err != nil {
+			return err
+		}
+	}
+
+	currentSlot := mv.netCfg.Beacon.EstimatedSlotAtTime(receivedAt.Unix())
+	if msg.Slot+exitRequestSlotWindow < currentSlot || msg.Slot > currentSlot+exitRequestSlotWindow {
+		e := ErrExitRequestSlotOutOfRange
+		e.got = msg.Slot
+		e.want = currentSlot
+		return e
+	}
+
+	return nil
+}
@@ -11,13 +11,13 @@ import (
 
 // MessageCounts tracks the number of various message types received for validation.
 type MessageCounts struct {
-	PreConsensus  int
-	Proposal      int
-	Prepare       int
-	Commit        int
-	Decided       int
-	RoundChange   int
-	PostConsensus int
+	PreConsensus  int `yaml:"PreConsensus"`
+	Proposal      int `yaml:"Proposal"`
+	Prepare       int `yaml:"Prepare"`
+	Commit        int `yaml:"Commit"`
+	Decided       int `yaml:"Decided"`
+	RoundChange   int `yaml:"RoundChange"`
+	PostConsensus int `yaml:"PostConsensus"`
 }
 
 // String provides a formatted representation of the MessageCounts.
@@ -135,21 +135,6 @@ func (c *MessageCounts) RecordPartialSignatureMessage(msg *spectypes.SignedParti
 	}
 }
 
-// maxMessageCounts is the maximum number of acceptable messages from a signer within a slot & round.
-func maxMessageCounts(committeeSize int) MessageCounts {
-	maxDecided := maxDecidedCount(committeeSize)
-
-	return MessageCounts{
-		PreConsensus:  1,
-		Proposal:      1,
-		Prepare:       1,
-		Commit:        1,
-		Decided:       maxDecided,
-		RoundChange:   1,
-		PostConsensus: 1,
-	}
-}
-
 func maxDecidedCount(committeeSize int) int {
 	f := (committeeSize - 1) / 3
 	return committeeSize * (f + 1) // N * (f + 1)
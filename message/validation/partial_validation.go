@@ -3,10 +3,13 @@ package validation
 // partial_validation.go contains methods for validating partial signature messages
 
 import (
+	"time"
+
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 
+	ssvmessage "github.com/bloxapp/ssv/protocol/v2/message"
 	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
 )
 
@@ -14,6 +17,7 @@ func (mv *messageValidator) validatePartialSignatureMessage(
 	share *ssvtypes.SSVShare,
 	signedMsg *spectypes.SignedPartialSignatureMessage,
 	msgID spectypes.MessageID,
+	receivedAt time.Time,
 	signatureVerifier func() error,
 ) (phase0.Slot, error) {
 	if mv.operatorDataStore != nil && mv.operatorDataStore.OperatorIDReady() {
@@ -103,6 +107,8 @@ func (mv *messageValidator) partialSignatureTypeMatchesRole(msgType spectypes.Pa
 		return msgType == spectypes.ValidatorRegistrationPartialSig
 	case spectypes.BNRoleVoluntaryExit:
 		return msgType == spectypes.VoluntaryExitPartialSig
+	case ssvmessage.BNRoleCommittee:
+		return false // committees batch their partial signatures
 	default:
 		panic("invalid role") // role validity should be checked before
 	}
@@ -178,7 +184,7 @@ func (mv *messageValidator) validateSignerBehaviorPartial(
 	}
 
 	if msgSlot <= signerState.Slot {
-		limits := maxMessageCounts(len(share.Committee))
+		limits := mv.policy().messageCounts(len(share.Committee))
 		if err := signerState.MessageCounts.ValidatePartialSignatureMessage(signedMsg, limits); err != nil {
 			return err
 		}
@@ -0,0 +1,195 @@
+package validation
+
+// policy.go contains the configurable limits and windows used by message validation.
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	ssvmessage "github.com/bloxapp/ssv/protocol/v2/message"
+)
+
+// policyRoles are the roles which must be covered by a Policy.
+var policyRoles = []spectypes.BeaconRole{
+	spectypes.BNRoleAttester,
+	spectypes.BNRoleAggregator,
+	spectypes.BNRoleProposer,
+	spectypes.BNRoleSyncCommittee,
+	spectypes.BNRoleSyncCommitteeContribution,
+	spectypes.BNRoleValidatorRegistration,
+	spectypes.BNRoleVoluntaryExit,
+	ssvmessage.BNRoleCommittee,
+}
+
+// RolePolicy holds the validation limits of a single role.
+type RolePolicy struct {
+	// LateSlots is the number of slots after a message's slot in which it's still accepted.
+	// Zero disables the lateness check for the role.
+	LateSlots phase0.Slot `yaml:"LateSlots" json:"late_slots"`
+	// MaxRound is the highest round accepted for the role.
+	MaxRound specqbft.Round `yaml:"MaxRound" json:"max_round"`
+}
+
+// Policy holds the limits and windows used by message validation.
+// The zero value is not usable, start from DefaultPolicy instead.
+type Policy struct {
+	// LateMessageMargin is the duration past a message's TTL in which it is still considered valid.
+	LateMessageMargin time.Duration `yaml:"LateMessageMargin" json:"late_message_margin"`
+	// ClockErrorTolerance is the maximum amount of clock error we expect to see between nodes.
+	ClockErrorTolerance time.Duration `yaml:"ClockErrorTolerance" json:"clock_error_tolerance"`
+	// AllowedRoundsInFuture is how many rounds a message may be ahead of the estimated round.
+	AllowedRoundsInFuture specqbft.Round `yaml:"AllowedRoundsInFuture" json:"allowed_rounds_in_future"`
+	// MaxDutiesPerEpoch is the maximum number of attester or aggregator duties of a validator in an epoch.
+	MaxDutiesPerEpoch int `yaml:"MaxDutiesPerEpoch" json:"max_duties_per_epoch"`
+	// MessageCounts is the maximum number of messages from a signer within a slot & round.
+	// A zero Decided count is derived from the committee size.
+	MessageCounts MessageCounts `yaml:"MessageCounts" json:"message_counts"`
+	// Roles holds the limits of each role, keyed by the role's name (e.g. ATTESTER).
+	Roles map[string]RolePolicy `yaml:"Roles" json:"roles"`
+	// Reject and Ignore override the outcome of validation errors, listed by their text
+	// (e.g. "round is too high for this role").
+	Reject []string `yaml:"Reject" json:"reject,omitempty"`
+	Ignore []string `yaml:"Ignore" json:"ignore,omitempty"`
+
+	// outcomes maps overridden error texts to whether they're rejected.
+	outcomes map[string]bool
+}
+
+// DefaultPolicy returns the policy which message validation uses unless configured otherwise.
+func DefaultPolicy() Policy {
+	return Policy{
+		LateMessageMargin:     3 * time.Second,
+		ClockErrorTolerance:   50 * time.Millisecond,
+		AllowedRoundsInFuture: 1,
+		MaxDutiesPerEpoch:     2,
+		MessageCounts: MessageCounts{
+			PreConsensus:  1,
+			Proposal:      1,
+			Prepare:       1,
+			Commit:        1,
+			RoundChange:   1,
+			PostConsensus: 1,
+		},
+		Roles: map[string]RolePolicy{
+			// TODO: check if max round for aggregator is correct as there are messages on stage exceeding the limit
+			// TODO: consider calculating max rounds based on quick timeout and slow timeout
+			spectypes.BNRoleAttester.String():                   {LateSlots: 32 + 2, MaxRound: 12},
+			spectypes.BNRoleAggregator.String():                 {LateSlots: 32 + 2, MaxRound: 12},
+			spectypes.BNRoleProposer.String():                   {LateSlots: 1 + 2, MaxRound: 6},
+			spectypes.BNRoleSyncCommittee.String():              {LateSlots: 1 + 2, MaxRound: 6},
+			spectypes.BNRoleSyncCommitteeContribution.String():  {LateSlots: 1 + 2, MaxRound: 6},
+			spectypes.BNRoleValidatorRegistration.String():      {},
+			spectypes.BNRoleVoluntaryExit.String():              {},
+			ssvmessage.RoleToString(ssvmessage.BNRoleCommittee): {LateSlots: 32 + 2, MaxRound: 12},
+		},
+	}
+}
+
+// LoadPolicy reads a YAML policy from the given file.
+// Settings which are missing from the file are taken from DefaultPolicy,
+// but a role's settings must be given in full.
+func LoadPolicy(path string) (Policy, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return Policy{}, errors.Wrap(err, "could not read policy file")
+	}
+	return ParsePolicy(data)
+}
+
+// ParsePolicy parses a YAML policy, taking missing settings from DefaultPolicy.
+func ParsePolicy(data []byte) (Policy, error) {
+	policy := DefaultPolicy()
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return Policy{}, errors.Wrap(err, "could not parse policy")
+	}
+	if err := policy.init(); err != nil {
+		return Policy{}, err
+	}
+	return policy, nil
+}
+
+// init validates the policy and indexes its outcome overrides.
+func (p *Policy) init() error {
+	if p.LateMessageMargin < 0 || p.ClockErrorTolerance < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	if p.MaxDutiesPerEpoch <= 0 {
+		return fmt.Errorf("max duties per epoch must be positive")
+	}
+	counts := p.MessageCounts
+	if counts.PreConsensus <= 0 || counts.Proposal <= 0 || counts.Prepare <= 0 || counts.Commit <= 0 ||
+		counts.RoundChange <= 0 || counts.PostConsensus <= 0 || counts.Decided < 0 {
+		return fmt.Errorf("message counts must be positive, having %v", counts.String())
+	}
+
+	for name := range p.Roles {
+		if !validPolicyRole(name) {
+			return fmt.Errorf("unknown role %q", name)
+		}
+	}
+	for _, role := range policyRoles {
+		if _, ok := p.Roles[ssvmessage.RoleToString(role)]; !ok {
+			return fmt.Errorf("missing policy for role %v", ssvmessage.RoleToString(role))
+		}
+	}
+
+	p.outcomes = make(map[string]bool, len(p.Reject)+len(p.Ignore))
+	for _, outcome := range []struct {
+		texts  []string
+		reject bool
+	}{{p.Reject, true}, {p.Ignore, false}} {
+		for _, text := range outcome.texts {
+			if _, ok := errorsByText[text]; !ok {
+				return fmt.Errorf("unknown validation error %q", text)
+			}
+			if reject, ok := p.outcomes[text]; ok && reject != outcome.reject {
+				return fmt.Errorf("validation error %q is both rejected and ignored", text)
+			}
+			p.outcomes[text] = outcome.reject
+		}
+	}
+	return nil
+}
+
+func validPolicyRole(name string) bool {
+	for _, role := range policyRoles {
+		if ssvmessage.RoleToString(role) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// role returns the limits of the given role.
+func (p *Policy) role(role spectypes.BeaconRole) RolePolicy {
+	rp, ok := p.Roles[ssvmessage.RoleToString(role)]
+	if !ok {
+		panic("unknown role") // roles are checked before and policies are validated
+	}
+	return rp
+}
+
+// messageCounts returns the maximum number of messages from a signer within a slot & round.
+func (p *Policy) messageCounts(committeeSize int) MessageCounts {
+	limits := p.MessageCounts
+	if limits.Decided == 0 {
+		limits.Decided = maxDecidedCount(committeeSize)
+	}
+	return limits
+}
+
+// rejects tells whether the given error should result in rejecting the message rather than ignoring it.
+func (p *Policy) rejects(err Error) bool {
+	if reject, ok := p.outcomes[err.text]; ok {
+		return reject
+	}
+	return err.Reject()
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicy(t *testing.T) {
+	t.Run("empty policy is the default", func(t *testing.T) {
+		policy, err := ParsePolicy(nil)
+		require.NoError(t, err)
+
+		expected := DefaultPolicy()
+		require.NoError(t, expected.init())
+		require.Equal(t, expected, policy)
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		policy, err := ParsePolicy([]byte(`
+LateMessageMargin: 5s
+MessageCounts:
+  Prepare: 2
+Roles:
+  ATTESTER:
+    LateSlots: 8
+    MaxRound: 4
+Reject:
+  - round is too high for this role
+Ignore:
+  - signer is not leader
+`))
+		require.NoError(t, err)
+
+		require.Equal(t, 5*time.Second, policy.LateMessageMargin)
+		require.Equal(t, DefaultPolicy().ClockErrorTolerance, policy.ClockErrorTolerance)
+		require.Equal(t, 2, policy.MessageCounts.Prepare)
+		require.Equal(t, maxDecidedCount(4), policy.messageCounts(4).Decided)
+		require.Equal(t, RolePolicy{LateSlots: 8, MaxRound: 4}, policy.role(spectypes.BNRoleAttester))
+		require.Equal(t, DefaultPolicy().Roles[spectypes.BNRoleProposer.String()], policy.role(spectypes.BNRoleProposer))
+
+		require.True(t, policy.rejects(ErrRoundTooHigh))
+		require.False(t, policy.rejects(ErrSignerNotLeader))
+		require.True(t, policy.rejects(ErrSignerNotInCommittee))
+		require.False(t, policy.rejects(ErrLateMessage))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, data := range map[string]string{
+			"malformed":          `LateMessageMargin: [`,
+			"negative duration":  `ClockErrorTolerance: -1s`,
+			"no duties":          `MaxDutiesPerEpoch: 0`,
+			"unknown role":       "Roles:\n  VALIDATOR: {MaxRound: 1}",
+			"unknown error":      "Reject:\n  - no such error",
+			"conflicting errors": "Reject:\n  - late message\nIgnore:\n  - late message",
+			"zero message count": "MessageCounts:\n  Proposal: 0",
+		} {
+			data := data
+			t.Run(name, func(t *testing.T) {
+				_, err := ParsePolicy([]byte(data))
+				require.Error(t, err)
+			})
+		}
+	})
+}
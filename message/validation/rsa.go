@@ -9,6 +9,21 @@ import (
 )
 
 func (mv *messageValidator) verifySignature(messageData []byte, operatorID spectypes.OperatorID, signature []byte) error {
+	operatorPubKey, err := mv.operatorPublicKey(operatorID)
+	if err != nil {
+		return err
+	}
+
+	if err := operatorPubKey.Verify(messageData, signature); err != nil {
+		e := ErrSignatureVerification
+		e.innerErr = fmt.Errorf("verify opid: %v signature: %w", operatorID, err)
+		return e
+	}
+
+	return nil
+}
+
+func (mv *messageValidator) operatorPublicKey(operatorID spectypes.OperatorID) (keys.OperatorPublicKey, error) {
 	operatorPubKey, ok := mv.operatorIDToPubkeyCache.Get(operatorID)
 	if !ok {
 		operator, found, err := mv.nodeStorage.GetOperatorData(nil, operatorID)
@@ -16,29 +31,23 @@ func (mv *messageValidator) verifySignature(messageData []byte, operatorID spect
 			e := ErrOperatorNotFound
 			e.got = operatorID
 			e.innerErr = err
-			return e
+			return nil, e
 		}
 		if !found {
 			e := ErrOperatorNotFound
 			e.got = operatorID
-			return e
+			return nil, e
 		}
 
 		operatorPubKey, err = keys.PublicKeyFromString(string(operator.PublicKey))
 		if err != nil {
 			e := ErrSignatureVerification
 			e.innerErr = fmt.Errorf("decode public key: %w", err)
-			return e
+			return nil, e
 		}
 
 		mv.operatorIDToPubkeyCache.Set(operatorID, operatorPubKey)
 	}
 
-	if err := operatorPubKey.Verify(messageData, signature); err != nil {
-		e := ErrSignatureVerification
-		e.innerErr = fmt.Errorf("verify opid: %v signature: %w", operatorID, err)
-		return e
-	}
-
-	return nil
+	return operatorPubKey, nil
 }
@@ -0,0 +1,281 @@
+package validation
+
+// signature_batch.go contains the batched verification of messages' BLS signatures
+
+import (
+	"context"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+
+	"github.com/bloxapp/ssv/monitoring/metricsreporter"
+	ssvmessage "github.com/bloxapp/ssv/protocol/v2/message"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// DefaultMaxSignatureBatchSize is the number of signatures after which a batch is verified without waiting for its window.
+const DefaultMaxSignatureBatchSize = 128
+
+// signatureRequest is a signature waiting in a batch for its verification.
+type signatureRequest struct {
+	pubKey bls.PublicKey
+	root   [32]byte
+	sig    bls.Sign
+	result chan bool
+}
+
+// batchVerifier collects the BLS signatures of messages being validated concurrently,
+// and verifies them together once the batch's window passes or the batch is full,
+// which is much cheaper than verifying each of them.
+// If a batch fails, its signatures are verified one by one to find the invalid ones.
+type batchVerifier struct {
+	ctx          context.Context
+	window       time.Duration
+	maxBatchSize int
+	metrics      metricsreporter.MetricsReporter
+	requests     chan *signatureRequest
+}
+
+func newBatchVerifier(ctx context.Context, window time.Duration, maxBatchSize int, metrics metricsreporter.MetricsReporter) *batchVerifier {
+	return &batchVerifier{
+		ctx:          ctx,
+		window:       window,
+		maxBatchSize: maxBatchSize,
+		metrics:      metrics,
+		requests:     make(chan *signatureRequest, maxBatchSize*4),
+	}
+}
+
+// Verify verifies the signature, waiting for its batch to be verified.
+// A signature waits for at most a window for its batch to be collected and another for it to be verified,
+// or until its deadline if that's earlier, and is then verified alone so that messages aren't delayed
+// past the batch window or their lateness window. Signatures which don't fit the pending batches
+// are verified immediately. A zero deadline means the message may wait for the whole bound.
+func (v *batchVerifier) Verify(pubKey bls.PublicKey, root [32]byte, sig bls.Sign, deadline time.Time) bool {
+	wait := 2 * v.window
+	if !deadline.IsZero() {
+		if untilDeadline := time.Until(deadline); untilDeadline < wait {
+			wait = untilDeadline
+		}
+	}
+	if wait < v.window {
+		return v.verifyOne(pubKey, root, sig)
+	}
+
+	request := &signatureRequest{
+		pubKey: pubKey,
+		root:   root,
+		sig:    sig,
+		result: make(chan bool, 1),
+	}
+	select {
+	case v.requests <- request:
+	default:
+		return v.verifyOne(pubKey, root, sig)
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case result := <-request.result:
+		return result
+	case <-timer.C:
+		// The batch wasn't verified in time, so its result is discarded.
+		return v.verifyOne(pubKey, root, sig)
+	case <-v.ctx.Done():
+		// Batching stopped, so the request may never be verified.
+		return v.verifyOne(pubKey, root, sig)
+	}
+}
+
+// Start collects signatures into batches until the context is done.
+func (v *batchVerifier) Start() {
+	for {
+		var batch []*signatureRequest
+		select {
+		case <-v.ctx.Done():
+			return
+		case request := <-v.requests:
+			batch = append(batch, request)
+		}
+
+		// Every signature in the batch arrived after the first one, so verifying it
+		// within the window of the first keeps all of them within their deadlines.
+		timer := time.NewTimer(v.window)
+	collect:
+		for len(batch) < v.maxBatchSize {
+			select {
+			case request := <-v.requests:
+				batch = append(batch, request)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		go v.verifyBatch(batch)
+	}
+}
+
+func (v *batchVerifier) verifyBatch(batch []*signatureRequest) {
+	v.metrics.MessageValidationBLSBatchSize(len(batch))
+	if len(batch) == 1 {
+		batch[0].result <- v.verifyOne(batch[0].pubKey, batch[0].root, batch[0].sig)
+		return
+	}
+
+	ssvtypes.MetricsSignaturesVerifications.WithLabelValues().Inc()
+	if verifyMultiple(batch) {
+		v.metrics.MessageValidationBLSVerifications(len(batch), true)
+		for _, request := range batch {
+			request.result <- true
+		}
+		return
+	}
+
+	// At least one of the signatures is invalid.
+	v.metrics.MessageValidationBLSBatchFallback()
+	for _, request := range batch {
+		request.result <- v.verifyOne(request.pubKey, request.root, request.sig)
+	}
+}
+
+// verifyMultiple verifies the signatures of different messages and keys together, scaling each of them
+// by a random factor so that invalid signatures can't cancel out each other in the aggregate.
+// (bls.MultiVerify does the same, but its pointer arithmetic fails the race detector's checks.)
+func verifyMultiple(batch []*signatureRequest) bool {
+	factors := make([]bls.Fr, len(batch))
+	sigs := make([]bls.G2, len(batch))
+	pubKeys := make([]bls.PublicKey, len(batch))
+	roots := make([]byte, 0, len(batch)*32)
+	for i, request := range batch {
+		factors[i].SetByCSPRNG()
+		sigs[i] = *bls.CastFromSign(&request.sig)
+		bls.G1Mul(bls.CastFromPublicKey(&pubKeys[i]), bls.CastFromPublicKey(&request.pubKey), &factors[i])
+		roots = append(roots, request.root[:]...)
+	}
+
+	var aggregateSig bls.G2
+	bls.G2MulVec(&aggregateSig, sigs, factors)
+	return bls.CastToSign(&aggregateSig).AggregateVerifyNoCheck(pubKeys, roots)
+}
+
+func (v *batchVerifier) verifyOne(pubKey bls.PublicKey, root [32]byte, sig bls.Sign) bool {
+	ssvtypes.MetricsSignaturesVerifications.WithLabelValues().Inc()
+	v.metrics.MessageValidationBLSVerifications(1, false)
+	return sig.VerifyByte(&pubKey, root[:])
+}
+
+// verifyBLSSignature verifies the BLS signature of the given message by its signers' shares,
+// provided that batched signature verification is enabled.
+func (mv *messageValidator) verifyBLSSignature(
+	share *ssvtypes.SSVShare,
+	msg spectypes.MessageSignature,
+	sigType spectypes.SignatureType,
+	deadline time.Time,
+) error {
+	if mv.batchVerifier == nil {
+		return nil
+	}
+
+	sig := bls.Sign{}
+	if err := sig.Deserialize(msg.GetSignature()); err != nil {
+		e := ErrSignatureVerification
+		e.innerErr = err
+		return e
+	}
+
+	// A message of multiple signers is signed by the aggregate of their shares.
+	var pubKey bls.PublicKey
+	signerPubKeys := make([][]byte, 0, len(msg.GetSigners()))
+	for i, signer := range msg.GetSigners() {
+		var signerPubKey []byte
+		for _, operator := range share.Committee {
+			if operator.OperatorID == signer {
+				signerPubKey = operator.PubKey
+				break
+			}
+		}
+		if signerPubKey == nil {
+			return ErrSignerNotInCommittee
+		}
+		signerPubKeys = append(signerPubKeys, signerPubKey)
+		pk, err := ssvtypes.DeserializeBLSPublicKey(signerPubKey)
+		if err != nil {
+			e := ErrSignatureVerification
+			e.innerErr = err
+			return e
+		}
+		if i == 0 {
+			pubKey = pk
+		} else {
+			pubKey.Add(&pk)
+		}
+	}
+
+	root, err := spectypes.ComputeSigningRoot(msg, spectypes.ComputeSignatureDomain(mv.netCfg.Domain, sigType))
+	if err != nil {
+		e := ErrSignatureVerification
+		e.innerErr = err
+		return e
+	}
+
+	if !mv.batchVerifier.Verify(pubKey, root, sig, deadline) {
+		return ErrSignatureVerification
+	}
+
+	// Consensus doesn't need to verify the signature again.
+	ssvtypes.MarkSignatureVerified(msg.GetSignature(), root, signerPubKeys)
+	return nil
+}
+
+// verifyMessageBLSSignature verifies the BLS signature of a consensus or partial signature message,
+// before its message ID is locked since batched verification may wait for the batch window.
+// Messages without signers or out of their slot's time are left to the rest of the validation to reject,
+// without spending a verification on them.
+func (mv *messageValidator) verifyMessageBLSSignature(share *ssvtypes.SSVShare, msg *queue.DecodedSSVMessage, receivedAt time.Time) error {
+	if mv.batchVerifier == nil || share == nil {
+		return nil
+	}
+
+	role := msg.GetID().GetRoleType()
+	switch msg.MsgType {
+	case spectypes.SSVConsensusMsgType:
+		signedMsg := msg.Body.(*specqbft.SignedMessage)
+		slot := phase0.Slot(signedMsg.Message.Height)
+		if len(signedMsg.Signers) == 0 || mv.validateSlotTime(slot, role, receivedAt) != nil {
+			return nil
+		}
+		return mv.verifyBLSSignature(share, signedMsg, spectypes.QBFTSignatureType, mv.messageDeadline(slot, role, receivedAt))
+
+	case spectypes.SSVPartialSignatureMsgType:
+		if role == ssvmessage.BNRoleCommittee {
+			return nil // committees batch their partial signatures
+		}
+		signedMsg := msg.Body.(*spectypes.SignedPartialSignatureMessage)
+		slot := signedMsg.Message.Slot
+		if signedMsg.Signer == 0 || mv.validateSlotTime(slot, role, receivedAt) != nil {
+			return nil
+		}
+		return mv.verifyBLSSignature(share, signedMsg, spectypes.PartialSignatureType, mv.messageDeadline(slot, role, receivedAt))
+
+	default:
+		return nil
+	}
+}
+
+// messageDeadline returns the time after which a message of the given slot and role, received at the given time,
+// becomes late, or the zero time if the role has no lateness window.
+func (mv *messageValidator) messageDeadline(slot phase0.Slot, role spectypes.BeaconRole, receivedAt time.Time) time.Time {
+	policy := mv.policy()
+	ttl := policy.role(role).LateSlots
+	if ttl == 0 {
+		return time.Time{}
+	}
+	deadline := mv.netCfg.Beacon.GetSlotStartTime(slot + ttl).Add(policy.LateMessageMargin)
+	return time.Now().Add(deadline.Sub(receivedAt))
+}
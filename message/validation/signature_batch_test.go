@@ -0,0 +1,210 @@
+package validation
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/bloxapp/ssv/monitoring/metricsreporter"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator/storage"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+type batchMetrics struct {
+	metricsreporter.MetricsReporter
+
+	mu         sync.Mutex
+	batched    int
+	individual int
+	fallbacks  int
+}
+
+func (m *batchMetrics) MessageValidationBLSVerifications(count int, batched bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if batched {
+		m.batched += count
+	} else {
+		m.individual += count
+	}
+}
+
+func (m *batchMetrics) MessageValidationBLSBatchSize(int) {}
+
+func (m *batchMetrics) MessageValidationBLSBatchFallback() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fallbacks++
+}
+
+func TestBatchVerifier(t *testing.T) {
+	ks := spectestingutils.Testing4SharesSet()
+
+	type signature struct {
+		pubKey bls.PublicKey
+		root   [32]byte
+		sig    bls.Sign
+		valid  bool
+	}
+	signatures := func(invalid int) []signature {
+		var sigs []signature
+		for i := 0; i < 16; i++ {
+			sk := ks.Shares[spectypes.OperatorID(i%4+1)]
+			root := [32]byte{byte(i)}
+			sig := sk.SignByte(root[:])
+			valid := i != invalid
+			if !valid {
+				sig = ks.Shares[spectypes.OperatorID(i%4+1)%4+1].SignByte(root[:])
+			}
+			sigs = append(sigs, signature{pubKey: *sk.GetPublicKey(), root: root, sig: *sig, valid: valid})
+		}
+		return sigs
+	}
+	verifyAll := func(v *batchVerifier, sigs []signature, deadline time.Time) {
+		var wg sync.WaitGroup
+		for _, s := range sigs {
+			s := s
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				require.Equal(t, s.valid, v.Verify(s.pubKey, s.root, s.sig, deadline))
+			}()
+		}
+		wg.Wait()
+	}
+
+	t.Run("batch", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		metrics := &batchMetrics{}
+		v := newBatchVerifier(ctx, 50*time.Millisecond, 64, metrics)
+		go v.Start()
+
+		verifyAll(v, signatures(-1), time.Time{})
+		require.Equal(t, 16, metrics.batched+metrics.individual)
+		require.Positive(t, metrics.batched)
+		require.Zero(t, metrics.fallbacks)
+	})
+
+	t.Run("fallback finds invalid signature", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		metrics := &batchMetrics{}
+		v := newBatchVerifier(ctx, 50*time.Millisecond, 64, metrics)
+		go v.Start()
+
+		verifyAll(v, signatures(5), time.Time{})
+		require.Positive(t, metrics.fallbacks)
+	})
+
+	t.Run("near deadline is verified immediately", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		metrics := &batchMetrics{}
+		v := newBatchVerifier(ctx, time.Hour, 64, metrics)
+		go v.Start()
+
+		verifyAll(v, signatures(3), time.Now().Add(time.Minute))
+		require.Equal(t, 16, metrics.individual)
+		require.Zero(t, metrics.batched)
+	})
+
+	t.Run("waits for at most two windows", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		metrics := &batchMetrics{}
+		// Not started, so the batches are never verified.
+		v := newBatchVerifier(ctx, 20*time.Millisecond, 64, metrics)
+
+		start := time.Now()
+		verifyAll(v, signatures(9), time.Time{})
+		require.Less(t, time.Since(start), time.Second)
+		require.Equal(t, 16, metrics.individual)
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		v := newBatchVerifier(ctx, time.Hour, 64, &batchMetrics{})
+
+		verifyAll(v, signatures(7), time.Time{})
+	})
+}
+
+func TestValidateSignatureBatching(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+
+	ns, err := storage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	ks := spectestingutils.Testing4SharesSet()
+	share := &ssvtypes.SSVShare{
+		Share: *spectestingutils.TestingShare(ks),
+		Metadata: ssvtypes.Metadata{
+			BeaconMetadata: &beaconprotocol.ValidatorMetadata{
+				Status: eth2apiv1.ValidatorStateActiveOngoing,
+				Index:  123,
+			},
+		},
+	}
+	require.NoError(t, ns.Shares().Save(nil, share))
+
+	netCfg := networkconfig.TestNetwork
+	slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+	height := specqbft.Height(slot)
+	msgID := spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, spectypes.BNRoleAttester)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	consensusMessage := func(t *testing.T, signedMsg *specqbft.SignedMessage) *spectypes.SSVMessage {
+		data, err := signedMsg.Encode()
+		require.NoError(t, err)
+		return &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   msgID,
+			Data:    data,
+		}
+	}
+
+	t.Run("valid signatures", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithSignatureBatching(ctx, 10*time.Millisecond, 0)).(*messageValidator)
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(spectypes.BNRoleAttester))
+
+		_, _, err := validator.validateSSVMessage(consensusMessage(t, spectestingutils.TestingProposalMessageWithHeight(ks.Shares[1], 1, height)), receivedAt, 0, nil)
+		require.NoError(t, err)
+
+		decided := spectestingutils.TestingCommitMultiSignerMessageWithHeight(
+			[]*bls.SecretKey{ks.Shares[1], ks.Shares[2], ks.Shares[3]},
+			[]spectypes.OperatorID{1, 2, 3},
+			height,
+		)
+		_, _, err = validator.validateSSVMessage(consensusMessage(t, decided), receivedAt, 0, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithSignatureBatching(ctx, 10*time.Millisecond, 0)).(*messageValidator)
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(spectypes.BNRoleAttester))
+
+		// signed by another operator's share.
+		signedMsg := spectestingutils.TestingProposalMessageWithHeight(ks.Shares[2], 1, height)
+		_, _, err := validator.validateSSVMessage(consensusMessage(t, signedMsg), receivedAt, 0, nil)
+		require.ErrorContains(t, err, ErrSignatureVerification.Error())
+	})
+}
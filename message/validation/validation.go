@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -37,20 +38,11 @@ import (
 )
 
 const (
-	// lateMessageMargin is the duration past a message's TTL in which it is still considered valid.
-	lateMessageMargin = time.Second * 3
-
-	// clockErrorTolerance is the maximum amount of clock error we expect to see between nodes.
-	clockErrorTolerance = time.Millisecond * 50
-
 	maxMessageSize             = maxConsensusMsgSize
 	maxConsensusMsgSize        = 8388608
 	maxPartialSignatureMsgSize = 1952
-	allowedRoundsInFuture      = 1
 	allowedRoundsInPast        = 2
-	lateSlotAllowance          = 2
 	signatureSize              = 96
-	maxDutiesPerEpoch          = 2
 )
 
 // PubsubMessageValidator defines methods for validating pubsub messages.
@@ -70,6 +62,12 @@ type MessageValidator interface {
 	SSVMessageValidator
 }
 
+// PolicyManager defines methods for inspecting and replacing the active validation policy.
+type PolicyManager interface {
+	Policy() Policy
+	SetPolicy(policy Policy) error
+}
+
 type messageValidator struct {
 	logger                  *zap.Logger
 	metrics                 metricsreporter.MetricsReporter
@@ -79,6 +77,9 @@ type messageValidator struct {
 	dutyStore               *dutystore.Store
 	operatorDataStore       operatordatastore.OperatorDataStore
 	operatorIDToPubkeyCache *hashmap.Map[spectypes.OperatorID, keys.OperatorPublicKey]
+	// operatorIDToCertificateCache holds the last verified certificate of each operator's Ed25519 key.
+	operatorIDToCertificateCache *hashmap.Map[spectypes.OperatorID, *keys.OperatorKeyCertificate]
+	activePolicy                 atomic.Pointer[Policy]
 
 	// validationLocks is a map of lock per SSV message ID to
 	// prevent concurrent access to the same state.
@@ -87,22 +88,38 @@ type messageValidator struct {
 
 	selfPID    peer.ID
 	selfAccept bool
+
+	// batchVerifier, if set, verifies the BLS signatures of consensus and partial signature messages in batches.
+	batchVerifier      *batchVerifier
+	signatureBatchCtx  context.Context
+	signatureBatchWait time.Duration
+	signatureBatchSize int
 }
 
 // NewMessageValidator returns a new MessageValidator with the given network configuration and options.
 func NewMessageValidator(netCfg networkconfig.NetworkConfig, opts ...Option) MessageValidator {
 	mv := &messageValidator{
-		logger:                  zap.NewNop(),
-		metrics:                 metricsreporter.NewNop(),
-		netCfg:                  netCfg,
-		operatorIDToPubkeyCache: hashmap.New[spectypes.OperatorID, keys.OperatorPublicKey](),
-		validationLocks:         make(map[spectypes.MessageID]*sync.Mutex),
+		logger:                       zap.NewNop(),
+		metrics:                      metricsreporter.NewNop(),
+		netCfg:                       netCfg,
+		operatorIDToPubkeyCache:      hashmap.New[spectypes.OperatorID, keys.OperatorPublicKey](),
+		operatorIDToCertificateCache: hashmap.New[spectypes.OperatorID, *keys.OperatorKeyCertificate](),
+		validationLocks:              make(map[spectypes.MessageID]*sync.Mutex),
+	}
+
+	if err := mv.SetPolicy(DefaultPolicy()); err != nil {
+		panic(err) // the default policy is always valid
 	}
 
 	for _, opt := range opts {
 		opt(mv)
 	}
 
+	if mv.signatureBatchWait > 0 {
+		mv.batchVerifier = newBatchVerifier(mv.signatureBatchCtx, mv.signatureBatchWait, mv.signatureBatchSize, mv.metrics)
+		go mv.batchVerifier.Start()
+	}
+
 	return mv
 }
 
@@ -144,6 +161,30 @@ func WithNodeStorage(nodeStorage operatorstorage.Storage) Option {
 	}
 }
 
+// WithPolicy sets the validation policy for the messageValidator.
+// It panics if the policy is invalid, so policies from configuration should be loaded with LoadPolicy first.
+func WithPolicy(policy Policy) Option {
+	return func(mv *messageValidator) {
+		if err := mv.SetPolicy(policy); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// WithSignatureBatching has the BLS signatures of consensus and partial signature messages verified,
+// collecting them for up to the given window (or until maxBatchSize are collected) to verify them together.
+// Verification stops batching once the context is done.
+func WithSignatureBatching(ctx context.Context, window time.Duration, maxBatchSize int) Option {
+	return func(mv *messageValidator) {
+		if maxBatchSize <= 0 {
+			maxBatchSize = DefaultMaxSignatureBatchSize
+		}
+		mv.signatureBatchCtx = ctx
+		mv.signatureBatchWait = window
+		mv.signatureBatchSize = maxBatchSize
+	}
+}
+
 // WithSelfAccept blindly accepts messages sent from self. Useful for testing.
 func WithSelfAccept(selfPID peer.ID, selfAccept bool) Option {
 	return func(mv *messageValidator) {
@@ -152,6 +193,25 @@ func WithSelfAccept(selfPID peer.ID, selfAccept bool) Option {
 	}
 }
 
+// Policy returns the active validation policy.
+func (mv *messageValidator) Policy() Policy {
+	return *mv.policy()
+}
+
+// SetPolicy validates the given policy and replaces the active one with it.
+// Messages which are being validated while the policy is replaced may be validated by either policy.
+func (mv *messageValidator) SetPolicy(policy Policy) error {
+	if err := policy.init(); err != nil {
+		return errors.Wrap(err, "invalid validation policy")
+	}
+	mv.activePolicy.Store(&policy)
+	return nil
+}
+
+func (mv *messageValidator) policy() *Policy {
+	return mv.activePolicy.Load()
+}
+
 // ConsensusDescriptor provides details about the consensus for a message. It's used for logging and metrics.
 type ConsensusDescriptor struct {
 	Round           specqbft.Round
@@ -200,7 +260,7 @@ func (d Descriptor) String() string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("validator PK: %v, role: %v, ssv message type: %v, slot: %v",
 		hex.EncodeToString(d.ValidatorPK),
-		d.Role.String(),
+		ssvmessage.RoleToString(d.Role),
 		ssvmessage.MsgTypeToString(d.SSVMessageType),
 		d.Slot,
 	))
@@ -257,7 +317,7 @@ func (mv *messageValidator) ValidatePubsubMessage(_ context.Context, peerID peer
 	if err != nil {
 		var valErr Error
 		if errors.As(err, &valErr) {
-			if valErr.Reject() {
+			if mv.policy().rejects(valErr) {
 				if !valErr.Silent() {
 					f = append(f, zap.Error(err))
 					mv.logger.Debug("rejecting invalid message", f...)
@@ -291,7 +351,7 @@ func (mv *messageValidator) ValidatePubsubMessage(_ context.Context, peerID peer
 // ValidateSSVMessage validates the given SSV message.
 // If successful, it returns the decoded message and its descriptor. Otherwise, it returns an error.
 func (mv *messageValidator) ValidateSSVMessage(ssvMessage *spectypes.SSVMessage) (*queue.DecodedSSVMessage, Descriptor, error) {
-	return mv.validateSSVMessage(ssvMessage, time.Now(), nil)
+	return mv.validateSSVMessage(ssvMessage, time.Now(), 0, nil)
 }
 
 func (mv *messageValidator) validateP2PMessage(pMsg *pubsub.Message, receivedAt time.Time) (*queue.DecodedSSVMessage, Descriptor, error) {
@@ -306,9 +366,38 @@ func (mv *messageValidator) validateP2PMessage(pMsg *pubsub.Message, receivedAt
 	messageData := pMsg.GetData()
 
 	var signatureVerifier func() error
+	// signer is the operator whose signature is verified, if the message is signed.
+	var signer spectypes.OperatorID
 
 	currentEpoch := mv.netCfg.Beacon.EstimatedEpochAtSlot(mv.netCfg.Beacon.EstimatedSlotAtTime(receivedAt.Unix()))
-	if currentEpoch > mv.netCfg.PermissionlessActivationEpoch {
+	if currentEpoch > mv.netCfg.PermissionlessActivationEpoch && commons.IsEd25519SignedSSVMessage(messageData) {
+		if !mv.netCfg.AcceptsEd25519Signatures(currentEpoch) {
+			e := ErrEd25519SignaturesNotAccepted
+			e.got = currentEpoch
+			e.want = mv.netCfg.Ed25519SignaturesEpoch
+			return nil, Descriptor{}, e
+		}
+
+		decMessageData, certificate, signature, err := commons.DecodeEd25519SignedSSVMessage(messageData)
+		messageData = decMessageData
+		if err != nil {
+			e := ErrMalformedSignedMessage
+			e.innerErr = err
+			return nil, Descriptor{}, e
+		}
+
+		signer = spectypes.OperatorID(certificate.OperatorID)
+		signatureVerifier = func() error {
+			return mv.verifyEd25519Signature(messageData, certificate, signature, currentEpoch)
+		}
+	} else if currentEpoch > mv.netCfg.PermissionlessActivationEpoch {
+		if !mv.netCfg.AcceptsRSASignatures(currentEpoch) {
+			e := ErrRSASignaturesNotAccepted
+			e.got = currentEpoch
+			e.want = mv.netCfg.RSASignaturesEndEpoch
+			return nil, Descriptor{}, e
+		}
+
 		decMessageData, operatorID, signature, err := commons.DecodeSignedSSVMessage(messageData)
 		messageData = decMessageData
 		if err != nil {
@@ -317,6 +406,7 @@ func (mv *messageValidator) validateP2PMessage(pMsg *pubsub.Message, receivedAt
 			return nil, Descriptor{}, e
 		}
 
+		signer = operatorID
 		signatureVerifier = func() error {
 			mv.metrics.MessageValidationRSAVerifications()
 			return mv.verifySignature(messageData, operatorID, signature)
@@ -367,10 +457,15 @@ func (mv *messageValidator) validateP2PMessage(pMsg *pubsub.Message, receivedAt
 
 	mv.metrics.SSVMessageType(msg.MsgType)
 
-	return mv.validateSSVMessage(msg, receivedAt, signatureVerifier)
+	return mv.validateSSVMessage(msg, receivedAt, signer, signatureVerifier)
 }
 
-func (mv *messageValidator) validateSSVMessage(ssvMessage *spectypes.SSVMessage, receivedAt time.Time, signatureVerifier func() error) (*queue.DecodedSSVMessage, Descriptor, error) {
+func (mv *messageValidator) validateSSVMessage(
+	ssvMessage *spectypes.SSVMessage,
+	receivedAt time.Time,
+	signer spectypes.OperatorID,
+	signatureVerifier func() error,
+) (*queue.DecodedSSVMessage, Descriptor, error) {
 	var descriptor Descriptor
 
 	if len(ssvMessage.Data) == 0 {
@@ -400,6 +495,16 @@ func (mv *messageValidator) validateSSVMessage(ssvMessage *spectypes.SSVMessage,
 		return nil, descriptor, ErrInvalidRole
 	}
 
+	if role == ssvmessage.BNRoleCommittee {
+		epoch := mv.netCfg.Beacon.EstimatedEpochAtSlot(mv.netCfg.Beacon.EstimatedSlotAtTime(receivedAt.Unix()))
+		if !mv.netCfg.AcceptsCommitteeConsensus(epoch) {
+			e := ErrCommitteeConsensusNotAccepted
+			e.got = epoch
+			e.want = mv.netCfg.CommitteeConsensusEpoch
+			return nil, descriptor, e
+		}
+	}
+
 	publicKey, err := ssvtypes.DeserializeBLSPublicKey(validatorPK)
 	if err != nil {
 		e := ErrDeserializePublicKey
@@ -444,6 +549,10 @@ func (mv *messageValidator) validateSSVMessage(ssvMessage *spectypes.SSVMessage,
 		return nil, descriptor, e
 	}
 
+	if err := mv.verifyMessageBLSSignature(share, msg, receivedAt); err != nil {
+		return nil, descriptor, err
+	}
+
 	// Lock this SSV message ID to prevent concurrent access to the same state.
 	mv.validationMutex.Lock()
 	mutex, ok := mv.validationLocks[msg.GetID()]
@@ -484,7 +593,36 @@ func (mv *messageValidator) validateSSVMessage(ssvMessage *spectypes.SSVMessage,
 			}
 
 			partialSignatureMessage := msg.Body.(*spectypes.SignedPartialSignatureMessage)
-			slot, err := mv.validatePartialSignatureMessage(share, partialSignatureMessage, msg.GetID(), signatureVerifier)
+			slot, err := mv.validatePartialSignatureMessage(share, partialSignatureMessage, msg.GetID(), receivedAt, signatureVerifier)
+			descriptor.Slot = slot
+			if err != nil {
+				return nil, descriptor, err
+			}
+
+		case ssvmessage.SSVExitRequestMsgType:
+			if len(msg.Data) > maxExitRequestMsgSize {
+				e := ErrSSVDataTooBig
+				e.got = len(ssvMessage.Data)
+				e.want = maxExitRequestMsgSize
+				return nil, descriptor, e
+			}
+
+			exitRequestMessage := msg.Body.(*ssvtypes.ExitRequestMessage)
+			descriptor.Slot = exitRequestMessage.Slot
+			if err := mv.validateExitRequestMessage(share, exitRequestMessage, msg.GetID(), receivedAt, signer, signatureVerifier); err != nil {
+				return nil, descriptor, err
+			}
+
+		case ssvmessage.SSVCommitteePartialSignatureMsgType:
+			if len(msg.Data) > maxCommitteePartialSignaturesMsgSize {
+				e := ErrSSVDataTooBig
+				e.got = len(ssvMessage.Data)
+				e.want = maxCommitteePartialSignaturesMsgSize
+				return nil, descriptor, e
+			}
+
+			committeePartialSignatures := msg.Body.(*ssvtypes.CommitteePartialSignatures)
+			slot, err := mv.validateCommitteePartialSignatures(share, committeePartialSignatures, msg.GetID(), receivedAt, signatureVerifier)
 			descriptor.Slot = slot
 			if err != nil {
 				return nil, descriptor, err
@@ -548,22 +686,18 @@ func (mv *messageValidator) validateSlotTime(messageSlot phase0.Slot, role spect
 
 func (mv *messageValidator) earlyMessage(slot phase0.Slot, receivedAt time.Time) bool {
 	return mv.netCfg.Beacon.GetSlotEndTime(mv.netCfg.Beacon.EstimatedSlotAtTime(receivedAt.Unix())).
-		Add(-clockErrorTolerance).Before(mv.netCfg.Beacon.GetSlotStartTime(slot))
+		Add(-mv.policy().ClockErrorTolerance).Before(mv.netCfg.Beacon.GetSlotStartTime(slot))
 }
 
 func (mv *messageValidator) lateMessage(slot phase0.Slot, role spectypes.BeaconRole, receivedAt time.Time) time.Duration {
-	var ttl phase0.Slot
-	switch role {
-	case spectypes.BNRoleProposer, spectypes.BNRoleSyncCommittee, spectypes.BNRoleSyncCommitteeContribution:
-		ttl = 1 + lateSlotAllowance
-	case spectypes.BNRoleAttester, spectypes.BNRoleAggregator:
-		ttl = 32 + lateSlotAllowance
-	case spectypes.BNRoleValidatorRegistration, spectypes.BNRoleVoluntaryExit:
+	policy := mv.policy()
+	ttl := policy.role(role).LateSlots
+	if ttl == 0 {
 		return 0
 	}
 
 	deadline := mv.netCfg.Beacon.GetSlotStartTime(slot + ttl).
-		Add(lateMessageMargin).Add(clockErrorTolerance)
+		Add(policy.LateMessageMargin).Add(policy.ClockErrorTolerance)
 
 	return mv.netCfg.Beacon.GetSlotStartTime(mv.netCfg.Beacon.EstimatedSlotAtTime(receivedAt.Unix())).
 		Sub(deadline)
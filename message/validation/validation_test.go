@@ -2,6 +2,7 @@ package validation
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"encoding/hex"
 	"math"
 	"testing"
@@ -13,6 +14,8 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pspb "github.com/libp2p/go-libp2p-pubsub/pb"
@@ -78,7 +81,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.NoError(t, err)
 	})
 
@@ -107,10 +110,10 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt, 0, nil)
 		require.NoError(t, err)
 
-		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt, 0, nil)
 		require.ErrorContains(t, err, ErrTooManySameTypeMessagesPerRound.Error())
 
 		state1 := state.GetSignerState(1)
@@ -128,7 +131,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.NoError(t, err)
 
 		ssvMsg.Data = encodedMsg
-		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt, 0, nil)
 		require.NoError(t, err)
 
 		require.NotNil(t, state1)
@@ -136,7 +139,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.EqualValues(t, 2, state1.Round)
 		require.EqualValues(t, MessageCounts{Prepare: 1}, state1.MessageCounts)
 
-		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt, 0, nil)
 		require.ErrorContains(t, err, ErrTooManySameTypeMessagesPerRound.Error())
 
 		signedMsg = spectestingutils.TestingCommitMessageWithHeight(ks.Shares[1], 1, height+1)
@@ -144,14 +147,14 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.NoError(t, err)
 
 		ssvMsg.Data = encodedMsg
-		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt.Add(netCfg.Beacon.SlotDurationSec()), nil)
+		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt.Add(netCfg.Beacon.SlotDurationSec()), 0, nil)
 		require.NoError(t, err)
 		require.NotNil(t, state1)
 		require.EqualValues(t, height+1, state1.Slot)
 		require.EqualValues(t, 1, state1.Round)
 		require.EqualValues(t, MessageCounts{Commit: 1}, state1.MessageCounts)
 
-		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt.Add(netCfg.Beacon.SlotDurationSec()), nil)
+		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt.Add(netCfg.Beacon.SlotDurationSec()), 0, nil)
 		require.ErrorContains(t, err, ErrTooManySameTypeMessagesPerRound.Error())
 
 		signedMsg = spectestingutils.TestingCommitMultiSignerMessageWithHeight([]*bls.SecretKey{ks.Shares[1], ks.Shares[2], ks.Shares[3]}, []spectypes.OperatorID{1, 2, 3}, height+1)
@@ -159,7 +162,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.NoError(t, err)
 
 		ssvMsg.Data = encodedMsg
-		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt.Add(netCfg.Beacon.SlotDurationSec()), nil)
+		_, _, err = validator.validateSSVMessage(ssvMsg, receivedAt.Add(netCfg.Beacon.SlotDurationSec()), 0, nil)
 		require.NoError(t, err)
 		require.NotNil(t, state1)
 		require.EqualValues(t, height+1, state1.Slot)
@@ -238,7 +241,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 
 		require.ErrorContains(t, err, ErrMalformedMessage.Error())
 	})
@@ -253,7 +256,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    []byte{},
 		}
 
-		_, _, err := validator.validateSSVMessage(message, time.Now(), nil)
+		_, _, err := validator.validateSSVMessage(message, time.Now(), 0, nil)
 		require.ErrorIs(t, err, ErrEmptyData)
 
 		message = &spectypes.SSVMessage{
@@ -262,7 +265,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    nil,
 		}
 
-		_, _, err = validator.validateSSVMessage(message, time.Now(), nil)
+		_, _, err = validator.validateSSVMessage(message, time.Now(), 0, nil)
 		require.ErrorIs(t, err, ErrEmptyData)
 	})
 
@@ -278,7 +281,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    bytes.Repeat([]byte{0x1}, tooBigMsgSize),
 		}
 
-		_, _, err := validator.validateSSVMessage(message, time.Now(), nil)
+		_, _, err := validator.validateSSVMessage(message, time.Now(), 0, nil)
 		expectedErr := ErrSSVDataTooBig
 		expectedErr.got = tooBigMsgSize
 		expectedErr.want = maxMessageSize
@@ -295,7 +298,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    bytes.Repeat([]byte{0x1}, maxMessageSize),
 		}
 
-		_, _, err := validator.validateSSVMessage(message, time.Now(), nil)
+		_, _, err := validator.validateSSVMessage(message, time.Now(), 0, nil)
 		require.ErrorContains(t, err, ErrMalformedMessage.Error())
 	})
 
@@ -309,7 +312,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    []byte{0x1},
 		}
 
-		_, _, err = validator.validateSSVMessage(message, time.Now(), nil)
+		_, _, err = validator.validateSSVMessage(message, time.Now(), 0, nil)
 		require.ErrorContains(t, err, ErrUnknownSSVMessageType.Error())
 	})
 
@@ -327,7 +330,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    encodedValidSignedMessage,
 		}
 
-		_, _, err = validator.validateSSVMessage(message, time.Now(), nil)
+		_, _, err = validator.validateSSVMessage(message, time.Now(), 0, nil)
 		require.ErrorContains(t, err, ErrDeserializePublicKey.Error())
 	})
 
@@ -348,7 +351,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    encodedValidSignedMessage,
 		}
 
-		_, _, err = validator.validateSSVMessage(message, time.Now(), nil)
+		_, _, err = validator.validateSSVMessage(message, time.Now(), 0, nil)
 		expectedErr := ErrUnknownValidator
 		expectedErr.got = hex.EncodeToString(sk.PublicKey().Marshal())
 		require.ErrorIs(t, err, expectedErr)
@@ -373,7 +376,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		expectedErr := ErrWrongDomain
 		expectedErr.got = hex.EncodeToString(wrongDomain[:])
 		expectedErr.want = hex.EncodeToString(netCfg.Domain[:])
@@ -398,10 +401,47 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorIs(t, err, ErrInvalidRole)
 	})
 
+	// Committee consensus messages are only accepted from the network's committee consensus epoch
+	t.Run("committee consensus before fork epoch", func(t *testing.T) {
+		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+		height := specqbft.Height(slot)
+
+		validSignedMessage := spectestingutils.TestingProposalMessageWithHeight(ks.Shares[1], 1, height)
+		encodedValidSignedMessage, err := validSignedMessage.Encode()
+		require.NoError(t, err)
+
+		message := &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, ssvmessage.BNRoleCommittee),
+			Data:    encodedValidSignedMessage,
+		}
+
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
+		expectedErr := ErrCommitteeConsensusNotAccepted
+		expectedErr.got = phase0.Epoch(1)
+		expectedErr.want = phase0.Epoch(0)
+		require.ErrorIs(t, err, expectedErr)
+
+		forkedCfg := netCfg
+		forkedCfg.CommitteeConsensusEpoch = 2
+		validator = NewMessageValidator(forkedCfg, WithNodeStorage(ns)).(*messageValidator)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
+		require.ErrorContains(t, err, ErrCommitteeConsensusNotAccepted.Error())
+
+		forkedCfg.CommitteeConsensusEpoch = 1
+		validator = NewMessageValidator(forkedCfg, WithNodeStorage(ns)).(*messageValidator)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
+		if err != nil {
+			require.NotContains(t, err.Error(), ErrCommitteeConsensusNotAccepted.Error())
+		}
+	})
+
 	// Perform validator registration or voluntary exit with a consensus type message will give an error
 	t.Run("unexpected consensus message", func(t *testing.T) {
 		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
@@ -420,7 +460,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorContains(t, err, ErrUnexpectedConsensusMessage.Error())
 
 		message = &spectypes.SSVMessage{
@@ -429,7 +469,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    encodedValidSignedMessage,
 		}
 
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorContains(t, err, ErrUnexpectedConsensusMessage.Error())
 	})
 
@@ -463,7 +503,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    encodedValidSignedMessage,
 		}
 
-		_, _, err = validator.validateSSVMessage(message, time.Now(), nil)
+		_, _, err = validator.validateSSVMessage(message, time.Now(), 0, nil)
 		expectedErr := ErrValidatorLiquidated
 		require.ErrorIs(t, err, expectedErr)
 
@@ -503,7 +543,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
 
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		expectedErr := ErrValidatorNotAttesting
 		expectedErr.got = eth2apiv1.ValidatorStateUnknown.String()
 		require.ErrorIs(t, err, expectedErr)
@@ -553,7 +593,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
 
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		expectedErr := ErrValidatorNotAttesting
 		expectedErr.got = eth2apiv1.ValidatorStatePendingQueued.String()
 		require.ErrorIs(t, err, expectedErr)
@@ -604,7 +644,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
 
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.NoError(t, err)
 
 		require.NoError(t, ns.Shares().Delete(nil, nonUpdatedMetadataShare.ValidatorPubKey))
@@ -641,7 +681,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		slot := netCfg.Beacon.FirstSlotAtEpoch(1)
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
 
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorIs(t, err, ErrNoShareMetadata)
 
 		require.NoError(t, ns.Shares().Delete(nil, noMetadataShare.ValidatorPubKey))
@@ -664,7 +704,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    encodedValidSignedMessage,
 		}
 
-		_, _, err = validator.validateSSVMessage(message, netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester)), nil)
+		_, _, err = validator.validateSSVMessage(message, netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester)), 0, nil)
 		require.NoError(t, err)
 
 		validSignedMessage = spectestingutils.TestingProposalMessageWithHeight(ks.Shares[1], 1, height+4)
@@ -672,7 +712,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.NoError(t, err)
 
 		message.Data = encodedValidSignedMessage
-		_, _, err = validator.validateSSVMessage(message, netCfg.Beacon.GetSlotStartTime(slot+4).Add(validator.waitAfterSlotStart(roleAttester)), nil)
+		_, _, err = validator.validateSSVMessage(message, netCfg.Beacon.GetSlotStartTime(slot+4).Add(validator.waitAfterSlotStart(roleAttester)), 0, nil)
 		require.NoError(t, err)
 
 		validSignedMessage = spectestingutils.TestingProposalMessageWithHeight(ks.Shares[1], 1, height+8)
@@ -680,7 +720,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.NoError(t, err)
 
 		message.Data = encodedValidSignedMessage
-		_, _, err = validator.validateSSVMessage(message, netCfg.Beacon.GetSlotStartTime(slot+8).Add(validator.waitAfterSlotStart(roleAttester)), nil)
+		_, _, err = validator.validateSSVMessage(message, netCfg.Beacon.GetSlotStartTime(slot+8).Add(validator.waitAfterSlotStart(roleAttester)), 0, nil)
 		require.ErrorContains(t, err, ErrTooManyDutiesPerEpoch.Error())
 	})
 
@@ -704,13 +744,13 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    encodedValidSignedMessage,
 		}
 
-		_, _, err = validator.validateSSVMessage(message, netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(spectypes.BNRoleProposer)), nil)
+		_, _, err = validator.validateSSVMessage(message, netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(spectypes.BNRoleProposer)), 0, nil)
 		require.ErrorContains(t, err, ErrNoDuty.Error())
 
 		dutyStore = dutystore.New()
 		dutyStore.Proposer.Add(epoch, slot, validatorIndex, &eth2apiv1.ProposerDuty{}, true)
 		validator = NewMessageValidator(netCfg, WithNodeStorage(ns), WithDutyStore(dutyStore)).(*messageValidator)
-		_, _, err = validator.validateSSVMessage(message, netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(spectypes.BNRoleProposer)), nil)
+		_, _, err = validator.validateSSVMessage(message, netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(spectypes.BNRoleProposer)), 0, nil)
 		require.NoError(t, err)
 	})
 
@@ -745,7 +785,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorIs(t, err, ErrSignerNotInCommittee)
 	})
 
@@ -767,7 +807,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorIs(t, err, ErrZeroSigner)
 	})
 
@@ -790,7 +830,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		expectedErr := ErrUnexpectedSigner
 		expectedErr.got = spectypes.OperatorID(2)
 		expectedErr.want = spectypes.OperatorID(1)
@@ -816,7 +856,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorIs(t, err, ErrDuplicatedPartialSignatureMessage)
 	})
 
@@ -839,7 +879,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorIs(t, err, ErrNoPartialMessages)
 	})
 
@@ -862,7 +902,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorContains(t, err, ErrMalformedMessage.Error())
 	})
 
@@ -920,7 +960,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 					}
 
 					receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
-					_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+					_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 					require.NoError(t, err)
 				}
 			}
@@ -949,7 +989,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			}
 
 			receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
-			_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+			_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 			require.ErrorContains(t, err, ErrUnknownPartialMessageType.Error())
 		})
 
@@ -988,7 +1028,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 					}
 
 					receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
-					_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+					_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 					require.ErrorContains(t, err, ErrPartialSignatureTypeRoleMismatch.Error())
 				}
 			}
@@ -1021,7 +1061,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		expectedErr := ErrUnknownQBFTMessageType
 		require.ErrorIs(t, err, expectedErr)
 	})
@@ -1061,7 +1101,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			}
 
 			receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-			_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+			_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 			require.ErrorIs(t, err, ErrZeroSignature)
 		})
 
@@ -1081,7 +1121,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			}
 
 			receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-			_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, nil)
+			_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, 0, nil)
 			require.ErrorIs(t, err, ErrZeroSignature)
 		})
 	})
@@ -1106,7 +1146,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorIs(t, err, ErrNoSigners)
 	})
 
@@ -1149,7 +1189,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			}
 
 			receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-			_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+			_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 			require.ErrorIs(t, err, ErrZeroSigner)
 		})
 
@@ -1168,7 +1208,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			}
 
 			receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-			_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+			_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 			require.ErrorIs(t, err, ErrZeroSigner)
 		})
 
@@ -1196,7 +1236,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorIs(t, err, ErrDuplicatedSigner)
 	})
 
@@ -1221,7 +1261,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorIs(t, err, ErrSignersNotSorted)
 	})
 
@@ -1246,7 +1286,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 
 		expectedErr := ErrWrongSignersLength
 		expectedErr.got = 2
@@ -1273,7 +1313,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 
 		expectedErr := ErrNonDecidedWithMultipleSigners
 		expectedErr.got = 3
@@ -1310,7 +1350,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 					Data:    encodedValidSignedMessage,
 				}
 
-				_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+				_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 				require.ErrorContains(t, err, ErrLateMessage.Error())
 			})
 		}
@@ -1334,7 +1374,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot - 1)
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		require.ErrorIs(t, err, ErrEarlyMessage)
 	})
 
@@ -1356,7 +1396,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		expectedErr := ErrSignerNotLeader
 		expectedErr.got = spectypes.OperatorID(2)
 		expectedErr.want = spectypes.OperatorID(1)
@@ -1383,7 +1423,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 
 		require.ErrorContains(t, err, ErrMalformedPrepareJustifications.Error())
 	})
@@ -1412,7 +1452,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 
 		expectedErr := ErrUnexpectedPrepareJustifications
 		expectedErr.got = specqbft.PrepareMsgType
@@ -1444,7 +1484,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 
 		expectedErr := ErrUnexpectedRoundChangeJustifications
 		expectedErr.got = specqbft.PrepareMsgType
@@ -1471,7 +1511,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 
 		require.ErrorContains(t, err, ErrMalformedRoundChangeJustifications.Error())
 	})
@@ -1496,7 +1536,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 
 		expectedErr := ErrInvalidHash
 		require.ErrorIs(t, err, expectedErr)
@@ -1519,7 +1559,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message1, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message1, receivedAt, 0, nil)
 		require.NoError(t, err)
 
 		signed2 := spectestingutils.TestingProposalMessageWithRound(ks.Shares[1], 1, 1)
@@ -1536,7 +1576,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    encodedSigned2,
 		}
 
-		_, _, err = validator.validateSSVMessage(message2, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message2, receivedAt, 0, nil)
 		expectedErr := ErrDuplicatedProposalWithDifferentData
 		require.ErrorIs(t, err, expectedErr)
 	})
@@ -1558,7 +1598,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message1, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message1, receivedAt, 0, nil)
 		require.NoError(t, err)
 
 		signed2 := spectestingutils.TestingPrepareMessage(ks.Shares[1], 1)
@@ -1573,7 +1613,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    encodedSigned2,
 		}
 
-		_, _, err = validator.validateSSVMessage(message2, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message2, receivedAt, 0, nil)
 		expectedErr := ErrTooManySameTypeMessagesPerRound
 		expectedErr.got = "prepare, having pre-consensus: 0, proposal: 0, prepare: 1, commit: 0, decided: 0, round change: 0, post-consensus: 0"
 		require.ErrorIs(t, err, expectedErr)
@@ -1596,7 +1636,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message1, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message1, receivedAt, 0, nil)
 		require.NoError(t, err)
 
 		signed2 := spectestingutils.TestingCommitMessage(ks.Shares[1], 1)
@@ -1609,7 +1649,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    encodedSigned2,
 		}
 
-		_, _, err = validator.validateSSVMessage(message2, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message2, receivedAt, 0, nil)
 		expectedErr := ErrTooManySameTypeMessagesPerRound
 		expectedErr.got = "commit, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 1, decided: 0, round change: 0, post-consensus: 0"
 		require.ErrorIs(t, err, expectedErr)
@@ -1632,7 +1672,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(message1, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message1, receivedAt, 0, nil)
 		require.NoError(t, err)
 
 		signed2 := spectestingutils.TestingRoundChangeMessage(ks.Shares[1], 1)
@@ -1645,7 +1685,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			Data:    encodedSigned2,
 		}
 
-		_, _, err = validator.validateSSVMessage(message2, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message2, receivedAt, 0, nil)
 		expectedErr := ErrTooManySameTypeMessagesPerRound
 		expectedErr.got = "round change, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 0, decided: 0, round change: 1, post-consensus: 0"
 		require.ErrorIs(t, err, expectedErr)
@@ -1673,11 +1713,11 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
 
 		for i := 0; i < maxDecidedCount(len(share.Committee)); i++ {
-			_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+			_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 			require.NoError(t, err)
 		}
 
-		_, _, err = validator.validateSSVMessage(message, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
 		expectedErr := ErrTooManySameTypeMessagesPerRound
 		expectedErr.got = "decided, having pre-consensus: 0, proposal: 0, prepare: 0, commit: 0, decided: 8, round change: 0, post-consensus: 0"
 		require.ErrorIs(t, err, expectedErr)
@@ -1711,12 +1751,39 @@ func Test_ValidateSSVMessage(t *testing.T) {
 				}
 
 				receivedAt := netCfg.Beacon.GetSlotStartTime(0).Add(validator.waitAfterSlotStart(role))
-				_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, nil)
+				_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, 0, nil)
 				require.ErrorContains(t, err, ErrRoundTooHigh.Error())
 			})
 		}
 	})
 
+	// Receive message from a round that is too high according to a custom policy should receive an error
+	t.Run("round too high by policy", func(t *testing.T) {
+		policy := DefaultPolicy()
+		policy.Roles[roleAttester.String()] = RolePolicy{LateSlots: 34, MaxRound: 2}
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns), WithPolicy(policy)).(*messageValidator)
+
+		msgID := spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester)
+		signedMessage := spectestingutils.TestingPrepareMessageWithRound(ks.Shares[1], 1, 3)
+		encodedMessage, err := signedMessage.Encode()
+		require.NoError(t, err)
+
+		ssvMessage := &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   msgID,
+			Data:    encodedMessage,
+		}
+
+		receivedAt := netCfg.Beacon.GetSlotStartTime(0).Add(validator.waitAfterSlotStart(roleAttester))
+		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, 0, nil)
+		require.ErrorContains(t, err, ErrRoundTooHigh.Error())
+
+		// Reloading the default policy accepts the round again.
+		require.NoError(t, validator.SetPolicy(DefaultPolicy()))
+		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, 0, nil)
+		require.NoError(t, err)
+	})
+
 	// Receive message from a round that is incorrect for current epoch should receive an error
 	t.Run("round already advanced", func(t *testing.T) {
 		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
@@ -1735,7 +1802,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, 0, nil)
 		require.NoError(t, err)
 
 		signedMessage = spectestingutils.TestingPrepareMessageWithRound(ks.Shares[1], 1, 1)
@@ -1743,7 +1810,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		require.NoError(t, err)
 
 		ssvMessage.Data = encodedMessage
-		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, 0, nil)
 		require.ErrorContains(t, err, ErrRoundAlreadyAdvanced.Error())
 	})
 
@@ -1767,7 +1834,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 				Data:    encodedMessage,
 			}
 
-			_, _, err = validator.validateSSVMessage(ssvMessage, netCfg.Beacon.GetSlotStartTime(slot+1).Add(validator.waitAfterSlotStart(roleAttester)), nil)
+			_, _, err = validator.validateSSVMessage(ssvMessage, netCfg.Beacon.GetSlotStartTime(slot+1).Add(validator.waitAfterSlotStart(roleAttester)), 0, nil)
 			require.NoError(t, err)
 
 			signedMessage = spectestingutils.TestingPrepareMessageWithHeight(ks.Shares[1], 1, height)
@@ -1775,7 +1842,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			require.NoError(t, err)
 
 			ssvMessage.Data = encodedMessage
-			_, _, err = validator.validateSSVMessage(ssvMessage, netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester)), nil)
+			_, _, err = validator.validateSSVMessage(ssvMessage, netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester)), 0, nil)
 			require.ErrorContains(t, err, ErrSlotAlreadyAdvanced.Error())
 		})
 
@@ -1798,7 +1865,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 				Data:    encodedMessage,
 			}
 
-			_, _, err = validator.validateSSVMessage(ssvMessage, netCfg.Beacon.GetSlotStartTime(slot+1).Add(validator.waitAfterSlotStart(roleAttester)), nil)
+			_, _, err = validator.validateSSVMessage(ssvMessage, netCfg.Beacon.GetSlotStartTime(slot+1).Add(validator.waitAfterSlotStart(roleAttester)), 0, nil)
 			require.NoError(t, err)
 
 			message = spectestingutils.PostConsensusAttestationMsg(ks.Shares[2], 2, height)
@@ -1811,7 +1878,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 			require.NoError(t, err)
 
 			ssvMessage.Data = encodedMessage
-			_, _, err = validator.validateSSVMessage(ssvMessage, netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester)), nil)
+			_, _, err = validator.validateSSVMessage(ssvMessage, netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester)), 0, nil)
 			require.ErrorContains(t, err, ErrSlotAlreadyAdvanced.Error())
 		})
 	})
@@ -1834,7 +1901,7 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		}
 
 		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
-		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, nil)
+		_, _, err = validator.validateSSVMessage(ssvMessage, receivedAt, 0, nil)
 		require.ErrorIs(t, err, ErrEventMessage)
 	})
 
@@ -2131,3 +2198,363 @@ func Test_ValidateSSVMessage(t *testing.T) {
 		})
 	})
 }
+
+func Test_ValidateEd25519SignedMessage(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+
+	ns, err := storage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	ks := spectestingutils.Testing4SharesSet()
+	share := &ssvtypes.SSVShare{
+		Share: *spectestingutils.TestingShare(ks),
+		Metadata: ssvtypes.Metadata{
+			BeaconMetadata: &beaconprotocol.ValidatorMetadata{
+				Status: eth2apiv1.ValidatorStateActiveOngoing,
+				Index:  123,
+			},
+		},
+	}
+	require.NoError(t, ns.Shares().Save(nil, share))
+
+	privKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	pubKey, err := privKey.Public().Base64()
+	require.NoError(t, err)
+
+	const operatorID = spectypes.OperatorID(1)
+	_, err = ns.SaveOperatorData(nil, &registrystorage.OperatorData{ID: operatorID, PublicKey: pubKey})
+	require.NoError(t, err)
+
+	// The transition from RSA to Ed25519 signatures lasts 10 epochs.
+	netCfg := networkconfig.TestNetwork
+	netCfg.Ed25519SignaturesEpoch = netCfg.PermissionlessActivationEpoch + 100
+	netCfg.RSASignaturesEndEpoch = netCfg.Ed25519SignaturesEpoch + 10
+
+	roleAttester := spectypes.BNRoleAttester
+
+	encodeMessage := func(epoch phase0.Epoch) []byte {
+		slot := netCfg.Beacon.FirstSlotAtEpoch(epoch)
+		signedMessage := spectestingutils.TestingProposalMessageWithHeight(ks.Shares[1], 1, specqbft.Height(slot))
+		encoded, err := signedMessage.Encode()
+		require.NoError(t, err)
+
+		encodedMsg, err := commons.EncodeNetworkMsg(&spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, roleAttester),
+			Data:    encoded,
+		})
+		require.NoError(t, err)
+		return encodedMsg
+	}
+	signRSA := func(epoch phase0.Epoch) []byte {
+		encodedMsg := encodeMessage(epoch)
+		signature, err := privKey.Sign(encodedMsg)
+		require.NoError(t, err)
+		return commons.EncodeSignedSSVMessage(encodedMsg, operatorID, signature)
+	}
+	signEd25519 := func(signer *keys.Ed25519Signer, signedAt, epoch phase0.Epoch) []byte {
+		encodedMsg := encodeMessage(epoch)
+		certificate, signature, err := signer.Sign(uint64(operatorID), uint64(signedAt), encodedMsg)
+		require.NoError(t, err)
+		return commons.EncodeEd25519SignedSSVMessage(encodedMsg, certificate, signature)
+	}
+	validate := func(validator *messageValidator, data []byte, epoch phase0.Epoch) error {
+		topicID := commons.ValidatorTopicID(share.ValidatorPubKey)
+		pMsg := &pubsub.Message{
+			Message: &pspb.Message{
+				Topic: &topicID[0],
+				Data:  data,
+			},
+		}
+		slot := netCfg.Beacon.FirstSlotAtEpoch(epoch)
+		receivedAt := netCfg.Beacon.GetSlotStartTime(slot).Add(validator.waitAfterSlotStart(roleAttester))
+		_, _, err := validator.validateP2PMessage(pMsg, receivedAt)
+		return err
+	}
+
+	t.Run("before transition", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		signer := keys.NewEd25519Signer(privKey, netCfg.Domain)
+		epoch := netCfg.Ed25519SignaturesEpoch - 1
+
+		require.ErrorContains(t, validate(validator, signEd25519(signer, epoch, epoch), epoch), ErrEd25519SignaturesNotAccepted.Error())
+		require.NoError(t, validate(validator, signRSA(epoch), epoch))
+	})
+
+	t.Run("during transition", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		signer := keys.NewEd25519Signer(privKey, netCfg.Domain)
+		epoch := netCfg.Ed25519SignaturesEpoch
+
+		require.NoError(t, validate(validator, signEd25519(signer, epoch, epoch), epoch))
+		require.NoError(t, validate(validator, signRSA(epoch+1), epoch+1))
+		require.NoError(t, validate(validator, signEd25519(signer, epoch, epoch+2), epoch+2))
+
+		// The certificate's verification is cached.
+		cached, ok := validator.operatorIDToCertificateCache.Get(operatorID)
+		require.True(t, ok)
+		require.Equal(t, uint64(epoch)+keys.Ed25519KeyValidityEpochs, cached.ExpiryEpoch)
+	})
+
+	t.Run("after transition", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		signer := keys.NewEd25519Signer(privKey, netCfg.Domain)
+		epoch := netCfg.RSASignaturesEndEpoch
+
+		require.ErrorContains(t, validate(validator, signRSA(epoch), epoch), ErrRSASignaturesNotAccepted.Error())
+		require.NoError(t, validate(validator, signEd25519(signer, epoch, epoch), epoch))
+	})
+
+	t.Run("expired certificate", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		signer := keys.NewEd25519Signer(privKey, netCfg.Domain)
+		signedAt := netCfg.Ed25519SignaturesEpoch
+		epoch := signedAt + keys.Ed25519KeyValidityEpochs + 1
+
+		require.ErrorContains(t, validate(validator, signEd25519(signer, signedAt, epoch), epoch), ErrOperatorKeyCertificateExpired.Error())
+	})
+
+	t.Run("certificate expiring too late", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		signer := keys.NewEd25519Signer(privKey, netCfg.Domain)
+		epoch := netCfg.Ed25519SignaturesEpoch
+
+		require.ErrorContains(t, validate(validator, signEd25519(signer, epoch+10, epoch), epoch), ErrOperatorKeyCertificateTooLong.Error())
+	})
+
+	t.Run("certificate by another key", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		otherKey, err := keys.GeneratePrivateKey()
+		require.NoError(t, err)
+		signer := keys.NewEd25519Signer(otherKey, netCfg.Domain)
+		epoch := netCfg.Ed25519SignaturesEpoch
+
+		require.ErrorContains(t, validate(validator, signEd25519(signer, epoch, epoch), epoch), ErrSignatureVerification.Error())
+		_, ok := validator.operatorIDToCertificateCache.Get(operatorID)
+		require.False(t, ok)
+	})
+
+	t.Run("malformed signature", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+		signer := keys.NewEd25519Signer(privKey, netCfg.Domain)
+		epoch := netCfg.Ed25519SignaturesEpoch
+
+		encodedMsg := encodeMessage(epoch)
+		certificate, _, err := signer.Sign(uint64(operatorID), uint64(epoch), encodedMsg)
+		require.NoError(t, err)
+		data := commons.EncodeEd25519SignedSSVMessage(encodedMsg, certificate, bytes.Repeat([]byte{1}, 64))
+		require.ErrorContains(t, validate(validator, data, epoch), ErrSignatureVerification.Error())
+	})
+}
+
+func Test_ValidateExitRequestMessage(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+
+	ns, err := storage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	ks := spectestingutils.Testing4SharesSet()
+	share := &ssvtypes.SSVShare{
+		Share: *spectestingutils.TestingShare(ks),
+		Metadata: ssvtypes.Metadata{
+			BeaconMetadata: &beaconprotocol.ValidatorMetadata{
+				Status: eth2apiv1.ValidatorStateActiveOngoing,
+				Index:  123,
+			},
+			OwnerAddress: crypto.PubkeyToAddress(ownerKey.PublicKey),
+		},
+	}
+	require.NoError(t, ns.Shares().Save(nil, share))
+
+	netCfg := networkconfig.TestNetwork
+	slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+	receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
+
+	exitRequestMessage := func(t *testing.T, key *ecdsa.PrivateKey, msgSlot phase0.Slot, operator spectypes.OperatorID) *spectypes.SSVMessage {
+		request := ssvtypes.ExitRequest{
+			ValidatorPubKey: hexutil.Bytes(share.ValidatorPubKey),
+			Deadline:        uint64(receivedAt.Add(time.Hour).Unix()),
+		}
+		require.NoError(t, request.Sign(netCfg, key))
+
+		data, err := (&ssvtypes.ExitRequestMessage{Request: request, Slot: msgSlot, Operator: operator}).Encode()
+		require.NoError(t, err)
+
+		return &spectypes.SSVMessage{
+			MsgType: ssvmessage.SSVExitRequestMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, spectypes.BNRoleVoluntaryExit),
+			Data:    data,
+		}
+	}
+
+	t.Run("happy flow", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		_, descriptor, err := validator.validateSSVMessage(exitRequestMessage(t, ownerKey, slot+4, 1), receivedAt, 1, nil)
+		require.NoError(t, err)
+		require.Equal(t, slot+4, descriptor.Slot)
+	})
+
+	t.Run("operator not in committee", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		_, _, err := validator.validateSSVMessage(exitRequestMessage(t, ownerKey, slot, 5), receivedAt, 5, nil)
+		require.ErrorContains(t, err, ErrSignerNotInCommittee.Error())
+	})
+
+	t.Run("signed by another operator", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		_, _, err := validator.validateSSVMessage(exitRequestMessage(t, ownerKey, slot, 1), receivedAt, 2, nil)
+		require.ErrorContains(t, err, ErrUnexpectedSigner.Error())
+	})
+
+	t.Run("invalid operator signature", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		verifier := func() error { return ErrSignatureVerification }
+		_, _, err := validator.validateSSVMessage(exitRequestMessage(t, ownerKey, slot, 1), receivedAt, 1, verifier)
+		require.ErrorContains(t, err, ErrSignatureVerification.Error())
+	})
+
+	t.Run("not signed by owner", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		otherKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+
+		_, _, err = validator.validateSSVMessage(exitRequestMessage(t, otherKey, slot, 1), receivedAt, 0, nil)
+		require.ErrorContains(t, err, ErrExitRequestNotByOwner.Error())
+	})
+
+	t.Run("wrong role", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		message := exitRequestMessage(t, ownerKey, slot, 1)
+		message.MsgID = spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, spectypes.BNRoleAttester)
+
+		_, _, err := validator.validateSSVMessage(message, receivedAt, 0, nil)
+		require.ErrorContains(t, err, ErrInvalidRole.Error())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		_, _, err := validator.validateSSVMessage(exitRequestMessage(t, ownerKey, slot, 1), receivedAt.Add(2*time.Hour), 0, nil)
+		require.ErrorContains(t, err, ErrInvalidExitRequest.Error())
+	})
+
+	t.Run("slot out of range", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		_, _, err := validator.validateSSVMessage(exitRequestMessage(t, ownerKey, slot+exitRequestSlotWindow+1, 1), receivedAt, 0, nil)
+		require.ErrorContains(t, err, ErrExitRequestSlotOutOfRange.Error())
+	})
+}
+
+func Test_ValidateCommitteePartialSignatures(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+
+	ns, err := storage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	ks := spectestingutils.Testing4SharesSet()
+	share := &ssvtypes.SSVShare{
+		Share: *spectestingutils.TestingShare(ks),
+		Metadata: ssvtypes.Metadata{
+			BeaconMetadata: &beaconprotocol.ValidatorMetadata{
+				Status: eth2apiv1.ValidatorStateActiveOngoing,
+				Index:  123,
+			},
+		},
+	}
+	require.NoError(t, ns.Shares().Save(nil, share))
+
+	netCfg := networkconfig.TestNetwork
+	netCfg.CommitteeConsensusEpoch = 1
+	slot := netCfg.Beacon.FirstSlotAtEpoch(1)
+	receivedAt := netCfg.Beacon.GetSlotStartTime(slot)
+
+	committeeMessage := func(t *testing.T, msgSlot phase0.Slot, signer spectypes.OperatorID, role spectypes.BeaconRole) *spectypes.SSVMessage {
+		msg := &ssvtypes.CommitteePartialSignatures{
+			Version: ssvtypes.CommitteeConsensusVersion,
+			Slot:    msgSlot,
+			Signer:  signer,
+			Messages: []*ssvtypes.ValidatorPartialSignature{
+				{
+					ValidatorPubKey: phase0.BLSPubKey{1},
+					Role:            spectypes.BNRoleAttester,
+					Message: &spectypes.PartialSignatureMessage{
+						PartialSignature: bytes.Repeat([]byte{1}, signatureSize),
+						SigningRoot:      [32]byte{1},
+						Signer:           signer,
+					},
+				},
+			},
+		}
+		data, err := msg.Encode()
+		require.NoError(t, err)
+
+		return &spectypes.SSVMessage{
+			MsgType: ssvmessage.SSVCommitteePartialSignatureMsgType,
+			MsgID:   spectypes.NewMsgID(netCfg.Domain, share.ValidatorPubKey, role),
+			Data:    data,
+		}
+	}
+
+	t.Run("happy flow", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		_, descriptor, err := validator.validateSSVMessage(committeeMessage(t, slot, 1, ssvmessage.BNRoleCommittee), receivedAt, 0, nil)
+		require.NoError(t, err)
+		require.Equal(t, slot, descriptor.Slot)
+	})
+
+	t.Run("wrong role", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		_, _, err := validator.validateSSVMessage(committeeMessage(t, slot, 1, spectypes.BNRoleAttester), receivedAt, 0, nil)
+		require.ErrorContains(t, err, ErrInvalidRole.Error())
+	})
+
+	t.Run("signer not in committee", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		_, _, err := validator.validateSSVMessage(committeeMessage(t, slot, 5, ssvmessage.BNRoleCommittee), receivedAt, 0, nil)
+		require.ErrorContains(t, err, ErrSignerNotInCommittee.Error())
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		message := committeeMessage(t, slot, 1, ssvmessage.BNRoleCommittee)
+		msg := &ssvtypes.CommitteePartialSignatures{}
+		require.NoError(t, msg.Decode(message.Data))
+		msg.Version++
+		message.Data, err = msg.Encode()
+		require.NoError(t, err)
+
+		_, _, err = validator.validateSSVMessage(message, receivedAt, 0, nil)
+		require.ErrorContains(t, err, ErrInvalidCommitteePartialSignatures.Error())
+	})
+
+	t.Run("slot already advanced", func(t *testing.T) {
+		validator := NewMessageValidator(netCfg, WithNodeStorage(ns)).(*messageValidator)
+
+		_, _, err := validator.validateSSVMessage(committeeMessage(t, slot+1, 1, ssvmessage.BNRoleCommittee), netCfg.Beacon.GetSlotStartTime(slot+1), 0, nil)
+		require.NoError(t, err)
+
+		_, _, err = validator.validateSSVMessage(committeeMessage(t, slot, 1, ssvmessage.BNRoleCommittee), netCfg.Beacon.GetSlotStartTime(slot+1), 0, nil)
+		require.ErrorContains(t, err, ErrSlotAlreadyAdvanced.Error())
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
@@ -128,6 +129,14 @@ var (
 		Help:    "Time message spent in queue (seconds)",
 		Buckets: []float64{0.001, 0.005, 0.010, 0.050, 0.100, 0.500, 1, 5, 10, 60},
 	}, []string{"msg_id"})
+	scheduledQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_message_scheduler_queue_depth",
+		Help: "Number of messages in the validators' queues consumed by the message scheduler",
+	}, []string{"role"})
+	scheduledQueueDrops = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_message_scheduler_queue_drops",
+		Help: "The amount of messages dropped from the validators' queues consumed by the message scheduler",
+	}, []string{"role", "reason"})
 	inCommitteeMessages = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "ssv_message_in_committee",
 		Help: "The amount of messages in committee",
@@ -148,6 +157,35 @@ var (
 		Name: "ssv_message_validation_rsa_checks",
 		Help: "The amount message validations",
 	}, []string{})
+	messageValidationEd25519Verifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_message_validation_ed25519_verifications",
+		Help: "The amount of Ed25519 operator signatures verified in message validation",
+	}, []string{})
+	messageValidationBLSVerifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_message_validation_bls_verifications",
+		Help: "The amount of BLS signatures verified in message validation, batched or individually",
+	}, []string{"mode"})
+	messageValidationBLSBatchSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ssv_message_validation_bls_batch_size",
+		Help:    "Number of BLS signatures verified together in message validation",
+		Buckets: []float64{1, 2, 4, 8, 16, 32, 64, 128, 256},
+	}, []string{})
+	messageValidationBLSBatchFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv_message_validation_bls_batch_fallbacks",
+		Help: "The amount of failed BLS signature batches, which were verified individually",
+	}, []string{})
+	clusterBalance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_cluster_balance",
+		Help: "Estimated balance in SSV of the clusters which the operator participates in",
+	}, []string{"owner", "operators"})
+	clusterRunwayBlocks = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_cluster_runway_blocks",
+		Help: "Estimated number of blocks until the clusters which the operator participates in can be liquidated",
+	}, []string{"owner", "operators"})
+	clusterRunwayDays = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv_cluster_runway_days",
+		Help: "Estimated number of days until the clusters which the operator participates in can be liquidated",
+	}, []string{"owner", "operators"})
 	pubsubPeerScore = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "ssv:p2p:pubsub:score:inspect",
 		Help: "Pubsub peer scores",
@@ -182,6 +220,10 @@ type MetricsReporter interface {
 	MessagesReceivedFromPeer(peerId peer.ID)
 	MessagesReceivedTotal()
 	MessageValidationRSAVerifications()
+	MessageValidationEd25519Verifications()
+	MessageValidationBLSVerifications(count int, batched bool)
+	MessageValidationBLSBatchSize(size int)
+	MessageValidationBLSBatchFallback()
 	LastBlockProcessed(block uint64)
 	LogsProcessingError(err error)
 	MessageAccepted(role spectypes.BeaconRole, round specqbft.Round)
@@ -200,12 +242,16 @@ type MetricsReporter interface {
 	MessageQueueSize(size int)
 	MessageQueueCapacity(size int)
 	MessageTimeInQueue(messageID spectypes.MessageID, d time.Duration)
+	QueueDepth(role string, depth int)
+	QueueDroppedMessages(role string, reason string, count int)
 	InCommitteeMessage(msgType spectypes.MsgType, decided bool)
 	NonCommitteeMessage(msgType spectypes.MsgType, decided bool)
 	PeerScore(peerId peer.ID, score float64)
 	PeerP4Score(peerId peer.ID, score float64)
 	ResetPeerScores()
 	PeerDisconnected(peerId peer.ID)
+	ClusterRunway(owner ethcommon.Address, operatorIDs []uint64, balance float64, blocks uint64, days float64)
+	ClusterRunwayRemoved(owner ethcommon.Address, operatorIDs []uint64)
 }
 
 type metricsReporter struct {
@@ -248,8 +294,12 @@ func New(opts ...Option) MetricsReporter {
 		messagesReceivedFromPeer,
 		messagesReceivedTotal,
 		messageValidationRSAVerifications,
+		messageValidationEd25519Verifications,
 		pubsubPeerScore,
 		pubsubPeerP4Score,
+		clusterBalance,
+		clusterRunwayBlocks,
+		clusterRunwayDays,
 	}
 
 	for i, c := range allMetrics {
@@ -348,6 +398,26 @@ func (m *metricsReporter) MessageValidationRSAVerifications() {
 	messageValidationRSAVerifications.WithLabelValues().Inc()
 }
 
+func (m *metricsReporter) MessageValidationEd25519Verifications() {
+	messageValidationEd25519Verifications.WithLabelValues().Inc()
+}
+
+func (m *metricsReporter) MessageValidationBLSVerifications(count int, batched bool) {
+	mode := "individual"
+	if batched {
+		mode = "batch"
+	}
+	messageValidationBLSVerifications.WithLabelValues(mode).Add(float64(count))
+}
+
+func (m *metricsReporter) MessageValidationBLSBatchSize(size int) {
+	messageValidationBLSBatchSize.WithLabelValues().Observe(float64(size))
+}
+
+func (m *metricsReporter) MessageValidationBLSBatchFallback() {
+	messageValidationBLSBatchFallbacks.WithLabelValues().Inc()
+}
+
 // TODO implement
 func (m *metricsReporter) LastBlockProcessed(uint64) {}
 func (m *metricsReporter) LogsProcessingError(error) {}
@@ -359,7 +429,7 @@ func (m *metricsReporter) MessageAccepted(
 	messageValidationResult.WithLabelValues(
 		messageAccepted,
 		"",
-		role.String(),
+		ssvmessage.RoleToString(role),
 		strconv.FormatUint(uint64(round), 10),
 	).Inc()
 }
@@ -372,7 +442,7 @@ func (m *metricsReporter) MessageIgnored(
 	messageValidationResult.WithLabelValues(
 		messageIgnored,
 		reason,
-		role.String(),
+		ssvmessage.RoleToString(role),
 		strconv.FormatUint(uint64(round), 10),
 	).Inc()
 }
@@ -385,7 +455,7 @@ func (m *metricsReporter) MessageRejected(
 	messageValidationResult.WithLabelValues(
 		messageRejected,
 		reason,
-		role.String(),
+		ssvmessage.RoleToString(role),
 		strconv.FormatUint(uint64(round), 10),
 	).Inc()
 }
@@ -442,6 +512,14 @@ func (m *metricsReporter) MessageTimeInQueue(messageID spectypes.MessageID, d ti
 	messageTimeInQueue.WithLabelValues(messageID.String()).Observe(d.Seconds())
 }
 
+func (m *metricsReporter) QueueDepth(role string, depth int) {
+	scheduledQueueDepth.WithLabelValues(role).Set(float64(depth))
+}
+
+func (m *metricsReporter) QueueDroppedMessages(role string, reason string, count int) {
+	scheduledQueueDrops.WithLabelValues(role, reason).Add(float64(count))
+}
+
 func (m *metricsReporter) InCommitteeMessage(msgType spectypes.MsgType, decided bool) {
 	str := "non-decided"
 	if decided {
@@ -475,3 +553,26 @@ func (m *metricsReporter) ResetPeerScores() {
 func (m *metricsReporter) PeerDisconnected(peerId peer.ID) {
 	messagesReceivedFromPeer.DeleteLabelValues(peerId.String())
 }
+
+func (m *metricsReporter) ClusterRunway(owner ethcommon.Address, operatorIDs []uint64, balance float64, blocks uint64, days float64) {
+	operators := formatOperatorIDs(operatorIDs)
+	clusterBalance.WithLabelValues(owner.Hex(), operators).Set(balance)
+	clusterRunwayBlocks.WithLabelValues(owner.Hex(), operators).Set(float64(blocks))
+	clusterRunwayDays.WithLabelValues(owner.Hex(), operators).Set(days)
+}
+
+// ClusterRunwayRemoved deletes the runway of a cluster which the operator no longer participates in, or which was liquidated
+func (m *metricsReporter) ClusterRunwayRemoved(owner ethcommon.Address, operatorIDs []uint64) {
+	operators := formatOperatorIDs(operatorIDs)
+	clusterBalance.DeleteLabelValues(owner.Hex(), operators)
+	clusterRunwayBlocks.DeleteLabelValues(owner.Hex(), operators)
+	clusterRunwayDays.DeleteLabelValues(owner.Hex(), operators)
+}
+
+func formatOperatorIDs(operatorIDs []uint64) string {
+	ids := make([]string, len(operatorIDs))
+	for i, id := range operatorIDs {
+		ids[i] = strconv.FormatUint(id, 10)
+	}
+	return strings.Join(ids, ",")
+}
@@ -5,6 +5,7 @@ import (
 
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
@@ -37,6 +38,10 @@ func (n *nopMetrics) EventProcessingFailed(eventName string)
 func (n *nopMetrics) MessagesReceivedFromPeer(peerId peer.ID)                                       {}
 func (n *nopMetrics) MessagesReceivedTotal()                                                        {}
 func (n *nopMetrics) MessageValidationRSAVerifications()                                            {}
+func (n *nopMetrics) MessageValidationEd25519Verifications()                                        {}
+func (n *nopMetrics) MessageValidationBLSVerifications(count int, batched bool)                     {}
+func (n *nopMetrics) MessageValidationBLSBatchSize(size int)                                        {}
+func (n *nopMetrics) MessageValidationBLSBatchFallback()                                            {}
 func (n *nopMetrics) LastBlockProcessed(block uint64)                                               {}
 func (n *nopMetrics) LogsProcessingError(err error)                                                 {}
 func (n *nopMetrics) MessageAccepted(role spectypes.BeaconRole, round specqbft.Round)               {}
@@ -56,9 +61,14 @@ func (n *nopMetrics) DroppedQueueMessage(messageID spectypes.MessageID)
 func (n *nopMetrics) MessageQueueSize(size int)                                            {}
 func (n *nopMetrics) MessageQueueCapacity(size int)                                        {}
 func (n *nopMetrics) MessageTimeInQueue(messageID spectypes.MessageID, d time.Duration)    {}
+func (n *nopMetrics) QueueDepth(role string, depth int)                                    {}
+func (n *nopMetrics) QueueDroppedMessages(role string, reason string, count int)           {}
 func (n *nopMetrics) InCommitteeMessage(msgType spectypes.MsgType, decided bool)           {}
 func (n *nopMetrics) NonCommitteeMessage(msgType spectypes.MsgType, decided bool)          {}
 func (n *nopMetrics) PeerScore(peerId peer.ID, score float64)                              {}
 func (n *nopMetrics) PeerP4Score(peerId peer.ID, score float64)                            {}
 func (n *nopMetrics) ResetPeerScores()                                                     {}
 func (n *nopMetrics) PeerDisconnected(peerId peer.ID)                                      {}
+func (n *nopMetrics) ClusterRunway(owner ethcommon.Address, operatorIDs []uint64, balance float64, blocks uint64, days float64) {
+}
+func (n *nopMetrics) ClusterRunwayRemoved(owner ethcommon.Address, operatorIDs []uint64) {}
@@ -1,6 +1,8 @@
 package commons
 
 import (
+	"bytes"
+	"crypto/ed25519"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -13,6 +15,7 @@ import (
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/protocol"
 
+	"github.com/bloxapp/ssv/operator/keys"
 	p2pprotocol "github.com/bloxapp/ssv/protocol/v2/p2p"
 )
 
@@ -60,6 +63,67 @@ func DecodeSignedSSVMessage(encoded []byte) ([]byte, spectypes.OperatorID, []byt
 	return message, operatorID, signature, nil
 }
 
+// ed25519SignedMessageMagic prefixes messages signed with operators' Ed25519 keys (version 2 of signed messages),
+// distinguishing them from RSA-signed messages which begin with the signature.
+var ed25519SignedMessageMagic = []byte{'s', 's', 'v', 's', 'i', 'g', 0, 2}
+
+const (
+	ed25519MagicOffset         = 0
+	ed25519OperatorIDOffset    = ed25519MagicOffset + 8
+	ed25519ExpiryOffset        = ed25519OperatorIDOffset + operatorIDSize
+	ed25519PublicKeyOffset     = ed25519ExpiryOffset + 8
+	ed25519CertSignatureOffset = ed25519PublicKeyOffset + ed25519.PublicKeySize
+	ed25519SignatureOffset     = ed25519CertSignatureOffset + signatureSize
+	ed25519MessageOffset       = ed25519SignatureOffset + ed25519.SignatureSize
+)
+
+// EncodeEd25519SignedSSVMessage serializes the message, the certificate of the operator's Ed25519 key and its signature into bytes
+func EncodeEd25519SignedSSVMessage(message []byte, certificate *keys.OperatorKeyCertificate, signature []byte) []byte {
+	b := make([]byte, ed25519MessageOffset+len(message))
+	copy(b[ed25519MagicOffset:], ed25519SignedMessageMagic)
+	binary.LittleEndian.PutUint64(b[ed25519OperatorIDOffset:], certificate.OperatorID)
+	binary.LittleEndian.PutUint64(b[ed25519ExpiryOffset:], certificate.ExpiryEpoch)
+	copy(b[ed25519PublicKeyOffset:ed25519CertSignatureOffset], certificate.PublicKey)
+	copy(b[ed25519CertSignatureOffset:ed25519SignatureOffset], certificate.Signature)
+	copy(b[ed25519SignatureOffset:ed25519MessageOffset], signature)
+	copy(b[ed25519MessageOffset:], message)
+	return b
+}
+
+// IsEd25519SignedSSVMessage returns whether the encoded message is signed with an operator's Ed25519 key
+func IsEd25519SignedSSVMessage(encoded []byte) bool {
+	return bytes.HasPrefix(encoded, ed25519SignedMessageMagic)
+}
+
+// DecodeEd25519SignedSSVMessage deserializes Ed25519-signed message bytes into the message, the certificate and the signature
+func DecodeEd25519SignedSSVMessage(encoded []byte) ([]byte, *keys.OperatorKeyCertificate, []byte, error) {
+	if !IsEd25519SignedSSVMessage(encoded) {
+		return nil, nil, nil, fmt.Errorf("message isn't signed with Ed25519")
+	}
+	if len(encoded) < ed25519MessageOffset {
+		return nil, nil, nil, fmt.Errorf("unexpected encoded message size of %d", len(encoded))
+	}
+
+	certificate := &keys.OperatorKeyCertificate{
+		OperatorID:  binary.LittleEndian.Uint64(encoded[ed25519OperatorIDOffset:ed25519ExpiryOffset]),
+		ExpiryEpoch: binary.LittleEndian.Uint64(encoded[ed25519ExpiryOffset:ed25519PublicKeyOffset]),
+		PublicKey:   ed25519.PublicKey(encoded[ed25519PublicKeyOffset:ed25519CertSignatureOffset]),
+		Signature:   encoded[ed25519CertSignatureOffset:ed25519SignatureOffset],
+	}
+	signature := encoded[ed25519SignatureOffset:ed25519MessageOffset]
+	return encoded[ed25519MessageOffset:], certificate, signature, nil
+}
+
+// DecodeAnySignedSSVMessage returns the message of either an RSA or Ed25519 signed message, without verifying it
+func DecodeAnySignedSSVMessage(encoded []byte) ([]byte, error) {
+	if IsEd25519SignedSSVMessage(encoded) {
+		message, _, _, err := DecodeEd25519SignedSSVMessage(encoded)
+		return message, err
+	}
+	message, _, _, err := DecodeSignedSSVMessage(encoded)
+	return message, err
+}
+
 // SubnetTopicID returns the topic to use for the given subnet
 func SubnetTopicID(subnet int) string {
 	if subnet < 0 {
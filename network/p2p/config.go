@@ -20,6 +20,8 @@ import (
 	"github.com/bloxapp/ssv/monitoring/metricsreporter"
 	"github.com/bloxapp/ssv/network"
 	"github.com/bloxapp/ssv/network/commons"
+	"github.com/bloxapp/ssv/network/discovery"
+	"github.com/bloxapp/ssv/network/peers"
 	"github.com/bloxapp/ssv/networkconfig"
 	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
 	"github.com/bloxapp/ssv/operator/keys"
@@ -48,6 +50,13 @@ type Config struct {
 	MaxPeers         int           `yaml:"MaxPeers" env:"P2P_MAX_PEERS" env-default:"60" env-description:"Connected peers limit for connections"`
 	TopicMaxPeers    int           `yaml:"TopicMaxPeers" env:"P2P_TOPIC_MAX_PEERS" env-default:"10" env-description:"Connected peers limit per pubsub topic"`
 
+	// StaticPeers are peers which we always stay connected to, and TrustedPeers are static peers which are also exempt from bans.
+	StaticPeers  string `yaml:"StaticPeers" env:"P2P_STATIC_PEERS" env-description:"Multiaddrs or ENRs of peers to always stay connected to, seperated with ';'"`
+	TrustedPeers string `yaml:"TrustedPeers" env:"P2P_TRUSTED_PEERS" env-description:"Multiaddrs or ENRs of static peers which are never banned, seperated with ';'"`
+	// BannedPeers and AllowedPeers are static lists of peers which are always refused, or exempt from bans.
+	BannedPeers  string `yaml:"BannedPeers" env:"P2P_BANNED_PEERS" env-description:"Peer IDs to refuse connections with, seperated with ';'"`
+	AllowedPeers string `yaml:"AllowedPeers" env:"P2P_ALLOWED_PEERS" env-description:"Peer IDs which are never banned, seperated with ';'"`
+
 	// Subnets is a static bit list of subnets that this node will register upon start.
 	Subnets string `yaml:"Subnets" env:"SUBNETS" env-description:"Hex string that represents the subnets that this node will join upon start"`
 	// PubSubScoring is a flag to turn on/off pubsub scoring
@@ -68,6 +77,9 @@ type Config struct {
 	Router network.MessageRouter
 	// UserAgent to use by libp2p identify protocol
 	UserAgent string
+	// ExecutionNode and ConsensusNode are the "name/version" of the node's clients, shared in its metadata.
+	ExecutionNode string
+	ConsensusNode string
 	// NodeStorage is used to get operator metadata.
 	NodeStorage storage.Storage
 	// Network defines a network configuration.
@@ -95,6 +107,11 @@ type Config struct {
 
 	// PeerScoreInspectorInterval is the interval at which the PeerScoreInspector is called.
 	PeerScoreInspectorInterval time.Duration
+
+	// PeerReputation configures the reputation of peers, defaults to peers.DefaultReputationConfig.
+	PeerReputation *peers.ReputationConfig
+	// PeerStore persists known peers and bans across restarts, optional.
+	PeerStore peers.PeerStore
 }
 
 // Libp2pOptions creates options list for the libp2p host
@@ -188,6 +205,52 @@ func (c *Config) TransformBootnodes() []string {
 	return append(extraBootnodes, c.Network.Bootnodes...)
 }
 
+// TransformPeerIDs parses a list of peer IDs seperated with ';'
+func TransformPeerIDs(s string) ([]peer.ID, error) {
+	var ids []peer.ID
+	for _, raw := range strings.Split(s, ";") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		id, err := peer.Decode(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode peer ID %q", raw)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// TransformStaticPeers parses a list of multiaddrs or ENRs seperated with ';'
+func TransformStaticPeers(s string) ([]peer.AddrInfo, error) {
+	var infos []peer.AddrInfo
+	for _, raw := range strings.Split(s, ";") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		if strings.HasPrefix(raw, "enr:") {
+			nodes, err := discovery.ParseENR(nil, true, raw)
+			if err != nil {
+				return nil, err
+			}
+			info, err := discovery.ToPeer(nodes[0])
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not convert ENR %q", raw)
+			}
+			infos = append(infos, *info)
+			continue
+		}
+		info, err := peer.AddrInfoFromString(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse multiaddr %q", raw)
+		}
+		infos = append(infos, *info)
+	}
+	return infos, nil
+}
+
 func userAgent(fromCfg string) string {
 	if len(fromCfg) > 0 {
 		return fromCfg
@@ -0,0 +1,28 @@
+package p2pv1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformStaticPeers(t *testing.T) {
+	pids := createTestPeerIDs(t, 1)
+
+	infos, err := TransformStaticPeers("")
+	require.NoError(t, err)
+	require.Empty(t, infos)
+
+	enr := "enr:-Km4QH9oua5xsG_0IN3oxiv5PBb10QXMkMvDeg2IrSSDlRxtONu9hShTmAZm2LjjADQOxGzBxd8VzXYFukmJULzcwrkBh2" +
+		"F0dG5ldHOIAAAAAAAAAACCaWSCdjSCaXCEA2WKt4Jwa4kxZmY3MmY3OQGJc2VjcDI1NmsxoQMN5-_WgtENfdSLAfS3vToaRI7rlrPZ5u" +
+		"ML3-_lQZXLJoN0Y3CCMsiDdWRwgi7g"
+	infos, err = TransformStaticPeers("/ip4/10.0.0.1/tcp/13001/p2p/" + pids[0].String() + ";" + enr)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, pids[0], infos[0].ID)
+	require.Equal(t, "/ip4/10.0.0.1/tcp/13001", infos[0].Addrs[0].String())
+	require.Equal(t, "/ip4/3.101.138.183/tcp/13000", infos[1].Addrs[0].String())
+
+	_, err = TransformStaticPeers("/ip4/10.0.0.1/tcp/13001")
+	require.Error(t, err)
+}
@@ -2,6 +2,7 @@ package p2pv1
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/connmgr"
 	connmgrcore "github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/core/host"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	libp2pdiscbackoff "github.com/libp2p/go-libp2p/p2p/discovery/backoff"
 	"go.uber.org/zap"
@@ -45,6 +47,8 @@ const (
 	peersReportingInterval          = 60 * time.Second
 	peerIdentitiesReportingInterval = 5 * time.Minute
 	topicsReportingInterval         = 180 * time.Second
+	peerStoreInterval               = 5 * time.Minute
+	staticPeersInterval             = 30 * time.Second
 )
 
 // p2pNetwork implements network.P2PNetwork
@@ -76,9 +80,16 @@ type p2pNetwork struct {
 	subnets          []byte
 	libConnManager   connmgrcore.ConnManager
 
+	staticPeers     *peers.StaticPeers
+	bannedPeers     []peer.ID
+	allowedPeers    []peer.ID
+	storedPeers     map[peer.ID]*peers.PeerRecord
+	storedPeersLock sync.Mutex
+
 	nodeStorage             operatorstorage.Storage
 	operatorPKHashToPKCache *hashmap.Map[string, []byte] // used for metrics
 	operatorSigner          keys.OperatorSigner
+	ed25519Signer           *keys.Ed25519Signer
 	operatorDataStore       operatordatastore.OperatorDataStore
 }
 
@@ -101,6 +112,7 @@ func New(logger *zap.Logger, cfg *Config, mr Metrics) network.P2PNetwork {
 		nodeStorage:             cfg.NodeStorage,
 		operatorPKHashToPKCache: hashmap.New[string, []byte](),
 		operatorSigner:          cfg.OperatorSigner,
+		ed25519Signer:           keys.NewEd25519Signer(cfg.OperatorSigner, cfg.Network.Domain),
 		operatorDataStore:       cfg.OperatorDataStore,
 		metrics:                 mr,
 	}
@@ -140,6 +152,9 @@ func (n *p2pNetwork) Close() error {
 	atomic.SwapInt32(&n.state, stateClosing)
 	defer atomic.StoreInt32(&n.state, stateClosed)
 	n.cancel()
+	if err := n.savePeers(n.interfaceLogger); err != nil {
+		n.interfaceLogger.Warn("could not save peers", zap.Error(err))
+	}
 	if err := n.libConnManager.Close(); err != nil {
 		n.interfaceLogger.Warn("could not close discovery", zap.Error(err))
 	}
@@ -169,6 +184,15 @@ func (n *p2pNetwork) Start(logger *zap.Logger) error {
 	go n.startDiscovery(logger)
 
 	async.Interval(n.ctx, connManagerGCInterval, n.peersBalancing(logger))
+	go n.connectStaticPeers(logger)()
+	async.Interval(n.ctx, staticPeersInterval, n.connectStaticPeers(logger))
+	if n.cfg.PeerStore != nil {
+		async.Interval(n.ctx, peerStoreInterval, func() {
+			if err := n.savePeers(logger); err != nil {
+				logger.Warn("could not save peers", zap.Error(err))
+			}
+		})
+	}
 	// don't report metrics in tests
 	if n.cfg.Metrics != nil {
 		async.Interval(n.ctx, peersReportingInterval, n.reportAllPeers(logger))
@@ -196,13 +220,29 @@ func (n *p2pNetwork) peersBalancing(logger *zap.Logger) func() {
 		ctx, cancel := context.WithTimeout(n.ctx, connManagerGCTimeout)
 		defer cancel()
 
-		connMgr := peers.NewConnManager(logger, n.libConnManager, n.idx)
+		connMgr := peers.NewConnManager(logger, n.libConnManager, n.idx, n.idx, n.staticPeers)
 		mySubnets := records.Subnets(n.subnets).Clone()
 		connMgr.TagBestPeers(logger, n.cfg.MaxPeers-1, mySubnets, allPeers, n.cfg.TopicMaxPeers)
 		connMgr.TrimPeers(ctx, logger, n.host.Network())
 	}
 }
 
+// connectStaticPeers returns a function which reconnects to static peers which aren't connected.
+func (n *p2pNetwork) connectStaticPeers(logger *zap.Logger) func() {
+	return func() {
+		for _, ai := range n.staticPeers.AddrInfos() {
+			if n.host.Network().Connectedness(ai.ID) == libp2pnetwork.Connected || n.idx.IsBanned(ai.ID) {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(n.ctx, connectTimeout)
+			if err := n.host.Connect(ctx, ai); err != nil {
+				logger.Debug("could not connect to static peer", fields.PeerID(ai.ID), zap.Error(err))
+			}
+			cancel()
+		}
+	}
+}
+
 // startDiscovery starts the required services
 // it will try to bootstrap discovery service, and inject a connect function.
 // the connect function checks if we can connect to the given peer and if so passing it to the backoff connector.
@@ -213,6 +253,18 @@ func (n *p2pNetwork) startDiscovery(logger *zap.Logger) {
 		defer cancel()
 		n.backoffConnector.Connect(ctx, discoveredPeers)
 	}()
+	// dial the best known peers before bootstrapping discovery,
+	// waiting for the connector to take each of them rather than dropping those which don't fit in its queue.
+	for _, ai := range n.knownPeersToDial() {
+		if !n.idx.CanConnect(ai.ID) {
+			continue
+		}
+		select {
+		case discoveredPeers <- ai:
+		case <-n.ctx.Done():
+			return
+		}
+	}
 	err := tasks.Retry(func() error {
 		return n.disc.Bootstrap(logger, func(e discovery.PeerEvent) {
 			if !n.idx.CanConnect(e.AddrInfo.ID) {
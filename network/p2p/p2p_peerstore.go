@@ -0,0 +1,189 @@
+package p2pv1
+
+import (
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/network/peers"
+	"github.com/bloxapp/ssv/network/records"
+)
+
+const (
+	// storedPeerTTL is how long a peer is remembered after it was last seen, unless it's banned or allowed.
+	storedPeerTTL = 7 * 24 * time.Hour
+	// maxStoredPeers is the maximum number of peers remembered, excluding banned or allowed peers.
+	maxStoredPeers = 1000
+)
+
+// restorePeers loads known peers and bans from the peer store into the peers index,
+// and applies the configured ban and allow lists on top of them.
+func (n *p2pNetwork) restorePeers(logger *zap.Logger) error {
+	n.storedPeersLock.Lock()
+	defer n.storedPeersLock.Unlock()
+
+	n.storedPeers = make(map[peer.ID]*peers.PeerRecord)
+	if n.cfg.PeerStore != nil {
+		stored, err := n.cfg.PeerStore.LoadPeers()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, record := range stored {
+			n.storedPeers[record.ID] = record
+			n.idx.SetReputation(record.ID, peers.Reputation{
+				Score:              record.Score,
+				BannedUntil:        record.BannedUntil,
+				BannedIndefinitely: record.BannedIndefinitely,
+				Allowed:            record.Allowed,
+				Updated:            now,
+			})
+		}
+		logger.Debug("restored stored peers", zap.Int("count", len(stored)))
+	}
+
+	for _, id := range n.bannedPeers {
+		n.idx.Ban(id, 0)
+	}
+	for _, id := range n.allowedPeers {
+		n.idx.Allow(id, true)
+	}
+	return nil
+}
+
+// savePeers persists the known peers and bans into the peer store.
+func (n *p2pNetwork) savePeers(logger *zap.Logger) error {
+	if n.cfg.PeerStore == nil || n.idx == nil {
+		return nil
+	}
+
+	n.storedPeersLock.Lock()
+	defer n.storedPeersLock.Unlock()
+
+	now := time.Now()
+	known := make(map[peer.ID]*peers.PeerRecord, len(n.storedPeers))
+	for id, stored := range n.storedPeers {
+		record := *stored
+		known[id] = &record
+	}
+	recordOf := func(id peer.ID) *peers.PeerRecord {
+		record, ok := known[id]
+		if !ok {
+			record = &peers.PeerRecord{ID: id}
+			known[id] = record
+		}
+		return record
+	}
+
+	for _, id := range n.host.Network().Peers() {
+		record := recordOf(id)
+		record.LastSeen = now
+		record.Subnets = n.idx.GetPeerSubnets(id).String()
+		record.Addrs = record.Addrs[:0]
+		for _, addr := range n.host.Peerstore().Addrs(id) {
+			record.Addrs = append(record.Addrs, addr.String())
+		}
+	}
+	for id, reputation := range n.idx.Reputations() {
+		record := recordOf(id)
+		record.Score = reputation.Score
+		record.BannedUntil = reputation.BannedUntil
+		record.BannedIndefinitely = reputation.BannedIndefinitely
+		record.Allowed = reputation.Allowed
+	}
+	// the configured lists are applied on every start, and so aren't persisted.
+	for _, id := range n.bannedPeers {
+		if record, ok := known[id]; ok {
+			record.BannedIndefinitely = false
+		}
+	}
+	for _, id := range n.allowedPeers {
+		if record, ok := known[id]; ok {
+			record.Allowed = false
+		}
+	}
+
+	var pinned, good []*peers.PeerRecord
+	for id, record := range known {
+		switch {
+		case id == n.host.ID():
+		case record.Allowed || record.BannedIndefinitely || now.Before(record.BannedUntil):
+			pinned = append(pinned, record)
+		case len(record.Addrs) > 0 && now.Sub(record.LastSeen) < storedPeerTTL && !n.idx.IsPoor(id):
+			good = append(good, record)
+		}
+	}
+	sort.Slice(good, func(i, j int) bool {
+		return good[i].LastSeen.After(good[j].LastSeen)
+	})
+	if len(good) > maxStoredPeers {
+		good = good[:maxStoredPeers]
+	}
+	toStore := append(pinned, good...)
+
+	if err := n.cfg.PeerStore.SavePeers(toStore); err != nil {
+		return err
+	}
+	n.storedPeers = make(map[peer.ID]*peers.PeerRecord, len(toStore))
+	for _, record := range toStore {
+		n.storedPeers[record.ID] = record
+	}
+	logger.Debug("saved known peers", zap.Int("count", len(toStore)))
+	return nil
+}
+
+// knownPeersToDial returns the stored peers worth dialing,
+// preferring peers which share more of our subnets and have a better score.
+func (n *p2pNetwork) knownPeersToDial() []peer.AddrInfo {
+	n.storedPeersLock.Lock()
+	defer n.storedPeersLock.Unlock()
+
+	mySubnets := records.Subnets(n.subnets).Clone()
+	type candidate struct {
+		info   peer.AddrInfo
+		shared int
+		score  float64
+	}
+	var candidates []candidate
+	for id, record := range n.storedPeers {
+		if len(record.Addrs) == 0 || n.idx.IsBanned(id) || n.idx.IsPoor(id) {
+			continue
+		}
+		info := peer.AddrInfo{ID: id}
+		for _, raw := range record.Addrs {
+			addr, err := ma.NewMultiaddr(raw)
+			if err != nil {
+				n.interfaceLogger.Debug("could not parse stored peer address", fields.PeerID(id), zap.Error(err))
+				continue
+			}
+			info.Addrs = append(info.Addrs, addr)
+		}
+		if len(info.Addrs) == 0 {
+			continue
+		}
+		subnets, _ := records.Subnets{}.FromString(record.Subnets)
+		candidates = append(candidates, candidate{
+			info:   info,
+			shared: len(records.SharedSubnets(subnets, mySubnets, 0)),
+			score:  record.Score,
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].shared != candidates[j].shared {
+			return candidates[i].shared > candidates[j].shared
+		}
+		return candidates[i].score > candidates[j].score
+	})
+	if len(candidates) > n.cfg.MaxPeers {
+		candidates = candidates[:n.cfg.MaxPeers]
+	}
+	infos := make([]peer.AddrInfo, len(candidates))
+	for i, c := range candidates {
+		infos[i] = c.info
+	}
+	return infos
+}
@@ -0,0 +1,94 @@
+package p2pv1
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/network/commons"
+	"github.com/bloxapp/ssv/network/peers"
+	"github.com/bloxapp/ssv/network/records"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestTransformPeerIDs(t *testing.T) {
+	pids := createTestPeerIDs(t, 2)
+
+	ids, err := TransformPeerIDs("")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	ids, err = TransformPeerIDs(pids[0].String() + "; " + pids[1].String() + ";")
+	require.NoError(t, err)
+	require.Equal(t, pids, ids)
+
+	_, err = TransformPeerIDs("not-a-peer")
+	require.Error(t, err)
+}
+
+func TestRestorePeers(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	pids := createTestPeerIDs(t, 5)
+	store := peers.NewPeerStore(logger, db)
+
+	mySubnets := make(records.Subnets, commons.Subnets())
+	mySubnets[1] = 1
+	mySubnets[2] = 1
+	withSubnets := func(subnets ...int) string {
+		s := make(records.Subnets, commons.Subnets())
+		for _, subnet := range subnets {
+			s[subnet] = 1
+		}
+		return s.String()
+	}
+	addrs := []string{"/ip4/127.0.0.1/tcp/13001"}
+	require.NoError(t, store.SavePeers([]*peers.PeerRecord{
+		{ID: pids[0], Addrs: addrs, Subnets: withSubnets(1), LastSeen: time.Now()},
+		{ID: pids[1], Addrs: addrs, Subnets: withSubnets(1, 2), LastSeen: time.Now()},
+		{ID: pids[2], Addrs: addrs, Subnets: withSubnets(1, 2), LastSeen: time.Now(), BannedUntil: time.Now().Add(time.Hour)},
+		{ID: pids[3], Addrs: addrs, Subnets: withSubnets(1, 2), LastSeen: time.Now()},
+	}))
+
+	n := &p2pNetwork{
+		cfg:             &Config{MaxPeers: 10, PeerStore: store},
+		interfaceLogger: logger,
+		subnets:         mySubnets,
+		bannedPeers:     []peer.ID{pids[3]},
+		allowedPeers:    []peer.ID{pids[4]},
+	}
+	n.idx = peers.NewPeersIndex(zap.NewNop(), nil, nil, n.getMaxPeers, nil, commons.Subnets(), time.Minute, peers.DefaultReputationConfig())
+
+	require.NoError(t, n.restorePeers(logger))
+	require.False(t, n.idx.IsBanned(pids[0]))
+	require.True(t, n.idx.IsBanned(pids[2]))
+	require.True(t, n.idx.IsBanned(pids[3]))
+	require.True(t, n.idx.Reputation(pids[4]).Allowed)
+
+	// banned peers are skipped, and peers sharing more subnets are dialed first.
+	toDial := n.knownPeersToDial()
+	require.Len(t, toDial, 2)
+	require.Equal(t, pids[1], toDial[0].ID)
+	require.Equal(t, pids[0], toDial[1].ID)
+}
+
+func createTestPeerIDs(t *testing.T, n int) []peer.ID {
+	pids := make([]peer.ID, n)
+	for i := range pids {
+		sk, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+		require.NoError(t, err)
+		pids[i], err = peer.IDFromPrivateKey(sk)
+		require.NoError(t, err)
+	}
+	return pids
+}
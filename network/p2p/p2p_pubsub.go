@@ -65,13 +65,23 @@ func (n *p2pNetwork) Broadcast(msg *spectypes.SSVMessage) error {
 		return errors.Wrap(err, "could not decode msg")
 	}
 
-	if n.cfg.Network.Beacon.EstimatedCurrentEpoch() > n.cfg.Network.PermissionlessActivationEpoch {
-		signature, err := n.operatorSigner.Sign(encodedMsg)
-		if err != nil {
-			return err
-		}
+	currentEpoch := n.cfg.Network.Beacon.EstimatedCurrentEpoch()
+	if currentEpoch > n.cfg.Network.PermissionlessActivationEpoch {
+		if n.cfg.Network.SignsWithEd25519(currentEpoch) {
+			certificate, signature, err := n.ed25519Signer.Sign(n.operatorDataStore.GetOperatorID(), uint64(currentEpoch), encodedMsg)
+			if err != nil {
+				return err
+			}
 
-		encodedMsg = commons.EncodeSignedSSVMessage(encodedMsg, n.operatorDataStore.GetOperatorID(), signature)
+			encodedMsg = commons.EncodeEd25519SignedSSVMessage(encodedMsg, certificate, signature)
+		} else {
+			signature, err := n.operatorSigner.Sign(encodedMsg)
+			if err != nil {
+				return err
+			}
+
+			encodedMsg = commons.EncodeSignedSSVMessage(encodedMsg, n.operatorDataStore.GetOperatorID(), signature)
+		}
 	}
 
 	vpk := msg.GetID().GetPubKey()
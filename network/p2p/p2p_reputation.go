@@ -0,0 +1,57 @@
+package p2pv1
+
+import (
+	"context"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/network/peers"
+)
+
+// reputationValidator wraps the message validator of the network,
+// reporting the result of each validation to the reputation of the peer that relayed the message.
+type reputationValidator struct {
+	logger  *zap.Logger
+	network *p2pNetwork
+}
+
+// ValidatorForTopic returns the message validator of the given topic
+func (rv *reputationValidator) ValidatorForTopic(topic string) func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+	validate := rv.network.msgValidator.ValidatorForTopic(topic)
+	return func(ctx context.Context, p peer.ID, pmsg *pubsub.Message) pubsub.ValidationResult {
+		res := validate(ctx, p, pmsg)
+		if p != rv.network.host.ID() {
+			rv.network.reportReputation(rv.logger, p, validationReputationEvent(res))
+		}
+		return res
+	}
+}
+
+// reportReputation reports the given event to the reputation of the given peer,
+// and disconnects the peer if it was banned as a result.
+func (n *p2pNetwork) reportReputation(logger *zap.Logger, id peer.ID, event peers.ReputationEvent) {
+	if n.idx == nil {
+		return
+	}
+	if !n.idx.ReportReputation(id, event) {
+		return
+	}
+	logger.Debug("banning peer due to low reputation", fields.PeerID(id), zap.Stringer("event", event))
+	if err := n.host.Network().ClosePeer(id); err != nil {
+		logger.Debug("could not close banned peer", fields.PeerID(id), zap.Error(err))
+	}
+}
+
+func validationReputationEvent(res pubsub.ValidationResult) peers.ReputationEvent {
+	switch res {
+	case pubsub.ValidationAccept:
+		return peers.ReputationMessageAccepted
+	case pubsub.ValidationReject:
+		return peers.ReputationMessageRejected
+	default:
+		return peers.ReputationMessageIgnored
+	}
+}
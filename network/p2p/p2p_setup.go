@@ -12,6 +12,7 @@ import (
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	libp2pdiscbackoff "github.com/libp2p/go-libp2p/p2p/discovery/backoff"
 	basichost "github.com/libp2p/go-libp2p/p2p/host/basic"
 	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
@@ -104,6 +105,26 @@ func (n *p2pNetwork) initCfg() error {
 	if n.cfg.TopicMaxPeers <= 0 {
 		n.cfg.TopicMaxPeers = minPeersBuffer / 2
 	}
+	bannedPeers, err := TransformPeerIDs(n.cfg.BannedPeers)
+	if err != nil {
+		return fmt.Errorf("parse banned peers: %w", err)
+	}
+	n.bannedPeers = bannedPeers
+	allowedPeers, err := TransformPeerIDs(n.cfg.AllowedPeers)
+	if err != nil {
+		return fmt.Errorf("parse allowed peers: %w", err)
+	}
+	n.allowedPeers = allowedPeers
+	staticPeers, err := TransformStaticPeers(n.cfg.StaticPeers)
+	if err != nil {
+		return fmt.Errorf("parse static peers: %w", err)
+	}
+	trustedPeers, err := TransformStaticPeers(n.cfg.TrustedPeers)
+	if err != nil {
+		return fmt.Errorf("parse trusted peers: %w", err)
+	}
+	n.staticPeers = peers.NewStaticPeers(staticPeers, trustedPeers)
+	n.allowedPeers = append(n.allowedPeers, n.staticPeers.Trusted()...)
 
 	return nil
 }
@@ -121,7 +142,7 @@ func (n *p2pNetwork) SetupHost(logger *zap.Logger) error {
 	if err != nil {
 		return errors.Wrap(err, "could not create resource manager")
 	}
-	n.connGater = connections.NewConnectionGater(logger, n.connectionsAtLimit)
+	n.connGater = connections.NewConnectionGater(logger, n.connectionsAtLimit, n.isPeerBanned, n.staticPeers)
 	opts = append(opts, libp2p.ResourceManager(rmgr), libp2p.ConnectionGater(n.connGater))
 	host, err := libp2p.New(opts...)
 	if err != nil {
@@ -148,6 +169,9 @@ func (n *p2pNetwork) SetupServices(logger *zap.Logger) error {
 	if err := n.setupPeerServices(logger); err != nil {
 		return errors.Wrap(err, "could not setup peer services")
 	}
+	if err := n.restorePeers(logger); err != nil {
+		return errors.Wrap(err, "could not restore peers")
+	}
 	if err := n.setupDiscovery(logger); err != nil {
 		return errors.Wrap(err, "could not setup discovery service")
 	}
@@ -173,14 +197,20 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 	domain := "0x" + hex.EncodeToString(n.cfg.Network.Domain[:])
 	self := records.NewNodeInfo(domain)
 	self.Metadata = &records.NodeMetadata{
-		NodeVersion: commons.GetNodeVersion(),
-		Subnets:     records.Subnets(n.subnets).String(),
+		NodeVersion:   commons.GetNodeVersion(),
+		ExecutionNode: n.cfg.ExecutionNode,
+		ConsensusNode: n.cfg.ConsensusNode,
+		Subnets:       records.Subnets(n.subnets).String(),
 	}
 	getPrivKey := func() crypto.PrivKey {
 		return libPrivKey
 	}
 
-	n.idx = peers.NewPeersIndex(logger, n.host.Network(), self, n.getMaxPeers, getPrivKey, p2pcommons.Subnets(), 10*time.Minute)
+	reputationCfg := peers.DefaultReputationConfig()
+	if n.cfg.PeerReputation != nil {
+		reputationCfg = *n.cfg.PeerReputation
+	}
+	n.idx = peers.NewPeersIndex(logger, n.host.Network(), self, n.getMaxPeers, getPrivKey, p2pcommons.Subnets(), 10*time.Minute, reputationCfg)
 	logger.Debug("peers index is ready")
 
 	var ids identify.IDService
@@ -218,6 +248,7 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 		PeerInfos:       n.idx,
 		ConnIdx:         n.idx,
 		SubnetsIdx:      n.idx,
+		ReputationIdx:   n.idx,
 		IDService:       ids,
 		Network:         n.host.Network(),
 		SubnetsProvider: subnetsProvider,
@@ -228,7 +259,7 @@ func (n *p2pNetwork) setupPeerServices(logger *zap.Logger) error {
 	n.host.SetStreamHandler(peers.NodeInfoProtocol, handshaker.Handler(logger))
 	logger.Debug("handshaker is ready")
 
-	n.connHandler = connections.NewConnHandler(n.ctx, handshaker, subnetsProvider, n.idx, n.idx, n.idx, n.metrics)
+	n.connHandler = connections.NewConnHandler(n.ctx, handshaker, subnetsProvider, n.idx, n.idx, n.idx, n.idx, n.staticPeers, n.metrics)
 	n.host.Network().Notify(n.connHandler.Handle(logger))
 	logger.Debug("connection handler is ready")
 
@@ -280,17 +311,17 @@ func (n *p2pNetwork) setupDiscovery(logger *zap.Logger) error {
 
 func (n *p2pNetwork) setupPubsub(logger *zap.Logger) error {
 	cfg := &topics.PubSubConfig{
-		Host:         n.host,
-		TraceLog:     n.cfg.PubSubTrace,
-		MsgValidator: n.msgValidator,
-		MsgHandler:   n.handlePubsubMessages(logger),
-		ScoreIndex:   n.idx,
+		Host:       n.host,
+		TraceLog:   n.cfg.PubSubTrace,
+		MsgHandler: n.handlePubsubMessages(logger),
+		ScoreIndex: n.idx,
 		//Discovery: n.disc,
 		OutboundQueueSize:   n.cfg.PubsubOutQueueSize,
 		ValidationQueueSize: n.cfg.PubsubValidationQueueSize,
 		ValidateThrottle:    n.cfg.PubsubValidateThrottle,
 		MsgIDCacheTTL:       n.cfg.PubsubMsgCacheTTL,
 		GetValidatorStats:   n.cfg.GetValidatorStats,
+		StaticPeers:         n.staticPeers.AddrInfos(),
 	}
 
 	if n.cfg.PeerScoreInspector != nil && n.cfg.PeerScoreInspectorInterval > 0 {
@@ -302,6 +333,10 @@ func (n *p2pNetwork) setupPubsub(logger *zap.Logger) error {
 		cfg.ScoreIndex = nil
 	}
 
+	if n.msgValidator != nil {
+		cfg.MsgValidator = &reputationValidator{logger: logger, network: n}
+	}
+
 	midHandler := topics.NewMsgIDHandler(n.ctx, time.Minute*2, n.cfg.Network)
 	n.msgResolver = midHandler
 	cfg.MsgIDHandler = midHandler
@@ -325,3 +360,10 @@ func (n *p2pNetwork) connectionsAtLimit() bool {
 	}
 	return n.idx.AtLimit(network.DirOutbound)
 }
+
+func (n *p2pNetwork) isPeerBanned(id peer.ID) bool {
+	if n.idx == nil {
+		return false
+	}
+	return n.idx.IsBanned(id)
+}
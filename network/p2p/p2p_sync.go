@@ -16,6 +16,7 @@ import (
 
 	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/network/commons"
+	ssvpeers "github.com/bloxapp/ssv/network/peers"
 	"github.com/bloxapp/ssv/protocol/v2/message"
 	p2pprotocol "github.com/bloxapp/ssv/protocol/v2/p2p"
 )
@@ -60,6 +61,7 @@ func (n *p2pNetwork) handleStream(logger *zap.Logger, handler p2pprotocol.Reques
 
 		smsg, err := commons.DecodeNetworkMsg(req)
 		if err != nil {
+			n.reportReputation(logger, stream.Conn().RemotePeer(), ssvpeers.ReputationStreamFailed)
 			return errors.Wrap(err, "could not decode msg from stream")
 		}
 
@@ -147,7 +149,7 @@ func (n *p2pNetwork) makeSyncRequest(logger *zap.Logger, peers []peer.ID, mid sp
 		}
 
 		if n.cfg.Network.Beacon.EstimatedCurrentEpoch() > n.cfg.Network.PermissionlessActivationEpoch {
-			decodedMsg, _, _, err := commons.DecodeSignedSSVMessage(raw)
+			decodedMsg, err := commons.DecodeAnySignedSSVMessage(raw)
 			if err != nil {
 				logger.Debug("could not decode signed SSV message", zap.Error(err))
 			} else {
@@ -164,6 +166,7 @@ func (n *p2pNetwork) makeSyncRequest(logger *zap.Logger, peers []peer.ID, mid sp
 		res, err := commons.DecodeNetworkMsg(raw)
 		if err != nil {
 			logger.Debug("could not decode stream response", zap.Error(err))
+			n.reportReputation(logger, pid, ssvpeers.ReputationStreamFailed)
 			continue
 		}
 
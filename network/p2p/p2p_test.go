@@ -18,6 +18,7 @@ import (
 	"github.com/bloxapp/ssv/logging"
 	"github.com/bloxapp/ssv/network/commons"
 	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/operator/keys"
 	"github.com/bloxapp/ssv/protocol/v2/message"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
 
@@ -80,6 +81,45 @@ func TestRSAUsage(t *testing.T) {
 	require.Equal(t, testMessage, decodedMessage)
 }
 
+func TestEd25519Usage(t *testing.T) {
+	operatorKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	testMessage := []byte("message")
+
+	const operatorID = spectypes.OperatorID(0x12345678)
+	domain := networkconfig.TestNetwork.Domain
+	certificate, signature, err := keys.NewEd25519Signer(operatorKey, domain).Sign(operatorID, 100, testMessage)
+	require.NoError(t, err)
+
+	encodedSignedSSVMessage := commons.EncodeEd25519SignedSSVMessage(testMessage, certificate, signature)
+	require.True(t, commons.IsEd25519SignedSSVMessage(encodedSignedSSVMessage))
+
+	decodedMessage, decodedCertificate, decodedSignature, err := commons.DecodeEd25519SignedSSVMessage(encodedSignedSSVMessage)
+	require.NoError(t, err)
+	require.Equal(t, testMessage, decodedMessage)
+	require.Equal(t, certificate, decodedCertificate)
+	require.Equal(t, signature, decodedSignature)
+	require.NoError(t, decodedCertificate.Verify(domain, operatorKey.Public()))
+	require.NoError(t, decodedCertificate.VerifySignature(decodedMessage, decodedSignature))
+
+	// Messages of either signature scheme are decoded without verification.
+	anyMessage, err := commons.DecodeAnySignedSSVMessage(encodedSignedSSVMessage)
+	require.NoError(t, err)
+	require.Equal(t, testMessage, anyMessage)
+
+	rsaSignature, err := operatorKey.Sign(testMessage)
+	require.NoError(t, err)
+	encodedRSASignedSSVMessage := commons.EncodeSignedSSVMessage(testMessage, operatorID, rsaSignature)
+	require.False(t, commons.IsEd25519SignedSSVMessage(encodedRSASignedSSVMessage))
+	anyMessage, err = commons.DecodeAnySignedSSVMessage(encodedRSASignedSSVMessage)
+	require.NoError(t, err)
+	require.Equal(t, testMessage, anyMessage)
+
+	_, _, _, err = commons.DecodeEd25519SignedSSVMessage(encodedSignedSSVMessage[:100])
+	require.ErrorContains(t, err, "unexpected encoded message size")
+}
+
 func TestGetMaxPeers(t *testing.T) {
 	n := &p2pNetwork{
 		cfg: &Config{MaxPeers: 40, TopicMaxPeers: 8},
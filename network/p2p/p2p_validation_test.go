@@ -5,6 +5,7 @@ import (
 	cryptorand "crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"math/rand"
 	"os"
 	"sort"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/bloxapp/ssv/message/validation"
 	"github.com/bloxapp/ssv/network/commons"
+	"github.com/bloxapp/ssv/network/peers"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
 )
 
@@ -334,6 +336,11 @@ func CreateVirtualNet(
 
 		},
 		PeerScoreInspectorInterval: time.Millisecond * 5,
+		// Peers must stay connected for their pubsub scores to be compared.
+		PeerReputation: &peers.ReputationConfig{
+			PruneThreshold: math.Inf(-1),
+			BanThreshold:   math.Inf(-1),
+		},
 	}, validatorPubKeys...)
 
 	require.NoError(t, err)
@@ -179,6 +179,8 @@ func (ln *LocalNet) NewTestP2pNetwork(ctx context.Context, nodeIndex int, keys t
 		cfg.PeerScoreInspectorInterval = options.PeerScoreInspectorInterval
 	}
 
+	cfg.PeerReputation = options.PeerReputation
+
 	cfg.OperatorDataStore = operatordatastore.New(&registrystorage.OperatorData{ID: spectypes.OperatorID(nodeIndex + 1)})
 
 	mr := metricsreporter.New()
@@ -198,6 +200,7 @@ type LocalNetOptions struct {
 	TotalValidators, ActiveValidators, MyValidators int
 	PeerScoreInspector                              func(selfPeer peer.ID, peerMap map[peer.ID]*pubsub.PeerScoreSnapshot)
 	PeerScoreInspectorInterval                      time.Duration
+	PeerReputation                                  *peers.ReputationConfig
 }
 
 // NewLocalNet creates a new mdns network
@@ -27,25 +27,30 @@ type PeerScore float64
 type ConnManager interface {
 	// TagBestPeers tags the best n peers from the given list, based on subnets distribution scores.
 	TagBestPeers(logger *zap.Logger, n int, mySubnets records.Subnets, allPeers []peer.ID, topicMaxPeers int)
-	// TrimPeers will trim unprotected peers.
+	// TrimPeers will trim unprotected peers, and banned peers regardless of protection.
+	// static peers are never trimmed, unless banned.
 	TrimPeers(ctx context.Context, logger *zap.Logger, net libp2pnetwork.Network)
 }
 
 // NewConnManager creates a new conn manager.
 // multiple instances can be created, but concurrency is not supported.
-func NewConnManager(logger *zap.Logger, connMgr connmgrcore.ConnManager, subnetsIdx SubnetsIndex) ConnManager {
+func NewConnManager(logger *zap.Logger, connMgr connmgrcore.ConnManager, subnetsIdx SubnetsIndex, reputationIdx ReputationIndex, staticPeers *StaticPeers) ConnManager {
 	return &connManager{
-		logger:      logger,
-		connManager: connMgr,
-		subnetsIdx:  subnetsIdx,
+		logger:        logger,
+		connManager:   connMgr,
+		subnetsIdx:    subnetsIdx,
+		reputationIdx: reputationIdx,
+		staticPeers:   staticPeers,
 	}
 }
 
 // connManager implements ConnManager
 type connManager struct {
-	logger      *zap.Logger
-	connManager connmgrcore.ConnManager
-	subnetsIdx  SubnetsIndex
+	logger        *zap.Logger
+	connManager   connmgrcore.ConnManager
+	subnetsIdx    SubnetsIndex
+	reputationIdx ReputationIndex
+	staticPeers   *StaticPeers
 }
 
 func (c connManager) TagBestPeers(logger *zap.Logger, n int, mySubnets records.Subnets, allPeers []peer.ID, topicMaxPeers int) {
@@ -72,7 +77,11 @@ func (c connManager) TrimPeers(ctx context.Context, logger *zap.Logger, net libp
 	// TODO: use libp2p's conn manager once ready
 	// c.connManager.TrimOpenConns(ctx)
 	for _, pid := range allPeers {
-		if !c.connManager.IsProtected(pid, protectedTag) {
+		banned := c.reputationIdx.IsBanned(pid)
+		if c.staticPeers.Contains(pid) && !banned {
+			continue
+		}
+		if !c.connManager.IsProtected(pid, protectedTag) || banned {
 			err := net.ClosePeer(pid)
 			logger.Debug("closing peer", zap.String("pid", pid.String()), zap.Error(err))
 			// if err != nil {
@@ -88,8 +97,10 @@ func (c connManager) TrimPeers(ctx context.Context, logger *zap.Logger, net libp
 // getBestPeers loop over all the existing peers and returns the best set
 // according to the number of shared subnets,
 // while considering subnets with low peer count to be more important.
+// peers with poor reputation are never considered to be the best.
 func (c connManager) getBestPeers(n int, mySubnets records.Subnets, allPeers []peer.ID, topicMaxPeers int) map[peer.ID]PeerScore {
 	peerScores := make(map[peer.ID]PeerScore)
+	allPeers = c.reputablePeers(allPeers)
 	if len(allPeers) < n {
 		for _, p := range allPeers {
 			peerScores[p] = 1
@@ -124,6 +135,19 @@ func (c connManager) getBestPeers(n int, mySubnets records.Subnets, allPeers []p
 	return GetTopScores(peerScores, n)
 }
 
+// reputablePeers filters out peers with poor reputation.
+func (c connManager) reputablePeers(allPeers []peer.ID) []peer.ID {
+	reputable := make([]peer.ID, 0, len(allPeers))
+	for _, pid := range allPeers {
+		if c.reputationIdx.IsPoor(pid) || c.reputationIdx.IsBanned(pid) {
+			c.logger.Debug("peer has poor reputation", zap.String("peer", pid.String()))
+			continue
+		}
+		reputable = append(reputable, pid)
+	}
+	return reputable
+}
+
 type peerLog struct {
 	Peer          peer.ID
 	Score         PeerScore
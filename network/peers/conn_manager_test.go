@@ -21,7 +21,7 @@ func TestTagBestPeers(t *testing.T) {
 	allSubs, _ := records.Subnets{}.FromString(records.AllSubnets)
 	si := NewSubnetsIndex(len(allSubs))
 
-	cm := NewConnManager(zap.NewNop(), connMgrMock, si).(*connManager)
+	cm := NewConnManager(zap.NewNop(), connMgrMock, si, NewReputationIndex(DefaultReputationConfig()), nil).(*connManager)
 
 	pids, err := createPeerIDs(50)
 	require.NoError(t, err)
@@ -39,6 +39,30 @@ func TestTagBestPeers(t *testing.T) {
 	require.Equal(t, 20, len(connMgrMock.tags))
 }
 
+func TestBestPeersExcludePoorReputation(t *testing.T) {
+	connMgrMock := newConnMgr()
+
+	allSubs, _ := records.Subnets{}.FromString(records.AllSubnets)
+	si := NewSubnetsIndex(len(allSubs))
+	ri := NewReputationIndex(DefaultReputationConfig())
+
+	cm := NewConnManager(zap.NewNop(), connMgrMock, si, ri, nil).(*connManager)
+
+	pids, err := createPeerIDs(10)
+	require.NoError(t, err)
+	for _, pid := range pids {
+		si.UpdatePeerSubnets(pid, createRandomSubnets(10))
+	}
+	for i := 0; i < 3; i++ {
+		ri.ReportReputation(pids[0], ReputationMessageRejected)
+	}
+	require.True(t, ri.IsPoor(pids[0]))
+
+	best := cm.getBestPeers(20, createRandomSubnets(40), pids, 10)
+	require.Len(t, best, len(pids)-1)
+	require.NotContains(t, best, pids[0])
+}
+
 func createRandomSubnets(n int) records.Subnets {
 	subnets, _ := records.Subnets{}.FromString(records.ZeroSubnets)
 	size := len(subnets)
@@ -13,6 +13,8 @@ import (
 	manet "github.com/multiformats/go-multiaddr/net"
 	leakybucket "github.com/prysmaticlabs/prysm/v4/container/leaky-bucket"
 	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/network/peers"
 )
 
 const (
@@ -27,17 +29,23 @@ const (
 // connGater implements ConnectionGater interface:
 // https://github.com/libp2p/go-libp2p/core/blob/master/connmgr/gater.go
 type connGater struct {
-	logger    *zap.Logger // struct logger to implement connmgr.ConnectionGater
-	atLimit   func() bool
-	ipLimiter *leakybucket.Collector
+	logger      *zap.Logger // struct logger to implement connmgr.ConnectionGater
+	atLimit     func() bool
+	isBanned    func(id peer.ID) bool
+	staticPeers *peers.StaticPeers
+	ipLimiter   *leakybucket.Collector
 }
 
-// NewConnectionGater creates a new instance of ConnectionGater
-func NewConnectionGater(logger *zap.Logger, atLimit func() bool) connmgr.ConnectionGater {
+// NewConnectionGater creates a new instance of ConnectionGater.
+// isBanned is used to refuse connections with peers that were banned due to low reputation,
+// while static peers are exempt from the connection limits.
+func NewConnectionGater(logger *zap.Logger, atLimit func() bool, isBanned func(id peer.ID) bool, staticPeers *peers.StaticPeers) connmgr.ConnectionGater {
 	return &connGater{
-		logger:    logger,
-		atLimit:   atLimit,
-		ipLimiter: leakybucket.NewCollector(ipLimitRate, ipLimitBurst, ipLimitPeriod, true),
+		logger:      logger,
+		atLimit:     atLimit,
+		isBanned:    isBanned,
+		staticPeers: staticPeers,
+		ipLimiter:   leakybucket.NewCollector(ipLimitRate, ipLimitBurst, ipLimitPeriod, true),
 	}
 }
 
@@ -45,7 +53,7 @@ func NewConnectionGater(logger *zap.Logger, atLimit func() bool) connmgr.Connect
 // to the addresses of that peer being available/resolved. Blocking connections
 // at this stage is typical for blacklisting scenarios
 func (n *connGater) InterceptPeerDial(id peer.ID) bool {
-	return true
+	return !n.isBanned(id)
 }
 
 // InterceptAddrDial is called on an imminent outbound dial to a peer on a
@@ -61,6 +69,9 @@ func (n *connGater) InterceptAddrDial(id peer.ID, multiaddr ma.Multiaddr) bool {
 // MUST call this method regardless, for correctness/consistency.
 func (n *connGater) InterceptAccept(multiaddrs libp2pnetwork.ConnMultiaddrs) bool {
 	remoteAddr := multiaddrs.RemoteMultiaddr()
+	if n.staticPeers.ContainsAddr(remoteAddr) {
+		return true
+	}
 	if !n.validateDial(remoteAddr) {
 		// Yield this goroutine to allow others to run in-between connection attempts.
 		runtime.Gosched()
@@ -74,6 +85,10 @@ func (n *connGater) InterceptAccept(multiaddrs libp2pnetwork.ConnMultiaddrs) boo
 // InterceptSecured is called for both inbound and outbound connections,
 // after a security handshake has taken place and we've authenticated the peer.
 func (n *connGater) InterceptSecured(direction libp2pnetwork.Direction, id peer.ID, multiaddrs libp2pnetwork.ConnMultiaddrs) bool {
+	if n.isBanned(id) {
+		n.logger.Debug("connection rejected due to peer ban", zap.String("peer_id", id.String()))
+		return false
+	}
 	return true
 }
 
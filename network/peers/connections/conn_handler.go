@@ -31,6 +31,8 @@ type connHandler struct {
 	subnetsIndex    peers.SubnetsIndex
 	connIdx         peers.ConnectionIndex
 	peerInfos       peers.PeerInfoIndex
+	reputationIdx   peers.ReputationIndex
+	staticPeers     *peers.StaticPeers
 	metrics         Metrics
 }
 
@@ -42,6 +44,8 @@ func NewConnHandler(
 	subnetsIndex peers.SubnetsIndex,
 	connIdx peers.ConnectionIndex,
 	peerInfos peers.PeerInfoIndex,
+	reputationIdx peers.ReputationIndex,
+	staticPeers *peers.StaticPeers,
 	mr Metrics,
 ) ConnHandler {
 	return &connHandler{
@@ -51,6 +55,8 @@ func NewConnHandler(
 		subnetsIndex:    subnetsIndex,
 		connIdx:         connIdx,
 		peerInfos:       peerInfos,
+		reputationIdx:   reputationIdx,
+		staticPeers:     staticPeers,
 		metrics:         mr,
 	}
 }
@@ -135,7 +141,7 @@ func (ch *connHandler) Handle(logger *zap.Logger) *libp2pnetwork.NotifyBundle {
 				}
 			}
 
-			if !ch.sharesEnoughSubnets(logger, conn) {
+			if !ch.staticPeers.Contains(pid) && !ch.sharesEnoughSubnets(logger, conn) {
 				return errors.New("peer doesn't share enough subnets")
 			}
 			return nil
@@ -170,7 +176,7 @@ func (ch *connHandler) Handle(logger *zap.Logger) *libp2pnetwork.NotifyBundle {
 				logger := connLogger(conn)
 				err := acceptConnection(logger, net, conn)
 				if err == nil {
-					if ch.connIdx.AtLimit(conn.Stat().Direction) {
+					if ch.connIdx.AtLimit(conn.Stat().Direction) && !ch.staticPeers.Contains(conn.RemotePeer()) {
 						err = errors.New("reached peers limit")
 					}
 				}
@@ -201,6 +207,7 @@ func (ch *connHandler) Handle(logger *zap.Logger) *libp2pnetwork.NotifyBundle {
 
 			metricsConnections.Dec()
 			ch.peerInfos.SetState(conn.RemotePeer(), peers.StateDisconnected)
+			ch.reputationIdx.Forget(conn.RemotePeer())
 			ch.metrics.PeerDisconnected(conn.RemotePeer())
 
 			logger := connLogger(conn)
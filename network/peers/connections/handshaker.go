@@ -51,6 +51,7 @@ type handshaker struct {
 	peerInfos      peers.PeerInfoIndex
 	connIdx        peers.ConnectionIndex
 	subnetsIdx     peers.SubnetsIndex
+	reputationIdx  peers.ReputationIndex
 	ids            identify.IDService
 	net            libp2pnetwork.Network
 	operatorSigner keys.OperatorSigner
@@ -66,6 +67,7 @@ type HandshakerCfg struct {
 	PeerInfos       peers.PeerInfoIndex
 	ConnIdx         peers.ConnectionIndex
 	SubnetsIdx      peers.SubnetsIndex
+	ReputationIdx   peers.ReputationIndex
 	IDService       identify.IDService
 	OperatorSigner  keys.OperatorSigner
 	SubnetsProvider SubnetsProvider
@@ -80,6 +82,7 @@ func NewHandshaker(ctx context.Context, cfg *HandshakerCfg, filters func() []Han
 		nodeInfos:       cfg.NodeInfos,
 		connIdx:         cfg.ConnIdx,
 		subnetsIdx:      cfg.SubnetsIdx,
+		reputationIdx:   cfg.ReputationIdx,
 		ids:             cfg.IDService,
 		filters:         filters,
 		peerInfos:       cfg.PeerInfos,
@@ -200,6 +203,9 @@ func (h *handshaker) updatePeerInfo(logger *zap.Logger, pid peer.ID, handshakeEr
 		info.LastHandshake = time.Now()
 		info.LastHandshakeError = handshakeErr
 	})
+	if handshakeErr != nil && h.reputationIdx != nil {
+		h.reputationIdx.ReportReputation(pid, peers.ReputationHandshakeFailed)
+	}
 }
 
 // updateNodeSubnets tries to update the subnets of the given peer
@@ -51,6 +51,21 @@ func (m NodeStorage) GetLastProcessedBlock(txn basedb.Reader) (*big.Int, bool, e
 	panic("implement me")
 }
 
+func (m NodeStorage) SaveBlockJournal(rw basedb.ReadWriter, journal *storage.BlockJournal) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m NodeStorage) ListBlockJournals(r basedb.Reader) ([]*storage.BlockJournal, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m NodeStorage) DeleteBlockJournal(rw basedb.ReadWriter, blockNumber uint64) error {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (m NodeStorage) DropRegistryData() error {
 	//TODO implement me
 	panic("implement me")
@@ -81,6 +96,11 @@ func (m NodeStorage) SaveOperatorData(txn basedb.ReadWriter, operatorData *regis
 	panic("implement me")
 }
 
+func (m NodeStorage) UpdateOperatorData(txn basedb.ReadWriter, operatorData *registrystorage.OperatorData) error {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (m NodeStorage) DeleteOperatorData(txn basedb.ReadWriter, id spectypes.OperatorID) error {
 	//TODO implement me
 	panic("implement me")
@@ -106,6 +126,11 @@ func (m NodeStorage) GetRecipientDataMany(txn basedb.Reader, owners []common.Add
 	panic("implement me")
 }
 
+func (m NodeStorage) ListRecipients(txn basedb.Reader) ([]*registrystorage.RecipientData, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (m NodeStorage) SaveRecipientData(txn basedb.ReadWriter, recipientData *registrystorage.RecipientData) (*registrystorage.RecipientData, error) {
 	//TODO implement me
 	panic("implement me")
@@ -126,6 +151,16 @@ func (m NodeStorage) Shares() registrystorage.Shares {
 	panic("implement me")
 }
 
+func (m NodeStorage) SignedExits() registrystorage.SignedExits {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m NodeStorage) Clusters() registrystorage.Clusters {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (m NodeStorage) DropOperators() error {
 	//TODO implement me
 	panic("implement me")
@@ -149,7 +184,7 @@ func (m NodeStorage) GetPrivateKeyHash() (string, bool, error) {
 	}
 }
 
-func (m NodeStorage) SavePrivateKeyHash(privKeyHash string) error {
+func (m NodeStorage) SavePrivateKeyHash(rw basedb.ReadWriter, privKeyHash string) error {
 	//TODO implement me
 	panic("implement me")
 }
@@ -120,5 +120,6 @@ type Index interface {
 	PeerInfoIndex
 	ScoreIndex
 	SubnetsIndex
+	ReputationIndex
 	io.Closer
 }
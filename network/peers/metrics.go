@@ -20,6 +20,14 @@ var (
 		Name: "ssv:network:subnets:my",
 		Help: "Marks subnets that this node is interested in",
 	}, []string{"subnet"})
+	metricsReputationEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:network:peers:reputation_events",
+		Help: "Counts reported peer reputation events",
+	}, []string{"event"})
+	metricsBannedPeers = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:network:peers:banned",
+		Help: "Counts peers banned due to low reputation",
+	})
 )
 
 func init() {
@@ -33,4 +41,10 @@ func init() {
 	if err := prometheus.Register(metricsMySubnets); err != nil {
 		logger.Debug("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsReputationEvents); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsBannedPeers); err != nil {
+		logger.Debug("could not register prometheus collector")
+	}
 }
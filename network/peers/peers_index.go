@@ -30,6 +30,7 @@ type peersIndex struct {
 	scoreIdx ScoreIndex
 	SubnetsIndex
 	PeerInfoIndex
+	ReputationIndex
 
 	selfLock *sync.RWMutex
 	self     *records.NodeInfo
@@ -39,16 +40,17 @@ type peersIndex struct {
 
 // NewPeersIndex creates a new Index
 func NewPeersIndex(logger *zap.Logger, network libp2pnetwork.Network, self *records.NodeInfo, maxPeers MaxPeersProvider,
-	netKeyProvider NetworkKeyProvider, subnetsCount int, pruneTTL time.Duration) *peersIndex {
+	netKeyProvider NetworkKeyProvider, subnetsCount int, pruneTTL time.Duration, reputationCfg ReputationConfig) *peersIndex {
 	return &peersIndex{
-		network:        network,
-		scoreIdx:       newScoreIndex(),
-		SubnetsIndex:   NewSubnetsIndex(subnetsCount),
-		PeerInfoIndex:  NewPeerInfoIndex(),
-		self:           self,
-		selfLock:       &sync.RWMutex{},
-		maxPeers:       maxPeers,
-		netKeyProvider: netKeyProvider,
+		network:         network,
+		scoreIdx:        newScoreIndex(),
+		SubnetsIndex:    NewSubnetsIndex(subnetsCount),
+		PeerInfoIndex:   NewPeerInfoIndex(),
+		ReputationIndex: NewReputationIndex(reputationCfg),
+		self:            self,
+		selfLock:        &sync.RWMutex{},
+		maxPeers:        maxPeers,
+		netKeyProvider:  netKeyProvider,
 	}
 }
 
@@ -56,7 +58,12 @@ func NewPeersIndex(logger *zap.Logger, network libp2pnetwork.Network, self *reco
 // a peer is considered to be bad if one of the following applies:
 // - pruned (that was not expired)
 // - bad score
+// - banned due to low reputation
 func (pi *peersIndex) IsBad(logger *zap.Logger, id peer.ID) bool {
+	if pi.IsBanned(id) {
+		logger.Debug("bad peer (banned)")
+		return true
+	}
 	// TODO: check scores
 	threshold := -10000.0
 	scores, err := pi.GetScore(id, "")
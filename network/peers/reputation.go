@@ -0,0 +1,331 @@
+package peers
+
+import (
+	"hash/maphash"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ReputationEvent is an observed behavior of a peer which affects its reputation.
+type ReputationEvent int
+
+const (
+	// ReputationMessageAccepted is reported when a message relayed by the peer passed validation.
+	ReputationMessageAccepted ReputationEvent = iota
+	// ReputationMessageIgnored is reported when a message relayed by the peer was ignored by validation.
+	ReputationMessageIgnored
+	// ReputationMessageRejected is reported when a message relayed by the peer was rejected by validation.
+	ReputationMessageRejected
+	// ReputationHandshakeFailed is reported when a handshake with the peer failed.
+	ReputationHandshakeFailed
+	// ReputationStreamFailed is reported when the peer misbehaved on a stream protocol,
+	// e.g. by sending a malformed request or response.
+	ReputationStreamFailed
+)
+
+func (e ReputationEvent) String() string {
+	switch e {
+	case ReputationMessageAccepted:
+		return "message_accepted"
+	case ReputationMessageIgnored:
+		return "message_ignored"
+	case ReputationMessageRejected:
+		return "message_rejected"
+	case ReputationHandshakeFailed:
+		return "handshake_failed"
+	case ReputationStreamFailed:
+		return "stream_failed"
+	default:
+		return "unknown"
+	}
+}
+
+// ReputationConfig controls how peer reputations are scored.
+type ReputationConfig struct {
+	// Weights is the score change of each event.
+	Weights map[ReputationEvent]float64
+	// MaxScore caps the score, so that well-behaved peers can't build up unlimited credit.
+	MaxScore float64
+	// HalfLife is the time in which a score decays halfway back to zero.
+	HalfLife time.Duration
+	// PruneThreshold is the score under which a peer is pruned when trimming connections.
+	PruneThreshold float64
+	// BanThreshold is the score under which a peer is banned.
+	BanThreshold float64
+	// BanDuration is how long a banned peer is refused connections.
+	BanDuration time.Duration
+}
+
+// DefaultReputationConfig returns the default ReputationConfig.
+func DefaultReputationConfig() ReputationConfig {
+	return ReputationConfig{
+		Weights: map[ReputationEvent]float64{
+			ReputationMessageAccepted: 0.1,
+			ReputationMessageIgnored:  0,
+			ReputationMessageRejected: -10,
+			ReputationHandshakeFailed: -20,
+			ReputationStreamFailed:    -10,
+		},
+		MaxScore:       20,
+		HalfLife:       10 * time.Minute,
+		PruneThreshold: -20,
+		BanThreshold:   -100,
+		BanDuration:    time.Hour,
+	}
+}
+
+// Reputation is a snapshot of a peer's reputation.
+type Reputation struct {
+	// Score is the decayed sum of the weights of the peer's events.
+	Score float64
+	// Events counts the events reported for the peer.
+	Events map[ReputationEvent]uint64
+	// BannedUntil is the time until which the peer is banned, if it was ever banned.
+	BannedUntil time.Time
+	// BannedIndefinitely is set for peers which are banned until explicitly unbanned.
+	BannedIndefinitely bool
+	// Allowed is set for peers which are exempt from bans and pruning.
+	Allowed bool
+	// Updated is the time the score was last updated at.
+	Updated time.Time
+}
+
+// Banned returns whether the peer is banned at the given time.
+func (r Reputation) Banned(now time.Time) bool {
+	if r.Allowed {
+		return false
+	}
+	return r.BannedIndefinitely || now.Before(r.BannedUntil)
+}
+
+// ReputationIndex is an interface for tracking the reputation of peers across connections.
+type ReputationIndex interface {
+	// ReportReputation records an event of the given peer,
+	// and returns true if the event caused the peer to be banned.
+	ReportReputation(id peer.ID, event ReputationEvent) bool
+	// Reputation returns the current reputation of the given peer.
+	Reputation(id peer.ID) Reputation
+	// IsBanned returns whether the given peer is banned.
+	IsBanned(id peer.ID) bool
+	// IsPoor returns whether the given peer's score is low enough for it to be pruned.
+	IsPoor(id peer.ID) bool
+	// Ban bans the given peer for the given duration, or until unbanned if the duration is zero.
+	Ban(id peer.ID, duration time.Duration)
+	// Unban lifts the ban of the given peer and resets its score.
+	Unban(id peer.ID)
+	// Allow exempts the given peer from bans and pruning, or revokes the exemption.
+	Allow(id peer.ID, allowed bool)
+	// Reputations returns the reputations of all known peers.
+	Reputations() map[peer.ID]Reputation
+	// SetReputation restores a previously known reputation of the given peer.
+	SetReputation(id peer.ID, reputation Reputation)
+	// Forget drops the reputation of the given peer once it's disconnected, unless it's banned, allowed
+	// or poor, which must outlive its connections so that it can't reset them by reconnecting.
+	Forget(id peer.ID)
+}
+
+// reputationShards is the number of shards of the reputation index,
+// so that reports of different peers don't contend on the same lock.
+const reputationShards = 64
+
+// reputationIndex implements ReputationIndex
+type reputationIndex struct {
+	cfg    ReputationConfig
+	now    func() time.Time
+	seed   maphash.Seed
+	shards [reputationShards]reputationShard
+}
+
+type reputationShard struct {
+	reputations map[peer.ID]*Reputation
+	lock        sync.RWMutex
+}
+
+// NewReputationIndex creates a new ReputationIndex
+func NewReputationIndex(cfg ReputationConfig) ReputationIndex {
+	return newReputationIndex(cfg, time.Now)
+}
+
+func newReputationIndex(cfg ReputationConfig, now func() time.Time) *reputationIndex {
+	ri := &reputationIndex{
+		cfg:  cfg,
+		now:  now,
+		seed: maphash.MakeSeed(),
+	}
+	for i := range ri.shards {
+		ri.shards[i].reputations = make(map[peer.ID]*Reputation)
+	}
+	return ri
+}
+
+// shard returns the shard holding the reputation of the given peer.
+func (ri *reputationIndex) shard(id peer.ID) *reputationShard {
+	return &ri.shards[maphash.String(ri.seed, string(id))%reputationShards]
+}
+
+func (ri *reputationIndex) ReportReputation(id peer.ID, event ReputationEvent) bool {
+	shard := ri.shard(id)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	now := ri.now()
+	r := shard.reputation(id, now)
+	r.Score = math.Min(ri.decay(r, now)+ri.cfg.Weights[event], ri.cfg.MaxScore)
+	r.Updated = now
+	r.Events[event]++
+	metricsReputationEvents.WithLabelValues(event.String()).Inc()
+
+	if r.Score < ri.cfg.BanThreshold && !r.Banned(now) && !r.Allowed {
+		r.BannedUntil = now.Add(ri.cfg.BanDuration)
+		metricsBannedPeers.Inc()
+		return true
+	}
+	return false
+}
+
+func (ri *reputationIndex) Reputation(id peer.ID) Reputation {
+	shard := ri.shard(id)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+
+	r, ok := shard.reputations[id]
+	if !ok {
+		return Reputation{}
+	}
+	return ri.snapshot(r, ri.now())
+}
+
+func (ri *reputationIndex) IsBanned(id peer.ID) bool {
+	shard := ri.shard(id)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+
+	r, ok := shard.reputations[id]
+	return ok && r.Banned(ri.now())
+}
+
+func (ri *reputationIndex) IsPoor(id peer.ID) bool {
+	shard := ri.shard(id)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+
+	r, ok := shard.reputations[id]
+	return ok && !r.Allowed && ri.decay(r, ri.now()) < ri.cfg.PruneThreshold
+}
+
+func (ri *reputationIndex) Ban(id peer.ID, duration time.Duration) {
+	shard := ri.shard(id)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	now := ri.now()
+	r := shard.reputation(id, now)
+	if duration == 0 {
+		r.BannedIndefinitely = true
+	} else {
+		r.BannedUntil = now.Add(duration)
+	}
+	metricsBannedPeers.Inc()
+}
+
+func (ri *reputationIndex) Unban(id peer.ID) {
+	shard := ri.shard(id)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	r, ok := shard.reputations[id]
+	if !ok {
+		return
+	}
+	r.Score = 0
+	r.BannedUntil = time.Time{}
+	r.BannedIndefinitely = false
+	r.Updated = ri.now()
+}
+
+func (ri *reputationIndex) Allow(id peer.ID, allowed bool) {
+	shard := ri.shard(id)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	shard.reputation(id, ri.now()).Allowed = allowed
+}
+
+func (ri *reputationIndex) Reputations() map[peer.ID]Reputation {
+	now := ri.now()
+	reputations := make(map[peer.ID]Reputation)
+	for i := range ri.shards {
+		shard := &ri.shards[i]
+		shard.lock.RLock()
+		for id, r := range shard.reputations {
+			reputations[id] = ri.snapshot(r, now)
+		}
+		shard.lock.RUnlock()
+	}
+	return reputations
+}
+
+func (ri *reputationIndex) SetReputation(id peer.ID, reputation Reputation) {
+	shard := ri.shard(id)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	events := make(map[ReputationEvent]uint64, len(reputation.Events))
+	for event, count := range reputation.Events {
+		events[event] = count
+	}
+	reputation.Events = events
+	shard.reputations[id] = &reputation
+}
+
+func (ri *reputationIndex) Forget(id peer.ID) {
+	shard := ri.shard(id)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	r, ok := shard.reputations[id]
+	if !ok {
+		return
+	}
+	now := ri.now()
+	if r.Allowed || r.Banned(now) || ri.decay(r, now) < ri.cfg.PruneThreshold {
+		return
+	}
+	delete(shard.reputations, id)
+}
+
+// reputation returns the reputation of the given peer, creating it if it doesn't exist.
+func (s *reputationShard) reputation(id peer.ID, now time.Time) *Reputation {
+	r, ok := s.reputations[id]
+	if !ok {
+		r = &Reputation{Events: make(map[ReputationEvent]uint64), Updated: now}
+		s.reputations[id] = r
+	}
+	return r
+}
+
+// snapshot returns a copy of the given reputation, with its score decayed to the given time.
+func (ri *reputationIndex) snapshot(r *Reputation, now time.Time) Reputation {
+	snapshot := *r
+	snapshot.Score = ri.decay(r, now)
+	snapshot.Events = make(map[ReputationEvent]uint64, len(r.Events))
+	for event, count := range r.Events {
+		snapshot.Events[event] = count
+	}
+	return snapshot
+}
+
+// decay returns the score of the given reputation at the given time.
+func (ri *reputationIndex) decay(r *Reputation, now time.Time) float64 {
+	if ri.cfg.HalfLife <= 0 {
+		return r.Score
+	}
+	elapsed := now.Sub(r.Updated)
+	if elapsed <= 0 {
+		return r.Score
+	}
+	return r.Score * math.Pow(0.5, float64(elapsed)/float64(ri.cfg.HalfLife))
+}
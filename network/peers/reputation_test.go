@@ -0,0 +1,145 @@
+package peers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReputationIndex(t *testing.T) {
+	pids, err := createPeerIDs(2)
+	require.NoError(t, err)
+
+	now := time.Now()
+	ri := newReputationIndex(DefaultReputationConfig(), func() time.Time { return now })
+
+	t.Run("unknown peer", func(t *testing.T) {
+		require.Equal(t, Reputation{}, ri.Reputation(pids[1]))
+		require.False(t, ri.IsBanned(pids[1]))
+		require.False(t, ri.IsPoor(pids[1]))
+	})
+
+	t.Run("score is capped", func(t *testing.T) {
+		for i := 0; i < 1000; i++ {
+			require.False(t, ri.ReportReputation(pids[1], ReputationMessageAccepted))
+		}
+		reputation := ri.Reputation(pids[1])
+		require.Equal(t, 20.0, reputation.Score)
+		require.Equal(t, uint64(1000), reputation.Events[ReputationMessageAccepted])
+	})
+
+	t.Run("poor and banned", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			require.False(t, ri.ReportReputation(pids[0], ReputationMessageRejected))
+		}
+		require.True(t, ri.IsPoor(pids[0]))
+		require.False(t, ri.IsBanned(pids[0]))
+
+		for i := 0; i < 7; i++ {
+			require.False(t, ri.ReportReputation(pids[0], ReputationStreamFailed))
+		}
+		require.True(t, ri.ReportReputation(pids[0], ReputationHandshakeFailed))
+		require.True(t, ri.IsBanned(pids[0]))
+
+		// further events don't ban the peer again
+		require.False(t, ri.ReportReputation(pids[0], ReputationMessageRejected))
+
+		reputation := ri.Reputation(pids[0])
+		require.Equal(t, -130.0, reputation.Score)
+		require.Equal(t, now.Add(time.Hour), reputation.BannedUntil)
+		require.Equal(t, uint64(4), reputation.Events[ReputationMessageRejected])
+		require.Equal(t, uint64(7), reputation.Events[ReputationStreamFailed])
+		require.Equal(t, uint64(1), reputation.Events[ReputationHandshakeFailed])
+	})
+
+	t.Run("score decays and ban expires", func(t *testing.T) {
+		now = now.Add(10 * time.Minute)
+		require.InDelta(t, -65.0, ri.Reputation(pids[0]).Score, 0.001)
+		require.True(t, ri.IsBanned(pids[0]))
+
+		now = now.Add(time.Hour)
+		require.False(t, ri.IsBanned(pids[0]))
+		require.False(t, ri.IsPoor(pids[0]))
+	})
+}
+
+func TestReputationIndexBans(t *testing.T) {
+	pids, err := createPeerIDs(1)
+	require.NoError(t, err)
+	pid := pids[0]
+
+	now := time.Now()
+	ri := newReputationIndex(DefaultReputationConfig(), func() time.Time { return now })
+
+	t.Run("indefinite ban", func(t *testing.T) {
+		ri.Ban(pid, 0)
+		now = now.Add(365 * 24 * time.Hour)
+		require.True(t, ri.IsBanned(pid))
+
+		ri.Unban(pid)
+		require.False(t, ri.IsBanned(pid))
+	})
+
+	t.Run("temporary ban", func(t *testing.T) {
+		ri.Ban(pid, time.Minute)
+		require.True(t, ri.IsBanned(pid))
+		now = now.Add(time.Minute)
+		require.False(t, ri.IsBanned(pid))
+	})
+
+	t.Run("allowed peers are never banned", func(t *testing.T) {
+		ri.Allow(pid, true)
+		for i := 0; i < 20; i++ {
+			require.False(t, ri.ReportReputation(pid, ReputationMessageRejected))
+		}
+		ri.Ban(pid, 0)
+		require.False(t, ri.IsBanned(pid))
+		require.False(t, ri.IsPoor(pid))
+
+		ri.Allow(pid, false)
+		require.True(t, ri.IsBanned(pid))
+		require.True(t, ri.IsPoor(pid))
+	})
+
+	t.Run("set reputation", func(t *testing.T) {
+		reputations := ri.Reputations()
+		require.Len(t, reputations, 1)
+
+		restored := newReputationIndex(DefaultReputationConfig(), func() time.Time { return now })
+		restored.SetReputation(pid, reputations[pid])
+		require.Equal(t, reputations[pid], restored.Reputation(pid))
+		require.True(t, restored.IsBanned(pid))
+	})
+}
+
+func TestReputationIndexForget(t *testing.T) {
+	pids, err := createPeerIDs(4)
+	require.NoError(t, err)
+
+	now := time.Now()
+	ri := newReputationIndex(DefaultReputationConfig(), func() time.Time { return now })
+
+	// A well-behaved peer and a slightly penalized one are forgotten once disconnected.
+	require.False(t, ri.ReportReputation(pids[0], ReputationMessageAccepted))
+	require.False(t, ri.ReportReputation(pids[1], ReputationMessageRejected))
+	// A poor peer, and a banned one, are remembered so that reconnecting doesn't reset them.
+	for i := 0; i < 3; i++ {
+		require.False(t, ri.ReportReputation(pids[2], ReputationMessageRejected))
+	}
+	ri.Ban(pids[3], time.Minute)
+	for _, pid := range pids {
+		ri.Forget(pid)
+	}
+
+	reputations := ri.Reputations()
+	require.Len(t, reputations, 2)
+	require.Contains(t, reputations, pids[2])
+	require.Contains(t, reputations, pids[3])
+
+	// Once their score decays and the ban expires, they're forgotten too.
+	now = now.Add(2 * time.Hour)
+	ri.Forget(pids[2])
+	ri.Forget(pids[3])
+	require.Empty(t, ri.Reputations())
+}
@@ -0,0 +1,101 @@
+package peers
+
+import (
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// StaticPeers is the set of peers which we always stay connected to,
+// such as our own sentry nodes or partner operators.
+// static peers are reconnected when lost, are never pruned and are exempt from connection limits.
+// a nil StaticPeers is an empty set.
+type StaticPeers struct {
+	peers   map[peer.ID]peer.AddrInfo
+	trusted map[peer.ID]struct{}
+	ips     map[string]struct{}
+}
+
+// NewStaticPeers creates a new StaticPeers, where trusted peers are also exempt from reputation bans
+func NewStaticPeers(static, trusted []peer.AddrInfo) *StaticPeers {
+	sp := &StaticPeers{
+		peers:   make(map[peer.ID]peer.AddrInfo),
+		trusted: make(map[peer.ID]struct{}),
+		ips:     make(map[string]struct{}),
+	}
+	add := func(ai peer.AddrInfo) {
+		existing := sp.peers[ai.ID]
+		existing.ID = ai.ID
+		existing.Addrs = append(existing.Addrs, ai.Addrs...)
+		sp.peers[ai.ID] = existing
+		for _, addr := range ai.Addrs {
+			if ip, err := manet.ToIP(addr); err == nil {
+				sp.ips[ip.String()] = struct{}{}
+			}
+		}
+	}
+	for _, ai := range static {
+		add(ai)
+	}
+	for _, ai := range trusted {
+		add(ai)
+		sp.trusted[ai.ID] = struct{}{}
+	}
+	return sp
+}
+
+// Contains returns whether the given peer is a static peer
+func (sp *StaticPeers) Contains(id peer.ID) bool {
+	if sp == nil {
+		return false
+	}
+	_, ok := sp.peers[id]
+	return ok
+}
+
+// IsTrusted returns whether the given peer is a trusted static peer
+func (sp *StaticPeers) IsTrusted(id peer.ID) bool {
+	if sp == nil {
+		return false
+	}
+	_, ok := sp.trusted[id]
+	return ok
+}
+
+// ContainsAddr returns whether the given address has the IP of a static peer,
+// used to recognize static peers before their peer ID is known.
+func (sp *StaticPeers) ContainsAddr(addr ma.Multiaddr) bool {
+	if sp == nil {
+		return false
+	}
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+	_, ok := sp.ips[ip.String()]
+	return ok
+}
+
+// AddrInfos returns the addresses of all static peers
+func (sp *StaticPeers) AddrInfos() []peer.AddrInfo {
+	if sp == nil {
+		return nil
+	}
+	infos := make([]peer.AddrInfo, 0, len(sp.peers))
+	for _, ai := range sp.peers {
+		infos = append(infos, ai)
+	}
+	return infos
+}
+
+// Trusted returns the IDs of trusted peers
+func (sp *StaticPeers) Trusted() []peer.ID {
+	if sp == nil {
+		return nil
+	}
+	ids := make([]peer.ID, 0, len(sp.trusted))
+	for id := range sp.trusted {
+		ids = append(ids, id)
+	}
+	return ids
+}
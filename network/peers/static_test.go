@@ -0,0 +1,36 @@
+package peers
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticPeers(t *testing.T) {
+	pids, err := createPeerIDs(3)
+	require.NoError(t, err)
+
+	static := peer.AddrInfo{ID: pids[0], Addrs: []ma.Multiaddr{ma.StringCast("/ip4/10.0.0.1/tcp/13001")}}
+	trusted := peer.AddrInfo{ID: pids[1], Addrs: []ma.Multiaddr{ma.StringCast("/ip4/10.0.0.2/tcp/13001")}}
+	sp := NewStaticPeers([]peer.AddrInfo{static}, []peer.AddrInfo{trusted})
+
+	require.True(t, sp.Contains(pids[0]))
+	require.True(t, sp.Contains(pids[1]))
+	require.False(t, sp.Contains(pids[2]))
+
+	require.False(t, sp.IsTrusted(pids[0]))
+	require.True(t, sp.IsTrusted(pids[1]))
+	require.Equal(t, []peer.ID{pids[1]}, sp.Trusted())
+
+	require.True(t, sp.ContainsAddr(ma.StringCast("/ip4/10.0.0.1/tcp/40000")))
+	require.False(t, sp.ContainsAddr(ma.StringCast("/ip4/10.0.0.3/tcp/13001")))
+
+	require.ElementsMatch(t, []peer.AddrInfo{static, trusted}, sp.AddrInfos())
+
+	var empty *StaticPeers
+	require.False(t, empty.Contains(pids[0]))
+	require.False(t, empty.ContainsAddr(ma.StringCast("/ip4/10.0.0.1/tcp/13001")))
+	require.Empty(t, empty.AddrInfos())
+}
@@ -0,0 +1,100 @@
+package peers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var (
+	peerStorePrefix = []byte("p2p_peers")
+)
+
+// PeerRecord is the persisted state of a known peer.
+type PeerRecord struct {
+	ID                 peer.ID   `json:"id"`
+	Addrs              []string  `json:"addrs"`
+	Subnets            string    `json:"subnets"`
+	LastSeen           time.Time `json:"last_seen"`
+	Score              float64   `json:"score"`
+	BannedUntil        time.Time `json:"banned_until"`
+	BannedIndefinitely bool      `json:"banned_indefinitely"`
+	Allowed            bool      `json:"allowed"`
+}
+
+// PeerStore persists known peers and bans, so they outlive restarts.
+type PeerStore interface {
+	// SavePeers replaces the stored peers with the given records.
+	SavePeers(records []*PeerRecord) error
+	// LoadPeers returns the stored peers.
+	LoadPeers() ([]*PeerRecord, error)
+}
+
+type peerStore struct {
+	logger *zap.Logger
+	db     basedb.Database
+}
+
+// NewPeerStore creates a new PeerStore
+func NewPeerStore(logger *zap.Logger, db basedb.Database) PeerStore {
+	return &peerStore{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (s *peerStore) SavePeers(records []*PeerRecord) error {
+	return s.db.Update(func(txn basedb.Txn) error {
+		keep := make(map[string]struct{}, len(records))
+		for _, record := range records {
+			keep[string(record.ID)] = struct{}{}
+		}
+		var stale [][]byte
+		err := txn.GetAll(peerStorePrefix, func(i int, obj basedb.Obj) error {
+			if _, ok := keep[string(obj.Key)]; !ok {
+				stale = append(stale, obj.Key)
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not read stored peers")
+		}
+		for _, key := range stale {
+			if err := txn.Delete(peerStorePrefix, key); err != nil {
+				return errors.Wrap(err, "could not delete stale peer")
+			}
+		}
+		for _, record := range records {
+			data, err := json.Marshal(record)
+			if err != nil {
+				return errors.Wrap(err, "could not marshal peer")
+			}
+			if err := txn.Set(peerStorePrefix, []byte(record.ID), data); err != nil {
+				return errors.Wrap(err, "could not save peer")
+			}
+		}
+		return nil
+	})
+}
+
+func (s *peerStore) LoadPeers() ([]*PeerRecord, error) {
+	var records []*PeerRecord
+	err := s.db.GetAll(peerStorePrefix, func(i int, obj basedb.Obj) error {
+		var record PeerRecord
+		if err := json.Unmarshal(obj.Value, &record); err != nil {
+			s.logger.Debug("could not unmarshal stored peer", zap.Error(err))
+			return nil
+		}
+		records = append(records, &record)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load peers")
+	}
+	return records, nil
+}
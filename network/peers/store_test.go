@@ -0,0 +1,46 @@
+package peers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestPeerStore(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	pids, err := createPeerIDs(3)
+	require.NoError(t, err)
+
+	store := NewPeerStore(logger, db)
+
+	stored, err := store.LoadPeers()
+	require.NoError(t, err)
+	require.Empty(t, stored)
+
+	lastSeen := time.Now().Round(0).UTC()
+	records := []*PeerRecord{
+		{ID: pids[0], Addrs: []string{"/ip4/127.0.0.1/tcp/13001"}, Subnets: "ff", LastSeen: lastSeen, Score: 1.5},
+		{ID: pids[1], BannedIndefinitely: true},
+		{ID: pids[2], Allowed: true},
+	}
+	require.NoError(t, store.SavePeers(records))
+
+	stored, err = store.LoadPeers()
+	require.NoError(t, err)
+	require.ElementsMatch(t, records, stored)
+
+	// saving replaces previously stored peers
+	require.NoError(t, store.SavePeers(records[1:]))
+	stored, err = store.LoadPeers()
+	require.NoError(t, err)
+	require.ElementsMatch(t, records[1:], stored)
+}
@@ -40,6 +40,15 @@ type NetworkConfig struct {
 	Bootnodes                     []string
 	WhitelistedOperatorKeys       []string
 	PermissionlessActivationEpoch spec.Epoch
+	// Ed25519SignaturesEpoch is the epoch from which messages signed with operators' Ed25519 keys are accepted,
+	// beginning the transition from RSA signatures. Zero means it's not scheduled.
+	Ed25519SignaturesEpoch spec.Epoch
+	// RSASignaturesEndEpoch is the epoch from which RSA-signed messages are rejected, ending the transition.
+	// Zero means they're always accepted.
+	RSASignaturesEndEpoch spec.Epoch
+	// CommitteeConsensusEpoch is the epoch from which the batched consensus of committees' attester
+	// and sync committee duties is accepted. Zero means it's not scheduled.
+	CommitteeConsensusEpoch spec.Epoch
 }
 
 func (n NetworkConfig) String() string {
@@ -51,6 +60,46 @@ func (n NetworkConfig) String() string {
 	return string(b)
 }
 
+// AcceptsEd25519Signatures returns whether messages signed with operators' Ed25519 keys are accepted at the epoch.
+func (n NetworkConfig) AcceptsEd25519Signatures(epoch spec.Epoch) bool {
+	return n.Ed25519SignaturesEpoch != 0 && epoch >= n.Ed25519SignaturesEpoch
+}
+
+// AcceptsRSASignatures returns whether messages signed with operators' RSA keys are accepted at the epoch.
+func (n NetworkConfig) AcceptsRSASignatures(epoch spec.Epoch) bool {
+	return n.RSASignaturesEndEpoch == 0 || epoch < n.RSASignaturesEndEpoch
+}
+
+// SignsWithEd25519 returns whether operators sign their messages with Ed25519 keys at the epoch.
+// They switch an epoch after Ed25519 signatures are accepted, so that peers whose clocks lag don't reject them.
+func (n NetworkConfig) SignsWithEd25519(epoch spec.Epoch) bool {
+	return n.AcceptsEd25519Signatures(epoch) && epoch > n.Ed25519SignaturesEpoch
+}
+
+// chainIDs are the chain IDs of the execution layers of the beacon networks.
+var chainIDs = map[spectypes.BeaconNetwork]uint64{
+	spectypes.MainNetwork:    1,
+	spectypes.PraterNetwork:  5,
+	spectypes.HoleskyNetwork: 17000,
+}
+
+// ChainID returns the chain ID of the network's execution layer, or zero if it's unknown.
+func (n NetworkConfig) ChainID() uint64 {
+	return chainIDs[n.Beacon.GetBeaconNetwork()]
+}
+
+// AcceptsCommitteeConsensus returns whether messages of committees' batched consensus are accepted at the epoch.
+func (n NetworkConfig) AcceptsCommitteeConsensus(epoch spec.Epoch) bool {
+	return n.CommitteeConsensusEpoch != 0 && epoch >= n.CommitteeConsensusEpoch
+}
+
+// RunsCommitteeConsensus returns whether operators decide attester and sync committee duties
+// by committees' batched consensus at the epoch.
+// They switch an epoch after its messages are accepted, so that peers whose clocks lag don't reject them.
+func (n NetworkConfig) RunsCommitteeConsensus(epoch spec.Epoch) bool {
+	return n.AcceptsCommitteeConsensus(epoch) && epoch > n.CommitteeConsensusEpoch
+}
+
 // ForkVersion returns the fork version of the network.
 func (n NetworkConfig) ForkVersion() [4]byte {
 	return n.Beacon.ForkVersion()
@@ -2,12 +2,15 @@ package nodeprobe
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/notifications"
 )
 
 const (
@@ -26,6 +29,9 @@ type Prober struct {
 	healthy          atomic.Bool
 	cond             *sync.Cond
 	unhealthyHandler func()
+	notifier         notifications.Notifier
+	// unhealthyNodes are the nodes which were unhealthy in the last probe which checked them.
+	unhealthyNodes map[string]struct{}
 }
 
 func NewProber(logger *zap.Logger, unhealthyHandler func(), nodes map[string]Node) *Prober {
@@ -35,9 +41,16 @@ func NewProber(logger *zap.Logger, unhealthyHandler func(), nodes map[string]Nod
 		interval:         probeInterval,
 		nodes:            nodes,
 		cond:             sync.NewCond(&sync.Mutex{}),
+		notifier:         notifications.NopNotifier{},
+		unhealthyNodes:   make(map[string]struct{}),
 	}
 }
 
+// SetNotifier has the prober notify when a node becomes unhealthy and when it recovers.
+func (p *Prober) SetNotifier(notifier notifications.Notifier) {
+	p.notifier = notifier
+}
+
 func (p *Prober) Healthy(context.Context) (bool, error) {
 	return p.healthy.Load(), nil
 }
@@ -73,6 +86,8 @@ func (p *Prober) probe(ctx context.Context) {
 
 	var healthy atomic.Bool
 	healthy.Store(true)
+	var resultsMu sync.Mutex
+	results := make(map[string]error)
 	var wg sync.WaitGroup
 	p.nodesMu.Lock()
 	for name, node := range p.nodes {
@@ -91,6 +106,12 @@ func (p *Prober) probe(ctx context.Context) {
 					healthy.Store(false)
 					cancel()
 				}
+				// Nodes whose check was canceled because another node is unhealthy weren't actually checked.
+				if err == nil || !errors.Is(err, context.Canceled) {
+					resultsMu.Lock()
+					results[name] = err
+					resultsMu.Unlock()
+				}
 			}()
 
 			err = node.Healthy(ctx)
@@ -102,6 +123,8 @@ func (p *Prober) probe(ctx context.Context) {
 	p.nodesMu.Unlock()
 	wg.Wait()
 
+	p.notifyChanges(results)
+
 	// Update readiness.
 	p.cond.L.Lock()
 	defer p.cond.L.Unlock()
@@ -119,6 +142,30 @@ func (p *Prober) probe(ctx context.Context) {
 	p.cond.Broadcast()
 }
 
+// notifyChanges notifies about the nodes which became unhealthy or recovered, given the results of a probe.
+func (p *Prober) notifyChanges(results map[string]error) {
+	for name, err := range results {
+		_, wasUnhealthy := p.unhealthyNodes[name]
+		switch {
+		case err != nil && !wasUnhealthy:
+			p.unhealthyNodes[name] = struct{}{}
+			event := notifications.NewEvent(notifications.EventNodeUnhealthy, fmt.Sprintf("%s is not healthy", name), map[string]string{
+				"node":  name,
+				"error": err.Error(),
+			})
+			event.DedupKey = "node_health/" + name
+			p.notifier.Notify(event)
+		case err == nil && wasUnhealthy:
+			delete(p.unhealthyNodes, name)
+			event := notifications.NewEvent(notifications.EventNodeRecovered, fmt.Sprintf("%s is healthy again", name), map[string]string{
+				"node": name,
+			})
+			event.DedupKey = "node_health/" + name
+			p.notifier.Notify(event)
+		}
+	}
+}
+
 func (p *Prober) Wait() {
 	p.logger.Info("waiting until nodes are healthy")
 
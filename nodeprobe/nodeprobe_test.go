@@ -3,12 +3,15 @@ package nodeprobe
 import (
 	"context"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/notifications"
 )
 
 func TestProber(t *testing.T) {
@@ -81,3 +84,53 @@ func (sc *node) Healthy(context.Context) error {
 	}
 	return nil
 }
+
+type recordingNotifier struct {
+	mu     sync.Mutex
+	events []*notifications.Event
+}
+
+func (n *recordingNotifier) Notify(event *notifications.Event) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, event)
+}
+
+func (n *recordingNotifier) types() []notifications.EventType {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var types []notifications.EventType
+	for _, event := range n.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestProber_Notifications(t *testing.T) {
+	ctx := context.Background()
+
+	node := &node{}
+	node.healthy.Store(nil)
+
+	notifier := &recordingNotifier{}
+	prober := NewProber(zap.L(), nil, map[string]Node{"test node": node})
+	prober.SetNotifier(notifier)
+
+	prober.probe(ctx)
+	require.Empty(t, notifier.types())
+
+	// A node becoming unhealthy is notified about once, until it recovers.
+	notHealthy := fmt.Errorf("not healthy")
+	node.healthy.Store(&notHealthy)
+	prober.probe(ctx)
+	prober.probe(ctx)
+	require.Equal(t, []notifications.EventType{notifications.EventNodeUnhealthy}, notifier.types())
+	require.Equal(t, "test node", notifier.events[0].Fields["node"])
+	require.Equal(t, "not healthy", notifier.events[0].Fields["error"])
+
+	node.healthy.Store(nil)
+	prober.probe(ctx)
+	prober.probe(ctx)
+	require.Equal(t, []notifications.EventType{notifications.EventNodeUnhealthy, notifications.EventNodeRecovered}, notifier.types())
+	require.Equal(t, notifier.events[0].DedupKey, notifier.events[1].DedupKey)
+}
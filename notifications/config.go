@@ -0,0 +1,149 @@
+package notifications
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultSource            = "ssv-node"
+	defaultTimeout           = 10 * time.Second
+	defaultRetryInterval     = 10 * time.Second
+	defaultRateLimitInterval = time.Hour
+)
+
+// Config configures the notifications of node events and the sinks they're sent to.
+type Config struct {
+	Sinks []SinkConfig `yaml:"Sinks"`
+	// Source names the node in notifications.
+	Source        string        `yaml:"Source" env:"NOTIFICATIONS_SOURCE" env-default:"ssv-node" env-description:"Name of the node in notifications"`
+	Timeout       time.Duration `yaml:"Timeout" env:"NOTIFICATIONS_TIMEOUT" env-default:"10s" env-description:"Timeout of sending a notification"`
+	RetryInterval time.Duration `yaml:"RetryInterval" env:"NOTIFICATIONS_RETRY_INTERVAL" env-default:"10s" env-description:"Delay before retrying a notification which failed to send, doubled after every failed attempt"`
+	MaxAttempts   int           `yaml:"MaxAttempts" env:"NOTIFICATIONS_MAX_ATTEMPTS" env-default:"10" env-description:"Number of attempts to send a notification before it's dropped"`
+}
+
+// SinkType is the kind of endpoint a sink sends notifications to, which determines their payload.
+type SinkType string
+
+const (
+	// SinkWebhook posts events as JSON.
+	SinkWebhook SinkType = "webhook"
+	// SinkSlack posts events to a Slack incoming webhook.
+	SinkSlack SinkType = "slack"
+	// SinkDiscord posts events to a Discord webhook.
+	SinkDiscord SinkType = "discord"
+	// SinkPagerDuty posts events to the PagerDuty Events API v2.
+	SinkPagerDuty SinkType = "pagerduty"
+)
+
+// SinkConfig configures a sink, and which of the events are sent to it.
+type SinkConfig struct {
+	// Name identifies the sink in the outbox and in logs, defaulting to its type.
+	Name string   `yaml:"Name"`
+	Type SinkType `yaml:"Type"`
+	URL  string   `yaml:"URL"`
+	// Headers are added to the sink's requests, such as for authorization.
+	Headers map[string]string `yaml:"Headers"`
+	// RoutingKey is the integration key of a PagerDuty service.
+	RoutingKey string `yaml:"RoutingKey"`
+
+	// Events are the event types sent to the sink, or all of them if empty.
+	Events []EventType `yaml:"Events"`
+	// MinSeverity is the lowest severity of events sent to the sink.
+	MinSeverity Severity `yaml:"MinSeverity"`
+	// RateLimit is the maximum number of events of each type sent to the sink per RateLimitInterval,
+	// or unlimited if zero. Events beyond the limit are dropped.
+	RateLimit         int           `yaml:"RateLimit"`
+	RateLimitInterval time.Duration `yaml:"RateLimitInterval"`
+}
+
+// PagerDutyEventsURL is the default URL of PagerDuty sinks.
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+func (c *SinkConfig) validate() error {
+	switch c.Type {
+	case SinkWebhook, SinkSlack, SinkDiscord:
+		if c.URL == "" {
+			return errors.New("missing URL")
+		}
+	case SinkPagerDuty:
+		if c.RoutingKey == "" {
+			return errors.New("missing routing key")
+		}
+		if c.URL == "" {
+			c.URL = PagerDutyEventsURL
+		}
+	default:
+		return errors.Errorf("unknown type %q", c.Type)
+	}
+	if u, err := url.Parse(c.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.Errorf("invalid URL %q", c.URL)
+	}
+	for _, eventType := range c.Events {
+		if !eventType.valid() {
+			return errors.Errorf("unknown event type %q", eventType)
+		}
+	}
+	if c.MinSeverity == "" {
+		c.MinSeverity = SeverityInfo
+	}
+	if !c.MinSeverity.valid() {
+		return errors.Errorf("unknown severity %q", c.MinSeverity)
+	}
+	if c.RateLimit < 0 {
+		return errors.New("negative rate limit")
+	}
+	if c.RateLimit > 0 && c.RateLimitInterval <= 0 {
+		c.RateLimitInterval = defaultRateLimitInterval
+	}
+	return nil
+}
+
+// accepts returns whether the sink is sent events of the given type and severity.
+func (c *SinkConfig) accepts(event *Event) bool {
+	if event.Severity.level() < c.MinSeverity.level() {
+		return false
+	}
+	if len(c.Events) == 0 {
+		return true
+	}
+	for _, eventType := range c.Events {
+		if eventType == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the config, and fills in the defaults of its sinks.
+func (c *Config) validate() error {
+	names := make(map[string]struct{}, len(c.Sinks))
+	for i := range c.Sinks {
+		sink := &c.Sinks[i]
+		if sink.Name == "" {
+			sink.Name = string(sink.Type)
+		}
+		if _, ok := names[sink.Name]; ok {
+			return errors.Errorf("duplicate sink name %q, sinks of the same type must be named", sink.Name)
+		}
+		names[sink.Name] = struct{}{}
+		if err := sink.validate(); err != nil {
+			return errors.Wrapf(err, "invalid sink %q", sink.Name)
+		}
+	}
+	if c.Source == "" {
+		c.Source = defaultSource
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.RetryInterval <= 0 {
+		c.RetryInterval = defaultRetryInterval
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 1
+	}
+	return nil
+}
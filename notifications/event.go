@@ -0,0 +1,115 @@
+package notifications
+
+import (
+	"time"
+)
+
+// EventType is the type of a node event which can be notified about.
+type EventType string
+
+const (
+	// EventValidatorAdded is a validator of the operator being added.
+	EventValidatorAdded EventType = "validator_added"
+	// EventValidatorRemoved is a validator of the operator being removed.
+	EventValidatorRemoved EventType = "validator_removed"
+	// EventValidatorLiquidated is a validator of the operator being stopped because its cluster was liquidated.
+	EventValidatorLiquidated EventType = "validator_liquidated"
+	// EventDutyMissed is a duty which didn't finish before the validator's next duty of the same role started.
+	EventDutyMissed EventType = "duty_missed"
+	// EventNodeUnhealthy is a beacon or execution node (or the event syncer) becoming unhealthy.
+	EventNodeUnhealthy EventType = "node_unhealthy"
+	// EventNodeRecovered is an unhealthy node becoming healthy again.
+	EventNodeRecovered EventType = "node_recovered"
+	// EventSlashingRejected is a signature refused by slashing protection.
+	EventSlashingRejected EventType = "slashing_rejected"
+)
+
+// EventTypes are all the event types, in the order they're documented in.
+var EventTypes = []EventType{
+	EventValidatorAdded,
+	EventValidatorRemoved,
+	EventValidatorLiquidated,
+	EventDutyMissed,
+	EventNodeUnhealthy,
+	EventNodeRecovered,
+	EventSlashingRejected,
+}
+
+func (t EventType) valid() bool {
+	for _, eventType := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Severity is how urgent an event is.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+func (s Severity) level() int {
+	switch s {
+	case SeverityWarning:
+		return 1
+	case SeverityCritical:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func (s Severity) valid() bool {
+	return s == SeverityInfo || s == SeverityWarning || s == SeverityCritical
+}
+
+// defaultSeverities are the severities of events which aren't given one.
+var defaultSeverities = map[EventType]Severity{
+	EventValidatorAdded:      SeverityInfo,
+	EventValidatorRemoved:    SeverityInfo,
+	EventValidatorLiquidated: SeverityWarning,
+	EventDutyMissed:          SeverityWarning,
+	EventNodeUnhealthy:       SeverityCritical,
+	EventNodeRecovered:       SeverityInfo,
+	EventSlashingRejected:    SeverityCritical,
+}
+
+// Event is a notification of a node event, as it's sent to webhooks.
+type Event struct {
+	Type     EventType `json:"type"`
+	Severity Severity  `json:"severity"`
+	Message  string    `json:"message"`
+	// Fields are the details of the event, such as the validator's public key.
+	Fields map[string]string `json:"fields,omitempty"`
+	// Source is the name of the node which sent the event.
+	Source string    `json:"source"`
+	Time   time.Time `json:"time"`
+	// DedupKey identifies the events of the same ongoing issue, so that an unhealthy node and its recovery
+	// can be grouped into a single PagerDuty incident.
+	DedupKey string `json:"dedup_key,omitempty"`
+}
+
+// NewEvent creates an event of the given type with its default severity.
+func NewEvent(eventType EventType, message string, fields map[string]string) *Event {
+	return &Event{
+		Type:     eventType,
+		Severity: defaultSeverities[eventType],
+		Message:  message,
+		Fields:   fields,
+	}
+}
+
+// Notifier is notified about node events.
+type Notifier interface {
+	Notify(event *Event)
+}
+
+// NopNotifier discards events.
+type NopNotifier struct{}
+
+func (NopNotifier) Notify(*Event) {}
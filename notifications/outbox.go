@@ -0,0 +1,85 @@
+package notifications
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var (
+	outboxPrefix = []byte("notifications_outbox/")
+)
+
+// outboxEntry is an event waiting to be sent to a sink.
+type outboxEntry struct {
+	Sink        string    `json:"sink"`
+	Event       *Event    `json:"event"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+
+	key []byte
+}
+
+// outbox persists the events waiting to be sent, so that they outlive restarts.
+// Entries are keyed by the time they were added, so they're listed in order.
+type outbox struct {
+	db      basedb.Database
+	lock    sync.Mutex
+	lastKey uint64
+}
+
+func newOutbox(db basedb.Database) *outbox {
+	return &outbox{db: db}
+}
+
+// add saves a new entry.
+func (o *outbox) add(entry *outboxEntry) error {
+	o.lock.Lock()
+	key := uint64(time.Now().UnixNano())
+	if key <= o.lastKey {
+		key = o.lastKey + 1
+	}
+	o.lastKey = key
+	o.lock.Unlock()
+
+	entry.key = make([]byte, 8)
+	binary.BigEndian.PutUint64(entry.key, key)
+	return o.save(entry)
+}
+
+// save saves an existing entry.
+func (o *outbox) save(entry *outboxEntry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal outbox entry")
+	}
+	return o.db.Set(outboxPrefix, entry.key, value)
+}
+
+func (o *outbox) delete(entry *outboxEntry) error {
+	return o.db.Delete(outboxPrefix, entry.key)
+}
+
+// list returns the entries, oldest first.
+func (o *outbox) list() ([]*outboxEntry, error) {
+	var entries []*outboxEntry
+	err := o.db.GetAll(outboxPrefix, func(i int, obj basedb.Obj) error {
+		entry := &outboxEntry{}
+		if err := json.Unmarshal(obj.Value, entry); err != nil {
+			return errors.Wrap(err, "could not unmarshal outbox entry")
+		}
+		entry.key = append([]byte{}, obj.Key...)
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,255 @@
+package notifications
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+const (
+	// maxRetryInterval caps the delay between the attempts to send a notification.
+	maxRetryInterval = time.Hour
+)
+
+// sink is a configured sink, with the state of its rate limits.
+type sink struct {
+	config SinkConfig
+	sink   Sink
+
+	mu      sync.Mutex
+	windows map[EventType]*rateLimitWindow
+}
+
+// rateLimitWindow counts the events of a type sent to a sink since the window started.
+type rateLimitWindow struct {
+	start   time.Time
+	count   int
+	dropped int
+}
+
+// allow returns whether the rate limit of the sink allows another event of the given type,
+// and the number of events of that type which were dropped in the previous window, if it just ended.
+func (s *sink) allow(eventType EventType, now time.Time) (allowed bool, dropped int) {
+	if s.config.RateLimit == 0 {
+		return true, 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window, ok := s.windows[eventType]
+	if !ok || now.Sub(window.start) >= s.config.RateLimitInterval {
+		if ok {
+			dropped = window.dropped
+		}
+		window = &rateLimitWindow{start: now}
+		s.windows[eventType] = window
+	}
+	if window.count >= s.config.RateLimit {
+		window.dropped++
+		return false, dropped
+	}
+	window.count++
+	return true, dropped
+}
+
+// Service notifies the configured sinks about node events. Events are written to an outbox in the database,
+// from which they're sent in the background, and retried with a backoff until they're sent or run out of attempts.
+type Service struct {
+	logger *zap.Logger
+	config Config
+	sinks  map[string]*sink
+	outbox *outbox
+	wake   chan struct{}
+	now    func() time.Time
+
+	dispatchMu sync.Mutex
+}
+
+// New creates a notification service for the sinks of the given config.
+func New(logger *zap.Logger, db basedb.Database, config Config) (*Service, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: config.Timeout}
+
+	s := &Service{
+		logger: logger.Named(logging.NameNotifications),
+		config: config,
+		sinks:  make(map[string]*sink, len(config.Sinks)),
+		outbox: newOutbox(db),
+		wake:   make(chan struct{}, 1),
+		now:    time.Now,
+	}
+	for _, sinkConfig := range config.Sinks {
+		httpSink, err := NewSink(sinkConfig, client)
+		if err != nil {
+			return nil, err
+		}
+		s.AddSink(sinkConfig, httpSink)
+	}
+	return s, nil
+}
+
+// AddSink adds a sink, which is sent the events accepted by the filters of its config.
+// The sink's config is not validated, and so must have its Name and MinSeverity set.
+func (s *Service) AddSink(config SinkConfig, snk Sink) {
+	s.sinks[config.Name] = &sink{
+		config:  config,
+		sink:    snk,
+		windows: make(map[EventType]*rateLimitWindow),
+	}
+}
+
+// Notify adds the event to the outbox of every sink which accepts it, to be sent in the background.
+func (s *Service) Notify(event *Event) {
+	now := s.now()
+	if event.Time.IsZero() {
+		event.Time = now
+	}
+	if event.Severity == "" {
+		event.Severity = defaultSeverities[event.Type]
+	}
+	if event.Source == "" {
+		event.Source = s.config.Source
+	}
+
+	added := false
+	for name, snk := range s.sinks {
+		if !snk.config.accepts(event) {
+			continue
+		}
+		logger := s.logger.With(zap.String("sink", name), zap.String("event", string(event.Type)))
+		allowed, dropped := snk.allow(event.Type, now)
+		if dropped > 0 {
+			logger.Warn("dropped notifications over the sink's rate limit", zap.Int("dropped", dropped))
+		}
+		if !allowed {
+			continue
+		}
+		if err := s.outbox.add(&outboxEntry{Sink: name, Event: event, NextAttempt: now}); err != nil {
+			logger.Error("could not add notification to outbox", zap.Error(err))
+			continue
+		}
+		added = true
+	}
+	if added {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Start sends the notifications of the outbox in the background, until the context is done.
+func (s *Service) Start(ctx context.Context) {
+	go s.Run(ctx)
+}
+
+// Run sends the notifications of the outbox as they're added and when their retries are due,
+// until the context is done.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		s.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush sends the due notifications of the outbox right away, such as before the node exits.
+func (s *Service) Flush(ctx context.Context) {
+	s.dispatch(ctx)
+}
+
+// dispatch sends the due notifications of the outbox. Each sink is sent its notifications in order,
+// so the notifications after one which isn't due or fails to send are left for a later dispatch.
+func (s *Service) dispatch(ctx context.Context) {
+	s.dispatchMu.Lock()
+	defer s.dispatchMu.Unlock()
+
+	entries, err := s.outbox.list()
+	if err != nil {
+		s.logger.Error("could not list notifications outbox", zap.Error(err))
+		return
+	}
+
+	blockedSinks := make(map[string]struct{})
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return
+		}
+		logger := s.logger.With(zap.String("sink", entry.Sink), zap.String("event", string(entry.Event.Type)))
+
+		snk, ok := s.sinks[entry.Sink]
+		if !ok {
+			logger.Warn("dropping notification of a sink which is no longer configured")
+			s.deleteEntry(logger, entry)
+			continue
+		}
+		if _, blocked := blockedSinks[entry.Sink]; blocked {
+			continue
+		}
+		if s.now().Before(entry.NextAttempt) {
+			blockedSinks[entry.Sink] = struct{}{}
+			continue
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+		err := snk.sink.Send(sendCtx, entry.Event)
+		cancel()
+		if err == nil {
+			logger.Debug("sent notification")
+			s.deleteEntry(logger, entry)
+			continue
+		}
+
+		blockedSinks[entry.Sink] = struct{}{}
+		entry.Attempts++
+		entry.LastError = err.Error()
+		if entry.Attempts >= s.config.MaxAttempts {
+			logger.Error("dropping notification which failed to send", zap.Int("attempts", entry.Attempts), zap.Error(err))
+			s.deleteEntry(logger, entry)
+			continue
+		}
+		entry.NextAttempt = s.now().Add(s.retryDelay(entry.Attempts))
+		logger.Warn("could not send notification, will retry",
+			zap.Int("attempts", entry.Attempts),
+			zap.Time("next_attempt", entry.NextAttempt),
+			zap.Error(err))
+		if err := s.outbox.save(entry); err != nil {
+			logger.Error("could not save notification to outbox", zap.Error(err))
+		}
+	}
+}
+
+// retryDelay returns the delay before the next attempt to send a notification, doubling the retry interval
+// after every failed attempt.
+func (s *Service) retryDelay(attempts int) time.Duration {
+	delay := s.config.RetryInterval
+	for i := 1; i < attempts && delay < maxRetryInterval; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryInterval {
+		delay = maxRetryInterval
+	}
+	return delay
+}
+
+func (s *Service) deleteEntry(logger *zap.Logger, entry *outboxEntry) {
+	if err := s.outbox.delete(entry); err != nil {
+		logger.Error("could not delete notification from outbox", zap.Error(err))
+	}
+}
@@ -0,0 +1,237 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+// receiver is a local HTTP endpoint which records the payloads posted to it.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	payloads map[string][]map[string]interface{}
+	failures int
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{payloads: make(map[string][]map[string]interface{})}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if r.failures > 0 {
+			r.failures--
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		r.payloads[req.URL.Path] = append(r.payloads[req.URL.Path], payload)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) received(path string) []map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.payloads[path]
+}
+
+func (r *receiver) fail(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = n
+}
+
+func newTestDB(t *testing.T) basedb.Database {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestServiceSinks(t *testing.T) {
+	ctx := context.Background()
+	r := newReceiver(t)
+
+	s, err := New(logging.TestLogger(t), newTestDB(t), Config{
+		Source: "test-node",
+		Sinks: []SinkConfig{
+			{Type: SinkWebhook, URL: r.URL + "/webhook", Headers: map[string]string{"Authorization": "Bearer token"}},
+			{Type: SinkSlack, URL: r.URL + "/slack", Events: []EventType{EventNodeUnhealthy, EventDutyMissed}, RateLimit: 1},
+			{Type: SinkDiscord, URL: r.URL + "/discord", MinSeverity: SeverityCritical},
+			{Type: SinkPagerDuty, URL: r.URL + "/pagerduty", RoutingKey: "key", Events: []EventType{EventNodeUnhealthy, EventNodeRecovered}},
+		},
+	})
+	require.NoError(t, err)
+
+	unhealthy := NewEvent(EventNodeUnhealthy, "consensus client is unhealthy", map[string]string{"node": "consensus client"})
+	unhealthy.DedupKey = "node/consensus client"
+	s.Notify(unhealthy)
+	s.Notify(NewEvent(EventValidatorAdded, "validator added", map[string]string{"pubkey": "0x01"}))
+	// The Slack sink's rate limit allows a single duty_missed event per hour.
+	s.Notify(NewEvent(EventDutyMissed, "missed duty", nil))
+	s.Notify(NewEvent(EventDutyMissed, "missed duty", nil))
+	recovered := NewEvent(EventNodeRecovered, "consensus client recovered", nil)
+	recovered.DedupKey = "node/consensus client"
+	s.Notify(recovered)
+	s.dispatch(ctx)
+
+	webhook := r.received("/webhook")
+	require.Len(t, webhook, 5)
+	require.Equal(t, "node_unhealthy", webhook[0]["type"])
+	require.Equal(t, "critical", webhook[0]["severity"])
+	require.Equal(t, "test-node", webhook[0]["source"])
+	require.Equal(t, map[string]interface{}{"node": "consensus client"}, webhook[0]["fields"])
+
+	slack := r.received("/slack")
+	require.Len(t, slack, 2)
+	require.True(t, strings.HasPrefix(slack[0]["text"].(string), "*[CRITICAL] consensus client is unhealthy*"))
+	require.Contains(t, slack[0]["text"], "`node`: consensus client")
+	require.Contains(t, slack[1]["text"], "missed duty")
+
+	discord := r.received("/discord")
+	require.Len(t, discord, 1)
+	require.Contains(t, discord[0]["content"], "**[CRITICAL] consensus client is unhealthy**")
+
+	pagerDuty := r.received("/pagerduty")
+	require.Len(t, pagerDuty, 2)
+	require.Equal(t, "trigger", pagerDuty[0]["event_action"])
+	require.Equal(t, "resolve", pagerDuty[1]["event_action"])
+	require.Equal(t, "key", pagerDuty[1]["routing_key"])
+	require.Equal(t, "node/consensus client", pagerDuty[1]["dedup_key"])
+
+	entries, err := s.outbox.list()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestServiceRetry(t *testing.T) {
+	ctx := context.Background()
+	r := newReceiver(t)
+	db := newTestDB(t)
+	config := Config{
+		Sinks:         []SinkConfig{{Type: SinkWebhook, URL: r.URL}},
+		RetryInterval: time.Minute,
+		MaxAttempts:   3,
+	}
+
+	now := time.Now()
+	s, err := New(logging.TestLogger(t), db, config)
+	require.NoError(t, err)
+	s.now = func() time.Time { return now }
+
+	// The first attempt fails, and the second one is held back until the retry interval passes.
+	r.fail(1)
+	s.Notify(NewEvent(EventSlashingRejected, "slashable attestation", nil))
+	s.Notify(NewEvent(EventValidatorRemoved, "validator removed", nil))
+	s.dispatch(ctx)
+	require.Empty(t, r.received("/"))
+
+	entries, err := s.outbox.list()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, 1, entries[0].Attempts)
+	require.Equal(t, now.Add(time.Minute).UnixNano(), entries[0].NextAttempt.UnixNano())
+	require.Contains(t, entries[0].LastError, "unexpected status 503")
+	// The remaining notification of the failed sink wasn't attempted.
+	require.Zero(t, entries[1].Attempts)
+
+	s.dispatch(ctx)
+	require.Empty(t, r.received("/"))
+
+	// The outbox outlives a restart, and the notifications are sent in order once the retry is due.
+	s, err = New(logging.TestLogger(t), db, config)
+	require.NoError(t, err)
+	s.now = func() time.Time { return now.Add(time.Minute) }
+	s.dispatch(ctx)
+	received := r.received("/")
+	require.Len(t, received, 2)
+	require.Equal(t, "slashing_rejected", received[0]["type"])
+	require.Equal(t, "validator_removed", received[1]["type"])
+
+	// Notifications are dropped after running out of attempts, with the retry interval doubling between them.
+	r.fail(3)
+	s.Notify(NewEvent(EventDutyMissed, "missed duty", nil))
+	s.dispatch(ctx)
+	s.now = func() time.Time { return now.Add(3 * time.Minute) }
+	s.dispatch(ctx)
+	entries, err = s.outbox.list()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, 2, entries[0].Attempts)
+	require.Equal(t, now.Add(5*time.Minute).UnixNano(), entries[0].NextAttempt.UnixNano())
+
+	s.now = func() time.Time { return now.Add(5 * time.Minute) }
+	s.dispatch(ctx)
+	entries, err = s.outbox.list()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	require.Len(t, r.received("/"), 2)
+}
+
+func TestServiceRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := newReceiver(t)
+
+	s, err := New(logging.TestLogger(t), newTestDB(t), Config{Sinks: []SinkConfig{{Type: SinkWebhook, URL: r.URL}}})
+	require.NoError(t, err)
+	s.Start(ctx)
+
+	s.Notify(NewEvent(EventValidatorLiquidated, "validator liquidated", nil))
+	require.Eventually(t, func() bool {
+		return len(r.received("/")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestConfigValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		sinks []SinkConfig
+		err   string
+	}{
+		{"unknown type", []SinkConfig{{Type: "email", URL: "http://localhost"}}, `unknown type "email"`},
+		{"missing URL", []SinkConfig{{Type: SinkSlack}}, "missing URL"},
+		{"invalid URL", []SinkConfig{{Type: SinkWebhook, URL: "localhost:80"}}, "invalid URL"},
+		{"missing routing key", []SinkConfig{{Type: SinkPagerDuty}}, "missing routing key"},
+		{"unknown event", []SinkConfig{{Type: SinkWebhook, URL: "http://localhost", Events: []EventType{"block_proposed"}}}, `unknown event type "block_proposed"`},
+		{"unknown severity", []SinkConfig{{Type: SinkWebhook, URL: "http://localhost", MinSeverity: "fatal"}}, `unknown severity "fatal"`},
+		{"duplicate name", []SinkConfig{{Type: SinkWebhook, URL: "http://a"}, {Type: SinkWebhook, URL: "http://b"}}, `duplicate sink name "webhook"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := Config{Sinks: test.sinks}
+			require.ErrorContains(t, config.validate(), test.err)
+		})
+	}
+
+	config := Config{Sinks: []SinkConfig{{Type: SinkPagerDuty, RoutingKey: "key"}, {Name: "ops", Type: SinkWebhook, URL: "http://a", RateLimit: 5}}}
+	require.NoError(t, config.validate())
+	require.Equal(t, PagerDutyEventsURL, config.Sinks[0].URL)
+	require.Equal(t, time.Hour, config.Sinks[1].RateLimitInterval)
+}
+
+func TestDiscordContentLimit(t *testing.T) {
+	payload, err := discordPayload(&Event{Message: strings.Repeat("x", 3000), Severity: SeverityInfo})
+	require.NoError(t, err)
+	require.Len(t, payload.(map[string]string)["content"], discordMaxContentLength)
+}
@@ -0,0 +1,156 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// discordMaxContentLength is the maximum length of the content of a Discord message.
+const discordMaxContentLength = 2000
+
+// Sink sends events to an endpoint.
+type Sink interface {
+	Send(ctx context.Context, event *Event) error
+}
+
+// NewSink creates the sink of the given config, which posts events with the given client.
+func NewSink(config SinkConfig, client *http.Client) (Sink, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	var payload func(event *Event) (interface{}, error)
+	switch config.Type {
+	case SinkWebhook:
+		payload = webhookPayload
+	case SinkSlack:
+		payload = slackPayload
+	case SinkDiscord:
+		payload = discordPayload
+	case SinkPagerDuty:
+		payload = func(event *Event) (interface{}, error) {
+			return pagerDutyPayload(event, config.RoutingKey)
+		}
+	}
+	return &httpSink{
+		url:     config.URL,
+		headers: config.Headers,
+		client:  client,
+		payload: payload,
+	}, nil
+}
+
+// httpSink posts events as JSON payloads.
+type httpSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+	payload func(event *Event) (interface{}, error)
+}
+
+func (s *httpSink) Send(ctx context.Context, event *Event) error {
+	payload, err := s.payload(event)
+	if err != nil {
+		return errors.Wrap(err, "could not format event")
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal payload")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not send request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return errors.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+func webhookPayload(event *Event) (interface{}, error) {
+	return event, nil
+}
+
+func slackPayload(event *Event) (interface{}, error) {
+	return map[string]string{
+		"text": formatText(event, "*%s*", "`%s`"),
+	}, nil
+}
+
+func discordPayload(event *Event) (interface{}, error) {
+	content := formatText(event, "**%s**", "`%s`")
+	if len(content) > discordMaxContentLength {
+		content = content[:discordMaxContentLength-3] + "..."
+	}
+	return map[string]string{
+		"content": content,
+	}, nil
+}
+
+// pagerDutyPayload formats an event for the PagerDuty Events API v2. Recoveries resolve the incident
+// of the event with the same dedup key.
+func pagerDutyPayload(event *Event, routingKey string) (interface{}, error) {
+	action := "trigger"
+	if event.Type == EventNodeRecovered && event.DedupKey != "" {
+		action = "resolve"
+	}
+	severity := "info"
+	switch event.Severity {
+	case SeverityWarning:
+		severity = "warning"
+	case SeverityCritical:
+		severity = "critical"
+	}
+	payload := map[string]interface{}{
+		"routing_key":  routingKey,
+		"event_action": action,
+		"payload": map[string]interface{}{
+			"summary":        fmt.Sprintf("%s: %s", event.Source, event.Message),
+			"source":         event.Source,
+			"severity":       severity,
+			"timestamp":      event.Time.UTC().Format(time.RFC3339),
+			"class":          string(event.Type),
+			"custom_details": event.Fields,
+		},
+	}
+	if event.DedupKey != "" {
+		payload["dedup_key"] = event.DedupKey
+	}
+	return payload, nil
+}
+
+// formatText formats an event as a chat message, with the given formats of its title and field names.
+func formatText(event *Event, titleFormat, fieldFormat string) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(titleFormat, fmt.Sprintf("[%s] %s", strings.ToUpper(string(event.Severity)), event.Message)))
+	b.WriteString(fmt.Sprintf("\nsource: %s, event: %s", event.Source, event.Type))
+
+	names := make([]string, 0, len(event.Fields))
+	for name := range event.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString(fmt.Sprintf("\n"+fieldFormat+": %s", name, event.Fields[name]))
+	}
+	return b.String()
+}
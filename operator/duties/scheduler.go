@@ -25,6 +25,7 @@ import (
 	"github.com/bloxapp/ssv/networkconfig"
 	"github.com/bloxapp/ssv/operator/duties/dutystore"
 	"github.com/bloxapp/ssv/operator/slotticker"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 )
 
@@ -63,6 +64,12 @@ type BeaconNode interface {
 	SubmitSyncCommitteeSubscriptions(ctx context.Context, subscription []*eth2apiv1.SyncCommitteeSubscription) error
 }
 
+// AttestationDataCache is implemented by beacon nodes which share attestation data between attester duties.
+type AttestationDataCache interface {
+	PrefetchAttestationData(slot phase0.Slot, committeeIndices []phase0.CommitteeIndex)
+	InvalidateAttestationData(fromSlot phase0.Slot)
+}
+
 type ExecutionClient interface {
 	BlockByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Block, error)
 }
@@ -87,7 +94,11 @@ type SchedulerOptions struct {
 	ValidatorExitCh     <-chan ExitDescriptor
 	SlotTickerProvider  slotticker.Provider
 	BuilderProposals    bool
-	DutyStore           *dutystore.Store
+	// ProposerSettings, if set, decides which validators register with builders, instead of BuilderProposals.
+	ProposerSettings beaconprotocol.ProposerSettingsProvider
+	DutyStore        *dutystore.Store
+	// AttestationDataCache, if set, is prefetched at the 1/3 slot mark and invalidated on reorgs.
+	AttestationDataCache AttestationDataCache
 }
 
 type Scheduler struct {
@@ -98,6 +109,8 @@ type Scheduler struct {
 	slotTickerProvider  slotticker.Provider
 	executeDuty         ExecuteDutyFunc
 	builderProposals    bool
+	proposerSettings    beaconprotocol.ProposerSettingsProvider
+	attDataCache        AttestationDataCache
 
 	handlers            []dutyHandler
 	blockPropagateDelay time.Duration
@@ -128,6 +141,8 @@ func NewScheduler(opts *SchedulerOptions) *Scheduler {
 		executeDuty:         opts.ExecuteDuty,
 		validatorController: opts.ValidatorController,
 		builderProposals:    opts.BuilderProposals,
+		proposerSettings:    opts.ProposerSettings,
+		attDataCache:        opts.AttestationDataCache,
 		indicesChg:          opts.IndicesChg,
 		blockPropagateDelay: blockPropagationDelay,
 
@@ -142,8 +157,8 @@ func NewScheduler(opts *SchedulerOptions) *Scheduler {
 		reorg:    make(chan ReorgEvent),
 		waitCond: sync.NewCond(&sync.Mutex{}),
 	}
-	if s.builderProposals {
-		s.handlers = append(s.handlers, NewValidatorRegistrationHandler())
+	if s.builderProposals || s.proposerSettings != nil {
+		s.handlers = append(s.handlers, NewValidatorRegistrationHandler(s.proposerSettings))
 	}
 	return s
 }
@@ -202,6 +217,12 @@ func (s *Scheduler) Start(ctx context.Context, logger *zap.Logger) error {
 		})
 	}
 
+	if s.attDataCache != nil {
+		reorgCh := make(chan ReorgEvent)
+		reorgFeed.Subscribe(reorgCh)
+		go s.invalidateAttestationData(ctx, logger, reorgCh)
+	}
+
 	go s.SlotTicker(ctx)
 
 	go indicesChangeFeed.FanOut(ctx, s.indicesChg)
@@ -360,6 +381,8 @@ func (s *Scheduler) HandleHeadEvent(logger *zap.Logger) func(event *eth2apiv1.Ev
 
 // ExecuteDuties tries to execute the given duties
 func (s *Scheduler) ExecuteDuties(logger *zap.Logger, duties []*spectypes.Duty) {
+	s.prefetchAttestationData(duties)
+
 	for _, duty := range duties {
 		duty := duty
 		logger := s.loggerWithDutyContext(logger, duty)
@@ -377,6 +400,50 @@ func (s *Scheduler) ExecuteDuties(logger *zap.Logger, duties []*spectypes.Duty)
 	}
 }
 
+// prefetchAttestationData requests the attestation data of the attester duties' committees once the slot's
+// block arrives or 1/3 of it passes, so that the duties share a single request per committee.
+func (s *Scheduler) prefetchAttestationData(duties []*spectypes.Duty) {
+	if s.attDataCache == nil {
+		return
+	}
+
+	committees := make(map[phase0.Slot]map[phase0.CommitteeIndex]struct{})
+	for _, duty := range duties {
+		if duty.Type != spectypes.BNRoleAttester {
+			continue
+		}
+		if committees[duty.Slot] == nil {
+			committees[duty.Slot] = make(map[phase0.CommitteeIndex]struct{})
+		}
+		committees[duty.Slot][duty.CommitteeIndex] = struct{}{}
+	}
+
+	for slot, slotCommittees := range committees {
+		slot := slot
+		committeeIndices := make([]phase0.CommitteeIndex, 0, len(slotCommittees))
+		for committeeIndex := range slotCommittees {
+			committeeIndices = append(committeeIndices, committeeIndex)
+		}
+		go func() {
+			s.waitOneThirdOrValidBlock(slot)
+			s.attDataCache.PrefetchAttestationData(slot, committeeIndices)
+		}()
+	}
+}
+
+// invalidateAttestationData drops attestation data which a reorg may have changed.
+func (s *Scheduler) invalidateAttestationData(ctx context.Context, logger *zap.Logger, reorgCh <-chan ReorgEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case reorgEvent := <-reorgCh:
+			logger.Debug("🔀 invalidating attestation data on reorg", fields.Slot(reorgEvent.Slot))
+			s.attDataCache.InvalidateAttestationData(reorgEvent.Slot)
+		}
+	}
+}
+
 // loggerWithDutyContext returns an instance of logger with the given duty's information
 func (s *Scheduler) loggerWithDutyContext(logger *zap.Logger, duty *spectypes.Duty) *zap.Logger {
 	return logger.
@@ -327,7 +327,7 @@ func TestScheduler_Regression_IndicesChangeStuck(t *testing.T) {
 	s := NewScheduler(opts)
 
 	// add multiple mock duty handlers
-	s.handlers = []dutyHandler{NewValidatorRegistrationHandler()}
+	s.handlers = []dutyHandler{NewValidatorRegistrationHandler(nil)}
 	mockBeaconNode.EXPECT().Events(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	mockTicker.EXPECT().Next().Return(nil).AnyTimes()
 	err := s.Start(ctx, logger)
@@ -342,3 +342,84 @@ func TestScheduler_Regression_IndicesChangeStuck(t *testing.T) {
 	}
 
 }
+
+type mockAttestationDataCache struct {
+	prefetched  chan []phase0.CommitteeIndex
+	invalidated chan phase0.Slot
+}
+
+func (m *mockAttestationDataCache) PrefetchAttestationData(slot phase0.Slot, committeeIndices []phase0.CommitteeIndex) {
+	m.prefetched <- committeeIndices
+}
+
+func (m *mockAttestationDataCache) InvalidateAttestationData(fromSlot phase0.Slot) {
+	m.invalidated <- fromSlot
+}
+
+func TestScheduler_PrefetchAttestationData(t *testing.T) {
+	cache := &mockAttestationDataCache{
+		prefetched: make(chan []phase0.CommitteeIndex, 1),
+	}
+	s := NewScheduler(&SchedulerOptions{
+		Ctx:                  context.Background(),
+		Network:              networkconfig.TestNetwork,
+		AttestationDataCache: cache,
+		SlotTickerProvider: func() slotticker.SlotTicker {
+			return nil
+		},
+	})
+
+	s.prefetchAttestationData([]*spectypes.Duty{
+		{Type: spectypes.BNRoleAttester, Slot: 10, CommitteeIndex: 1},
+		{Type: spectypes.BNRoleAttester, Slot: 10, CommitteeIndex: 1},
+		{Type: spectypes.BNRoleAttester, Slot: 10, CommitteeIndex: 2},
+		{Type: spectypes.BNRoleSyncCommittee, Slot: 10, CommitteeIndex: 3},
+	})
+
+	// Nothing is prefetched before the slot's block arrives or 1/3 of it passes.
+	select {
+	case <-cache.prefetched:
+		t.Fatal("attestation data prefetched early")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.waitCond.L.Lock()
+	s.headSlot = 10
+	s.waitCond.Broadcast()
+	s.waitCond.L.Unlock()
+
+	select {
+	case committeeIndices := <-cache.prefetched:
+		require.ElementsMatch(t, []phase0.CommitteeIndex{1, 2}, committeeIndices)
+	case <-time.After(time.Second):
+		t.Fatal("attestation data wasn't prefetched")
+	}
+}
+
+func TestScheduler_InvalidateAttestationDataOnReorg(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cache := &mockAttestationDataCache{
+		invalidated: make(chan phase0.Slot, 1),
+	}
+	s := NewScheduler(&SchedulerOptions{
+		Ctx:                  context.Background(),
+		Network:              networkconfig.TestNetwork,
+		AttestationDataCache: cache,
+		SlotTickerProvider: func() slotticker.SlotTicker {
+			return nil
+		},
+	})
+
+	reorgCh := make(chan ReorgEvent)
+	go s.invalidateAttestationData(ctx, logging.TestLogger(t), reorgCh)
+	reorgCh <- ReorgEvent{Slot: 64, Current: true}
+
+	select {
+	case slot := <-cache.invalidated:
+		require.Equal(t, phase0.Slot(64), slot)
+	case <-time.After(time.Second):
+		t.Fatal("attestation data wasn't invalidated")
+	}
+}
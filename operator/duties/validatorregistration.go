@@ -6,16 +6,23 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"go.uber.org/zap"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 )
 
 const validatorRegistrationEpochInterval = uint64(10)
 
 type ValidatorRegistrationHandler struct {
 	baseHandler
+
+	// proposerSettings, if set, skips validators which don't use builders.
+	proposerSettings beaconprotocol.ProposerSettingsProvider
 }
 
-func NewValidatorRegistrationHandler() *ValidatorRegistrationHandler {
-	return &ValidatorRegistrationHandler{}
+func NewValidatorRegistrationHandler(proposerSettings beaconprotocol.ProposerSettingsProvider) *ValidatorRegistrationHandler {
+	return &ValidatorRegistrationHandler{
+		proposerSettings: proposerSettings,
+	}
 }
 
 func (h *ValidatorRegistrationHandler) Name() string {
@@ -53,6 +60,10 @@ func (h *ValidatorRegistrationHandler) HandleDuties(ctx context.Context) {
 				pk := phase0.BLSPubKey{}
 				copy(pk[:], share.ValidatorPubKey)
 
+				if h.proposerSettings != nil && !h.proposerSettings.ProposerSettings(pk).BuilderEnabled {
+					continue
+				}
+
 				h.executeDuties(h.logger, []*spectypes.Duty{{
 					Type:   spectypes.BNRoleValidatorRegistration,
 					PubKey: pk,
@@ -17,6 +17,9 @@ type ExitDescriptor struct {
 	PubKey         phase0.BLSPubKey
 	ValidatorIndex phase0.ValidatorIndex
 	BlockNumber    uint64
+	// Slot, if set, is the slot of the exit duty instead of one derived from BlockNumber,
+	// such as for exits requested by the validator's owner rather than on-chain.
+	Slot phase0.Slot
 }
 
 type VoluntaryExitHandler struct {
@@ -77,14 +80,20 @@ func (h *VoluntaryExitHandler) HandleDuties(ctx context.Context) {
 				return
 			}
 
-			blockSlot, err := h.blockSlot(ctx, exitDescriptor.BlockNumber)
-			if err != nil {
-				h.logger.Warn("failed to get block time from execution client, skipping voluntary exit duty",
-					zap.Error(err))
-				continue
-			}
+			var blockSlot, dutySlot phase0.Slot
+			if exitDescriptor.Slot != 0 {
+				dutySlot = exitDescriptor.Slot
+			} else {
+				var err error
+				blockSlot, err = h.blockSlot(ctx, exitDescriptor.BlockNumber)
+				if err != nil {
+					h.logger.Warn("failed to get block time from execution client, skipping voluntary exit duty",
+						zap.Error(err))
+					continue
+				}
 
-			dutySlot := blockSlot + voluntaryExitSlotsToPostpone
+				dutySlot = blockSlot + voluntaryExitSlotsToPostpone
+			}
 
 			duty := &spectypes.Duty{
 				Type:           spectypes.BNRoleVoluntaryExit,
@@ -0,0 +1,115 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	crand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	// Ed25519KeyValidityEpochs is the number of epochs for which an operator's Ed25519 key is certified.
+	Ed25519KeyValidityEpochs = 256
+
+	// ed25519KeyRenewalEpochs is the number of epochs before its expiry at which an Ed25519 key is replaced,
+	// so that messages signed just before renewal are still valid when they're received.
+	ed25519KeyRenewalEpochs = Ed25519KeyValidityEpochs / 2
+
+	ed25519CertificatePrefix = "ssv operator ed25519 key"
+)
+
+// OperatorKeyCertificate delegates signing the operator's messages to an Ed25519 key until its expiry epoch,
+// and is signed by the operator's RSA key which is registered on-chain.
+type OperatorKeyCertificate struct {
+	OperatorID  uint64
+	ExpiryEpoch uint64
+	PublicKey   ed25519.PublicKey
+	Signature   []byte
+}
+
+// signingData returns the data signed by the operator's RSA key, bound to the network's domain.
+func (c *OperatorKeyCertificate) signingData(domain [4]byte) []byte {
+	data := make([]byte, 0, len(ed25519CertificatePrefix)+len(domain)+8+8+ed25519.PublicKeySize)
+	data = append(data, ed25519CertificatePrefix...)
+	data = append(data, domain[:]...)
+	data = binary.LittleEndian.AppendUint64(data, c.OperatorID)
+	data = binary.LittleEndian.AppendUint64(data, c.ExpiryEpoch)
+	return append(data, c.PublicKey...)
+}
+
+// Verify verifies that the certificate was signed by the operator's RSA key.
+// It doesn't check the certificate's expiry.
+func (c *OperatorKeyCertificate) Verify(domain [4]byte, operatorKey OperatorPublicKey) error {
+	if len(c.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("unexpected Ed25519 public key size of %d", len(c.PublicKey))
+	}
+	if err := operatorKey.Verify(c.signingData(domain), c.Signature); err != nil {
+		return fmt.Errorf("verify certificate: %w", err)
+	}
+	return nil
+}
+
+// VerifySignature verifies the signature of the data by the certified Ed25519 key.
+func (c *OperatorKeyCertificate) VerifySignature(data, signature []byte) error {
+	if len(c.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("unexpected Ed25519 public key size of %d", len(c.PublicKey))
+	}
+	if !ed25519.Verify(c.PublicKey, data, signature) {
+		return errors.New("invalid Ed25519 signature")
+	}
+	return nil
+}
+
+// Ed25519Signer signs the operator's messages with an ephemeral Ed25519 key certified by the operator's RSA key,
+// which is much cheaper to sign with than RSA, and whose signatures are smaller. The key is replaced before its certificate expires.
+type Ed25519Signer struct {
+	operatorKey OperatorSigner
+	domain      [4]byte
+
+	mu          sync.Mutex
+	privateKey  ed25519.PrivateKey
+	certificate *OperatorKeyCertificate
+}
+
+// NewEd25519Signer returns an Ed25519Signer certifying its keys with the operator's key in the network's domain.
+func NewEd25519Signer(operatorKey OperatorSigner, domain [4]byte) *Ed25519Signer {
+	return &Ed25519Signer{
+		operatorKey: operatorKey,
+		domain:      domain,
+	}
+}
+
+// Sign signs the data at the epoch, returning the certificate of the key that signed it.
+func (s *Ed25519Signer) Sign(operatorID uint64, epoch uint64, data []byte) (*OperatorKeyCertificate, []byte, error) {
+	s.mu.Lock()
+	if s.certificate == nil || s.certificate.OperatorID != operatorID || epoch+ed25519KeyRenewalEpochs > s.certificate.ExpiryEpoch {
+		if err := s.renew(operatorID, epoch); err != nil {
+			s.mu.Unlock()
+			return nil, nil, err
+		}
+	}
+	privateKey, certificate := s.privateKey, s.certificate
+	s.mu.Unlock()
+
+	return certificate, ed25519.Sign(privateKey, data), nil
+}
+
+func (s *Ed25519Signer) renew(operatorID uint64, epoch uint64) error {
+	publicKey, privateKey, err := ed25519.GenerateKey(crand.Reader)
+	if err != nil {
+		return fmt.Errorf("could not generate Ed25519 key: %w", err)
+	}
+	certificate := &OperatorKeyCertificate{
+		OperatorID:  operatorID,
+		ExpiryEpoch: epoch + Ed25519KeyValidityEpochs,
+		PublicKey:   publicKey,
+	}
+	certificate.Signature, err = s.operatorKey.Sign(certificate.signingData(s.domain))
+	if err != nil {
+		return fmt.Errorf("could not certify Ed25519 key: %w", err)
+	}
+	s.privateKey, s.certificate = privateKey, certificate
+	return nil
+}
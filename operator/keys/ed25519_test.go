@@ -0,0 +1,114 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testDomain = [4]byte{0x0, 0x0, 0x5, 0x1}
+
+func TestEd25519Signer(t *testing.T) {
+	operatorKey, err := GeneratePrivateKey()
+	require.NoError(t, err)
+	signer := NewEd25519Signer(operatorKey, testDomain)
+
+	data := []byte("message")
+	certificate, signature, err := signer.Sign(1, 100, data)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), certificate.OperatorID)
+	require.Equal(t, uint64(100+Ed25519KeyValidityEpochs), certificate.ExpiryEpoch)
+	require.NoError(t, certificate.Verify(testDomain, operatorKey.Public()))
+	require.NoError(t, certificate.VerifySignature(data, signature))
+	require.Error(t, certificate.VerifySignature([]byte("other message"), signature))
+
+	// The certificate is bound to the operator's key, the network's domain and its fields.
+	otherKey, err := GeneratePrivateKey()
+	require.NoError(t, err)
+	require.Error(t, certificate.Verify(testDomain, otherKey.Public()))
+	require.Error(t, certificate.Verify([4]byte{0x1}, operatorKey.Public()))
+	extended := *certificate
+	extended.ExpiryEpoch++
+	require.Error(t, extended.Verify(testDomain, operatorKey.Public()))
+
+	// The key is reused until half of its validity has passed.
+	sameCertificate, _, err := signer.Sign(1, 100+ed25519KeyRenewalEpochs, data)
+	require.NoError(t, err)
+	require.Same(t, certificate, sameCertificate)
+
+	renewedCertificate, signature, err := signer.Sign(1, 101+ed25519KeyRenewalEpochs, data)
+	require.NoError(t, err)
+	require.NotEqual(t, certificate.PublicKey, renewedCertificate.PublicKey)
+	require.NoError(t, renewedCertificate.Verify(testDomain, operatorKey.Public()))
+	require.NoError(t, renewedCertificate.VerifySignature(data, signature))
+
+	// A new key is certified when the operator's ID changes.
+	otherCertificate, _, err := signer.Sign(2, 101+ed25519KeyRenewalEpochs, data)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), otherCertificate.OperatorID)
+	require.NotEqual(t, renewedCertificate.PublicKey, otherCertificate.PublicKey)
+
+	malformed := *otherCertificate
+	malformed.PublicKey = malformed.PublicKey[:ed25519.PublicKeySize-1]
+	require.ErrorContains(t, malformed.Verify(testDomain, operatorKey.Public()), "unexpected Ed25519 public key size")
+}
+
+// The benchmarks below compare signing and verifying a message with the operator's RSA key
+// to doing so with a certified Ed25519 key, whose certificate is verified once per key.
+
+var benchmarkMessage = make([]byte, 1024)
+
+func BenchmarkOperatorSignRSA(b *testing.B) {
+	operatorKey, err := GeneratePrivateKey()
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := operatorKey.Sign(benchmarkMessage); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkOperatorSignEd25519(b *testing.B) {
+	operatorKey, err := GeneratePrivateKey()
+	require.NoError(b, err)
+	signer := NewEd25519Signer(operatorKey, testDomain)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := signer.Sign(1, 100, benchmarkMessage); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkOperatorVerifyRSA(b *testing.B) {
+	operatorKey, err := GeneratePrivateKey()
+	require.NoError(b, err)
+	signature, err := operatorKey.Sign(benchmarkMessage)
+	require.NoError(b, err)
+	publicKey := operatorKey.Public()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := publicKey.Verify(benchmarkMessage, signature); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkOperatorVerifyEd25519(b *testing.B) {
+	operatorKey, err := GeneratePrivateKey()
+	require.NoError(b, err)
+	certificate, signature, err := NewEd25519Signer(operatorKey, testDomain).Sign(1, 100, benchmarkMessage)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := certificate.VerifySignature(benchmarkMessage, signature); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -113,3 +113,37 @@ func TestPublicKeyFromString(t *testing.T) {
 	require.NoError(t, err, "Failed to parse public key")
 	require.NotNil(t, pubKey, "Parsed public key is nil")
 }
+
+func TestWithPreviousKeys(t *testing.T) {
+	currentKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	previousKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	key := keys.WithPreviousKeys(currentKey, previousKey)
+
+	for _, encryptingKey := range []keys.OperatorPrivateKey{currentKey, previousKey} {
+		encrypted, err := encryptingKey.Public().Encrypt([]byte("share"))
+		require.NoError(t, err)
+		decrypted, err := key.Decrypt(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, []byte("share"), decrypted)
+	}
+
+	encrypted, err := otherKey.Public().Encrypt([]byte("share"))
+	require.NoError(t, err)
+	_, err = key.Decrypt(encrypted)
+	require.Error(t, err)
+
+	// The rotated key signs and hashes as the current key.
+	currentHash, err := currentKey.StorageHash()
+	require.NoError(t, err)
+	hash, err := key.StorageHash()
+	require.NoError(t, err)
+	require.Equal(t, currentHash, hash)
+	signature, err := key.Sign([]byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, currentKey.Public().Verify([]byte("data"), signature))
+}
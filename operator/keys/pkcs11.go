@@ -0,0 +1,195 @@
+package keys
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/miekg/pkcs11"
+)
+
+const (
+	// pkcs11Sessions is the number of sessions opened with the token, which bounds the
+	// number of concurrent operations since a session performs one operation at a time.
+	pkcs11Sessions = 4
+
+	// The hashes of HSM keys are of signatures of these messages, since their private keys can't be exported.
+	// Signatures are deterministic, so the hashes are stable, and only the key's holder can compute them.
+	pkcs11StorageHashMessage = "ssv operator key storage hash"
+	pkcs11EKMHashMessage     = "ssv operator key manager encryption key"
+)
+
+// PKCS11Options locates an operator private key in an HSM.
+type PKCS11Options struct {
+	// Module is the path to the HSM's PKCS#11 module.
+	Module string
+	// Slot is the ID of the slot of the token holding the key.
+	Slot uint
+	// PIN is the user PIN of the token.
+	PIN string
+	// Label is the label of the RSA private key object.
+	Label string
+}
+
+// pkcs11PrivateKey is an operator private key which never leaves the HSM holding it.
+type pkcs11PrivateKey struct {
+	ctx       *pkcs11.Ctx
+	sessions  chan pkcs11.SessionHandle
+	object    pkcs11.ObjectHandle
+	publicKey *rsa.PublicKey
+}
+
+// PrivateKeyFromPKCS11 returns the RSA private key with the label in the HSM's token,
+// which signs and decrypts in the HSM. Since its private key can't be exported,
+// Bytes and Base64 return nil.
+func PrivateKeyFromPKCS11(opts PKCS11Options) (OperatorPrivateKey, error) {
+	ctx := pkcs11.New(opts.Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("could not load PKCS#11 module %q", opts.Module)
+	}
+	if err := ctx.Initialize(); err != nil && !isPKCS11Error(err, pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED) {
+		ctx.Destroy()
+		return nil, fmt.Errorf("could not initialize PKCS#11 module: %w", err)
+	}
+
+	key := &pkcs11PrivateKey{
+		ctx:      ctx,
+		sessions: make(chan pkcs11.SessionHandle, pkcs11Sessions),
+	}
+	if err := key.open(opts); err != nil {
+		_ = key.Close()
+		return nil, err
+	}
+	return key, nil
+}
+
+func (k *pkcs11PrivateKey) open(opts PKCS11Options) error {
+	for i := 0; i < pkcs11Sessions; i++ {
+		session, err := k.ctx.OpenSession(opts.Slot, pkcs11.CKF_SERIAL_SESSION)
+		if err != nil {
+			return fmt.Errorf("could not open session with slot %d: %w", opts.Slot, err)
+		}
+		k.sessions <- session
+	}
+
+	// Logging in applies to all of the application's sessions with the token.
+	session := <-k.sessions
+	defer func() { k.sessions <- session }()
+	if err := k.ctx.Login(session, pkcs11.CKU_USER, opts.PIN); err != nil && !isPKCS11Error(err, pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		return fmt.Errorf("could not log in to token: %w", err)
+	}
+
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_RSA),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, opts.Label),
+	}
+	if err := k.ctx.FindObjectsInit(session, template); err != nil {
+		return fmt.Errorf("could not search for key: %w", err)
+	}
+	objects, _, err := k.ctx.FindObjects(session, 2)
+	if finalErr := k.ctx.FindObjectsFinal(session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return fmt.Errorf("could not search for key: %w", err)
+	}
+	switch len(objects) {
+	case 0:
+		return fmt.Errorf("could not find RSA private key %q", opts.Label)
+	case 1:
+	default:
+		return fmt.Errorf("found multiple RSA private keys %q", opts.Label)
+	}
+	k.object = objects[0]
+
+	attributes, err := k.ctx.GetAttributeValue(session, k.object, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("could not get public key of %q: %w", opts.Label, err)
+	}
+	k.publicKey = &rsa.PublicKey{
+		N: new(big.Int).SetBytes(attributes[0].Value),
+		E: int(new(big.Int).SetBytes(attributes[1].Value).Int64()),
+	}
+	return nil
+}
+
+// Close closes the key's sessions and unloads the PKCS#11 module.
+func (k *pkcs11PrivateKey) Close() error {
+	close(k.sessions)
+	for session := range k.sessions {
+		_ = k.ctx.CloseSession(session)
+	}
+	err := k.ctx.Finalize()
+	k.ctx.Destroy()
+	return err
+}
+
+func (k *pkcs11PrivateKey) Public() OperatorPublicKey {
+	return &publicKey{pubKey: k.publicKey}
+}
+
+// Sign signs the SHA-256 of the data with PKCS #1 v1.5 padding, as privateKey does.
+func (k *pkcs11PrivateKey) Sign(data []byte) ([]byte, error) {
+	session := <-k.sessions
+	defer func() { k.sessions <- session }()
+
+	if err := k.ctx.SignInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_SHA256_RSA_PKCS, nil)}, k.object); err != nil {
+		return nil, fmt.Errorf("could not sign: %w", err)
+	}
+	signature, err := k.ctx.Sign(session, data)
+	if err != nil {
+		return nil, fmt.Errorf("could not sign: %w", err)
+	}
+	return signature, nil
+}
+
+// Decrypt decrypts data encrypted with PKCS #1 v1.5 padding, as privateKey does.
+func (k *pkcs11PrivateKey) Decrypt(data []byte) ([]byte, error) {
+	session := <-k.sessions
+	defer func() { k.sessions <- session }()
+
+	if err := k.ctx.DecryptInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS, nil)}, k.object); err != nil {
+		return nil, fmt.Errorf("could not decrypt key: %w", err)
+	}
+	decrypted, err := k.ctx.Decrypt(session, data)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt key: %w", err)
+	}
+	return decrypted, nil
+}
+
+func (k *pkcs11PrivateKey) StorageHash() (string, error) {
+	return k.signatureHash(pkcs11StorageHashMessage)
+}
+
+func (k *pkcs11PrivateKey) EKMHash() (string, error) {
+	return k.signatureHash(pkcs11EKMHashMessage)
+}
+
+func (k *pkcs11PrivateKey) signatureHash(message string) (string, error) {
+	signature, err := k.Sign([]byte(message))
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(signature)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func (k *pkcs11PrivateKey) Bytes() []byte {
+	return nil
+}
+
+func (k *pkcs11PrivateKey) Base64() []byte {
+	return nil
+}
+
+func isPKCS11Error(err error, code uint) bool {
+	pkcs11Err, ok := err.(pkcs11.Error)
+	return ok && uint(pkcs11Err) == code
+}
@@ -0,0 +1,157 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	softHSMTokenLabel = "ssv-test"
+	softHSMPIN        = "1234"
+	softHSMKeyLabel   = "operator"
+)
+
+// softHSMModule returns the path to SoftHSM's PKCS#11 module, from SOFTHSM2_MODULE or its usual locations.
+func softHSMModule(t *testing.T) string {
+	candidates := []string{
+		os.Getenv("SOFTHSM2_MODULE"),
+		"/usr/lib/softhsm/libsofthsm2.so",
+		"/usr/local/lib/softhsm/libsofthsm2.so",
+		"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+		"/opt/homebrew/lib/softhsm/libsofthsm2.so",
+	}
+	for _, path := range candidates {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	t.Skip("SoftHSM isn't installed, set SOFTHSM2_MODULE to its PKCS#11 module")
+	return ""
+}
+
+// setupSoftHSM initializes a token in a temporary SoftHSM store and imports the key to it,
+// returning the token's slot.
+func setupSoftHSM(t *testing.T, module string, key *rsa.PrivateKey) uint {
+	dir := t.TempDir()
+	conf := filepath.Join(dir, "softhsm2.conf")
+	tokens := filepath.Join(dir, "tokens")
+	require.NoError(t, os.Mkdir(tokens, 0700))
+	require.NoError(t, os.WriteFile(conf, []byte(fmt.Sprintf("directories.tokendir = %s\nobjectstore.backend = file\n", tokens)), 0600))
+	t.Setenv("SOFTHSM2_CONF", conf)
+
+	ctx := pkcs11.New(module)
+	require.NotNil(t, ctx)
+	defer ctx.Destroy()
+	require.NoError(t, ctx.Initialize())
+	defer func() { require.NoError(t, ctx.Finalize()) }()
+
+	slots, err := ctx.GetSlotList(false)
+	require.NoError(t, err)
+	require.NotEmpty(t, slots)
+	require.NoError(t, ctx.InitToken(slots[0], softHSMPIN, softHSMTokenLabel))
+
+	// SoftHSM moves initialized tokens to a new slot.
+	slots, err = ctx.GetSlotList(true)
+	require.NoError(t, err)
+	var slot uint
+	found := false
+	for _, s := range slots {
+		info, err := ctx.GetTokenInfo(s)
+		require.NoError(t, err)
+		if info.Label == softHSMTokenLabel {
+			slot, found = s, true
+		}
+	}
+	require.True(t, found)
+
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ctx.CloseSession(session)) }()
+	require.NoError(t, ctx.Login(session, pkcs11.CKU_SO, softHSMPIN))
+	require.NoError(t, ctx.InitPIN(session, softHSMPIN))
+	require.NoError(t, ctx.Logout(session))
+	require.NoError(t, ctx.Login(session, pkcs11.CKU_USER, softHSMPIN))
+
+	_, err = ctx.CreateObject(session, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_RSA),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_DECRYPT, true),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, softHSMKeyLabel),
+		pkcs11.NewAttribute(pkcs11.CKA_MODULUS, key.N.Bytes()),
+		pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, big.NewInt(int64(key.E)).Bytes()),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE_EXPONENT, key.D.Bytes()),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIME_1, key.Primes[0].Bytes()),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIME_2, key.Primes[1].Bytes()),
+		pkcs11.NewAttribute(pkcs11.CKA_EXPONENT_1, key.Precomputed.Dp.Bytes()),
+		pkcs11.NewAttribute(pkcs11.CKA_EXPONENT_2, key.Precomputed.Dq.Bytes()),
+		pkcs11.NewAttribute(pkcs11.CKA_COEFFICIENT, key.Precomputed.Qinv.Bytes()),
+	})
+	require.NoError(t, err)
+	return slot
+}
+
+func TestPKCS11PrivateKey(t *testing.T) {
+	module := softHSMModule(t)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	memoryKey := &privateKey{privKey: rsaKey}
+	slot := setupSoftHSM(t, module, rsaKey)
+
+	_, err = PrivateKeyFromPKCS11(PKCS11Options{Module: module, Slot: slot, PIN: softHSMPIN, Label: "missing"})
+	require.ErrorContains(t, err, `could not find RSA private key "missing"`)
+	_, err = PrivateKeyFromPKCS11(PKCS11Options{Module: module, Slot: slot, PIN: "4321", Label: softHSMKeyLabel})
+	require.ErrorContains(t, err, "could not log in to token")
+
+	hsmKey, err := PrivateKeyFromPKCS11(PKCS11Options{Module: module, Slot: slot, PIN: softHSMPIN, Label: softHSMKeyLabel})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, hsmKey.(*pkcs11PrivateKey).Close()) }()
+
+	// The HSM key is interchangeable with the same key in memory.
+	memoryPublicKey, err := memoryKey.Public().Base64()
+	require.NoError(t, err)
+	hsmPublicKey, err := hsmKey.Public().Base64()
+	require.NoError(t, err)
+	require.Equal(t, memoryPublicKey, hsmPublicKey)
+
+	data := []byte("message")
+	signature, err := hsmKey.Sign(data)
+	require.NoError(t, err)
+	require.NoError(t, memoryKey.Public().Verify(data, signature))
+	memorySignature, err := memoryKey.Sign(data)
+	require.NoError(t, err)
+	require.Equal(t, memorySignature, signature)
+
+	encrypted, err := memoryKey.Public().Encrypt([]byte("share"))
+	require.NoError(t, err)
+	decrypted, err := hsmKey.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, []byte("share"), decrypted)
+
+	// Its hashes are stable, and suitable as the key manager's encryption key.
+	ekmHash, err := hsmKey.EKMHash()
+	require.NoError(t, err)
+	require.Len(t, ekmHash, 64)
+	ekmHash2, err := hsmKey.EKMHash()
+	require.NoError(t, err)
+	require.Equal(t, ekmHash, ekmHash2)
+	storageHash, err := hsmKey.StorageHash()
+	require.NoError(t, err)
+	require.NotEqual(t, ekmHash, storageHash)
+	require.Nil(t, hsmKey.Bytes())
+}
@@ -0,0 +1,32 @@
+package keys
+
+type rotatedPrivateKey struct {
+	OperatorPrivateKey
+	previous []OperatorPrivateKey
+}
+
+// WithPreviousKeys returns the current key, which also decrypts data encrypted to the previous keys,
+// such as shares encrypted before the operator's key was rotated.
+// Signatures and hashes are only of the current key.
+func WithPreviousKeys(current OperatorPrivateKey, previous ...OperatorPrivateKey) OperatorPrivateKey {
+	if len(previous) == 0 {
+		return current
+	}
+	return &rotatedPrivateKey{
+		OperatorPrivateKey: current,
+		previous:           previous,
+	}
+}
+
+func (k *rotatedPrivateKey) Decrypt(data []byte) ([]byte, error) {
+	decrypted, err := k.OperatorPrivateKey.Decrypt(data)
+	if err == nil {
+		return decrypted, nil
+	}
+	for _, previous := range k.previous {
+		if decrypted, previousErr := previous.Decrypt(data); previousErr == nil {
+			return decrypted, nil
+		}
+	}
+	return nil, err
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/protocol/v2/message"
 
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"go.uber.org/zap"
@@ -80,11 +81,15 @@ func New(logger *zap.Logger, opts Options, slotTickerProvider slotticker.Provide
 		spectypes.BNRoleSyncCommitteeContribution,
 		spectypes.BNRoleValidatorRegistration,
 		spectypes.BNRoleVoluntaryExit,
+		message.BNRoleCommittee,
 	}
 	for _, role := range roles {
-		storageMap.Add(role, qbftstorage.New(opts.DB, role.String()))
+		storageMap.Add(role, qbftstorage.New(opts.DB, message.RoleToString(role)))
 	}
 
+	// Beacon clients which cache attestation data have it prefetched by the scheduler.
+	attestationDataCache, _ := opts.BeaconNode.(duties.AttestationDataCache)
+
 	node := &operatorNode{
 		context:          opts.Context,
 		validatorsCtrl:   opts.ValidatorController,
@@ -96,17 +101,19 @@ func New(logger *zap.Logger, opts Options, slotTickerProvider slotticker.Provide
 		storage:          opts.ValidatorOptions.RegistryStorage,
 		qbftStorage:      storageMap,
 		dutyScheduler: duties.NewScheduler(&duties.SchedulerOptions{
-			Ctx:                 opts.Context,
-			BeaconNode:          opts.BeaconNode,
-			ExecutionClient:     opts.ExecutionClient,
-			Network:             opts.Network,
-			ValidatorController: opts.ValidatorController,
-			IndicesChg:          opts.ValidatorController.IndicesChangeChan(),
-			ValidatorExitCh:     opts.ValidatorController.ValidatorExitChan(),
-			ExecuteDuty:         opts.ValidatorController.ExecuteDuty,
-			BuilderProposals:    opts.ValidatorOptions.BuilderProposals,
-			DutyStore:           opts.DutyStore,
-			SlotTickerProvider:  slotTickerProvider,
+			Ctx:                  opts.Context,
+			BeaconNode:           opts.BeaconNode,
+			ExecutionClient:      opts.ExecutionClient,
+			Network:              opts.Network,
+			ValidatorController:  opts.ValidatorController,
+			IndicesChg:           opts.ValidatorController.IndicesChangeChan(),
+			ValidatorExitCh:      opts.ValidatorController.ValidatorExitChan(),
+			ExecuteDuty:          opts.ValidatorController.ExecuteDuty,
+			BuilderProposals:     opts.ValidatorOptions.BuilderProposals,
+			ProposerSettings:     opts.ValidatorOptions.ProposerSettings,
+			DutyStore:            opts.DutyStore,
+			SlotTickerProvider:   slotTickerProvider,
+			AttestationDataCache: attestationDataCache,
 		}),
 		feeRecipientCtrl: fee_recipient.NewController(&fee_recipient.ControllerOptions{
 			Ctx:                opts.Context,
@@ -23,6 +23,7 @@ type Config struct {
 
 type slotTicker struct {
 	logger       *zap.Logger
+	now          func() time.Time
 	timer        Timer
 	slotDuration time.Duration
 	genesisTime  time.Time
@@ -34,9 +35,17 @@ func New(logger *zap.Logger, cfg Config) *slotTicker {
 	return newWithCustomTimer(logger, cfg, NewTimer)
 }
 
+// NewWithClock returns a SlotTicker which measures time with the given Clock.
+func NewWithClock(logger *zap.Logger, cfg Config, clock Clock) *slotTicker {
+	return newSlotTicker(logger, cfg, clock.Now, clock.NewTimer)
+}
+
 func newWithCustomTimer(logger *zap.Logger, cfg Config, timerProvider TimerProvider) *slotTicker {
-	now := time.Now()
-	timeSinceGenesis := now.Sub(cfg.GenesisTime)
+	return newSlotTicker(logger, cfg, time.Now, timerProvider)
+}
+
+func newSlotTicker(logger *zap.Logger, cfg Config, now func() time.Time, timerProvider TimerProvider) *slotTicker {
+	timeSinceGenesis := now().Sub(cfg.GenesisTime)
 
 	var initialDelay time.Duration
 	if timeSinceGenesis < 0 {
@@ -45,11 +54,12 @@ func newWithCustomTimer(logger *zap.Logger, cfg Config, timerProvider TimerProvi
 	} else {
 		slotsSinceGenesis := timeSinceGenesis / cfg.SlotDuration
 		nextSlotStartTime := cfg.GenesisTime.Add((slotsSinceGenesis + 1) * cfg.SlotDuration)
-		initialDelay = time.Until(nextSlotStartTime)
+		initialDelay = nextSlotStartTime.Sub(now())
 	}
 
 	return &slotTicker{
 		logger:       logger,
+		now:          now,
 		timer:        timerProvider(initialDelay),
 		slotDuration: cfg.SlotDuration,
 		genesisTime:  cfg.GenesisTime,
@@ -61,7 +71,7 @@ func newWithCustomTimer(logger *zap.Logger, cfg Config, timerProvider TimerProvi
 // Note: This function is not thread-safe and should be called in a serialized fashion.
 // Make sure no concurrent calls happen, as it can result in unexpected behavior.
 func (s *slotTicker) Next() <-chan time.Time {
-	timeSinceGenesis := time.Since(s.genesisTime)
+	timeSinceGenesis := s.now().Sub(s.genesisTime)
 	if timeSinceGenesis < 0 {
 		return s.timer.C()
 	}
@@ -79,7 +89,7 @@ func (s *slotTicker) Next() <-chan time.Time {
 		s.logger.Debug("double tick", zap.Uint64("slot", uint64(s.slot)))
 	}
 	nextSlotStartTime := s.genesisTime.Add(time.Duration(nextSlot) * s.slotDuration)
-	s.timer.Reset(time.Until(nextSlotStartTime))
+	s.timer.Reset(nextSlotStartTime.Sub(s.now()))
 	s.slot = nextSlot
 	return s.timer.C()
 }
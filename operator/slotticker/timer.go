@@ -2,6 +2,12 @@ package slotticker
 
 import "time"
 
+// Clock provides the current time and timers, allowing a SlotTicker to run on a virtual clock.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
 type TimerProvider func(d time.Duration) Timer
 
 type Timer interface {
@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// BlockJournalDepth is the number of blocks, behind the last processed block,
+// for which block journals are kept and reorgs can be undone.
+const BlockJournalDepth = 64
+
+var blockJournalPrefix = []byte("block_journal/")
+
+// BlockJournal records the registry changes made by the events of a processed block,
+// so that they can be undone if the block is reorganized out of the canonical chain.
+type BlockJournal struct {
+	BlockNumber uint64         `json:"block_number"`
+	BlockHash   common.Hash    `json:"block_hash"`
+	Entries     []JournalEntry `json:"entries,omitempty"`
+}
+
+// JournalEntry records the state of a registry object before it was changed by an event.
+// Only the fields of the changed object are set.
+type JournalEntry struct {
+	// AddedOperatorID is the ID of an operator which was added.
+	AddedOperatorID uint64 `json:"added_operator_id,omitempty"`
+
+	// PreviousOperator is the data of an operator before it was changed.
+	PreviousOperator *registrystorage.OperatorData `json:"previous_operator,omitempty"`
+
+	// RecipientOwner is the owner of recipient data which was changed,
+	// and PreviousRecipient is the data before the change, or nil if it didn't exist.
+	RecipientOwner    *common.Address                `json:"recipient_owner,omitempty"`
+	PreviousRecipient *registrystorage.RecipientData `json:"previous_recipient,omitempty"`
+
+	// ValidatorPubKey is the validator of a share which was changed,
+	// and PreviousShare is the encoded share before the change, or nil if it didn't exist.
+	ValidatorPubKey []byte `json:"validator_pubkey,omitempty"`
+	PreviousShare   []byte `json:"previous_share,omitempty"`
+
+	// EncryptedShareKey is the secret key of an own share which was removed from the key manager,
+	// encrypted with the operator's public key like in the contract's events.
+	EncryptedShareKey []byte `json:"encrypted_share_key,omitempty"`
+
+	// ClusterOwner and ClusterOperatorIDs identify a cluster which was changed,
+	// and PreviousCluster is the cluster before the change, or nil if it didn't exist.
+	ClusterOwner       *common.Address          `json:"cluster_owner,omitempty"`
+	ClusterOperatorIDs []uint64                 `json:"cluster_operator_ids,omitempty"`
+	PreviousCluster    *registrystorage.Cluster `json:"previous_cluster,omitempty"`
+
+	// PreviousNetworkFees is the network fees before they were changed.
+	PreviousNetworkFees *registrystorage.NetworkFees `json:"previous_network_fees,omitempty"`
+}
+
+// OperatorAdded records that the given operator was added.
+func (j *BlockJournal) OperatorAdded(id uint64) {
+	if j == nil {
+		return
+	}
+	j.Entries = append(j.Entries, JournalEntry{AddedOperatorID: id})
+}
+
+// OperatorChanged records the data of an operator before it's changed.
+func (j *BlockJournal) OperatorChanged(previous *registrystorage.OperatorData) {
+	if j == nil {
+		return
+	}
+	copied := *previous
+	j.Entries = append(j.Entries, JournalEntry{PreviousOperator: &copied})
+}
+
+// ClusterChanged records the given cluster before it's changed, or nil if there is none.
+func (j *BlockJournal) ClusterChanged(owner common.Address, operatorIDs []uint64, previous *registrystorage.Cluster) {
+	if j == nil {
+		return
+	}
+	j.Entries = append(j.Entries, JournalEntry{
+		ClusterOwner:       &owner,
+		ClusterOperatorIDs: append([]uint64{}, operatorIDs...),
+		PreviousCluster:    previous,
+	})
+}
+
+// NetworkFeesChanged records the network fees before they're changed.
+func (j *BlockJournal) NetworkFeesChanged(previous *registrystorage.NetworkFees) {
+	if j == nil {
+		return
+	}
+	copied := *previous
+	j.Entries = append(j.Entries, JournalEntry{PreviousNetworkFees: &copied})
+}
+
+// RecipientChanged records the recipient data of the given owner before it's changed, or nil if there is none.
+func (j *BlockJournal) RecipientChanged(owner common.Address, previous *registrystorage.RecipientData) {
+	if j == nil {
+		return
+	}
+	entry := JournalEntry{RecipientOwner: &owner}
+	if previous != nil {
+		copied := *previous
+		if previous.Nonce != nil {
+			nonce := *previous.Nonce
+			copied.Nonce = &nonce
+		}
+		entry.PreviousRecipient = &copied
+	}
+	j.Entries = append(j.Entries, entry)
+}
+
+// ShareChanged records the share of the given validator before it's changed, or nil if there is none.
+func (j *BlockJournal) ShareChanged(validatorPubKey []byte, previous *types.SSVShare) error {
+	if j == nil {
+		return nil
+	}
+	entry := JournalEntry{ValidatorPubKey: validatorPubKey}
+	if previous != nil {
+		encoded, err := previous.Encode()
+		if err != nil {
+			return err
+		}
+		entry.PreviousShare = encoded
+	}
+	j.Entries = append(j.Entries, entry)
+	return nil
+}
+
+// ShareKeyRemoved records the encrypted secret key of an own share before it's removed from the key manager.
+func (j *BlockJournal) ShareKeyRemoved(validatorPubKey []byte, encryptedKey []byte) {
+	if j == nil {
+		return
+	}
+	j.Entries = append(j.Entries, JournalEntry{ValidatorPubKey: validatorPubKey, EncryptedShareKey: encryptedKey})
+}
+
+// SaveBlockJournal saves the journal of a processed block.
+func (s *storage) SaveBlockJournal(rw basedb.ReadWriter, journal *BlockJournal) error {
+	raw, err := json.Marshal(journal)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal block journal")
+	}
+	return s.db.Using(rw).Set(storagePrefix, blockJournalKey(journal.BlockNumber), raw)
+}
+
+// ListBlockJournals returns the journals of the processed blocks, ordered by block number.
+func (s *storage) ListBlockJournals(r basedb.Reader) ([]*BlockJournal, error) {
+	var journals []*BlockJournal
+	err := s.db.UsingReader(r).GetAll(append(append([]byte{}, storagePrefix...), blockJournalPrefix...), func(i int, obj basedb.Obj) error {
+		journal := &BlockJournal{}
+		if err := json.Unmarshal(obj.Value, journal); err != nil {
+			return errors.Wrap(err, "could not unmarshal block journal")
+		}
+		journals = append(journals, journal)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(journals, func(i, j int) bool {
+		return journals[i].BlockNumber < journals[j].BlockNumber
+	})
+	return journals, nil
+}
+
+// DeleteBlockJournal deletes the journal of the given block.
+func (s *storage) DeleteBlockJournal(rw basedb.ReadWriter, blockNumber uint64) error {
+	return s.db.Using(rw).Delete(storagePrefix, blockJournalKey(blockNumber))
+}
+
+func (s *storage) dropBlockJournals() error {
+	return s.db.DropPrefix(append(append([]byte{}, storagePrefix...), blockJournalPrefix...))
+}
+
+// blockJournalKey builds the key of a block's journal, ordered by block number, e.g. "block_journal/<uint64 big endian>"
+func blockJournalKey(blockNumber uint64) []byte {
+	key := make([]byte, len(blockJournalPrefix)+8)
+	copy(key, blockJournalPrefix)
+	binary.BigEndian.PutUint64(key[len(blockJournalPrefix):], blockNumber)
+	return key
+}
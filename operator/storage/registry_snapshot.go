@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/operator/keys"
+)
+
+// RegistrySnapshotVersion is the version of the registry snapshot format.
+const RegistrySnapshotVersion = 1
+
+// RegistrySnapshot is a checkpoint of the registry at a block, signed by the operator which exported it.
+// A fresh node can import it and sync only the events after its block, instead of the full history.
+// It doesn't contain secret keys, an importing operator decrypts its own shares' keys from their ValidatorAdded events.
+// Its shares don't have the exporting operator's fields, which are set for the importing operator instead.
+type RegistrySnapshot struct {
+	Version     int         `json:"version"`
+	Network     string      `json:"network"`
+	BlockNumber uint64      `json:"block_number"`
+	BlockHash   common.Hash `json:"block_hash"`
+	// StateRoot is the root of the registry state (see RegistryState.Root).
+	StateRoot common.Hash `json:"state_root"`
+	RegistryState
+
+	// Signer is the base64 public key of the operator which signed the snapshot.
+	Signer    string `json:"signer"`
+	Signature []byte `json:"signature"`
+}
+
+// NewRegistrySnapshot creates an unsigned snapshot of the registry of the given storage,
+// which must have processed the events up to the given block.
+func NewRegistrySnapshot(s Storage, network string, header *ethtypes.Header) (*RegistrySnapshot, error) {
+	lastProcessedBlock, found, err := s.GetLastProcessedBlock(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get last processed block")
+	}
+	if !found || lastProcessedBlock.Cmp(header.Number) != 0 {
+		return nil, fmt.Errorf("registry isn't at block %d, last processed block is %v", header.Number, lastProcessedBlock)
+	}
+
+	state, err := LoadRegistryState(s)
+	if err != nil {
+		return nil, err
+	}
+	state.Shares = OperatorAgnosticShares(state.Shares)
+	root, err := state.Root()
+	if err != nil {
+		return nil, err
+	}
+	return &RegistrySnapshot{
+		Version:       RegistrySnapshotVersion,
+		Network:       network,
+		BlockNumber:   header.Number.Uint64(),
+		BlockHash:     header.Hash(),
+		StateRoot:     root,
+		RegistryState: *state,
+	}, nil
+}
+
+// Sign signs the snapshot with the given operator key.
+func (s *RegistrySnapshot) Sign(signer keys.OperatorSigner) error {
+	publicKey, err := signer.Public().Base64()
+	if err != nil {
+		return errors.Wrap(err, "could not encode signer public key")
+	}
+	s.Signer = string(publicKey)
+
+	root, err := s.signingRoot()
+	if err != nil {
+		return err
+	}
+	s.Signature, err = signer.Sign(root)
+	if err != nil {
+		return errors.Wrap(err, "could not sign snapshot")
+	}
+	return nil
+}
+
+// Verify checks that the snapshot is of the given network and signed by the given trusted signer.
+func (s *RegistrySnapshot) Verify(network string, trustedSigner string) error {
+	if s.Version != RegistrySnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+	if s.Network != network {
+		return fmt.Errorf("snapshot is of network %s", s.Network)
+	}
+	if s.Signer != trustedSigner {
+		return errors.New("snapshot isn't signed by the trusted signer")
+	}
+	publicKey, err := keys.PublicKeyFromString(s.Signer)
+	if err != nil {
+		return errors.Wrap(err, "could not decode signer public key")
+	}
+	root, err := s.signingRoot()
+	if err != nil {
+		return err
+	}
+	if err := publicKey.Verify(root, s.Signature); err != nil {
+		return errors.Wrap(err, "invalid snapshot signature")
+	}
+	stateRoot, err := s.RegistryState.Root()
+	if err != nil {
+		return err
+	}
+	if stateRoot != s.StateRoot {
+		return fmt.Errorf("snapshot state root %s doesn't match its registry's %s", s.StateRoot, stateRoot)
+	}
+	return nil
+}
+
+// VerifyHeader checks that the snapshot's block is the given canonical block.
+func (s *RegistrySnapshot) VerifyHeader(header *ethtypes.Header) error {
+	if header.Number.Uint64() != s.BlockNumber {
+		return fmt.Errorf("header is of block %d, snapshot is of block %d", header.Number, s.BlockNumber)
+	}
+	if header.Hash() != s.BlockHash {
+		return fmt.Errorf("snapshot block hash %s doesn't match the canonical block %s", s.BlockHash, header.Hash())
+	}
+	return nil
+}
+
+// signingRoot returns the signed data of the snapshot, which is its JSON encoding without the signature.
+func (s *RegistrySnapshot) signingRoot() ([]byte, error) {
+	unsigned := *s
+	unsigned.Signature = nil
+	root, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal snapshot")
+	}
+	return root, nil
+}
+
+// ImportRegistrySnapshot saves the registry of the given snapshot to the given storage, with the shares' fields
+// of the given operator, and sets its block as the last processed block. The storage must not have processed any events.
+// The state root of the imported registry is checked against the snapshot's.
+func ImportRegistrySnapshot(s Storage, snapshot *RegistrySnapshot, operatorID spectypes.OperatorID) error {
+	txn := s.Begin()
+	defer txn.Discard()
+
+	if _, found, err := s.GetLastProcessedBlock(txn); err != nil {
+		return errors.Wrap(err, "could not get last processed block")
+	} else if found {
+		return errors.New("registry has already processed events")
+	}
+
+	for i := range snapshot.Operators {
+		if _, err := s.SaveOperatorData(txn, &snapshot.Operators[i]); err != nil {
+			return errors.Wrap(err, "could not save operator data")
+		}
+	}
+	if err := s.Shares().Save(txn, OperatorShares(snapshot.Shares, operatorID)...); err != nil {
+		return errors.Wrap(err, "could not save shares")
+	}
+	for _, recipientData := range snapshot.Recipients {
+		if _, err := s.SaveRecipientData(txn, recipientData); err != nil {
+			return errors.Wrap(err, "could not save recipient data")
+		}
+	}
+
+	for _, cluster := range snapshot.Clusters {
+		if err := s.Clusters().SaveCluster(txn, cluster); err != nil {
+			return errors.Wrap(err, "could not save cluster")
+		}
+	}
+	if snapshot.NetworkFees != nil {
+		if err := s.Clusters().SaveNetworkFees(txn, snapshot.NetworkFees); err != nil {
+			return errors.Wrap(err, "could not save network fees")
+		}
+	}
+
+	imported, err := LoadRegistryStateTxn(s, txn)
+	if err != nil {
+		return errors.Wrap(err, "could not load imported registry")
+	}
+	stateRoot, err := imported.Root()
+	if err != nil {
+		return err
+	}
+	if stateRoot != snapshot.StateRoot {
+		return fmt.Errorf("imported registry's state root %s doesn't match the snapshot's %s", stateRoot, snapshot.StateRoot)
+	}
+
+	// Journal the snapshot's block, so that a reorg of it is detected.
+	if err := s.SaveBlockJournal(txn, &BlockJournal{BlockNumber: snapshot.BlockNumber, BlockHash: snapshot.BlockHash}); err != nil {
+		return errors.Wrap(err, "could not save block journal")
+	}
+	if err := s.SaveLastProcessedBlock(txn, new(big.Int).SetUint64(snapshot.BlockNumber)); err != nil {
+		return errors.Wrap(err, "could not save last processed block")
+	}
+	return txn.Commit()
+}
@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/operator/keys"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestRegistrySnapshot(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	newStorage := func() Storage {
+		db, err := kv.NewInMemory(logger, basedb.Options{})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		s, err := NewNodeStorage(logger, db)
+		require.NoError(t, err)
+		return s
+	}
+
+	synced := newStorage()
+	for id := uint64(1); id <= 4; id++ {
+		_, err := synced.SaveOperatorData(nil, &registrystorage.OperatorData{
+			ID:           id,
+			PublicKey:    []byte{byte(id)},
+			OwnerAddress: common.Address{byte(id)},
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, synced.Shares().Save(nil, &types.SSVShare{
+		Share: spectypes.Share{
+			ValidatorPubKey: spectypes.ValidatorPK{1, 2, 3},
+			OperatorID:      1,
+			SharePubKey:     []byte{7},
+			Committee:       []*spectypes.Operator{{OperatorID: 1, PubKey: []byte{7}}, {OperatorID: 2, PubKey: []byte{8}}},
+		},
+		Metadata: types.Metadata{
+			OwnerAddress: common.Address{1},
+		},
+	}))
+	require.NoError(t, synced.BumpNonce(nil, common.Address{1}))
+	require.NoError(t, synced.BumpNonce(nil, common.Address{1}))
+	require.NoError(t, synced.Clusters().SaveCluster(nil, &registrystorage.Cluster{
+		Owner:          common.Address{1},
+		OperatorIDs:    []uint64{1, 2},
+		ValidatorCount: 1,
+		Active:         true,
+		Balance:        big.NewInt(1000),
+		UpdatedBlock:   90,
+	}))
+	require.NoError(t, synced.Clusters().SaveNetworkFees(nil, &registrystorage.NetworkFees{
+		NetworkFee:                   big.NewInt(1),
+		LiquidationThresholdPeriod:   100,
+		MinimumLiquidationCollateral: big.NewInt(10),
+	}))
+
+	header := &ethtypes.Header{Number: big.NewInt(100), Root: common.Hash{9}, Difficulty: big.NewInt(0)}
+
+	// The snapshot must be of the last processed block.
+	_, err := NewRegistrySnapshot(synced, "testnet", header)
+	require.Error(t, err)
+	require.NoError(t, synced.SaveLastProcessedBlock(nil, big.NewInt(100)))
+
+	snapshot, err := NewRegistrySnapshot(synced, "testnet", header)
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), snapshot.BlockHash)
+	require.NoError(t, snapshot.VerifyHeader(header))
+	require.Error(t, snapshot.VerifyHeader(&ethtypes.Header{Number: big.NewInt(100), Root: common.Hash{8}, Difficulty: big.NewInt(0)}))
+	require.Error(t, snapshot.VerifyHeader(&ethtypes.Header{Number: big.NewInt(101), Root: common.Hash{9}, Difficulty: big.NewInt(0)}))
+
+	// The exporting operator's fields of its shares aren't exported.
+	require.Zero(t, snapshot.Shares[0].OperatorID)
+	require.Nil(t, snapshot.Shares[0].SharePubKey)
+	syncedState, err := LoadRegistryState(synced)
+	require.NoError(t, err)
+	syncedRoot, err := syncedState.Root()
+	require.NoError(t, err)
+	require.Equal(t, syncedRoot, snapshot.StateRoot)
+
+	signer, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	require.NoError(t, snapshot.Sign(signer))
+	signerPublicKey, err := signer.Public().Base64()
+	require.NoError(t, err)
+
+	// Verify the snapshot as it's read from a file.
+	data, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+	decoded := &RegistrySnapshot{}
+	require.NoError(t, json.Unmarshal(data, decoded))
+	require.NoError(t, decoded.Verify("testnet", string(signerPublicKey)))
+	require.Error(t, decoded.Verify("mainnet", string(signerPublicKey)))
+
+	otherSigner, err := keys.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherPublicKey, err := otherSigner.Public().Base64()
+	require.NoError(t, err)
+	require.Error(t, decoded.Verify("testnet", string(otherPublicKey)))
+
+	tampered := &RegistrySnapshot{}
+	require.NoError(t, json.Unmarshal(data, tampered))
+	tampered.Shares[0].OwnerAddress = common.Address{2}
+	require.Error(t, tampered.Verify("testnet", string(signerPublicKey)))
+
+	// A snapshot whose registry doesn't match its state root isn't verified or imported, even if it's signed.
+	tampered.StateRoot = snapshot.StateRoot
+	require.NoError(t, tampered.Sign(signer))
+	require.ErrorContains(t, tampered.Verify("testnet", string(signerPublicKey)), "state root")
+	tampered.StateRoot = common.Hash{1}
+	require.ErrorContains(t, ImportRegistrySnapshot(newStorage(), tampered, 2), "state root")
+
+	// Importing restores the registry, including nonces, at the snapshot's block,
+	// with the shares' fields of the importing operator.
+	fresh := newStorage()
+	require.NoError(t, ImportRegistrySnapshot(fresh, decoded, 2))
+	imported, err := LoadRegistryState(fresh)
+	require.NoError(t, err)
+	require.Len(t, imported.Shares, 1)
+	require.EqualValues(t, 2, imported.Shares[0].OperatorID)
+	require.Equal(t, []byte{8}, imported.Shares[0].SharePubKey)
+	require.True(t, fresh.Shares().List(nil, registrystorage.ByOperatorID(2))[0].BelongsToOperator(2))
+	importedRoot, err := imported.Root()
+	require.NoError(t, err)
+	require.Equal(t, snapshot.StateRoot, importedRoot)
+	imported.Shares = OperatorAgnosticShares(imported.Shares)
+	require.Empty(t, snapshot.RegistryState.Diff(imported))
+	require.Len(t, imported.Clusters, 1)
+	require.EqualValues(t, 100, imported.NetworkFees.LiquidationThresholdPeriod)
+	nonce, err := fresh.GetNextNonce(nil, common.Address{1})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, nonce)
+	lastProcessedBlock, found, err := fresh.GetLastProcessedBlock(nil)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 100, lastProcessedBlock.Uint64())
+	journals, err := fresh.ListBlockJournals(nil)
+	require.NoError(t, err)
+	require.Len(t, journals, 1)
+	require.Equal(t, snapshot.BlockHash, journals[0].BlockHash)
+
+	// An operator outside the shares' committees imports them without its fields.
+	other := newStorage()
+	require.NoError(t, ImportRegistrySnapshot(other, decoded, 3))
+	require.Zero(t, other.Shares().List(nil)[0].OperatorID)
+	require.Nil(t, other.Shares().List(nil)[0].SharePubKey)
+
+	// A node which has synced events doesn't import snapshots.
+	require.Error(t, ImportRegistrySnapshot(fresh, decoded, 2))
+}
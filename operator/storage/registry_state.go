@@ -0,0 +1,293 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// RegistryState is the registry data which the node builds from the contract's events.
+type RegistryState struct {
+	Operators  []registrystorage.OperatorData   `json:"operators"`
+	Shares     []*types.SSVShare                `json:"shares"`
+	Recipients []*registrystorage.RecipientData `json:"recipients"`
+	// Clusters and NetworkFees are omitted when empty, so that snapshots which predate them still verify.
+	Clusters    []*registrystorage.Cluster   `json:"clusters,omitempty"`
+	NetworkFees *registrystorage.NetworkFees `json:"network_fees,omitempty"`
+}
+
+// LoadRegistryState reads the registry data of the given storage, sorted by operator ID, validator, owner and cluster ID.
+func LoadRegistryState(s Storage) (*RegistryState, error) {
+	return LoadRegistryStateTxn(s, nil)
+}
+
+// LoadRegistryStateTxn reads the registry data of the given storage with the given reader,
+// sorted by operator ID, validator, owner and cluster ID.
+func LoadRegistryStateTxn(s Storage, r basedb.Reader) (*RegistryState, error) {
+	operators, err := s.ListOperators(r, 0, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list operators")
+	}
+	recipients, err := s.ListRecipients(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list recipients")
+	}
+	clusters, err := s.Clusters().ListClusters(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list clusters")
+	}
+	networkFees, err := s.Clusters().GetNetworkFees(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get network fees")
+	}
+	state := &RegistryState{
+		Operators:   operators,
+		Shares:      s.Shares().List(r),
+		Recipients:  recipients,
+		Clusters:    clusters,
+		NetworkFees: networkFees,
+	}
+
+	sort.Slice(state.Operators, func(i, j int) bool {
+		return state.Operators[i].ID < state.Operators[j].ID
+	})
+	sort.Slice(state.Shares, func(i, j int) bool {
+		return bytes.Compare(state.Shares[i].ValidatorPubKey, state.Shares[j].ValidatorPubKey) < 0
+	})
+	sort.Slice(state.Recipients, func(i, j int) bool {
+		return bytes.Compare(state.Recipients[i].Owner.Bytes(), state.Recipients[j].Owner.Bytes()) < 0
+	})
+	return state, nil
+}
+
+// Root returns the hash of the state's encoding, without the fields of shares which are specific
+// to the operator storing them, so that it's the same at every node which processed the same events.
+func (s *RegistryState) Root() (common.Hash, error) {
+	agnostic := *s
+	agnostic.Shares = OperatorAgnosticShares(s.Shares)
+	encoded, err := json.Marshal(agnostic)
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "could not encode registry state")
+	}
+	return sha256.Sum256(encoded), nil
+}
+
+// OperatorAgnosticShares returns copies of the given shares without the operator ID and share public key
+// of the operator storing them.
+func OperatorAgnosticShares(shares []*types.SSVShare) []*types.SSVShare {
+	agnostic := make([]*types.SSVShare, len(shares))
+	for i, share := range shares {
+		copied := *share
+		copied.OperatorID = 0
+		copied.SharePubKey = nil
+		agnostic[i] = &copied
+	}
+	return agnostic
+}
+
+// OperatorShares returns copies of the given operator agnostic shares, with the operator ID and share public key
+// of the given operator set in the shares of its committees.
+func OperatorShares(shares []*types.SSVShare, operatorID spectypes.OperatorID) []*types.SSVShare {
+	own := make([]*types.SSVShare, len(shares))
+	for i, share := range shares {
+		copied := *share
+		copied.OperatorID = 0
+		copied.SharePubKey = nil
+		for _, operator := range share.Committee {
+			if operatorID != 0 && operator.OperatorID == operatorID {
+				copied.OperatorID = operatorID
+				copied.SharePubKey = operator.PubKey
+				break
+			}
+		}
+		own[i] = &copied
+	}
+	return own
+}
+
+// Diff returns the differences of the other state from this state, one per line.
+// Beacon metadata of shares isn't compared, since it doesn't come from the contract's events.
+func (s *RegistryState) Diff(other *RegistryState) []string {
+	var diff []string
+
+	operators := make(map[uint64]registrystorage.OperatorData, len(s.Operators))
+	for _, od := range s.Operators {
+		operators[od.ID] = od
+	}
+	for _, od := range other.Operators {
+		expected, ok := operators[od.ID]
+		delete(operators, od.ID)
+		switch {
+		case !ok:
+			diff = append(diff, fmt.Sprintf("+ operator %d (owner %s)", od.ID, od.OwnerAddress))
+		case !equalOperators(expected, od):
+			diff = append(diff, fmt.Sprintf("~ operator %d (owner %s, fee %s, was owner %s, fee %s)",
+				od.ID, od.OwnerAddress, formatAmount(od.Fee), expected.OwnerAddress, formatAmount(expected.Fee)))
+		}
+	}
+	for _, od := range s.Operators {
+		if _, ok := operators[od.ID]; ok {
+			diff = append(diff, fmt.Sprintf("- operator %d (owner %s)", od.ID, od.OwnerAddress))
+		}
+	}
+
+	shares := make(map[string]*types.SSVShare, len(s.Shares))
+	for _, share := range s.Shares {
+		shares[hex.EncodeToString(share.ValidatorPubKey)] = share
+	}
+	for _, share := range other.Shares {
+		pubKey := hex.EncodeToString(share.ValidatorPubKey)
+		expected, ok := shares[pubKey]
+		delete(shares, pubKey)
+		switch {
+		case !ok:
+			diff = append(diff, fmt.Sprintf("+ share %s (owner %s)", pubKey, share.OwnerAddress))
+		case !equalShares(expected, share):
+			diff = append(diff, fmt.Sprintf("~ share %s (owner %s, liquidated %t, was owner %s, liquidated %t)",
+				pubKey, share.OwnerAddress, share.Liquidated, expected.OwnerAddress, expected.Liquidated))
+		}
+	}
+	for _, share := range s.Shares {
+		pubKey := hex.EncodeToString(share.ValidatorPubKey)
+		if _, ok := shares[pubKey]; ok {
+			diff = append(diff, fmt.Sprintf("- share %s (owner %s)", pubKey, share.OwnerAddress))
+		}
+	}
+
+	recipients := make(map[string]*registrystorage.RecipientData, len(s.Recipients))
+	for _, rd := range s.Recipients {
+		recipients[rd.Owner.Hex()] = rd
+	}
+	for _, rd := range other.Recipients {
+		expected, ok := recipients[rd.Owner.Hex()]
+		delete(recipients, rd.Owner.Hex())
+		switch {
+		case !ok:
+			diff = append(diff, fmt.Sprintf("+ recipient of %s (%x)", rd.Owner, rd.FeeRecipient))
+		case !reflect.DeepEqual(expected, rd):
+			diff = append(diff, fmt.Sprintf("~ recipient of %s (%x, nonce %s, was %x, nonce %s)",
+				rd.Owner, rd.FeeRecipient, formatNonce(rd.Nonce), expected.FeeRecipient, formatNonce(expected.Nonce)))
+		}
+	}
+	for _, rd := range s.Recipients {
+		if _, ok := recipients[rd.Owner.Hex()]; ok {
+			diff = append(diff, fmt.Sprintf("- recipient of %s (%x)", rd.Owner, rd.FeeRecipient))
+		}
+	}
+
+	clusters := make(map[string]*registrystorage.Cluster, len(s.Clusters))
+	for _, cluster := range s.Clusters {
+		clusters[hex.EncodeToString(cluster.ID())] = cluster
+	}
+	for _, cluster := range other.Clusters {
+		id := hex.EncodeToString(cluster.ID())
+		expected, ok := clusters[id]
+		delete(clusters, id)
+		switch {
+		case !ok:
+			diff = append(diff, fmt.Sprintf("+ cluster of %s %v (balance %s)", cluster.Owner, cluster.OperatorIDs, formatAmount(cluster.Balance)))
+		case !equalClusters(expected, cluster):
+			diff = append(diff, fmt.Sprintf("~ cluster of %s %v (balance %s, validators %d, was balance %s, validators %d)",
+				cluster.Owner, cluster.OperatorIDs, formatAmount(cluster.Balance), cluster.ValidatorCount,
+				formatAmount(expected.Balance), expected.ValidatorCount))
+		}
+	}
+	for _, cluster := range s.Clusters {
+		if _, ok := clusters[hex.EncodeToString(cluster.ID())]; ok {
+			diff = append(diff, fmt.Sprintf("- cluster of %s %v (balance %s)", cluster.Owner, cluster.OperatorIDs, formatAmount(cluster.Balance)))
+		}
+	}
+
+	if !equalNetworkFees(s.NetworkFees, other.NetworkFees) {
+		diff = append(diff, fmt.Sprintf("~ network fees (%s, was %s)", formatNetworkFees(other.NetworkFees), formatNetworkFees(s.NetworkFees)))
+	}
+
+	return diff
+}
+
+// equalOperators compares operators' data, comparing fees by value.
+func equalOperators(a, b registrystorage.OperatorData) bool {
+	return a.ID == b.ID &&
+		bytes.Equal(a.PublicKey, b.PublicKey) &&
+		a.OwnerAddress == b.OwnerAddress &&
+		equalAmounts(a.Fee, b.Fee) &&
+		equalAmounts(a.DeclaredFee, b.DeclaredFee) &&
+		a.WhitelistedAddress == b.WhitelistedAddress
+}
+
+func equalClusters(a, b *registrystorage.Cluster) bool {
+	return a.Owner == b.Owner &&
+		reflect.DeepEqual(a.OperatorIDs, b.OperatorIDs) &&
+		a.ValidatorCount == b.ValidatorCount &&
+		a.NetworkFeeIndex == b.NetworkFeeIndex &&
+		a.Index == b.Index &&
+		a.Active == b.Active &&
+		equalAmounts(a.Balance, b.Balance) &&
+		a.UpdatedBlock == b.UpdatedBlock
+}
+
+// equalNetworkFees compares network fees by value, treating nil as zero fees.
+func equalNetworkFees(a, b *registrystorage.NetworkFees) bool {
+	if a == nil {
+		a = &registrystorage.NetworkFees{}
+	}
+	if b == nil {
+		b = &registrystorage.NetworkFees{}
+	}
+	return equalAmounts(a.NetworkFee, b.NetworkFee) &&
+		a.LiquidationThresholdPeriod == b.LiquidationThresholdPeriod &&
+		equalAmounts(a.MinimumLiquidationCollateral, b.MinimumLiquidationCollateral)
+}
+
+// equalAmounts compares amounts by value, treating nil as zero.
+func equalAmounts(a, b *big.Int) bool {
+	if a == nil {
+		a = new(big.Int)
+	}
+	if b == nil {
+		b = new(big.Int)
+	}
+	return a.Cmp(b) == 0
+}
+
+func formatAmount(amount *big.Int) string {
+	if amount == nil {
+		return "0"
+	}
+	return amount.String()
+}
+
+func formatNetworkFees(networkFees *registrystorage.NetworkFees) string {
+	if networkFees == nil {
+		return "none"
+	}
+	return fmt.Sprintf("network fee %s, liquidation threshold period %d, minimum liquidation collateral %s",
+		formatAmount(networkFees.NetworkFee), networkFees.LiquidationThresholdPeriod, formatAmount(networkFees.MinimumLiquidationCollateral))
+}
+
+// equalShares compares the shares' data which comes from the contract's events.
+func equalShares(a, b *types.SSVShare) bool {
+	return reflect.DeepEqual(a.Share, b.Share) &&
+		a.OwnerAddress == b.OwnerAddress &&
+		a.Liquidated == b.Liquidated
+}
+
+func formatNonce(nonce *registrystorage.Nonce) string {
+	if nonce == nil {
+		return "none"
+	}
+	return fmt.Sprint(*nonce)
+}
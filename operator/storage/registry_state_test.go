@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"testing"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestRegistryState(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	newStorage := func(operators []uint64, liquidated bool, recipients []common.Address) Storage {
+		db, err := kv.NewInMemory(logger, basedb.Options{})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		s, err := NewNodeStorage(logger, db)
+		require.NoError(t, err)
+
+		for _, id := range operators {
+			_, err := s.SaveOperatorData(nil, &registrystorage.OperatorData{
+				ID:           id,
+				PublicKey:    []byte{byte(id)},
+				OwnerAddress: common.Address{byte(id)},
+			})
+			require.NoError(t, err)
+		}
+		for _, owner := range recipients {
+			_, err := s.SaveRecipientData(nil, &registrystorage.RecipientData{Owner: owner})
+			require.NoError(t, err)
+		}
+		require.NoError(t, s.Shares().Save(nil, &types.SSVShare{
+			Share: spectypes.Share{
+				ValidatorPubKey: spectypes.ValidatorPK{1, 2, 3},
+				SharePubKey:     []byte{4, 5, 6},
+			},
+			Metadata: types.Metadata{
+				OwnerAddress: common.Address{1},
+				Liquidated:   liquidated,
+			},
+		}))
+		return s
+	}
+
+	live, err := LoadRegistryState(newStorage([]uint64{3, 1, 2}, false, []common.Address{{1}, {2}}))
+	require.NoError(t, err)
+	require.Len(t, live.Operators, 3)
+	require.EqualValues(t, 1, live.Operators[0].ID)
+	require.EqualValues(t, 3, live.Operators[2].ID)
+	require.Len(t, live.Shares, 1)
+	require.Len(t, live.Recipients, 2)
+
+	// Identical states have no differences, regardless of beacon metadata.
+	same, err := LoadRegistryState(newStorage([]uint64{1, 2, 3}, false, []common.Address{{1}, {2}}))
+	require.NoError(t, err)
+	same.Shares[0].BeaconMetadata = &beaconprotocol.ValidatorMetadata{Index: 1}
+	require.Empty(t, live.Diff(same))
+
+	replayed, err := LoadRegistryState(newStorage([]uint64{1, 2, 4}, true, []common.Address{{2}, {3}}))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"+ operator 4 (owner 0x0400000000000000000000000000000000000000)",
+		"- operator 3 (owner 0x0300000000000000000000000000000000000000)",
+		"~ share 010203 (owner 0x0100000000000000000000000000000000000000, liquidated true, was owner 0x0100000000000000000000000000000000000000, liquidated false)",
+		"+ recipient of 0x0300000000000000000000000000000000000000 (0000000000000000000000000000000000000000)",
+		"- recipient of 0x0100000000000000000000000000000000000000 (0000000000000000000000000000000000000000)",
+	}, live.Diff(replayed))
+}
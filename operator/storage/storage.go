@@ -35,6 +35,10 @@ type Storage interface {
 	SaveLastProcessedBlock(rw basedb.ReadWriter, offset *big.Int) error
 	GetLastProcessedBlock(r basedb.Reader) (*big.Int, bool, error)
 
+	SaveBlockJournal(rw basedb.ReadWriter, journal *BlockJournal) error
+	ListBlockJournals(r basedb.Reader) ([]*BlockJournal, error)
+	DeleteBlockJournal(rw basedb.ReadWriter, blockNumber uint64) error
+
 	GetConfig(rw basedb.ReadWriter) (*ConfigLock, bool, error)
 	SaveConfig(rw basedb.ReadWriter, config *ConfigLock) error
 	DeleteConfig(rw basedb.ReadWriter) error
@@ -44,9 +48,11 @@ type Storage interface {
 	registrystorage.Operators
 	registrystorage.Recipients
 	Shares() registrystorage.Shares
+	SignedExits() registrystorage.SignedExits
+	Clusters() registrystorage.Clusters
 
 	GetPrivateKeyHash() (string, bool, error)
-	SavePrivateKeyHash(privKeyHash string) error
+	SavePrivateKeyHash(rw basedb.ReadWriter, privKeyHash string) error
 }
 
 type storage struct {
@@ -56,6 +62,8 @@ type storage struct {
 	operatorStore  registrystorage.Operators
 	recipientStore registrystorage.Recipients
 	shareStore     registrystorage.Shares
+	signedExits    registrystorage.SignedExits
+	clusterStore   registrystorage.Clusters
 }
 
 // NewNodeStorage creates a new instance of Storage
@@ -65,6 +73,8 @@ func NewNodeStorage(logger *zap.Logger, db basedb.Database) (Storage, error) {
 		db:             db,
 		operatorStore:  registrystorage.NewOperatorsStorage(logger, db, storagePrefix),
 		recipientStore: registrystorage.NewRecipientsStorage(logger, db, storagePrefix),
+		signedExits:    registrystorage.NewSignedExitsStorage(logger, db, storagePrefix),
+		clusterStore:   registrystorage.NewClustersStorage(logger, db, storagePrefix),
 	}
 	var err error
 	stg.shareStore, err = registrystorage.NewSharesStorage(logger, db, storagePrefix)
@@ -86,6 +96,14 @@ func (s *storage) Shares() registrystorage.Shares {
 	return s.shareStore
 }
 
+func (s *storage) SignedExits() registrystorage.SignedExits {
+	return s.signedExits
+}
+
+func (s *storage) Clusters() registrystorage.Clusters {
+	return s.clusterStore
+}
+
 func (s *storage) GetOperatorDataByPubKey(r basedb.Reader, operatorPubKey []byte) (*registrystorage.OperatorData, bool, error) {
 	return s.operatorStore.GetOperatorDataByPubKey(r, operatorPubKey)
 }
@@ -102,6 +120,10 @@ func (s *storage) SaveOperatorData(rw basedb.ReadWriter, operatorData *registrys
 	return s.operatorStore.SaveOperatorData(rw, operatorData)
 }
 
+func (s *storage) UpdateOperatorData(rw basedb.ReadWriter, operatorData *registrystorage.OperatorData) error {
+	return s.operatorStore.UpdateOperatorData(rw, operatorData)
+}
+
 func (s *storage) DeleteOperatorData(rw basedb.ReadWriter, id spectypes.OperatorID) error {
 	return s.operatorStore.DeleteOperatorData(rw, id)
 }
@@ -122,6 +144,10 @@ func (s *storage) GetRecipientDataMany(r basedb.Reader, owners []common.Address)
 	return s.recipientStore.GetRecipientDataMany(r, owners)
 }
 
+func (s *storage) ListRecipients(r basedb.Reader) ([]*registrystorage.RecipientData, error) {
+	return s.recipientStore.ListRecipients(r)
+}
+
 func (s *storage) SaveRecipientData(rw basedb.ReadWriter, recipientData *registrystorage.RecipientData) (*registrystorage.RecipientData, error) {
 	return s.recipientStore.SaveRecipientData(rw, recipientData)
 }
@@ -147,6 +173,10 @@ func (s *storage) DropRegistryData() error {
 	if err != nil {
 		return errors.Wrap(err, "failed to drop last processed block")
 	}
+	err = s.dropBlockJournals()
+	if err != nil {
+		return errors.Wrap(err, "failed to drop block journals")
+	}
 	err = s.DropShares()
 	if err != nil {
 		return errors.Wrap(err, "failed to drop operators")
@@ -159,6 +189,10 @@ func (s *storage) DropRegistryData() error {
 	if err != nil {
 		return errors.Wrap(err, "failed to drop shares")
 	}
+	err = s.clusterStore.DropClusters()
+	if err != nil {
+		return errors.Wrap(err, "failed to drop clusters")
+	}
 	return nil
 }
 
@@ -211,8 +245,8 @@ func (s *storage) GetPrivateKeyHash() (string, bool, error) {
 }
 
 // SavePrivateKeyHash saves operator private key hash
-func (s *storage) SavePrivateKeyHash(hashedKey string) error {
-	return s.db.Set(storagePrefix, []byte(HashedPrivateKey), []byte(hashedKey))
+func (s *storage) SavePrivateKeyHash(rw basedb.ReadWriter, hashedKey string) error {
+	return s.db.Using(rw).Set(storagePrefix, []byte(HashedPrivateKey), []byte(hashedKey))
 }
 
 func (s *storage) UpdateValidatorMetadata(pk string, metadata *beacon.ValidatorMetadata) error {
@@ -43,7 +43,7 @@ func TestSaveAndGetPrivateKeyHash(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, pkPem, string(encodedPubKey))
 
-	require.NoError(t, operatorStorage.SavePrivateKeyHash(parsedPrivKeyHash))
+	require.NoError(t, operatorStorage.SavePrivateKeyHash(nil, parsedPrivKeyHash))
 	extractedHash, found, err := operatorStorage.GetPrivateKeyHash()
 	require.True(t, true, found)
 	require.NoError(t, err)
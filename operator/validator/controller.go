@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"encoding/json"
@@ -24,6 +25,8 @@ import (
 	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/message/validation"
 	"github.com/bloxapp/ssv/network"
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/notifications"
 	operatordatastore "github.com/bloxapp/ssv/operator/datastore"
 	"github.com/bloxapp/ssv/operator/duties"
 	nodestorage "github.com/bloxapp/ssv/operator/storage"
@@ -69,6 +72,7 @@ type ControllerOptions struct {
 	FullNode                   bool `yaml:"FullNode" env:"FULLNODE" env-default:"false" env-description:"Save decided history rather than just highest messages"`
 	Exporter                   bool `yaml:"Exporter" env:"EXPORTER" env-default:"false" env-description:""`
 	BuilderProposals           bool `yaml:"BuilderProposals" env:"BUILDER_PROPOSALS" env-default:"false" env-description:"Use external builders to produce blocks"`
+	BatchedConsensus           bool `yaml:"BatchedConsensus" env:"BATCHED_CONSENSUS" env-default:"false" env-description:"Run a single consensus per committee and slot for attester and sync committee duties, once the network's committee consensus fork is reached"`
 	KeyManager                 spectypes.KeyManager
 	OperatorDataStore          operatordatastore.OperatorDataStore
 	RegistryStorage            nodestorage.Storage
@@ -78,12 +82,22 @@ type ControllerOptions struct {
 	StorageMap                 *storage.QBFTStores
 	Metrics                    validator.Metrics
 	MessageValidator           validation.MessageValidator
+	Notifier                   notifications.Notifier
 	ValidatorsMap              *validatorsmap.ValidatorsMap
 
 	// worker flags
 	WorkersCount    int `yaml:"MsgWorkersCount" env:"MSG_WORKERS_COUNT" env-default:"256" env-description:"Number of goroutines to use for message workers"`
 	QueueBufferSize int `yaml:"MsgWorkerBufferSize" env:"MSG_WORKER_BUFFER_SIZE" env-default:"1024" env-description:"Buffer size for message workers"`
+	QueueWorkers    int `yaml:"QueueWorkers" env:"QUEUE_WORKERS" env-default:"0" env-description:"Number of workers consuming all the validators' queues, or 0 for a goroutine per queue"`
 	GasLimit        uint64
+	// ProposerSettings, if set, overrides BuilderProposals and GasLimit per validator.
+	ProposerSettings beaconprotocol.ProposerSettingsProvider
+	// GraffitiData holds the node's variables of graffiti templates.
+	GraffitiData beaconprotocol.GraffitiData
+	// NetworkConfig is used to verify owners' exit requests.
+	NetworkConfig networkconfig.NetworkConfig
+	// SignedExitsDir, if set, is where pre-signed voluntary exits are written to, in addition to the database.
+	SignedExitsDir string `yaml:"SignedExitsDir" env:"SIGNED_EXITS_DIR" env-description:"Directory to write pre-signed voluntary exits to"`
 }
 
 // Controller represent the validators controller,
@@ -108,6 +122,8 @@ type Controller interface {
 	StartValidator(share *ssvtypes.SSVShare) error
 	StopValidator(pubKey spectypes.ValidatorPK) error
 	LiquidateCluster(owner common.Address, operatorIDs []uint64, toLiquidate []*ssvtypes.SSVShare) error
+	RequestExit(request *ssvtypes.ExitRequest) error
+	VerifyExitClaim(claim *ssvtypes.ExitClaim) error
 	ReactivateCluster(owner common.Address, operatorIDs []uint64, toReactivate []*ssvtypes.SSVShare) error
 	UpdateFeeRecipient(owner, recipient common.Address) error
 	ExitValidator(pubKey phase0.BLSPubKey, blockNumber uint64, validatorIndex phase0.ValidatorIndex) error
@@ -178,6 +194,19 @@ type controller struct {
 	metadataLastUpdated       map[string]time.Time
 	indicesChange             chan struct{}
 	validatorExitCh           chan duties.ExitDescriptor
+
+	networkConfig       networkconfig.NetworkConfig
+	requestedExits      map[requestedExit]phase0.Slot
+	requestedExitsLock  sync.Mutex
+	exitClaimNonces     map[string]uint64
+	exitClaimNoncesLock sync.Mutex
+	signedExitsStorage  registrystorage.SignedExits
+	signedExitsDir      string
+
+	// committeeAnchors caches the public key of each committee's anchor validator at committeeAnchorsEpoch.
+	committeeAnchors      map[string]phase0.BLSPubKey
+	committeeAnchorsEpoch phase0.Epoch
+	committeeAnchorsLock  sync.Mutex
 }
 
 // NewController creates a new validator controller instance
@@ -206,9 +235,13 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		FullNode:          options.FullNode,
 		Exporter:          options.Exporter,
 		BuilderProposals:  options.BuilderProposals,
+		BatchedConsensus:  options.BatchedConsensus && options.NetworkConfig.CommitteeConsensusEpoch != 0,
 		GasLimit:          options.GasLimit,
+		ProposerSettings:  options.ProposerSettings,
+		GraffitiData:      options.GraffitiData,
 		MessageValidator:  options.MessageValidator,
 		Metrics:           options.Metrics,
+		Notifier:          options.Notifier,
 	}
 
 	// If full node, increase queue size to make enough room
@@ -225,6 +258,13 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		metrics = options.Metrics
 	}
 
+	if options.QueueWorkers > 0 {
+		scheduler := queue.NewScheduler(logger.Named(logging.NameController), options.QueueWorkers,
+			options.BeaconNetwork.GetSlotStartTime, metrics)
+		go scheduler.Start(options.Context)
+		validatorOptions.Scheduler = scheduler
+	}
+
 	ctrl := controller{
 		logger:            logger.Named(logging.NameController),
 		metrics:           metrics,
@@ -257,9 +297,18 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		validatorExitCh:         make(chan duties.ExitDescriptor),
 		committeeValidatorSetup: make(chan struct{}, 1),
 
+		networkConfig:      options.NetworkConfig,
+		requestedExits:     make(map[requestedExit]phase0.Slot),
+		exitClaimNonces:    make(map[string]uint64),
+		signedExitsStorage: options.RegistryStorage.SignedExits(),
+		signedExitsDir:     options.SignedExitsDir,
+
 		messageValidator: options.MessageValidator,
 	}
 
+	ctrl.validatorOptions.VoluntaryExitEscrow = &ctrl
+	ctrl.validatorOptions.CommitteeValidators = &ctrl
+
 	// Start automatic expired item deletion in nonCommitteeValidators.
 	go ctrl.nonCommitteeValidators.Start()
 
@@ -324,6 +373,10 @@ func (c *controller) handleRouterMessages() {
 			if msg.MsgType == message.SSVEventMsgType {
 				continue
 			}
+			if msg.MsgType == message.SSVExitRequestMsgType {
+				c.handleExitRequestMessage(msg)
+				continue
+			}
 
 			pk := msg.GetID().GetPubKey()
 			hexPK := hex.EncodeToString(pk)
@@ -580,6 +633,11 @@ func (c *controller) ExecuteDuty(logger *zap.Logger, duty *spectypes.Duty) {
 
 	pubKeyString := hex.EncodeToString(pk[:])
 	if v, ok := c.GetValidator(pubKeyString); ok {
+		epoch := c.networkConfig.Beacon.EstimatedEpochAtSlot(duty.Slot)
+		if c.validatorOptions.BatchedConsensus && runner.IsCommitteeRole(duty.Type) && c.networkConfig.RunsCommitteeConsensus(epoch) {
+			c.executeCommitteeDuty(logger, v, duty)
+			return
+		}
 		ssvMsg, err := CreateDutyExecuteMsg(duty, pk, types.GetDefaultDomain())
 		if err != nil {
 			logger.Error("could not create duty execute msg", zap.Error(err))
@@ -599,6 +657,120 @@ func (c *controller) ExecuteDuty(logger *zap.Logger, duty *spectypes.Duty) {
 	}
 }
 
+// executeCommitteeDuty adds the duty to the committee runner of the validator's committee anchor,
+// and has it execute the committee's duty for the slot.
+func (c *controller) executeCommitteeDuty(logger *zap.Logger, v *validator.Validator, duty *spectypes.Duty) {
+	anchor := c.committeeAnchor(v, c.networkConfig.Beacon.EstimatedEpochAtSlot(duty.Slot))
+	if anchor == nil {
+		logger.Warn("could not find committee anchor", fields.PubKey(duty.PubKey[:]))
+		return
+	}
+	committeeRunner, ok := anchor.DutyRunners[message.BNRoleCommittee].(*runner.CommitteeRunner)
+	if !ok {
+		logger.Warn("committee anchor has no committee runner", fields.PubKey(anchor.Share.ValidatorPubKey))
+		return
+	}
+	committeeRunner.AddDuty(duty, &v.Share.Share)
+
+	var anchorPK phase0.BLSPubKey
+	copy(anchorPK[:], anchor.Share.ValidatorPubKey)
+	committeeDuty := &spectypes.Duty{
+		Type:   message.BNRoleCommittee,
+		PubKey: anchorPK,
+		Slot:   duty.Slot,
+	}
+	ssvMsg, err := CreateDutyExecuteMsg(committeeDuty, anchorPK, types.GetDefaultDomain())
+	if err != nil {
+		logger.Error("could not create duty execute msg", zap.Error(err))
+		return
+	}
+	dec, err := queue.DecodeSSVMessage(ssvMsg)
+	if err != nil {
+		logger.Error("could not decode duty execute msg", zap.Error(err))
+		return
+	}
+	if pushed := anchor.Queues[message.BNRoleCommittee].Q.TryPush(dec); !pushed {
+		logger.Warn("dropping ExecuteDuty message because the queue is full")
+	}
+}
+
+// committeeAnchor returns the validator hosting the committee runner of the given validator's committee,
+// which is the one with the lowest public key of the committee's attesting validators at the epoch.
+// The anchor is chosen from the registry's shares, which every operator of the committee shares,
+// rather than from the validators this operator happens to run, and it's cached per committee for the epoch.
+func (c *controller) committeeAnchor(v *validator.Validator, epoch phase0.Epoch) *validator.Validator {
+	c.committeeAnchorsLock.Lock()
+	if c.committeeAnchors == nil || c.committeeAnchorsEpoch != epoch {
+		c.committeeAnchors = c.findCommitteeAnchors(epoch)
+		c.committeeAnchorsEpoch = epoch
+	}
+	anchorPK, ok := c.committeeAnchors[committeeKey(v.Share.Committee)]
+	c.committeeAnchorsLock.Unlock()
+	if !ok {
+		return nil
+	}
+
+	anchor, ok := c.validatorsMap.GetValidator(hex.EncodeToString(anchorPK[:]))
+	if !ok || !anchor.Started() {
+		return nil
+	}
+	return anchor
+}
+
+// findCommitteeAnchors returns the anchor of each of this operator's committees at the epoch.
+func (c *controller) findCommitteeAnchors(epoch phase0.Epoch) map[string]phase0.BLSPubKey {
+	anchors := make(map[string]phase0.BLSPubKey)
+	shares := c.sharesStorage.List(nil,
+		registrystorage.ByOperatorID(c.operatorDataStore.GetOperatorID()),
+		registrystorage.ByNotLiquidated(),
+		registrystorage.ByAttesting(epoch),
+	)
+	for _, share := range shares {
+		key := committeeKey(share.Committee)
+		if anchor, ok := anchors[key]; ok && bytes.Compare(anchor[:], share.ValidatorPubKey) <= 0 {
+			continue
+		}
+		var pk phase0.BLSPubKey
+		copy(pk[:], share.ValidatorPubKey)
+		anchors[key] = pk
+	}
+	return anchors
+}
+
+// resetCommitteeAnchors has the committees' anchors chosen again, after validators were added or removed.
+func (c *controller) resetCommitteeAnchors() {
+	c.committeeAnchorsLock.Lock()
+	defer c.committeeAnchorsLock.Unlock()
+	c.committeeAnchors = nil
+}
+
+// IsCommitteeValidator implements runner.CommitteeValidators.
+func (c *controller) IsCommitteeValidator(pubKey phase0.BLSPubKey, committee []*spectypes.Operator) bool {
+	share := c.sharesStorage.Get(nil, pubKey[:])
+	return share != nil && !share.Liquidated && sameCommittee(share.Committee, committee)
+}
+
+// committeeKey identifies a committee by its operator IDs.
+func committeeKey(committee []*spectypes.Operator) string {
+	operatorIDs := make([]uint64, len(committee))
+	for i, operator := range committee {
+		operatorIDs[i] = operator.OperatorID
+	}
+	return beaconprotocol.ClusterString(operatorIDs)
+}
+
+func sameCommittee(a, b []*spectypes.Operator) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].OperatorID != b[i].OperatorID {
+			return false
+		}
+	}
+	return true
+}
+
 // CreateDutyExecuteMsg returns ssvMsg with event type of duty execute
 func CreateDutyExecuteMsg(duty *spectypes.Duty, pubKey phase0.BLSPubKey, domain spectypes.DomainType) (*spectypes.SSVMessage, error) {
 	executeDutyData := types.ExecuteDutyData{Duty: duty}
@@ -676,6 +848,7 @@ func (c *controller) onMetadataUpdated(pk string, meta *beaconprotocol.Validator
 func (c *controller) onShareStop(pubKey spectypes.ValidatorPK) {
 	// remove from ValidatorsMap
 	v := c.validatorsMap.RemoveValidator(hex.EncodeToString(pubKey))
+	c.resetCommitteeAnchors()
 
 	// stop instance
 	if v != nil {
@@ -710,6 +883,7 @@ func (c *controller) onShareInit(share *ssvtypes.SSVShare) (*validator.Validator
 
 		v = validator.NewValidator(ctx, cancel, opts)
 		c.validatorsMap.CreateValidator(hex.EncodeToString(share.ValidatorPubKey), v)
+		c.resetCommitteeAnchors()
 
 		c.printShare(share, "setup validator done")
 
@@ -918,7 +1092,7 @@ func SetupRunners(ctx context.Context, logger *zap.Logger, options validator.Opt
 			},
 			Storage:               options.Storage.Get(role),
 			Network:               options.Network,
-			Timer:                 roundtimer.New(ctx, options.BeaconNetwork, role, nil),
+			Timer:                 roundtimer.NewWithClock(ctx, options.BeaconNetwork, role, nil, options.Clock),
 			SignatureVerification: true,
 		}
 		config.ValueCheckF = valueCheckF
@@ -941,6 +1115,8 @@ func SetupRunners(ctx context.Context, logger *zap.Logger, options validator.Opt
 			qbftCtrl := buildController(spectypes.BNRoleProposer, proposedValueCheck)
 			runners[role] = runner.NewProposerRunner(options.BeaconNetwork.GetBeaconNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, proposedValueCheck, 0)
 			runners[role].(*runner.ProposerRunner).ProducesBlindedBlocks = options.BuilderProposals // apply blinded block flag
+			runners[role].(*runner.ProposerRunner).ProposerSettings = options.ProposerSettings
+			runners[role].(*runner.ProposerRunner).GraffitiData = options.GraffitiData
 		case spectypes.BNRoleAggregator:
 			aggregatorValueCheckF := specssv.AggregatorValueCheckF(options.Signer, options.BeaconNetwork.GetBeaconNetwork(), options.SSVShare.Share.ValidatorPubKey, options.SSVShare.BeaconMetadata.Index)
 			qbftCtrl := buildController(spectypes.BNRoleAggregator, aggregatorValueCheckF)
@@ -956,9 +1132,23 @@ func SetupRunners(ctx context.Context, logger *zap.Logger, options validator.Opt
 		case spectypes.BNRoleValidatorRegistration:
 			qbftCtrl := buildController(spectypes.BNRoleValidatorRegistration, nil)
 			runners[role] = runner.NewValidatorRegistrationRunner(options.BeaconNetwork.GetBeaconNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer)
+			runners[role].(*runner.ValidatorRegistrationRunner).ProposerSettings = options.ProposerSettings
 		case spectypes.BNRoleVoluntaryExit:
 			runners[role] = runner.NewVoluntaryExitRunner(options.BeaconNetwork.GetBeaconNetwork(), &options.SSVShare.Share, options.Beacon, options.Network, options.Signer)
+			runners[role].(*runner.VoluntaryExitRunner).Escrow = options.VoluntaryExitEscrow
+		}
+	}
+	if options.BatchedConsensus {
+		// The value check is built before the runner, whose pending attester duties it checks for slashing.
+		var committeeRunner *runner.CommitteeRunner
+		slashable := func(vote *phase0.AttestationData) error {
+			return committeeRunner.IsVoteSlashable(vote)
 		}
+		committeeValueCheckF := runner.CommitteeValueCheckF(options.BeaconNetwork.GetBeaconNetwork(), options.SSVShare.Share.ValidatorPubKey, slashable)
+		qbftCtrl := buildController(message.BNRoleCommittee, committeeValueCheckF)
+		committeeRunner = runner.NewCommitteeRunner(options.BeaconNetwork.GetBeaconNetwork(), &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, committeeValueCheckF, 0).(*runner.CommitteeRunner)
+		committeeRunner.Validators = options.CommitteeValidators
+		runners[message.BNRoleCommittee] = committeeRunner
 	}
 	return runners
 }
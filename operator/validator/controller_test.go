@@ -4,7 +4,10 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -1058,6 +1061,51 @@ func TestGetIndices(t *testing.T) {
 	require.Equal(t, 3, len(activeIndicesForNextEpoch)) // should return including ValidatorStatePendingQueued
 }
 
+func TestVoluntaryExitEscrow(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := getBaseStorage(logger)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctr := setupController(logger, MockControllerOptions{})
+	ctr.requestedExits = make(map[requestedExit]phase0.Slot)
+	ctr.signedExitsStorage = registrystorage.NewSignedExitsStorage(logger, db, []byte("test"))
+	ctr.signedExitsDir = t.TempDir()
+
+	pubKey := phase0.BLSPubKey{1}
+	ctr.requestedExits[requestedExit{PubKey: pubKey, Epoch: 100, PreSign: true}] = 10
+
+	// only the requested exits are planned.
+	require.Equal(t, &runner.VoluntaryExitPlan{Epoch: 100, PreSign: true}, ctr.VoluntaryExitPlan(pubKey, 10))
+	require.Nil(t, ctr.VoluntaryExitPlan(pubKey, 11))
+	require.Nil(t, ctr.VoluntaryExitPlan(phase0.BLSPubKey{2}, 10))
+
+	signedExit := func(epoch phase0.Epoch) *phase0.SignedVoluntaryExit {
+		return &phase0.SignedVoluntaryExit{
+			Message:   &phase0.VoluntaryExit{Epoch: epoch, ValidatorIndex: 1},
+			Signature: phase0.BLSSignature{1},
+		}
+	}
+	require.NoError(t, ctr.SignedVoluntaryExit(logger, pubKey, signedExit(100), true))
+	require.NoError(t, ctr.SignedVoluntaryExit(logger, pubKey, signedExit(200), false))
+
+	// every signed exit is recorded, while only pre-signed exits are written out.
+	records, err := ctr.signedExitsStorage.ListSignedExits(nil, pubKey[:])
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.True(t, records[0].PreSigned)
+	require.False(t, records[1].PreSigned)
+
+	files, err := os.ReadDir(ctr.signedExitsDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(filepath.Join(ctr.signedExitsDir, files[0].Name()))
+	require.NoError(t, err)
+	written := &phase0.SignedVoluntaryExit{}
+	require.NoError(t, json.Unmarshal(data, written))
+	require.Equal(t, signedExit(100), written)
+}
+
 func setupController(logger *zap.Logger, opts MockControllerOptions) controller {
 	return controller{
 		metadataUpdateInterval:  0,
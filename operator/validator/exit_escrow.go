@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+)
+
+// VoluntaryExitPlan returns the plan of the exit requested by the validator's owner for the given slot, if any.
+func (c *controller) VoluntaryExitPlan(pubKey phase0.BLSPubKey, slot phase0.Slot) *runner.VoluntaryExitPlan {
+	c.requestedExitsLock.Lock()
+	defer c.requestedExitsLock.Unlock()
+
+	for exit, exitSlot := range c.requestedExits {
+		if exit.PubKey == pubKey && exitSlot == slot {
+			return &runner.VoluntaryExitPlan{
+				Epoch:   exit.Epoch,
+				PreSign: exit.PreSign,
+			}
+		}
+	}
+	return nil
+}
+
+// SignedVoluntaryExit records every exit signed by the committee,
+// and writes pre-signed exits to the signed exits directory, if set, for their owners to collect.
+func (c *controller) SignedVoluntaryExit(logger *zap.Logger, pubKey phase0.BLSPubKey, signedExit *phase0.SignedVoluntaryExit, preSigned bool) error {
+	err := c.signedExitsStorage.SaveSignedExit(nil, &registrystorage.SignedExit{
+		ValidatorPubKey: pubKey[:],
+		Exit:            signedExit,
+		PreSigned:       preSigned,
+		SignedAt:        time.Now(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not save signed exit")
+	}
+
+	if !preSigned || c.signedExitsDir == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(signedExit, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not encode signed exit")
+	}
+	if err := os.MkdirAll(c.signedExitsDir, 0700); err != nil {
+		return errors.Wrap(err, "could not create signed exits directory")
+	}
+	path := filepath.Join(c.signedExitsDir, fmt.Sprintf("exit_%x_%d.json", pubKey[:], signedExit.Message.Epoch))
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return errors.Wrap(err, "could not write signed exit")
+	}
+	logger.Info("pre-signed voluntary exit saved", zap.String("path", path))
+	return nil
+}
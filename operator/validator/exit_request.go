@@ -0,0 +1,188 @@
+package validator
+
+import (
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/operator/duties"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+const (
+	// requestedExitSlotsToPostpone gives an exit request time to reach the rest of the committee before the exit duty.
+	requestedExitSlotsToPostpone = phase0.Slot(4)
+	// requestedExitSlotsToRemember is how long an exit request is remembered, to ignore its duplicates.
+	requestedExitSlotsToRemember = phase0.Slot(64)
+)
+
+// requestedExit identifies an exit requested by the validator's owner, as relayed by an operator of its committee.
+// Each operator's relay is scheduled at the slot it chose, so that all operators schedule the same exit duties
+// regardless of the order in which the relays reach them.
+type requestedExit struct {
+	PubKey   phase0.BLSPubKey
+	Epoch    phase0.Epoch
+	PreSign  bool
+	Operator spectypes.OperatorID
+}
+
+// RequestExit verifies an owner's exit request, shares it with the rest of the validator's committee,
+// and schedules the voluntary exit without waiting for an on-chain transaction.
+// Pre-signed exits are delivered to the owner by the escrow rather than submitted.
+func (c *controller) RequestExit(request *ssvtypes.ExitRequest) error {
+	share, err := c.verifyExitRequest(request)
+	if err != nil {
+		return err
+	}
+
+	msg := &ssvtypes.ExitRequestMessage{
+		Request:  *request,
+		Slot:     c.beacon.GetBeaconNetwork().EstimatedCurrentSlot() + requestedExitSlotsToPostpone,
+		Operator: c.operatorDataStore.GetOperatorID(),
+	}
+	data, err := msg.Encode()
+	if err != nil {
+		return errors.Wrap(err, "could not encode exit request")
+	}
+	err = c.network.Broadcast(&spectypes.SSVMessage{
+		MsgType: message.SSVExitRequestMsgType,
+		MsgID:   spectypes.NewMsgID(ssvtypes.GetDefaultDomain(), share.ValidatorPubKey, spectypes.BNRoleVoluntaryExit),
+		Data:    data,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not broadcast exit request")
+	}
+
+	c.scheduleRequestedExit(share, request, msg.Operator, msg.Slot)
+	return nil
+}
+
+// handleExitRequestMessage schedules the exit requested by another operator of the validator's committee.
+func (c *controller) handleExitRequestMessage(msg *queue.DecodedSSVMessage) {
+	exitRequestMsg, ok := msg.Body.(*ssvtypes.ExitRequestMessage)
+	if !ok {
+		return
+	}
+	share, err := c.verifyExitRequest(&exitRequestMsg.Request)
+	if err != nil {
+		c.logger.Debug("ignoring exit request",
+			fields.PubKey(exitRequestMsg.Request.ValidatorPubKey),
+			zap.Error(err))
+		return
+	}
+	if !share.BelongsToOperator(exitRequestMsg.Operator) {
+		c.logger.Debug("ignoring exit request relayed by an operator outside the committee",
+			fields.PubKey(exitRequestMsg.Request.ValidatorPubKey),
+			fields.OperatorID(exitRequestMsg.Operator))
+		return
+	}
+	c.scheduleRequestedExit(share, &exitRequestMsg.Request, exitRequestMsg.Operator, exitRequestMsg.Slot)
+}
+
+// VerifyExitClaim checks that the claim is addressed to this operator and signed by the owner of one of its validators,
+// which authorizes the owner to collect the validator's signed exits. Each claim is accepted only once.
+func (c *controller) VerifyExitClaim(claim *ssvtypes.ExitClaim) error {
+	now := time.Now()
+	if err := claim.Verify(c.networkConfig, c.operatorDataStore.GetOperatorID(), now); err != nil {
+		return err
+	}
+	share := c.sharesStorage.Get(nil, claim.ValidatorPubKey)
+	if share == nil {
+		return errors.New("validator not found")
+	}
+	if share.OwnerAddress != claim.Claimant {
+		return errors.Errorf("validator is owned by %s rather than %s", share.OwnerAddress, claim.Claimant)
+	}
+	if !share.BelongsToOperator(c.operatorDataStore.GetOperatorID()) {
+		return errors.New("validator isn't managed by this operator")
+	}
+
+	nonce := string(claim.Nonce)
+	c.exitClaimNoncesLock.Lock()
+	defer c.exitClaimNoncesLock.Unlock()
+	for other, expiry := range c.exitClaimNonces {
+		if time.Unix(int64(expiry), 0).Before(now) {
+			delete(c.exitClaimNonces, other)
+		}
+	}
+	if _, ok := c.exitClaimNonces[nonce]; ok {
+		return errors.New("exit claim was already used")
+	}
+	c.exitClaimNonces[nonce] = claim.Expiry
+	return nil
+}
+
+// verifyExitRequest checks that the request is signed by the validator's owner, and that the validator is ours to exit.
+func (c *controller) verifyExitRequest(request *ssvtypes.ExitRequest) (*ssvtypes.SSVShare, error) {
+	if err := request.Verify(c.networkConfig, time.Now()); err != nil {
+		return nil, err
+	}
+	share := c.sharesStorage.Get(nil, request.ValidatorPubKey)
+	if share == nil {
+		return nil, errors.New("validator not found")
+	}
+	if share.OwnerAddress != request.Owner {
+		return nil, errors.Errorf("validator is owned by %s rather than %s", share.OwnerAddress, request.Owner)
+	}
+	if !share.BelongsToOperator(c.operatorDataStore.GetOperatorID()) {
+		return nil, errors.New("validator isn't managed by this operator")
+	}
+	if share.BeaconMetadata == nil {
+		return nil, errors.New("validator has no beacon metadata")
+	}
+	return share, nil
+}
+
+// scheduleRequestedExit schedules the exit duty at the slot chosen by the relaying operator,
+// unless that operator recently relayed it already or another exit of the validator is scheduled for that slot.
+func (c *controller) scheduleRequestedExit(share *ssvtypes.SSVShare, request *ssvtypes.ExitRequest, operator spectypes.OperatorID, slot phase0.Slot) {
+	pk := phase0.BLSPubKey{}
+	copy(pk[:], share.ValidatorPubKey)
+	exit := requestedExit{
+		PubKey:   pk,
+		Epoch:    request.Epoch,
+		PreSign:  request.PreSign,
+		Operator: operator,
+	}
+
+	c.requestedExitsLock.Lock()
+	currentSlot := c.beacon.GetBeaconNetwork().EstimatedCurrentSlot()
+	requested := false
+	for other, otherSlot := range c.requestedExits {
+		if otherSlot+requestedExitSlotsToRemember < currentSlot {
+			delete(c.requestedExits, other)
+		}
+	}
+	for other, otherSlot := range c.requestedExits {
+		if other == exit || (other.PubKey == pk && otherSlot == slot) {
+			requested = true
+			break
+		}
+	}
+	if !requested {
+		c.requestedExits[exit] = slot
+	}
+	c.requestedExitsLock.Unlock()
+
+	if requested {
+		return
+	}
+
+	logger := c.taskLogger("RequestExit",
+		fields.PubKey(pk[:]),
+		fields.Slot(slot),
+		zap.Uint64("validator_index", uint64(share.BeaconMetadata.Index)),
+		zap.Bool("pre_sign", request.PreSign),
+	)
+	c.scheduleExit(logger, duties.ExitDescriptor{
+		PubKey:         pk,
+		ValidatorIndex: share.BeaconMetadata.Index,
+		Slot:           slot,
+	})
+}
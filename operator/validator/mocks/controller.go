@@ -188,6 +188,20 @@ func (mr *MockControllerMockRecorder) ReactivateCluster(owner, operatorIDs, toRe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateCluster", reflect.TypeOf((*MockController)(nil).ReactivateCluster), owner, operatorIDs, toReactivate)
 }
 
+// RequestExit mocks base method.
+func (m *MockController) RequestExit(request *types0.ExitRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestExit", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestExit indicates an expected call of RequestExit.
+func (mr *MockControllerMockRecorder) RequestExit(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestExit", reflect.TypeOf((*MockController)(nil).RequestExit), request)
+}
+
 // StartNetworkHandlers mocks base method.
 func (m *MockController) StartNetworkHandlers() {
 	m.ctrl.T.Helper()
@@ -280,6 +294,20 @@ func (mr *MockControllerMockRecorder) ValidatorExitChan() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorExitChan", reflect.TypeOf((*MockController)(nil).ValidatorExitChan))
 }
 
+// VerifyExitClaim mocks base method.
+func (m *MockController) VerifyExitClaim(claim *types0.ExitClaim) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyExitClaim", claim)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyExitClaim indicates an expected call of VerifyExitClaim.
+func (mr *MockControllerMockRecorder) VerifyExitClaim(claim interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyExitClaim", reflect.TypeOf((*MockController)(nil).VerifyExitClaim), claim)
+}
+
 // MockRecipients is a mock of Recipients interface.
 type MockRecipients struct {
 	ctrl     *gomock.Controller
@@ -114,6 +114,13 @@ func (c *controller) ExitValidator(pubKey phase0.BLSPubKey, blockNumber uint64,
 		BlockNumber:    blockNumber,
 	}
 
+	c.scheduleExit(logger, exitDesc)
+
+	return nil
+}
+
+// scheduleExit passes the exit to the duty scheduler in the background.
+func (c *controller) scheduleExit(logger *zap.Logger, exitDesc duties.ExitDescriptor) {
 	go func() {
 		select {
 		case c.validatorExitCh <- exitDesc:
@@ -122,6 +129,4 @@ func (c *controller) ExitValidator(pubKey phase0.BLSPubKey, blockNumber uint64,
 			logger.Error("failed to schedule ExitValidator duty!")
 		}
 	}()
-
-	return nil
 }
@@ -62,7 +62,13 @@ type Options struct {
 	Network        Network
 	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true"`
 	Graffiti       []byte
-	GasLimit       uint64
-	CommonTimeout  time.Duration // Optional.
-	LongTimeout    time.Duration // Optional.
+	// GraffitiTemplate, if set, renders the graffiti of proposed blocks instead, see GraffitiData for its variables.
+	GraffitiTemplate string `yaml:"GraffitiTemplate" env:"GRAFFITI_TEMPLATE" env-description:"Graffiti template of proposed blocks, with the variables .OperatorID, .Cluster, .NodeVersion, .ExecutionClient and .ConsensusClient"`
+	GasLimit         uint64
+	CommonTimeout    time.Duration // Optional.
+	LongTimeout      time.Duration // Optional.
+	// BuilderRelays, if set, receives the validator registrations submitted to the beacon node.
+	BuilderRelays eth2client.ValidatorRegistrationsSubmitter // Optional.
+	// ProposerSettings, if set, overrides GasLimit per validator.
+	ProposerSettings ProposerSettingsProvider // Optional.
 }
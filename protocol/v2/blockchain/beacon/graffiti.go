@@ -0,0 +1,88 @@
+package beacon
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// GraffitiLength is the length of a block's graffiti, longer graffiti is truncated.
+const GraffitiLength = 32
+
+// GraffitiData holds the variables of graffiti templates, such as "SSV {{.OperatorID}} {{.ConsensusClient}}".
+type GraffitiData struct {
+	// OperatorID is the ID of the proposing operator.
+	OperatorID uint64
+	// Cluster is the validator's cluster as dash separated operator IDs, such as "1-2-3-4".
+	Cluster string
+	// NodeVersion is the ssv-node version.
+	NodeVersion string
+	// ExecutionClient and ConsensusClient are the names of the node's clients, such as "Geth" and "Lighthouse".
+	ExecutionClient string
+	ConsensusClient string
+}
+
+// ClusterString formats the operator IDs of a cluster for GraffitiData.
+func ClusterString(operatorIDs []uint64) string {
+	ids := make([]string, len(operatorIDs))
+	for i, id := range operatorIDs {
+		ids[i] = strconv.FormatUint(id, 10)
+	}
+	return strings.Join(ids, "-")
+}
+
+// ClientName returns the name of a client from its "name/version" string.
+func ClientName(nameVersion string) string {
+	name, _, _ := strings.Cut(nameVersion, "/")
+	return name
+}
+
+// GraffitiTemplate is a parsed graffiti template, see GraffitiData for its variables.
+type GraffitiTemplate struct {
+	text string
+	tmpl *template.Template
+}
+
+// ParseGraffiti parses a graffiti template, and checks that it renders.
+func ParseGraffiti(text string) (*GraffitiTemplate, error) {
+	tmpl, err := template.New("graffiti").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid graffiti template")
+	}
+	t := &GraffitiTemplate{text: text, tmpl: tmpl}
+	if _, err := t.Render(GraffitiData{}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// String returns the text of the template.
+func (t *GraffitiTemplate) String() string {
+	return t.text
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t *GraffitiTemplate) MarshalText() ([]byte, error) {
+	return []byte(t.text), nil
+}
+
+// Render renders the template, truncating it to GraffitiLength bytes.
+func (t *GraffitiTemplate) Render(data GraffitiData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return nil, errors.Wrap(err, "could not render graffiti")
+	}
+	graffiti := buf.Bytes()
+	if len(graffiti) > GraffitiLength {
+		graffiti = graffiti[:GraffitiLength]
+		// don't cut a character in half.
+		for len(graffiti) > 0 && !utf8.Valid(graffiti) {
+			graffiti = graffiti[:len(graffiti)-1]
+		}
+	}
+	return graffiti, nil
+}
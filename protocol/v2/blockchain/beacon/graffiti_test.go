@@ -0,0 +1,45 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderGraffiti(t *testing.T) {
+	data := GraffitiData{
+		OperatorID:      2,
+		Cluster:         ClusterString([]uint64{1, 2, 3, 4}),
+		NodeVersion:     "v1.2.3",
+		ExecutionClient: ClientName("Geth/v1.13.4-stable/linux-amd64/go1.21.3"),
+		ConsensusClient: ClientName("Lighthouse/v4.5.0/x86_64-linux"),
+	}
+
+	render := func(text string) (string, error) {
+		tmpl, err := ParseGraffiti(text)
+		if err != nil {
+			return "", err
+		}
+		graffiti, err := tmpl.Render(data)
+		return string(graffiti), err
+	}
+
+	graffiti, err := render("SSV {{.OperatorID}} {{.Cluster}}")
+	require.NoError(t, err)
+	require.Equal(t, "SSV 2 1-2-3-4", graffiti)
+
+	graffiti, err = render("{{.NodeVersion}} {{.ExecutionClient}}/{{.ConsensusClient}}")
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.3 Geth/Lighthouse", graffiti)
+
+	// truncated to 32 bytes, without cutting a character in half.
+	graffiti, err = render("SSV {{.Cluster}} 0123456789abcdefghi✓")
+	require.NoError(t, err)
+	require.Equal(t, "SSV 1-2-3-4 0123456789abcdefghi", graffiti)
+
+	// unknown variables are rejected when parsing, rather than at the duty.
+	_, err = ParseGraffiti("SSV {{.Operator}}")
+	require.Error(t, err)
+	_, err = ParseGraffiti("SSV {{.OperatorID")
+	require.Error(t, err)
+}
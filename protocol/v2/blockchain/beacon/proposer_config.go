@@ -0,0 +1,231 @@
+package beacon
+
+import (
+	"encoding/hex"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// BuilderOptions configures block building of a validator. Unset fields are inherited.
+type BuilderOptions struct {
+	// Enabled is whether to propose builder (MEV) blocks, rather than only local blocks.
+	Enabled *bool `yaml:"enabled" json:"enabled,omitempty"`
+	// GasLimit is the gas limit registered with builders.
+	GasLimit uint64 `yaml:"gas_limit" json:"gas_limit,omitempty"`
+	// Relays restricts the relays used for the validator, by URL or host.
+	Relays []string `yaml:"relays" json:"relays,omitempty"`
+}
+
+// ProposerOptions holds the proposer options of a validator, owner or the default.
+type ProposerOptions struct {
+	Builder BuilderOptions `yaml:"builder" json:"builder"`
+	// Graffiti is a graffiti template of the validator's blocks, see GraffitiData for its variables.
+	Graffiti string `yaml:"graffiti" json:"graffiti,omitempty"`
+
+	// graffiti is the parsed Graffiti template.
+	graffiti *GraffitiTemplate
+}
+
+// ProposerConfig is a proposer config file, similar to those of Teku and Lighthouse.
+// Besides builder options, it may override the graffiti template, so that a committee can agree on its graffiti.
+// Options are resolved in order of precedence from proposer_config (by validator public key),
+// owner_config (by owner address), default_config and lastly the node's own options.
+//
+// Validator registrations are signed by the whole cluster,
+// so all of its operators must register the same gas limit.
+type ProposerConfig struct {
+	Proposers map[string]ProposerOptions `yaml:"proposer_config" json:"proposer_config,omitempty"`
+	Owners    map[string]ProposerOptions `yaml:"owner_config" json:"owner_config,omitempty"`
+	Default   ProposerOptions            `yaml:"default_config" json:"default_config"`
+
+	proposers map[phase0.BLSPubKey]ProposerOptions
+	owners    map[common.Address]ProposerOptions
+}
+
+// LoadProposerConfig reads a proposer config from a YAML (or JSON) file.
+func LoadProposerConfig(path string) (*ProposerConfig, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read proposer config file")
+	}
+	var config ProposerConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "could not parse proposer config file")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate checks the config and indexes its validators and owners.
+func (c *ProposerConfig) Validate() error {
+	c.proposers = make(map[phase0.BLSPubKey]ProposerOptions, len(c.Proposers))
+	for key, options := range c.Proposers {
+		b, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil || len(b) != len(phase0.BLSPubKey{}) {
+			return errors.Errorf("invalid validator public key %q", key)
+		}
+		options, err := options.validate()
+		if err != nil {
+			return errors.Wrapf(err, "invalid options of validator %s", key)
+		}
+		var pubKey phase0.BLSPubKey
+		copy(pubKey[:], b)
+		c.proposers[pubKey] = options
+	}
+	c.owners = make(map[common.Address]ProposerOptions, len(c.Owners))
+	for key, options := range c.Owners {
+		if !common.IsHexAddress(key) {
+			return errors.Errorf("invalid owner address %q", key)
+		}
+		options, err := options.validate()
+		if err != nil {
+			return errors.Wrapf(err, "invalid options of owner %s", key)
+		}
+		c.owners[common.HexToAddress(key)] = options
+	}
+	defaults, err := c.Default.validate()
+	if err != nil {
+		return errors.Wrap(err, "invalid default options")
+	}
+	c.Default = defaults
+	return nil
+}
+
+// validate checks the options and returns them with their graffiti template parsed.
+func (o ProposerOptions) validate() (ProposerOptions, error) {
+	for _, relay := range o.Builder.Relays {
+		if RelayHost(relay) == "" {
+			return o, errors.Errorf("invalid relay %q", relay)
+		}
+	}
+	o.graffiti = nil
+	if o.Graffiti != "" {
+		graffiti, err := ParseGraffiti(o.Graffiti)
+		if err != nil {
+			return o, err
+		}
+		o.graffiti = graffiti
+	}
+	return o, nil
+}
+
+// ProposerSettings are the resolved proposer options of a validator.
+type ProposerSettings struct {
+	BuilderEnabled bool     `json:"builder_enabled"`
+	GasLimit       uint64   `json:"gas_limit"`
+	Relays         []string `json:"relays,omitempty"`
+	// Graffiti is the graffiti template, if any, rather than the validator's static graffiti.
+	Graffiti *GraffitiTemplate `json:"graffiti,omitempty"`
+}
+
+// UsesRelay returns whether the given relay host may be used for the validator.
+func (s ProposerSettings) UsesRelay(host string) bool {
+	if len(s.Relays) == 0 {
+		return true
+	}
+	for _, relay := range s.Relays {
+		if RelayHost(relay) == host {
+			return true
+		}
+	}
+	return false
+}
+
+// apply overrides the settings with the options which are set.
+func (s ProposerSettings) apply(options ProposerOptions) ProposerSettings {
+	if options.Builder.Enabled != nil {
+		s.BuilderEnabled = *options.Builder.Enabled
+	}
+	if options.Builder.GasLimit != 0 {
+		s.GasLimit = options.Builder.GasLimit
+	}
+	if options.Builder.Relays != nil {
+		s.Relays = options.Builder.Relays
+	}
+	if options.graffiti != nil {
+		s.Graffiti = options.graffiti
+	}
+	return s
+}
+
+// RelayHost returns the host of a relay given by URL or host, which identifies the relay.
+func RelayHost(relay string) string {
+	if !strings.Contains(relay, "://") {
+		return strings.TrimSpace(relay)
+	}
+	u, err := url.Parse(relay)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// ProposerSettingsProvider resolves the proposer settings of validators.
+type ProposerSettingsProvider interface {
+	ProposerSettings(pubKey phase0.BLSPubKey) ProposerSettings
+}
+
+// ProposerConfigManager resolves proposer settings from a ProposerConfig which may be replaced at runtime.
+type ProposerConfigManager struct {
+	defaults ProposerSettings
+	ownerOf  func(pubKey phase0.BLSPubKey) (common.Address, bool)
+
+	mu     sync.RWMutex
+	config *ProposerConfig
+}
+
+// NewProposerConfigManager creates a new ProposerConfigManager, falling back to the given node defaults.
+// ownerOf returns the owner of a validator, to resolve owner options.
+func NewProposerConfigManager(defaults ProposerSettings, ownerOf func(pubKey phase0.BLSPubKey) (common.Address, bool)) *ProposerConfigManager {
+	return &ProposerConfigManager{
+		defaults: defaults,
+		ownerOf:  ownerOf,
+		config:   &ProposerConfig{},
+	}
+}
+
+// SetProposerConfig replaces the proposer config.
+func (m *ProposerConfigManager) SetProposerConfig(config *ProposerConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.config = config
+	return nil
+}
+
+// ProposerConfig returns the current proposer config.
+func (m *ProposerConfigManager) ProposerConfig() *ProposerConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config
+}
+
+// ProposerSettings implements ProposerSettingsProvider.
+func (m *ProposerConfigManager) ProposerSettings(pubKey phase0.BLSPubKey) ProposerSettings {
+	config := m.ProposerConfig()
+
+	settings := m.defaults.apply(config.Default)
+	if m.ownerOf != nil {
+		if owner, ok := m.ownerOf(pubKey); ok {
+			if options, ok := config.owners[owner]; ok {
+				settings = settings.apply(options)
+			}
+		}
+	}
+	if options, ok := config.proposers[pubKey]; ok {
+		settings = settings.apply(options)
+	}
+	return settings
+}
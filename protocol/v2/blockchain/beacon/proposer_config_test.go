@@ -0,0 +1,83 @@
+package beacon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const testProposerConfig = `
+proposer_config:
+  "0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000":
+    builder:
+      enabled: true
+      gas_limit: 36000000
+      relays: ["https://0xabc@relay1.example"]
+    graffiti: "SSV {{.OperatorID}}"
+owner_config:
+  "0x0000000000000000000000000000000000000002":
+    builder:
+      enabled: false
+default_config:
+  builder:
+    enabled: true
+    relays: ["relay2.example"]
+`
+
+func TestProposerConfigManager(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "proposer_config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testProposerConfig), 0600))
+
+	config, err := LoadProposerConfig(path)
+	require.NoError(t, err)
+
+	proposer := phase0.BLSPubKey{1}
+	ownedValidator := phase0.BLSPubKey{2}
+	otherValidator := phase0.BLSPubKey{3}
+	owners := map[phase0.BLSPubKey]common.Address{
+		proposer:       common.HexToAddress("0x02"),
+		ownedValidator: common.HexToAddress("0x02"),
+	}
+	ownerOf := func(pubKey phase0.BLSPubKey) (common.Address, bool) {
+		owner, ok := owners[pubKey]
+		return owner, ok
+	}
+	m := NewProposerConfigManager(ProposerSettings{GasLimit: 30000000}, ownerOf)
+
+	// before a config is set, the node defaults are used.
+	require.Equal(t, ProposerSettings{GasLimit: 30000000}, m.ProposerSettings(proposer))
+
+	require.NoError(t, m.SetProposerConfig(config))
+
+	settings := m.ProposerSettings(proposer)
+	require.True(t, settings.BuilderEnabled)
+	require.Equal(t, uint64(36000000), settings.GasLimit)
+	require.Equal(t, []string{"https://0xabc@relay1.example"}, settings.Relays)
+	require.Equal(t, "SSV {{.OperatorID}}", settings.Graffiti.String())
+	graffiti, err := settings.Graffiti.Render(GraffitiData{OperatorID: 1})
+	require.NoError(t, err)
+	require.Equal(t, "SSV 1", string(graffiti))
+	require.True(t, settings.UsesRelay("relay1.example"))
+	require.False(t, settings.UsesRelay("relay2.example"))
+
+	settings = m.ProposerSettings(ownedValidator)
+	require.False(t, settings.BuilderEnabled)
+	require.Equal(t, uint64(30000000), settings.GasLimit)
+
+	settings = m.ProposerSettings(otherValidator)
+	require.True(t, settings.BuilderEnabled)
+	require.True(t, settings.UsesRelay("relay2.example"))
+	require.Nil(t, settings.Graffiti)
+}
+
+func TestProposerConfigValidate(t *testing.T) {
+	require.Error(t, (&ProposerConfig{Proposers: map[string]ProposerOptions{"0x01": {}}}).Validate())
+	require.Error(t, (&ProposerConfig{Owners: map[string]ProposerOptions{"owner": {}}}).Validate())
+	require.Error(t, (&ProposerConfig{Default: ProposerOptions{Builder: BuilderOptions{Relays: []string{"https://%zz"}}}}).Validate())
+	require.Error(t, (&ProposerConfig{Default: ProposerOptions{Graffiti: "{{.Unknown}}"}}).Validate())
+	require.NoError(t, (&ProposerConfig{}).Validate())
+}
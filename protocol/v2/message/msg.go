@@ -12,8 +12,17 @@ const (
 	SSVSyncMsgType spectypes.MsgType = 100
 	// SSVEventMsgType extends spec msg type
 	SSVEventMsgType spectypes.MsgType = 200
+	// SSVExitRequestMsgType extends spec msg type, carrying an owner's exit request to the validator's committee
+	SSVExitRequestMsgType spectypes.MsgType = 300
+	// SSVCommitteePartialSignatureMsgType extends spec msg type, carrying an operator's batched partial signatures
+	// for the duties of the validators sharing its committee
+	SSVCommitteePartialSignatureMsgType spectypes.MsgType = 301
 )
 
+// BNRoleCommittee extends spec beacon roles, identifying the batched consensus of the attester and
+// sync committee duties of all the validators sharing a committee of operators
+const BNRoleCommittee spectypes.BeaconRole = 100
+
 // MsgTypeToString extension for spec msg type. convert spec msg type to string
 func MsgTypeToString(mt spectypes.MsgType) string {
 	switch mt {
@@ -27,6 +36,10 @@ func MsgTypeToString(mt spectypes.MsgType) string {
 		return "sync"
 	case SSVEventMsgType:
 		return "event"
+	case SSVExitRequestMsgType:
+		return "exit_request"
+	case SSVCommitteePartialSignatureMsgType:
+		return "committee_partial_signature"
 	default:
 		return fmt.Sprintf("unknown(%d)", mt)
 	}
@@ -47,6 +60,14 @@ func QBFTMsgTypeToString(mt specqbft.MessageType) string {
 	}
 }
 
+// RoleToString extension for spec beacon role. convert beacon role to string
+func RoleToString(role spectypes.BeaconRole) string {
+	if role == BNRoleCommittee {
+		return "COMMITTEE"
+	}
+	return role.String()
+}
+
 // BeaconRoleFromString returns BeaconRole from string
 func BeaconRoleFromString(s string) (spectypes.BeaconRole, error) {
 	switch s {
@@ -64,6 +85,8 @@ func BeaconRoleFromString(s string) (spectypes.BeaconRole, error) {
 		return spectypes.BNRoleValidatorRegistration, nil
 	case "VOLUNTARY_EXIT":
 		return spectypes.BNRoleVoluntaryExit, nil
+	case "COMMITTEE":
+		return BNRoleCommittee, nil
 	default:
 		return 0, fmt.Errorf("unknown role: %s", s)
 	}
@@ -8,6 +8,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/message"
 )
 
 var (
@@ -48,7 +50,7 @@ func newMetrics(msgID spectypes.MessageID) *metrics {
 		proposalDuration: metricsStageDuration.WithLabelValues("proposal"),
 		prepareDuration:  metricsStageDuration.WithLabelValues("prepare"),
 		commitDuration:   metricsStageDuration.WithLabelValues("commit"),
-		round:            metricsRound.WithLabelValues(msgID.GetRoleType().String()),
+		round:            metricsRound.WithLabelValues(message.RoleToString(msgID.GetRoleType())),
 	}
 }
 
@@ -9,6 +9,8 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+
+	"github.com/bloxapp/ssv/protocol/v2/message"
 )
 
 //go:generate mockgen -package=mocks -destination=./mocks/timer.go -source=./timer.go
@@ -44,8 +46,10 @@ type RoundTimer struct {
 	ctx context.Context
 	// cancelCtx cancels the current context, will be called from Kill()
 	cancelCtx context.CancelFunc
-	// timer is the underlying time.Timer
-	timer *time.Timer
+	// timer is the underlying timer of the current round
+	timer ClockTimer
+	// clock is the source of time for the timer
+	clock Clock
 	// result holds the result of the timer
 	done OnRoundTimeoutF
 	// round is the current round of the timer
@@ -60,12 +64,22 @@ type RoundTimer struct {
 
 // New creates a new instance of RoundTimer.
 func New(pctx context.Context, beaconNetwork BeaconNetwork, role spectypes.BeaconRole, done OnRoundTimeoutF) *RoundTimer {
+	return NewWithClock(pctx, beaconNetwork, role, done, SystemClock)
+}
+
+// NewWithClock creates a new instance of RoundTimer which measures time with the given Clock.
+// A nil clock defaults to SystemClock.
+func NewWithClock(pctx context.Context, beaconNetwork BeaconNetwork, role spectypes.BeaconRole, done OnRoundTimeoutF, clock Clock) *RoundTimer {
+	if clock == nil {
+		clock = SystemClock
+	}
 	ctx, cancelCtx := context.WithCancel(pctx)
 	return &RoundTimer{
 		mtx:           &sync.RWMutex{},
 		ctx:           ctx,
 		cancelCtx:     cancelCtx,
 		timer:         nil,
+		clock:         clock,
 		done:          done,
 		role:          role,
 		beaconNetwork: beaconNetwork,
@@ -80,7 +94,7 @@ func New(pctx context.Context, beaconNetwork BeaconNetwork, role spectypes.Beaco
 // RoundTimeout calculates the timeout duration for a specific role, height, and round.
 //
 // Timeout Rules:
-// - For roles BNRoleAttester and BNRoleSyncCommittee, the base timeout is 1/3 of the slot duration.
+// - For roles BNRoleAttester, BNRoleSyncCommittee and BNRoleCommittee, the base timeout is 1/3 of the slot duration.
 // - For roles BNRoleAggregator and BNRoleSyncCommitteeContribution, the base timeout is 2/3 of the slot duration.
 // - For role BNRoleProposer, the timeout is either quickTimeout or slowTimeout, depending on the round.
 //
@@ -104,7 +118,7 @@ func (t *RoundTimer) RoundTimeout(height specqbft.Height, round specqbft.Round)
 
 	// Set base duration based on role
 	switch t.role {
-	case spectypes.BNRoleAttester, spectypes.BNRoleSyncCommittee:
+	case spectypes.BNRoleAttester, spectypes.BNRoleSyncCommittee, message.BNRoleCommittee:
 		// third of the slot time
 		baseDuration = t.beaconNetwork.SlotDurationSec() / 3
 	case spectypes.BNRoleAggregator, spectypes.BNRoleSyncCommitteeContribution:
@@ -134,7 +148,7 @@ func (t *RoundTimer) RoundTimeout(height specqbft.Height, round specqbft.Round)
 	dutyStartTime := t.beaconNetwork.GetSlotStartTime(phase0.Slot(height))
 
 	// Calculate the time until the duty should start plus the timeout duration
-	return time.Until(dutyStartTime.Add(timeoutDuration))
+	return dutyStartTime.Add(timeoutDuration).Sub(t.clock.Now())
 }
 
 // OnTimeout sets a function called on timeout.
@@ -155,37 +169,26 @@ func (t *RoundTimer) TimeoutForRound(height specqbft.Height, round specqbft.Roun
 	atomic.StoreInt64(&t.round, int64(round))
 	timeout := t.RoundTimeout(height, round)
 
-	// preparing the underlying timer
-	timer := t.timer
-	if timer == nil {
-		timer = time.NewTimer(timeout)
-	} else {
-		timer.Stop()
-		// draining the channel of existing timer
-		select {
-		case <-timer.C:
-		default:
-		}
+	t.mtx.Lock() // write to t.timer
+	defer t.mtx.Unlock()
+
+	// stopping the timer of the previous round
+	if t.timer != nil {
+		t.timer.Stop()
 	}
-	timer.Reset(timeout)
-	// spawns a new goroutine to listen to the timer
-	go t.waitForRound(round, timer.C)
+	t.timer = t.clock.AfterFunc(timeout, func() {
+		t.onTimeout(round)
+	})
 }
 
-func (t *RoundTimer) waitForRound(round specqbft.Round, timeout <-chan time.Time) {
-	ctx, cancel := context.WithCancel(t.ctx)
-	defer cancel()
-	select {
-	case <-ctx.Done():
-	case <-timeout:
-		if t.Round() == round {
-			func() {
-				t.mtx.RLock() // read t.done
-				defer t.mtx.RUnlock()
-				if done := t.done; done != nil {
-					done(round)
-				}
-			}()
-		}
+func (t *RoundTimer) onTimeout(round specqbft.Round) {
+	if t.ctx.Err() != nil || t.Round() != round {
+		return
+	}
+
+	t.mtx.RLock() // read t.done
+	defer t.mtx.RUnlock()
+	if done := t.done; done != nil {
+		done(round)
 	}
 }
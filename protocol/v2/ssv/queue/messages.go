@@ -21,7 +21,7 @@ type DecodedSSVMessage struct {
 	*spectypes.SSVMessage
 
 	// Body is the decoded Data.
-	Body interface{} // *SignedMessage | *SignedPartialSignatureMessage | *EventMsg
+	Body interface{} // *SignedMessage | *SignedPartialSignatureMessage | *EventMsg | *ExitRequestMessage | *CommitteePartialSignatures
 }
 
 // DecodeSSVMessage decodes an SSVMessage and returns a DecodedSSVMessage.
@@ -46,6 +46,18 @@ func DecodeSSVMessage(m *spectypes.SSVMessage) (*DecodedSSVMessage, error) {
 			return nil, errors.Wrap(err, "failed to decode EventMsg")
 		}
 		body = msg
+	case ssvmessage.SSVExitRequestMsgType:
+		msg := &ssvtypes.ExitRequestMessage{}
+		if err := msg.Decode(m.Data); err != nil {
+			return nil, errors.Wrap(err, "failed to decode ExitRequestMessage")
+		}
+		body = msg
+	case ssvmessage.SSVCommitteePartialSignatureMsgType:
+		msg := &ssvtypes.CommitteePartialSignatures{}
+		if err := msg.Decode(m.Data); err != nil {
+			return nil, errors.Wrap(err, "failed to decode CommitteePartialSignatures")
+		}
+		body = msg
 	default:
 		return nil, ErrUnknownMessageType
 	}
@@ -72,6 +84,13 @@ func compareHeightOrSlot(state *State, m *DecodedSSVMessage) int {
 		if mm.Message.Slot > state.Slot {
 			return 1
 		}
+	} else if mm, ok := m.Body.(*ssvtypes.CommitteePartialSignatures); ok {
+		if mm.Slot == state.Slot {
+			return 0
+		}
+		if mm.Slot > state.Slot {
+			return 1
+		}
 	}
 	return -1
 }
@@ -120,6 +139,9 @@ func scoreMessageSubtype(state *State, m *DecodedSSVMessage, relativeHeight int)
 		isPostConsensusMessage = mm.Message.Type == spectypes.PostConsensusPartialSig
 		isPreConsensusMessage = !isPostConsensusMessage
 	}
+	if _, ok := m.Body.(*ssvtypes.CommitteePartialSignatures); ok {
+		isPostConsensusMessage = true
+	}
 
 	// Current height.
 	if relativeHeight == 0 {
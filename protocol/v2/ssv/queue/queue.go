@@ -32,6 +32,10 @@ type Queue interface {
 	// TryPop returns immediately with the next message in the queue, or nil if there is none.
 	TryPop(MessagePrioritizer, Filter) *DecodedSSVMessage
 
+	// Shed removes the messages matching the filter from the queue, and returns the number of removed messages.
+	// Like pops, sheds aren't thread-safe, so don't call Shed concurrently with Pop or TryPop.
+	Shed(Filter) int
+
 	// Empty returns true if the queue is empty.
 	Empty() bool
 
@@ -181,6 +185,26 @@ func (q *priorityQueue) pop(prioritizer MessagePrioritizer, filter Filter) *Deco
 	return nil
 }
 
+func (q *priorityQueue) Shed(filter Filter) int {
+	q.readInbox()
+
+	shed := 0
+	var prior *item
+	for current := q.head; current != nil; current = current.next {
+		if !filter(current.message) {
+			prior = current
+			continue
+		}
+		if prior == nil {
+			q.head = current.next
+		} else {
+			prior.next = current.next
+		}
+		shed++
+	}
+	return shed
+}
+
 func (q *priorityQueue) Empty() bool {
 	return q.head == nil && len(q.inbox) == 0
 }
@@ -0,0 +1,446 @@
+package queue
+
+// scheduler.go contains the node-wide scheduling of the consumption of validators' queues
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"go.uber.org/zap"
+
+	ssvtypes "github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// Reasons for which messages are dropped from scheduled queues.
+const (
+	// DropReasonFull is a message which was dropped because its queue was full.
+	DropReasonFull = "full"
+	// DropReasonStale is a message of a height lower than its consumer's, shed to make room in a full queue.
+	DropReasonStale = "stale"
+)
+
+// SchedulerMetrics records metrics about the queues of a Scheduler, aggregated by role.
+type SchedulerMetrics interface {
+	QueueDepth(role string, depth int)
+	QueueDroppedMessages(role string, reason string, count int)
+}
+
+// StateFunc returns the current state of a queue's consumer,
+// alongside a filter of the messages which can be processed in that state.
+type StateFunc func() (*State, Filter, error)
+
+// Handler handles a message popped from a scheduled queue.
+type Handler func(*DecodedSSVMessage)
+
+// queueStatus is the scheduling status of a ScheduledQueue.
+type queueStatus int
+
+const (
+	// statusIdle is a queue with no message which can be processed in its consumer's state.
+	statusIdle queueStatus = iota
+	// statusPending is a queue waiting for a worker to pop its next message.
+	statusPending
+	// statusReady is a queue whose next message is waiting for a worker to handle it.
+	statusReady
+	// statusRunning is a queue whose message is being handled.
+	statusRunning
+)
+
+// Scheduler consumes the queues of all the validators with a bounded pool of workers.
+//
+// Each queue offers the scheduler at most one message at a time, which is the highest priority message
+// according to the MessagePrioritizer of the queue's state. Workers handle the offered message with the
+// earliest duty deadline (the end of the message's slot) first, breaking ties in the order in which the
+// messages were offered, so that a busy queue can't starve the others. Messages whose deadline passed
+// are handled only once no message before its deadline is offered, so that they don't delay live duties.
+// A queue's messages are handled sequentially, since its consumer's state isn't thread-safe.
+type Scheduler struct {
+	logger        *zap.Logger
+	workers       int
+	slotStartTime func(phase0.Slot) time.Time
+	metrics       SchedulerMetrics
+
+	mtx     sync.Mutex
+	wake    *sync.Cond
+	pending []*ScheduledQueue
+	ready   offers
+	expired offers
+	seq     uint64
+	depths  map[string]int
+	closed  bool
+}
+
+// NewScheduler returns a Scheduler of the given number of workers.
+// slotStartTime is used to find the duty deadlines of messages.
+func NewScheduler(logger *zap.Logger, workers int, slotStartTime func(phase0.Slot) time.Time, metrics SchedulerMetrics) *Scheduler {
+	s := &Scheduler{
+		logger:        logger,
+		workers:       workers,
+		slotStartTime: slotStartTime,
+		metrics:       metrics,
+		depths:        make(map[string]int),
+	}
+	s.wake = sync.NewCond(&s.mtx)
+	return s
+}
+
+// Start runs the scheduler's workers until the context is done.
+func (s *Scheduler) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work()
+		}()
+	}
+
+	<-ctx.Done()
+	s.mtx.Lock()
+	s.closed = true
+	s.wake.Broadcast()
+	s.mtx.Unlock()
+	wg.Wait()
+}
+
+// Register schedules the consumption of the given queue of the given role by the given handler,
+// until the context is done. Pushes must go through the returned queue for its messages to be scheduled,
+// and are dropped once it holds the given capacity of messages.
+func (s *Scheduler) Register(ctx context.Context, role string, q Queue, capacity int, state StateFunc, handler Handler) *ScheduledQueue {
+	sq := &ScheduledQueue{
+		Queue:     q,
+		scheduler: s,
+		role:      role,
+		capacity:  capacity,
+		stateFunc: state,
+		handler:   handler,
+	}
+
+	s.mtx.Lock()
+	s.addDepth(sq, q.Len())
+	s.mtx.Unlock()
+	s.notify(sq)
+
+	go func() {
+		<-ctx.Done()
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		s.addDepth(sq, -sq.depth)
+		sq.removed = true
+	}()
+	return sq
+}
+
+func (s *Scheduler) work() {
+	for {
+		s.mtx.Lock()
+		for !s.closed && len(s.pending) == 0 && s.ready.Len() == 0 && s.expired.Len() == 0 {
+			s.wake.Wait()
+		}
+		if s.closed {
+			s.mtx.Unlock()
+			return
+		}
+
+		// Popping comes first, since the deadlines of pending queues are unknown until then.
+		if len(s.pending) > 0 {
+			sq := s.pending[0]
+			s.pending[0] = nil
+			s.pending = s.pending[1:]
+			s.mtx.Unlock()
+			s.pop(sq)
+			continue
+		}
+
+		o := s.next()
+		sq := o.queue
+		if sq.removed {
+			s.mtx.Unlock()
+			continue
+		}
+		sq.status = statusRunning
+		s.mtx.Unlock()
+
+		sq.handler(o.msg)
+
+		s.mtx.Lock()
+		s.enqueue(sq)
+		s.mtx.Unlock()
+	}
+}
+
+// pop offers the highest priority message of the queue which can be processed in its consumer's state.
+func (s *Scheduler) pop(sq *ScheduledQueue) {
+	s.mtx.Lock()
+	if sq.removed {
+		s.mtx.Unlock()
+		return
+	}
+	sq.dirty = false
+	s.mtx.Unlock()
+
+	state, filter, err := sq.stateFunc()
+	var msg *DecodedSSVMessage
+	if err != nil {
+		s.logger.Debug("❗ could not get queue state", zap.String("role", sq.role), zap.Error(err))
+	} else {
+		sq.popLock.Lock()
+		msg = sq.Queue.TryPop(NewMessagePrioritizer(state), filter)
+		sq.popLock.Unlock()
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if sq.removed {
+		return
+	}
+	if state != nil {
+		sq.state = *state
+	}
+	if msg == nil {
+		// Messages pushed meanwhile may be processable in the current state.
+		if sq.dirty {
+			s.enqueue(sq)
+		} else {
+			sq.status = statusIdle
+		}
+		return
+	}
+
+	s.addDepth(sq, -1)
+	sq.status = statusReady
+	s.seq++
+	heap.Push(&s.ready, &offer{
+		queue:    sq,
+		msg:      msg,
+		deadline: s.deadline(msg),
+		seq:      s.seq,
+	})
+	s.wake.Signal()
+}
+
+// next pops the offer with the earliest deadline among those whose deadline hasn't passed,
+// or if there are none, among the expired ones. Must be called with the lock held.
+func (s *Scheduler) next() *offer {
+	now := time.Now()
+	for s.ready.Len() > 0 {
+		deadline := s.ready[0].deadline
+		if deadline.IsZero() || deadline.After(now) {
+			break
+		}
+		heap.Push(&s.expired, heap.Pop(&s.ready))
+	}
+	if s.ready.Len() > 0 {
+		return heap.Pop(&s.ready).(*offer)
+	}
+	return heap.Pop(&s.expired).(*offer)
+}
+
+// notify schedules a pop from the queue after a message is pushed to it.
+func (s *Scheduler) notify(sq *ScheduledQueue) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if sq.removed {
+		return
+	}
+	sq.dirty = true
+	if sq.status == statusIdle {
+		s.enqueue(sq)
+	}
+}
+
+// enqueue marks the queue as pending a pop. Must be called with the lock held.
+func (s *Scheduler) enqueue(sq *ScheduledQueue) {
+	sq.status = statusPending
+	s.pending = append(s.pending, sq)
+	s.wake.Signal()
+}
+
+// addDepth updates the depth of the queue and of its role. Must be called with the lock held.
+func (s *Scheduler) addDepth(sq *ScheduledQueue, n int) {
+	if n == 0 || sq.removed {
+		return
+	}
+	sq.depth += n
+	s.depths[sq.role] += n
+	s.metrics.QueueDepth(sq.role, s.depths[sq.role])
+}
+
+// deadline returns the end of the message's slot, or the zero time if the message has no slot.
+func (s *Scheduler) deadline(msg *DecodedSSVMessage) time.Time {
+	slot, ok := messageSlot(msg)
+	if !ok {
+		return time.Time{}
+	}
+	return s.slotStartTime(slot + 1)
+}
+
+// ScheduledQueue is a Queue whose consumption is scheduled by a Scheduler.
+// Its pushes notify the scheduler, and when it's full, make room by shedding stale messages.
+type ScheduledQueue struct {
+	Queue
+	scheduler *Scheduler
+	role      string
+	capacity  int
+	stateFunc StateFunc
+	handler   Handler
+
+	// popLock serializes the scheduler's pops and the pushers' sheds.
+	popLock sync.Mutex
+
+	// Guarded by the scheduler's lock.
+	status  queueStatus
+	dirty   bool
+	removed bool
+	depth   int
+	state   State
+}
+
+func (q *ScheduledQueue) TryPush(msg *DecodedSSVMessage) bool {
+	if q.tryPush(msg) {
+		return true
+	}
+
+	// The queue is full, so try making room by shedding the messages its consumer has moved past.
+	s := q.scheduler
+	s.mtx.Lock()
+	state := q.state
+	s.mtx.Unlock()
+	if shed := q.shed(func(m *DecodedSSVMessage) bool { return isStale(&state, m) }); shed > 0 {
+		s.mtx.Lock()
+		s.addDepth(q, -shed)
+		s.mtx.Unlock()
+		s.metrics.QueueDroppedMessages(q.role, DropReasonStale, shed)
+
+		if q.tryPush(msg) {
+			return true
+		}
+	}
+
+	s.metrics.QueueDroppedMessages(q.role, DropReasonFull, 1)
+	return false
+}
+
+// tryPush pushes the message unless the queue holds its capacity of messages,
+// counting the messages which were moved from the underlying queue's inbox too.
+func (q *ScheduledQueue) tryPush(msg *DecodedSSVMessage) bool {
+	s := q.scheduler
+	s.mtx.Lock()
+	if q.depth >= q.capacity {
+		s.mtx.Unlock()
+		return false
+	}
+	s.addDepth(q, 1)
+	s.mtx.Unlock()
+
+	if !q.Queue.TryPush(msg) {
+		s.mtx.Lock()
+		s.addDepth(q, -1)
+		s.mtx.Unlock()
+		return false
+	}
+	s.notify(q)
+	return true
+}
+
+func (q *ScheduledQueue) Shed(filter Filter) int {
+	shed := q.shed(filter)
+	q.scheduler.mtx.Lock()
+	q.scheduler.addDepth(q, -shed)
+	q.scheduler.mtx.Unlock()
+	return shed
+}
+
+func (q *ScheduledQueue) shed(filter Filter) int {
+	q.popLock.Lock()
+	defer q.popLock.Unlock()
+	return q.Queue.Shed(filter)
+}
+
+func (q *ScheduledQueue) Push(msg *DecodedSSVMessage) {
+	q.Queue.Push(msg)
+	q.scheduler.mtx.Lock()
+	q.scheduler.addDepth(q, 1)
+	q.scheduler.mtx.Unlock()
+	q.scheduler.notify(q)
+}
+
+// isStale returns true if the message is of a height lower than the state's.
+// Event messages are never stale.
+func isStale(state *State, m *DecodedSSVMessage) bool {
+	if state.Height == 0 {
+		return false
+	}
+	switch m.Body.(type) {
+	case *ssvtypes.EventMsg:
+		return false
+	}
+	slot, ok := messageSlot(m)
+	return ok && slot < phase0.Slot(state.Height)
+}
+
+// messageSlot returns the slot of the message, where heights are slots.
+func messageSlot(m *DecodedSSVMessage) (phase0.Slot, bool) {
+	switch mm := m.Body.(type) {
+	case *specqbft.SignedMessage:
+		return phase0.Slot(mm.Message.Height), true
+	case *spectypes.SignedPartialSignatureMessage:
+		return mm.Message.Slot, true
+	case *ssvtypes.CommitteePartialSignatures:
+		return mm.Slot, true
+	case *ssvtypes.EventMsg:
+		switch mm.Type {
+		case ssvtypes.Timeout:
+			if data, err := mm.GetTimeoutData(); err == nil {
+				return phase0.Slot(data.Height), true
+			}
+		case ssvtypes.ExecuteDuty:
+			if data, err := mm.GetExecuteDutyData(); err == nil && data.Duty != nil {
+				return data.Duty.Slot, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// offer is a message offered by a queue to the scheduler's workers.
+type offer struct {
+	queue    *ScheduledQueue
+	msg      *DecodedSSVMessage
+	deadline time.Time
+	seq      uint64
+}
+
+// offers is a heap of offers by their deadlines, where messages without a deadline come last.
+type offers []*offer
+
+func (o offers) Len() int { return len(o) }
+
+func (o offers) Less(i, j int) bool {
+	di, dj := o[i].deadline, o[j].deadline
+	if !di.Equal(dj) {
+		if di.IsZero() || dj.IsZero() {
+			return dj.IsZero()
+		}
+		return di.Before(dj)
+	}
+	return o[i].seq < o[j].seq
+}
+
+func (o offers) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
+
+func (o *offers) Push(x any) { *o = append(*o, x.(*offer)) }
+
+func (o *offers) Pop() any {
+	old := *o
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*o = old[:n-1]
+	return x
+}
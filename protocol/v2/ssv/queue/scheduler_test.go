@@ -0,0 +1,201 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/qbft"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+)
+
+type schedulerMetrics struct {
+	mtx     sync.Mutex
+	depths  map[string]int
+	dropped map[string]int
+}
+
+func newSchedulerMetrics() *schedulerMetrics {
+	return &schedulerMetrics{
+		depths:  make(map[string]int),
+		dropped: make(map[string]int),
+	}
+}
+
+func (m *schedulerMetrics) QueueDepth(role string, depth int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.depths[role] = depth
+}
+
+func (m *schedulerMetrics) QueueDroppedMessages(role string, reason string, count int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.dropped[role+"/"+reason] += count
+}
+
+func (m *schedulerMetrics) depth(role string) int {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.depths[role]
+}
+
+func testSlotStartTime(slot phase0.Slot) time.Time {
+	return time.Unix(int64(slot)*12, 0)
+}
+
+// handledMessages records the heights of the messages handled by a scheduler.
+type handledMessages struct {
+	mtx     sync.Mutex
+	heights []string
+	done    chan struct{}
+}
+
+func (h *handledMessages) handler(name string, expected int) Handler {
+	return func(msg *DecodedSSVMessage) {
+		h.mtx.Lock()
+		defer h.mtx.Unlock()
+		h.heights = append(h.heights, fmt.Sprintf("%s@%d", name, msg.Body.(*qbft.SignedMessage).Message.Height))
+		if len(h.heights) == expected {
+			close(h.done)
+		}
+	}
+}
+
+func runningState(height qbft.Height) StateFunc {
+	return func() (*State, Filter, error) {
+		return &State{HasRunningInstance: true, Height: height, Round: 1, Quorum: 3}, FilterAny, nil
+	}
+}
+
+func TestScheduler_Deadlines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	metrics := newSchedulerMetrics()
+	scheduler := NewScheduler(logging.TestLogger(t), 1, testSlotStartTime, metrics)
+	handled := &handledMessages{done: make(chan struct{})}
+
+	a := scheduler.Register(ctx, "ATTESTER", New(10), 10, runningState(101), handled.handler("a", 3))
+	b := scheduler.Register(ctx, "PROPOSER", New(10), 10, runningState(100), handled.handler("b", 3))
+	decodeAndPush(t, a, mockConsensusMessage{Height: 101, Type: qbft.PrepareMsgType}, mockState)
+	decodeAndPush(t, a, mockConsensusMessage{Height: 102, Type: qbft.PrepareMsgType}, mockState)
+	decodeAndPush(t, b, mockConsensusMessage{Height: 100, Type: qbft.PrepareMsgType}, mockState)
+	require.Equal(t, 2, metrics.depth("ATTESTER"))
+	require.Equal(t, 1, metrics.depth("PROPOSER"))
+
+	go scheduler.Start(ctx)
+	select {
+	case <-handled.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("messages weren't handled")
+	}
+
+	// The earliest deadline comes first, and each queue is consumed by its own priority.
+	require.Equal(t, []string{"b@100", "a@101", "a@102"}, handled.heights)
+	require.Eventually(t, func() bool {
+		return metrics.depth("ATTESTER") == 0 && metrics.depth("PROPOSER") == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestScheduler_ExpiredDeadlines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Slot 100 has ended, and slot 101 is running.
+	genesis := time.Now().Add(-101*12*time.Second - 6*time.Second)
+	slotStartTime := func(slot phase0.Slot) time.Time {
+		return genesis.Add(time.Duration(slot) * 12 * time.Second)
+	}
+	scheduler := NewScheduler(logging.TestLogger(t), 1, slotStartTime, newSchedulerMetrics())
+	handled := &handledMessages{done: make(chan struct{})}
+
+	a := scheduler.Register(ctx, "ATTESTER", New(10), 10, runningState(100), handled.handler("a", 3))
+	b := scheduler.Register(ctx, "PROPOSER", New(10), 10, runningState(101), handled.handler("b", 3))
+	decodeAndPush(t, a, mockConsensusMessage{Height: 100, Type: qbft.PrepareMsgType}, mockState)
+	decodeAndPush(t, a, mockConsensusMessage{Height: 100, Type: qbft.CommitMsgType}, mockState)
+	decodeAndPush(t, b, mockConsensusMessage{Height: 101, Type: qbft.PrepareMsgType}, mockState)
+
+	go scheduler.Start(ctx)
+	select {
+	case <-handled.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("messages weren't handled")
+	}
+
+	// Expired messages are handled after the live ones, rather than first for their earlier deadline.
+	require.Equal(t, []string{"b@101", "a@100", "a@100"}, handled.heights)
+}
+
+func TestScheduler_Fairness(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scheduler := NewScheduler(logging.TestLogger(t), 1, testSlotStartTime, newSchedulerMetrics())
+	handled := &handledMessages{done: make(chan struct{})}
+
+	a := scheduler.Register(ctx, "ATTESTER", New(10), 10, runningState(100), handled.handler("a", 4))
+	b := scheduler.Register(ctx, "ATTESTER", New(10), 10, runningState(100), handled.handler("b", 4))
+	decodeAndPush(t, a, mockConsensusMessage{Height: 100, Type: qbft.ProposalMsgType}, mockState)
+	decodeAndPush(t, a, mockConsensusMessage{Height: 100, Type: qbft.PrepareMsgType}, mockState)
+	decodeAndPush(t, a, mockConsensusMessage{Height: 100, Type: qbft.CommitMsgType}, mockState)
+	decodeAndPush(t, b, mockConsensusMessage{Height: 100, Type: qbft.ProposalMsgType}, mockState)
+
+	go scheduler.Start(ctx)
+	select {
+	case <-handled.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("messages weren't handled")
+	}
+
+	// Messages of the same deadline are handled in turns.
+	require.Equal(t, []string{"a@100", "b@100", "a@100", "a@100"}, handled.heights)
+}
+
+func TestScheduler_Shedding(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	metrics := newSchedulerMetrics()
+	scheduler := NewScheduler(logging.TestLogger(t), 1, testSlotStartTime, metrics)
+
+	// The scheduler isn't started, so pushed messages stay in the queue,
+	// as of a consumer at height 100.
+	q := scheduler.Register(ctx, "ATTESTER", New(2), 2, runningState(100), func(*DecodedSSVMessage) {
+		t.Error("unexpected message")
+	})
+	scheduler.mtx.Lock()
+	q.state = State{Height: 100}
+	scheduler.mtx.Unlock()
+
+	decode := func(height qbft.Height) *DecodedSSVMessage {
+		msg, err := DecodeSSVMessage(mockConsensusMessage{Height: height, Type: qbft.PrepareMsgType}.ssvMessage(mockState))
+		require.NoError(t, err)
+		return msg
+	}
+	require.True(t, q.TryPush(decode(99)))
+	require.True(t, q.TryPush(decode(101)))
+
+	// The stale message makes room for a new one.
+	require.True(t, q.TryPush(decode(102)))
+	require.Equal(t, 2, q.Len())
+
+	// Without stale messages, new ones are dropped.
+	require.False(t, q.TryPush(decode(103)))
+	require.Equal(t, 2, q.Len())
+	require.Equal(t, 2, metrics.depth("ATTESTER"))
+	metrics.mtx.Lock()
+	require.Equal(t, map[string]int{"ATTESTER/stale": 1, "ATTESTER/full": 1}, metrics.dropped)
+	metrics.mtx.Unlock()
+
+	// The queue is unregistered once its context is done.
+	cancel()
+	require.Eventually(t, func() bool {
+		return metrics.depth("ATTESTER") == 0
+	}, time.Second, 10*time.Millisecond)
+}
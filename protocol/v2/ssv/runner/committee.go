@@ -0,0 +1,609 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	"github.com/bloxapp/ssv/protocol/v2/qbft/controller"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/runner/metrics"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// IsCommitteeRole returns true if the duties of the given role are batched by a CommitteeRunner.
+func IsCommitteeRole(role spectypes.BeaconRole) bool {
+	return role == spectypes.BNRoleAttester || role == spectypes.BNRoleSyncCommittee
+}
+
+// committeeDuty is a duty of one of the committee's validators.
+type committeeDuty struct {
+	duty  *spectypes.Duty
+	share *spectypes.Share
+}
+
+type validatorRole struct {
+	pubKey phase0.BLSPubKey
+	role   spectypes.BeaconRole
+}
+
+// CommitteeValidators tells which validators share a committee of operators.
+type CommitteeValidators interface {
+	IsCommitteeValidator(pubKey phase0.BLSPubKey, committee []*spectypes.Operator) bool
+}
+
+// CommitteeRunner runs a single consensus instance per slot for the attester and sync committee duties
+// of all the validators sharing its committee of operators, deciding on the vote (head, source and target)
+// which they all sign, and batches their post-consensus partial signatures into a single message per operator.
+//
+// It's hosted by the committee's anchor validator, whose share signs the consensus messages.
+type CommitteeRunner struct {
+	BaseRunner *BaseRunner
+	// Validators, if set, bounds the partial signatures kept for duties which weren't signed yet
+	// to those of the committee's validators.
+	Validators CommitteeValidators
+
+	beacon   specssv.BeaconNode
+	network  specssv.Network
+	signer   spectypes.KeyManager
+	valCheck specqbft.ProposedValueCheckF
+
+	started time.Time
+	metrics metrics.ConsensusMetrics
+
+	// pending holds the duties added per slot, which are signed once their slot decides.
+	pending     map[phase0.Slot][]*committeeDuty
+	pendingLock sync.Mutex
+
+	// duties, postConsensus, unknown and submitted belong to the running slot.
+	duties        map[validatorRole]*committeeDuty
+	postConsensus map[validatorRole]*specssv.PartialSigContainer
+	// unknown holds partial signatures of duties which weren't signed by this operator yet, one per signer.
+	unknown   map[validatorRole]map[spectypes.OperatorID]*spectypes.PartialSignatureMessage
+	submitted map[validatorRole]bool
+}
+
+func NewCommitteeRunner(
+	beaconNetwork spectypes.BeaconNetwork,
+	share *spectypes.Share,
+	qbftController *controller.Controller,
+	beacon specssv.BeaconNode,
+	network specssv.Network,
+	signer spectypes.KeyManager,
+	valCheck specqbft.ProposedValueCheckF,
+	highestDecidedSlot phase0.Slot,
+) Runner {
+	return &CommitteeRunner{
+		BaseRunner: &BaseRunner{
+			BeaconRoleType:     message.BNRoleCommittee,
+			BeaconNetwork:      beaconNetwork,
+			Share:              share,
+			QBFTController:     qbftController,
+			highestDecidedSlot: highestDecidedSlot,
+		},
+
+		beacon:   beacon,
+		network:  network,
+		signer:   signer,
+		valCheck: valCheck,
+
+		metrics: metrics.NewConsensusMetrics(message.BNRoleCommittee),
+
+		pending: make(map[phase0.Slot][]*committeeDuty),
+	}
+}
+
+// CommitteeValueCheckF checks the vote decided by a committee, which is attestation data without a committee index.
+// slashable checks whether the vote is slashable for any of the committee's attesters.
+func CommitteeValueCheckF(
+	beaconNetwork spectypes.BeaconNetwork,
+	anchorPubKey spectypes.ValidatorPK,
+	slashable func(vote *phase0.AttestationData) error,
+) specqbft.ProposedValueCheckF {
+	return func(data []byte) error {
+		cd := &spectypes.ConsensusData{}
+		if err := cd.Decode(data); err != nil {
+			return errors.Wrap(err, "failed decoding consensus data")
+		}
+		if cd.Duty.Type != message.BNRoleCommittee {
+			return errors.New("wrong beacon role type")
+		}
+		if !anchorPubKey.MessageIDBelongs(spectypes.NewMsgID(spectypes.DomainType{}, cd.Duty.PubKey[:], message.BNRoleCommittee)) {
+			return errors.New("wrong validator pk")
+		}
+		if len(cd.PreConsensusJustifications) > 0 {
+			return errors.New("committee invalid justifications")
+		}
+
+		vote, err := cd.GetAttestationData()
+		if err != nil {
+			return errors.Wrap(err, "could not get vote")
+		}
+		if vote.Slot != cd.Duty.Slot {
+			return errors.New("vote slot != duty slot")
+		}
+		if vote.Index != 0 {
+			return errors.New("vote has a committee index")
+		}
+		if vote.Target.Epoch > beaconNetwork.EstimatedCurrentEpoch()+1 {
+			return errors.New("attestation data target epoch is into far future")
+		}
+		if vote.Source.Epoch >= vote.Target.Epoch {
+			return errors.New("attestation data source > target")
+		}
+		return slashable(vote)
+	}
+}
+
+// IsVoteSlashable returns an error if signing the vote would be slashable for any of the attester duties
+// added for its slot, as the attester runner checks before deciding.
+func (r *CommitteeRunner) IsVoteSlashable(vote *phase0.AttestationData) error {
+	r.pendingLock.Lock()
+	defer r.pendingLock.Unlock()
+
+	for _, d := range r.pending[vote.Slot] {
+		if d.duty.Type != spectypes.BNRoleAttester {
+			continue
+		}
+		attestationData, _ := committeeDutyObject(vote, d.duty)
+		if err := r.signer.IsAttestationSlashable(d.share.SharePubKey, attestationData.(*phase0.AttestationData)); err != nil {
+			return errors.Wrapf(err, "vote is slashable for validator %x", d.duty.PubKey[:])
+		}
+	}
+	return nil
+}
+
+// AddDuty adds a duty of one of the committee's validators, to be signed once its slot decides.
+// The committee's duty must be executed for the slot afterwards, even if it's running already.
+func (r *CommitteeRunner) AddDuty(duty *spectypes.Duty, share *spectypes.Share) {
+	r.pendingLock.Lock()
+	defer r.pendingLock.Unlock()
+
+	r.pending[duty.Slot] = append(r.pending[duty.Slot], &committeeDuty{duty: duty, share: share})
+}
+
+func (r *CommitteeRunner) StartNewDuty(logger *zap.Logger, duty *spectypes.Duty) error {
+	if state := r.BaseRunner.State; state != nil && state.StartingDuty.Slot == duty.Slot {
+		// The slot is running already, so duties added since are signed now if it decided,
+		// or once it decides otherwise.
+		if state.DecidedValue == nil {
+			return nil
+		}
+		return r.signDuties(logger)
+	}
+	return r.BaseRunner.baseStartNewDuty(logger, r, duty)
+}
+
+// HasRunningDuty returns true if a duty is already running (StartNewDuty called and returned nil)
+func (r *CommitteeRunner) HasRunningDuty() bool {
+	return r.BaseRunner.hasRunningDuty()
+}
+
+func (r *CommitteeRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg *spectypes.SignedPartialSignatureMessage) error {
+	return errors.New("no pre consensus sigs required for committee role")
+}
+
+func (r *CommitteeRunner) ProcessConsensus(logger *zap.Logger, signedMsg *specqbft.SignedMessage) error {
+	decided, _, err := r.BaseRunner.baseConsensusMsgProcessing(logger, r, signedMsg)
+	if err != nil {
+		return errors.Wrap(err, "failed processing consensus message")
+	}
+
+	// Decided returns true only once so if it is true it must be for the current running instance
+	if !decided {
+		return nil
+	}
+
+	r.metrics.EndConsensus()
+	r.metrics.StartPostConsensus()
+
+	return r.signDuties(logger)
+}
+
+func (r *CommitteeRunner) ProcessPostConsensus(logger *zap.Logger, signedMsg *spectypes.SignedPartialSignatureMessage) error {
+	return errors.New("committee post consensus sigs are batched")
+}
+
+// ProcessCommitteePostConsensus collects the batched partial signatures of an operator,
+// and submits the duties which reached a quorum.
+func (r *CommitteeRunner) ProcessCommitteePostConsensus(logger *zap.Logger, msg *types.CommitteePartialSignatures) error {
+	if !r.HasRunningDuty() {
+		return errors.New("no running duty")
+	}
+	decidedValue := r.GetState().DecidedValue
+	if decidedValue == nil {
+		return errors.New("no decided value")
+	}
+	if msg.Slot != decidedValue.Duty.Slot {
+		return errors.New("invalid partial sig slot")
+	}
+	if err := msg.Validate(); err != nil {
+		return errors.Wrap(err, "invalid committee partial signatures")
+	}
+	if !r.inCommittee(msg.Signer) {
+		return errors.New("unknown signer")
+	}
+
+	logger = logger.With(fields.Slot(msg.Slot))
+	logger.Debug("🧩 got batched partial signatures",
+		zap.Uint64("signer", msg.Signer),
+		zap.Int("duties", len(msg.Messages)))
+
+	for _, partialSig := range msg.Messages {
+		key := validatorRole{pubKey: partialSig.ValidatorPubKey, role: partialSig.Role}
+		if _, ok := r.duties[key]; !ok {
+			// The duty may be signed once it's added, if it's of one of the committee's validators.
+			if r.Validators != nil && !r.Validators.IsCommitteeValidator(key.pubKey, r.GetShare().Committee) {
+				continue
+			}
+			if r.unknown[key] == nil {
+				r.unknown[key] = make(map[spectypes.OperatorID]*spectypes.PartialSignatureMessage)
+			}
+			r.unknown[key][msg.Signer] = partialSig.Message
+			continue
+		}
+		if err := r.processDutyPartialSignature(logger, key, partialSig.Message); err != nil {
+			logger.Warn("❗ failed processing partial signature",
+				fields.PubKey(key.pubKey[:]),
+				fields.Role(key.role),
+				zap.Error(err))
+		}
+	}
+
+	if len(r.submitted) == len(r.duties) && r.pendingCount(msg.Slot) == 0 {
+		r.GetState().Finished = true
+	}
+	return nil
+}
+
+// processDutyPartialSignature adds the partial signature of a signed duty, and submits the duty once it reaches a quorum.
+func (r *CommitteeRunner) processDutyPartialSignature(logger *zap.Logger, key validatorRole, partialSig *spectypes.PartialSignatureMessage) error {
+	if r.submitted[key] {
+		return nil
+	}
+
+	d := r.duties[key]
+	vote, err := r.GetState().DecidedValue.GetAttestationData()
+	if err != nil {
+		return errors.Wrap(err, "could not get vote")
+	}
+	obj, domainType := committeeDutyObject(vote, d.duty)
+	root, err := r.signingRoot(obj, d.duty.Slot, domainType)
+	if err != nil {
+		return err
+	}
+	if partialSig.SigningRoot != root {
+		return errors.New("wrong signing root")
+	}
+
+	container := r.postConsensus[key]
+	if container.HasSigner(partialSig.Signer, partialSig.SigningRoot) {
+		r.resolveDuplicateSignature(d.share, container, partialSig)
+	} else {
+		container.AddSignature(partialSig)
+	}
+	if !container.HasQuorum(root) {
+		return nil
+	}
+
+	sig, err := types.ReconstructSignature(container, root, d.share.ValidatorPubKey)
+	if err != nil {
+		// If the reconstructed signature verification failed, fall back to verifying each partial signature
+		for operatorID, signature := range container.GetSignatures(root) {
+			if err := verifyBeaconPartialSignature(d.share, operatorID, signature, root); err != nil {
+				container.Remove(operatorID, root)
+			}
+		}
+		return errors.Wrap(err, "got post-consensus quorum but it has invalid signatures")
+	}
+	specSig := phase0.BLSSignature{}
+	copy(specSig[:], sig)
+
+	submissionEnd := r.metrics.StartBeaconSubmission()
+	start := time.Now()
+	switch d.duty.Type {
+	case spectypes.BNRoleAttester:
+		aggregationBitfield := bitfield.NewBitlist(d.duty.CommitteeLength)
+		aggregationBitfield.SetBitAt(d.duty.ValidatorCommitteeIndex, true)
+		err = r.beacon.SubmitAttestation(&phase0.Attestation{
+			Data:            obj.(*phase0.AttestationData),
+			Signature:       specSig,
+			AggregationBits: aggregationBitfield,
+		})
+	case spectypes.BNRoleSyncCommittee:
+		err = r.beacon.SubmitSyncMessage(&altair.SyncCommitteeMessage{
+			Slot:            d.duty.Slot,
+			BeaconBlockRoot: vote.BeaconBlockRoot,
+			ValidatorIndex:  d.duty.ValidatorIndex,
+			Signature:       specSig,
+		})
+	}
+	if err != nil {
+		r.metrics.RoleSubmissionFailed()
+		logger.Error("❌ failed to submit committee duty", fields.PubKey(key.pubKey[:]), fields.Role(key.role), zap.Error(err))
+		return errors.Wrap(err, "could not submit to Beacon chain reconstructed signature")
+	}
+	r.submitted[key] = true
+
+	submissionEnd()
+	r.metrics.EndDutyFullFlow(r.GetState().RunningInstance.State.Round)
+	r.metrics.RoleSubmitted()
+
+	logger.Info("✅ successfully submitted committee duty",
+		fields.PubKey(key.pubKey[:]),
+		fields.Role(key.role),
+		zap.String("block_root", hex.EncodeToString(vote.BeaconBlockRoot[:])),
+		fields.ConsensusTime(time.Since(r.started)),
+		fields.SubmissionTime(time.Since(start)),
+		fields.Height(r.BaseRunner.QBFTController.Height),
+		fields.Round(r.GetState().RunningInstance.State.Round))
+	return nil
+}
+
+// signDuties signs the duties added for the decided slot, and broadcasts their partial signatures in a single message.
+func (r *CommitteeRunner) signDuties(logger *zap.Logger) error {
+	decidedValue := r.GetState().DecidedValue
+	vote, err := decidedValue.GetAttestationData()
+	if err != nil {
+		return errors.Wrap(err, "could not get vote")
+	}
+	slot := decidedValue.Duty.Slot
+
+	r.pendingLock.Lock()
+	duties := r.pending[slot]
+	delete(r.pending, slot)
+	r.pendingLock.Unlock()
+
+	batch := &types.CommitteePartialSignatures{
+		Version: types.CommitteeConsensusVersion,
+		Slot:    slot,
+		Signer:  r.GetShare().OperatorID,
+	}
+	for _, d := range duties {
+		key := validatorRole{pubKey: d.duty.PubKey, role: d.duty.Type}
+		if _, ok := r.duties[key]; ok {
+			continue
+		}
+
+		obj, domainType := committeeDutyObject(vote, d.duty)
+		partialSig, err := r.signDutyObject(d.share, obj, slot, domainType)
+		if err != nil {
+			logger.Warn("❗ failed signing committee duty",
+				fields.PubKey(key.pubKey[:]),
+				fields.Role(key.role),
+				zap.Error(err))
+			continue
+		}
+		r.duties[key] = d
+		r.postConsensus[key] = specssv.NewPartialSigContainer(d.share.Quorum)
+		batch.Messages = append(batch.Messages, &types.ValidatorPartialSignature{
+			ValidatorPubKey: key.pubKey,
+			Role:            key.role,
+			Message:         partialSig,
+		})
+
+		// Process the partial signatures which arrived before the duty was signed.
+		for _, unknownSig := range r.unknown[key] {
+			if err := r.processDutyPartialSignature(logger, key, unknownSig); err != nil {
+				logger.Debug("❗ failed processing early partial signature", fields.PubKey(key.pubKey[:]), zap.Error(err))
+			}
+		}
+		delete(r.unknown, key)
+	}
+	if len(batch.Messages) == 0 {
+		return nil
+	}
+
+	data, err := batch.Encode()
+	if err != nil {
+		return errors.Wrap(err, "failed to encode committee partial signatures")
+	}
+	msgToBroadcast := &spectypes.SSVMessage{
+		MsgType: message.SSVCommitteePartialSignatureMsgType,
+		MsgID:   spectypes.NewMsgID(r.GetShare().DomainType, r.GetShare().ValidatorPubKey, r.BaseRunner.BeaconRoleType),
+		Data:    data,
+	}
+	if err := r.GetNetwork().Broadcast(msgToBroadcast); err != nil {
+		return errors.Wrap(err, "can't broadcast batched partial post consensus sigs")
+	}
+	logger.Debug("🧩 broadcasted batched partial signatures", fields.Slot(slot), zap.Int("duties", len(batch.Messages)))
+	return nil
+}
+
+// committeeDutyObject returns the object which the given duty signs for the decided vote.
+func committeeDutyObject(vote *phase0.AttestationData, duty *spectypes.Duty) (ssz.HashRoot, phase0.DomainType) {
+	if duty.Type == spectypes.BNRoleSyncCommittee {
+		return spectypes.SSZBytes(vote.BeaconBlockRoot[:]), spectypes.DomainSyncCommittee
+	}
+	attestationData := *vote
+	attestationData.Index = duty.CommitteeIndex
+	return &attestationData, spectypes.DomainAttester
+}
+
+func (r *CommitteeRunner) signDutyObject(share *spectypes.Share, obj ssz.HashRoot, slot phase0.Slot, domainType phase0.DomainType) (*spectypes.PartialSignatureMessage, error) {
+	epoch := r.BaseRunner.BeaconNetwork.EstimatedEpochAtSlot(slot)
+	domain, err := r.beacon.DomainData(epoch, domainType)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get beacon domain")
+	}
+	sig, root, err := r.signer.SignBeaconObject(obj, domain, share.SharePubKey, domainType)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign beacon object")
+	}
+	return &spectypes.PartialSignatureMessage{
+		PartialSignature: sig,
+		SigningRoot:      root,
+		Signer:           share.OperatorID,
+	}, nil
+}
+
+func (r *CommitteeRunner) signingRoot(obj ssz.HashRoot, slot phase0.Slot, domainType phase0.DomainType) ([32]byte, error) {
+	epoch := r.BaseRunner.BeaconNetwork.EstimatedEpochAtSlot(slot)
+	domain, err := r.beacon.DomainData(epoch, domainType)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not get beacon domain")
+	}
+	root, err := spectypes.ComputeETHSigningRoot(obj, domain)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not compute ETH signing root")
+	}
+	return root, nil
+}
+
+// resolveDuplicateSignature keeps the valid one of a signer's signatures, if any.
+func (r *CommitteeRunner) resolveDuplicateSignature(share *spectypes.Share, container *specssv.PartialSigContainer, msg *spectypes.PartialSignatureMessage) {
+	previousSignature, err := container.GetSignature(msg.Signer, msg.SigningRoot)
+	if err == nil && verifyBeaconPartialSignature(share, msg.Signer, previousSignature, msg.SigningRoot) == nil {
+		return
+	}
+	container.Remove(msg.Signer, msg.SigningRoot)
+	if verifyBeaconPartialSignature(share, msg.Signer, msg.PartialSignature, msg.SigningRoot) == nil {
+		container.AddSignature(msg)
+	}
+}
+
+func (r *CommitteeRunner) inCommittee(signer spectypes.OperatorID) bool {
+	for _, operator := range r.GetShare().Committee {
+		if operator.OperatorID == signer {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *CommitteeRunner) pendingCount(slot phase0.Slot) int {
+	r.pendingLock.Lock()
+	defer r.pendingLock.Unlock()
+	return len(r.pending[slot])
+}
+
+func (r *CommitteeRunner) expectedPreConsensusRootsAndDomain() ([]ssz.HashRoot, phase0.DomainType, error) {
+	return []ssz.HashRoot{}, spectypes.DomainError, errors.New("no expected pre consensus roots for committee")
+}
+
+// expectedPostConsensusRootsAndDomain an INTERNAL function, the roots of committees differ per duty
+func (r *CommitteeRunner) expectedPostConsensusRootsAndDomain() ([]ssz.HashRoot, phase0.DomainType, error) {
+	return []ssz.HashRoot{}, spectypes.DomainError, errors.New("committee post consensus roots differ per duty")
+}
+
+// executeDuty steps:
+// 1) get attestation data from BN, which the duties of all the committee's validators vote on
+// 2) start consensus on the vote
+// 3) Once consensus decides, sign partial attestations & sync committee messages and broadcast them in a batch
+// 4) collect 2f+1 partial sigs per duty, reconstruct and submit them to the BN
+func (r *CommitteeRunner) executeDuty(logger *zap.Logger, duty *spectypes.Duty) error {
+	r.duties = make(map[validatorRole]*committeeDuty)
+	r.postConsensus = make(map[validatorRole]*specssv.PartialSigContainer)
+	r.unknown = make(map[validatorRole]map[spectypes.OperatorID]*spectypes.PartialSignatureMessage)
+	r.submitted = make(map[validatorRole]bool)
+
+	// Request the attestation data of one of the attesters, as the data only differs by committee index.
+	var committeeIndex phase0.CommitteeIndex
+	r.pendingLock.Lock()
+	for slot := range r.pending {
+		if slot < duty.Slot {
+			delete(r.pending, slot)
+		}
+	}
+	for _, d := range r.pending[duty.Slot] {
+		if d.duty.Type == spectypes.BNRoleAttester {
+			committeeIndex = d.duty.CommitteeIndex
+			break
+		}
+	}
+	r.pendingLock.Unlock()
+
+	start := time.Now()
+	attData, _, err := r.GetBeaconNode().GetAttestationData(duty.Slot, committeeIndex)
+	if err != nil {
+		return errors.Wrap(err, "failed to get attestation data")
+	}
+	logger = logger.With(zap.Duration("attestation_data_time", time.Since(start)))
+
+	vote, ok := attData.(*phase0.AttestationData)
+	if !ok {
+		return errors.New("unexpected attestation data type")
+	}
+	voteCopy := *vote
+	voteCopy.Index = 0
+	voteBytes, err := voteCopy.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal vote")
+	}
+
+	r.started = time.Now()
+	r.metrics.StartDutyFullFlow()
+	r.metrics.StartConsensus()
+
+	input := &spectypes.ConsensusData{
+		Duty:    *duty,
+		Version: spec.DataVersionPhase0,
+		DataSSZ: voteBytes,
+	}
+	if err := r.BaseRunner.decide(logger, r, input); err != nil {
+		return errors.Wrap(err, "can't start new duty runner instance for duty")
+	}
+	return nil
+}
+
+func (r *CommitteeRunner) GetBaseRunner() *BaseRunner {
+	return r.BaseRunner
+}
+
+func (r *CommitteeRunner) GetNetwork() specssv.Network {
+	return r.network
+}
+
+func (r *CommitteeRunner) GetBeaconNode() specssv.BeaconNode {
+	return r.beacon
+}
+
+func (r *CommitteeRunner) GetShare() *spectypes.Share {
+	return r.BaseRunner.Share
+}
+
+func (r *CommitteeRunner) GetState() *State {
+	return r.BaseRunner.State
+}
+
+func (r *CommitteeRunner) GetValCheckF() specqbft.ProposedValueCheckF {
+	return r.valCheck
+}
+
+func (r *CommitteeRunner) GetSigner() spectypes.KeyManager {
+	return r.signer
+}
+
+// Encode returns the encoded struct in bytes or error
+func (r *CommitteeRunner) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// Decode returns error if decoding failed
+func (r *CommitteeRunner) Decode(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
+
+// GetRoot returns the root used for signing and verification
+func (r *CommitteeRunner) GetRoot() ([32]byte, error) {
+	marshaledRoot, err := r.Encode()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not encode DutyRunnerState")
+	}
+	ret := sha256.Sum256(marshaledRoot)
+	return ret, nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec"
 
 	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/controller"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner/metrics"
 )
@@ -30,6 +31,10 @@ type ProposerRunner struct {
 	BaseRunner *BaseRunner
 	// ProducesBlindedBlocks is true when the runner will only produce blinded blocks
 	ProducesBlindedBlocks bool
+	// ProposerSettings, if set, decides whether to produce blinded blocks per duty instead of ProducesBlindedBlocks
+	ProposerSettings beacon.ProposerSettingsProvider
+	// GraffitiData holds the node's variables of the graffiti template from ProposerSettings, if any.
+	GraffitiData beacon.GraffitiData
 
 	beacon   specssv.BeaconNode
 	network  specssv.Network
@@ -66,6 +71,45 @@ func NewProposerRunner(
 	}
 }
 
+// producesBlindedBlocks returns whether to produce a blinded block for the current duty.
+func (r *ProposerRunner) producesBlindedBlocks() bool {
+	if r.ProposerSettings == nil {
+		return r.ProducesBlindedBlocks
+	}
+	var pk phase0.BLSPubKey
+	copy(pk[:], r.GetShare().ValidatorPubKey)
+	return r.ProposerSettings.ProposerSettings(pk).BuilderEnabled
+}
+
+// graffiti returns the graffiti of the current duty's block,
+// rendered from the validator's graffiti template if it has one, or its static graffiti otherwise.
+func (r *ProposerRunner) graffiti(logger *zap.Logger) []byte {
+	if r.ProposerSettings == nil {
+		return r.GetShare().Graffiti
+	}
+	var pk phase0.BLSPubKey
+	copy(pk[:], r.GetShare().ValidatorPubKey)
+	template := r.ProposerSettings.ProposerSettings(pk).Graffiti
+	if template == nil {
+		return r.GetShare().Graffiti
+	}
+
+	data := r.GraffitiData
+	data.OperatorID = uint64(r.GetShare().OperatorID)
+	operatorIDs := make([]uint64, len(r.GetShare().Committee))
+	for i, operator := range r.GetShare().Committee {
+		operatorIDs[i] = uint64(operator.OperatorID)
+	}
+	data.Cluster = beacon.ClusterString(operatorIDs)
+
+	graffiti, err := template.Render(data)
+	if err != nil {
+		logger.Warn("could not render graffiti template, using static graffiti", zap.Error(err))
+		return r.GetShare().Graffiti
+	}
+	return graffiti
+}
+
 func (r *ProposerRunner) StartNewDuty(logger *zap.Logger, duty *spectypes.Duty) error {
 	return r.BaseRunner.baseStartNewDuty(logger, r, duty)
 }
@@ -109,15 +153,16 @@ func (r *ProposerRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg *spec
 	var ver spec.DataVersion
 	var obj ssz.Marshaler
 	var start = time.Now()
-	if r.ProducesBlindedBlocks {
+	graffiti := r.graffiti(logger)
+	if r.producesBlindedBlocks() {
 		// get block data
-		obj, ver, err = r.GetBeaconNode().GetBlindedBeaconBlock(duty.Slot, r.GetShare().Graffiti, fullSig)
+		obj, ver, err = r.GetBeaconNode().GetBlindedBeaconBlock(duty.Slot, graffiti, fullSig)
 		if err != nil {
 			return errors.Wrap(err, "failed to get blinded beacon block")
 		}
 	} else {
 		// get block data
-		obj, ver, err = r.GetBeaconNode().GetBeaconBlock(duty.Slot, r.GetShare().Graffiti, fullSig)
+		obj, ver, err = r.GetBeaconNode().GetBeaconBlock(duty.Slot, graffiti, fullSig)
 		if err != nil {
 			return errors.Wrap(err, "failed to get beacon block")
 		}
@@ -76,9 +76,14 @@ func (b *BaseRunner) validatePartialSigMsgForSlot(
 }
 
 func (b *BaseRunner) verifyBeaconPartialSignature(signer uint64, signature spectypes.Signature, root [32]byte) error {
+	return verifyBeaconPartialSignature(b.Share, signer, signature, root)
+}
+
+// verifyBeaconPartialSignature verifies a partial signature of the given share's signer
+func verifyBeaconPartialSignature(share *spectypes.Share, signer uint64, signature spectypes.Signature, root [32]byte) error {
 	types.MetricsSignaturesVerifications.WithLabelValues().Inc()
 
-	for _, n := range b.Share.Committee {
+	for _, n := range share.Committee {
 		if n.GetID() == signer {
 			pk, err := types.DeserializeBLSPublicKey(n.GetPublicKey())
 			if err != nil {
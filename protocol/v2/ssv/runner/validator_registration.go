@@ -15,12 +15,15 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/logging/fields"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/qbft/controller"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner/metrics"
 )
 
 type ValidatorRegistrationRunner struct {
 	BaseRunner *BaseRunner
+	// ProposerSettings, if set, provides the gas limit to register instead of the default one
+	ProposerSettings beacon.ProposerSettingsProvider
 
 	beacon   specssv.BeaconNode
 	network  specssv.Network
@@ -167,9 +170,14 @@ func (r *ValidatorRegistrationRunner) calculateValidatorRegistration() (*v1.Vali
 
 	epoch := r.BaseRunner.BeaconNetwork.EstimatedEpochAtSlot(r.BaseRunner.State.StartingDuty.Slot)
 
+	gasLimit := uint64(spectypes.DefaultGasLimit)
+	if r.ProposerSettings != nil {
+		gasLimit = r.ProposerSettings.ProposerSettings(pk).GasLimit
+	}
+
 	return &v1.ValidatorRegistration{
 		FeeRecipient: r.BaseRunner.Share.FeeRecipientAddress,
-		GasLimit:     spectypes.DefaultGasLimit,
+		GasLimit:     gasLimit,
 		Timestamp:    r.BaseRunner.BeaconNetwork.EpochStartTime(epoch),
 		Pubkey:       pk,
 	}, nil
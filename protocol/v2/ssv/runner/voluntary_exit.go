@@ -17,9 +17,27 @@ import (
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner/metrics"
 )
 
+// VoluntaryExitPlan describes how a voluntary exit duty is performed, when it isn't a regular exit.
+type VoluntaryExitPlan struct {
+	// Epoch, if set, is the epoch of the exit instead of the epoch of the duty.
+	Epoch phase0.Epoch
+	// PreSign has the exit returned to its owner rather than submitted to the beacon node.
+	PreSign bool
+}
+
+// VoluntaryExitEscrow plans voluntary exit duties, and receives every voluntary exit signed by the committee.
+type VoluntaryExitEscrow interface {
+	// VoluntaryExitPlan returns the plan of the validator's exit duty at the given slot, or nil for a regular exit.
+	VoluntaryExitPlan(pubKey phase0.BLSPubKey, slot phase0.Slot) *VoluntaryExitPlan
+	// SignedVoluntaryExit records a signed exit, and delivers it to its owner if it's pre-signed.
+	SignedVoluntaryExit(logger *zap.Logger, pubKey phase0.BLSPubKey, signedExit *phase0.SignedVoluntaryExit, preSigned bool) error
+}
+
 // Duty runner for validator voluntary exit duty
 type VoluntaryExitRunner struct {
 	BaseRunner *BaseRunner
+	// Escrow, if set, may have exits pre-signed rather than submitted, and keeps the record of every signed exit.
+	Escrow VoluntaryExitEscrow
 
 	beacon   specssv.BeaconNode
 	network  specssv.Network
@@ -27,6 +45,7 @@ type VoluntaryExitRunner struct {
 	valCheck specqbft.ProposedValueCheckF
 
 	voluntaryExit *phase0.VoluntaryExit
+	plan          *VoluntaryExitPlan
 
 	metrics metrics.ConsensusMetrics
 }
@@ -53,6 +72,10 @@ func NewVoluntaryExitRunner(
 }
 
 func (r *VoluntaryExitRunner) StartNewDuty(logger *zap.Logger, duty *spectypes.Duty) error {
+	r.plan = nil
+	if r.Escrow != nil {
+		r.plan = r.Escrow.VoluntaryExitPlan(duty.PubKey, duty.Slot)
+	}
 	return r.BaseRunner.baseStartNewNonBeaconDuty(logger, r, duty)
 }
 
@@ -62,7 +85,8 @@ func (r *VoluntaryExitRunner) HasRunningDuty() bool {
 }
 
 // Check for quorum of partial signatures over VoluntaryExit and,
-// if has quorum, constructs SignedVoluntaryExit and submits to BeaconNode
+// if has quorum, constructs SignedVoluntaryExit and submits to BeaconNode,
+// or hands it to the escrow if it's pre-signed
 func (r *VoluntaryExitRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg *spectypes.SignedPartialSignatureMessage) error {
 	quorum, roots, err := r.BaseRunner.basePreConsensusMsgProcessing(r, signedMsg)
 	if err != nil {
@@ -91,6 +115,25 @@ func (r *VoluntaryExitRunner) ProcessPreConsensus(logger *zap.Logger, signedMsg
 		Signature: specSig,
 	}
 
+	preSigned := r.plan != nil && r.plan.PreSign
+	if r.Escrow != nil {
+		pubKey := phase0.BLSPubKey{}
+		copy(pubKey[:], r.GetShare().ValidatorPubKey)
+		if err := r.Escrow.SignedVoluntaryExit(logger, pubKey, signedVoluntaryExit, preSigned); err != nil {
+			return errors.Wrap(err, "could not record signed voluntary exit")
+		}
+	}
+
+	if preSigned {
+		logger.Debug("voluntary exit pre-signed successfully",
+			fields.Epoch(r.voluntaryExit.Epoch),
+			zap.Uint64("validator_index", uint64(r.voluntaryExit.ValidatorIndex)),
+			zap.String("signature", hex.EncodeToString(specSig[:])),
+		)
+		r.GetState().Finished = true
+		return nil
+	}
+
 	if err := r.beacon.SubmitVoluntaryExit(signedVoluntaryExit); err != nil {
 		return errors.Wrap(err, "could not submit voluntary exit")
 	}
@@ -178,9 +221,12 @@ func (r *VoluntaryExitRunner) executeDuty(logger *zap.Logger, duty *spectypes.Du
 	return nil
 }
 
-// Returns *phase0.VoluntaryExit object with current epoch and own validator index
+// Returns *phase0.VoluntaryExit object with current epoch (or the planned one) and own validator index
 func (r *VoluntaryExitRunner) calculateVoluntaryExit() (*phase0.VoluntaryExit, error) {
 	epoch := r.BaseRunner.BeaconNetwork.EstimatedEpochAtSlot(r.BaseRunner.State.StartingDuty.Slot)
+	if r.plan != nil && r.plan.Epoch != 0 {
+		epoch = r.plan.Epoch
+	}
 	validatorIndex := r.GetState().StartingDuty.ValidatorIndex
 	return &phase0.VoluntaryExit{
 		Epoch:          epoch,
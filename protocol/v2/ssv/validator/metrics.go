@@ -22,6 +22,7 @@ type Metrics interface {
 	ValidatorUnknown(publicKey []byte)
 
 	queue.Metrics
+	queue.SchedulerMetrics
 }
 
 type NopMetrics struct{}
@@ -43,3 +44,5 @@ func (n NopMetrics) DroppedQueueMessage(spectypes.MessageID)               {}
 func (n NopMetrics) MessageQueueSize(int)                                  {}
 func (n NopMetrics) MessageQueueCapacity(int)                              {}
 func (n NopMetrics) MessageTimeInQueue(spectypes.MessageID, time.Duration) {}
+func (n NopMetrics) QueueDepth(string, int)                                {}
+func (n NopMetrics) QueueDroppedMessages(string, string, int)              {}
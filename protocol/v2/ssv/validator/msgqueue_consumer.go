@@ -63,6 +63,34 @@ func (v *Validator) StartQueueConsumer(logger *zap.Logger, msgID spectypes.Messa
 	}
 }
 
+// scheduleQueue has the queue of the given message ID consumed with the handler by the node's queue.Scheduler,
+// until the validator is stopped.
+func (v *Validator) scheduleQueue(logger *zap.Logger, msgID spectypes.MessageID, handler MessageHandler) {
+	v.mtx.Lock() // write-lock for v.Queues
+	defer v.mtx.Unlock()
+
+	role := msgID.GetRoleType()
+	q, ok := v.Queues[role]
+	if !ok {
+		logger.Error("❌ missing queue for role type", fields.Role(role))
+		return
+	}
+	container := q
+	q.Q = v.scheduler.Register(v.ctx, message.RoleToString(role), q.Q, v.queueSize,
+		func() (*queue.State, queue.Filter, error) {
+			return v.queueStateAndFilter(container, msgID)
+		},
+		func(msg *queue.DecodedSSVMessage) {
+			if err := handler(logger, msg); err != nil {
+				v.logMsg(logger, msg, "❗ could not handle message",
+					fields.MessageType(msg.SSVMessage.MsgType),
+					zap.Error(err))
+			}
+		},
+	)
+	v.Queues[role] = q
+}
+
 // ConsumeQueue consumes messages from the queue.Queue of the controller
 // it checks for current state
 func (v *Validator) ConsumeQueue(logger *zap.Logger, msgID spectypes.MessageID, handler MessageHandler) error {
@@ -89,51 +117,13 @@ func (v *Validator) ConsumeQueue(logger *zap.Logger, msgID spectypes.MessageID,
 	lens := make([]int, 0, 10)
 
 	for ctx.Err() == nil {
-		// Construct a representation of the current state.
-		state := *q.queueState
-		runner := v.DutyRunners.DutyRunnerForMsgID(msgID)
-		if runner == nil {
-			return fmt.Errorf("could not get duty runner for msg ID %v", msgID)
-		}
-		var runningInstance *instance.Instance
-		if runner.HasRunningDuty() {
-			runningInstance = runner.GetBaseRunner().State.RunningInstance
-			if runningInstance != nil {
-				decided, _ := runningInstance.IsDecided()
-				state.HasRunningInstance = !decided
-			}
-		}
-		state.Height = v.GetLastHeight(msgID)
-		state.Round = v.GetLastRound(msgID)
-		state.Quorum = v.Share.Quorum
-
-		filter := queue.FilterAny
-		if !runner.HasRunningDuty() {
-			// If no duty is running, pop only ExecuteDuty messages.
-			filter = func(m *queue.DecodedSSVMessage) bool {
-				e, ok := m.Body.(*types.EventMsg)
-				if !ok {
-					return false
-				}
-				return e.Type == types.ExecuteDuty
-			}
-		} else if runningInstance != nil && runningInstance.State.ProposalAcceptedForCurrentRound == nil {
-			// If no proposal was accepted for the current round, skip prepare & commit messages
-			// for the current height and round.
-			filter = func(m *queue.DecodedSSVMessage) bool {
-				sm, ok := m.Body.(*specqbft.SignedMessage)
-				if !ok {
-					return true
-				}
-				if sm.Message.Height != state.Height || sm.Message.Round != state.Round {
-					return true
-				}
-				return sm.Message.MsgType != specqbft.PrepareMsgType && sm.Message.MsgType != specqbft.CommitMsgType
-			}
+		state, filter, err := v.queueStateAndFilter(q, msgID)
+		if err != nil {
+			return err
 		}
 
 		// Pop the highest priority message for the current state.
-		msg := q.Q.Pop(ctx, queue.NewMessagePrioritizer(&state), filter)
+		msg := q.Q.Pop(ctx, queue.NewMessagePrioritizer(state), filter)
 		if ctx.Err() != nil {
 			break
 		}
@@ -161,6 +151,88 @@ func (v *Validator) ConsumeQueue(logger *zap.Logger, msgID spectypes.MessageID,
 	return nil
 }
 
+// ProcessPendingMessages pops and handles the messages in the queue of the given message ID
+// until none of the remaining messages can be processed in the current state, and returns
+// the number of handled messages.
+// It's meant for deterministic drivers (such as simulations) of validators started with
+// StartWithoutConsumers, and must not be called concurrently with a running queue consumer.
+func (v *Validator) ProcessPendingMessages(logger *zap.Logger, msgID spectypes.MessageID, handler MessageHandler) (int, error) {
+	v.mtx.RLock() // read v.Queues
+	q, ok := v.Queues[msgID.GetRoleType()]
+	v.mtx.RUnlock()
+	if !ok {
+		return 0, fmt.Errorf("queue not found for role %s", msgID.GetRoleType().String())
+	}
+
+	handled := 0
+	for {
+		state, filter, err := v.queueStateAndFilter(q, msgID)
+		if err != nil {
+			return handled, err
+		}
+		msg := q.Q.TryPop(queue.NewMessagePrioritizer(state), filter)
+		if msg == nil {
+			return handled, nil
+		}
+		handled++
+
+		if err := handler(logger, msg); err != nil {
+			v.logMsg(logger, msg, "❗ could not handle message",
+				fields.MessageType(msg.SSVMessage.MsgType),
+				zap.Error(err))
+		}
+	}
+}
+
+// queueStateAndFilter returns a representation of the current state of the given queue,
+// alongside a filter of the messages which can be processed in that state.
+func (v *Validator) queueStateAndFilter(q queueContainer, msgID spectypes.MessageID) (*queue.State, queue.Filter, error) {
+	// Construct a representation of the current state.
+	state := *q.queueState
+	runner := v.DutyRunners.DutyRunnerForMsgID(msgID)
+	if runner == nil {
+		return nil, nil, fmt.Errorf("could not get duty runner for msg ID %v", msgID)
+	}
+	var runningInstance *instance.Instance
+	if runner.HasRunningDuty() {
+		runningInstance = runner.GetBaseRunner().State.RunningInstance
+		if runningInstance != nil {
+			decided, _ := runningInstance.IsDecided()
+			state.HasRunningInstance = !decided
+		}
+	}
+	state.Height = v.GetLastHeight(msgID)
+	state.Round = v.GetLastRound(msgID)
+	state.Quorum = v.Share.Quorum
+
+	filter := queue.FilterAny
+	if !runner.HasRunningDuty() {
+		// If no duty is running, pop only ExecuteDuty messages.
+		filter = func(m *queue.DecodedSSVMessage) bool {
+			e, ok := m.Body.(*types.EventMsg)
+			if !ok {
+				return false
+			}
+			return e.Type == types.ExecuteDuty
+		}
+	} else if runningInstance != nil && runningInstance.State.ProposalAcceptedForCurrentRound == nil {
+		// If no proposal was accepted for the current round, skip prepare & commit messages
+		// for the current height and round.
+		filter = func(m *queue.DecodedSSVMessage) bool {
+			sm, ok := m.Body.(*specqbft.SignedMessage)
+			if !ok {
+				return true
+			}
+			if sm.Message.Height != state.Height || sm.Message.Round != state.Round {
+				return true
+			}
+			return sm.Message.MsgType != specqbft.PrepareMsgType && sm.Message.MsgType != specqbft.CommitMsgType
+		}
+	}
+
+	return &state, filter, nil
+}
+
 func (v *Validator) logMsg(logger *zap.Logger, msg *queue.DecodedSSVMessage, logMsg string, withFields ...zap.Field) {
 	baseFields := []zap.Field{}
 	switch msg.SSVMessage.MsgType {
@@ -7,8 +7,11 @@ import (
 
 	"github.com/bloxapp/ssv/ibft/storage"
 	"github.com/bloxapp/ssv/message/validation"
+	"github.com/bloxapp/ssv/notifications"
 	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	qbftctrl "github.com/bloxapp/ssv/protocol/v2/qbft/controller"
+	"github.com/bloxapp/ssv/protocol/v2/qbft/roundtimer"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
 	"github.com/bloxapp/ssv/protocol/v2/types"
 )
@@ -30,10 +33,27 @@ type Options struct {
 	FullNode          bool
 	Exporter          bool
 	BuilderProposals  bool
-	QueueSize         int
-	GasLimit          uint64
-	MessageValidator  validation.MessageValidator
-	Metrics           Metrics
+	// BatchedConsensus sets up the committee runners, which decide attester and sync committee duties
+	// by a single consensus per committee and slot from the network's committee consensus epoch.
+	BatchedConsensus bool
+	// CommitteeValidators, if set, tells committee runners which validators share their committee.
+	CommitteeValidators runner.CommitteeValidators
+	QueueSize           int
+	// Scheduler, if set, consumes the validator's queues instead of a goroutine per queue.
+	Scheduler *queue.Scheduler
+	GasLimit  uint64
+	// ProposerSettings, if set, overrides BuilderProposals and GasLimit per validator.
+	ProposerSettings beacon.ProposerSettingsProvider
+	// GraffitiData holds the node's variables of graffiti templates.
+	GraffitiData beacon.GraffitiData
+	// VoluntaryExitEscrow, if set, may have exits pre-signed rather than submitted, and keeps the record of every signed exit.
+	VoluntaryExitEscrow runner.VoluntaryExitEscrow
+	MessageValidator    validation.MessageValidator
+	Metrics             Metrics
+	// Notifier, if set, is notified about missed duties.
+	Notifier notifications.Notifier
+	// Clock is the source of time for the round timers, defaults to roundtimer.SystemClock.
+	Clock roundtimer.Clock
 }
 
 func (o *Options) defaults() {
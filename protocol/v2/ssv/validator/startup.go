@@ -16,6 +16,16 @@ import (
 
 // Start starts a Validator.
 func (v *Validator) Start(logger *zap.Logger) (started bool, err error) {
+	return v.start(logger, true)
+}
+
+// StartWithoutConsumers starts a Validator without spawning its queue consumers,
+// leaving it to the caller to process queued messages with ProcessPendingMessages.
+func (v *Validator) StartWithoutConsumers(logger *zap.Logger) (started bool, err error) {
+	return v.start(logger, false)
+}
+
+func (v *Validator) start(logger *zap.Logger, consumeQueues bool) (started bool, err error) {
 	logger = logger.Named(logging.NameValidator).With(fields.PubKey(v.Share.ValidatorPubKey))
 
 	if !atomic.CompareAndSwapUint32(&v.state, uint32(NotStarted), uint32(Started)) {
@@ -53,11 +63,23 @@ func (v *Validator) Start(logger *zap.Logger) (started bool, err error) {
 		if err := n.Subscribe(identifier.GetPubKey()); err != nil {
 			return true, err
 		}
-		go v.StartQueueConsumer(logger, identifier, v.ProcessMessage)
+		if !consumeQueues {
+			continue
+		}
+		if v.scheduler != nil {
+			v.scheduleQueue(logger, identifier, v.ProcessMessage)
+		} else {
+			go v.StartQueueConsumer(logger, identifier, v.ProcessMessage)
+		}
 	}
 	return true, nil
 }
 
+// Started returns whether the validator is running.
+func (v *Validator) Started() bool {
+	return atomic.LoadUint32(&v.state) == uint32(Started)
+}
+
 // Stop stops a Validator.
 func (v *Validator) Stop() {
 	if atomic.CompareAndSwapUint32(&v.state, uint32(Started), uint32(NotStarted)) {
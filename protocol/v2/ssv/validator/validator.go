@@ -2,7 +2,9 @@ package validator
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"strconv"
 	"sync"
 
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
@@ -14,6 +16,7 @@ import (
 	"github.com/bloxapp/ssv/ibft/storage"
 	"github.com/bloxapp/ssv/logging/fields"
 	"github.com/bloxapp/ssv/message/validation"
+	"github.com/bloxapp/ssv/notifications"
 	"github.com/bloxapp/ssv/protocol/v2/message"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
@@ -42,6 +45,9 @@ type Validator struct {
 	state uint32
 
 	messageValidator validation.MessageValidator
+	scheduler        *queue.Scheduler
+	queueSize        int
+	notifier         notifications.Notifier
 }
 
 // NewValidator creates a new instance of Validator.
@@ -51,6 +57,9 @@ func NewValidator(pctx context.Context, cancel func(), options Options) *Validat
 	if options.Metrics == nil {
 		options.Metrics = &NopMetrics{}
 	}
+	if options.Notifier == nil {
+		options.Notifier = notifications.NopNotifier{}
+	}
 
 	v := &Validator{
 		mtx:              &sync.RWMutex{},
@@ -65,6 +74,9 @@ func NewValidator(pctx context.Context, cancel func(), options Options) *Validat
 		state:            uint32(NotStarted),
 		dutyIDs:          hashmap.New[spectypes.BeaconRole, string](),
 		messageValidator: options.MessageValidator,
+		scheduler:        options.Scheduler,
+		queueSize:        options.QueueSize,
+		notifier:         options.Notifier,
 	}
 
 	for _, dutyRunner := range options.DutyRunners {
@@ -107,9 +119,27 @@ func (v *Validator) StartDuty(logger *zap.Logger, duty *spectypes.Duty) error {
 
 	logger.Info("ℹ️ starting duty processing")
 
+	if dutyRunner.HasRunningDuty() {
+		v.notifyMissedDuty(logger, baseRunner, duty)
+	}
+
 	return dutyRunner.StartNewDuty(logger, duty)
 }
 
+// notifyMissedDuty notifies about the running duty of the runner, which didn't finish before the given duty started.
+func (v *Validator) notifyMissedDuty(logger *zap.Logger, baseRunner *runner.BaseRunner, duty *spectypes.Duty) {
+	missed := baseRunner.State.StartingDuty
+	if missed == nil || missed.Slot >= duty.Slot {
+		return
+	}
+	logger.Warn("duty didn't finish before the next duty started", zap.Uint64("missed_slot", uint64(missed.Slot)))
+	v.notifier.Notify(notifications.NewEvent(notifications.EventDutyMissed, "duty didn't finish before the next duty started", map[string]string{
+		"validator": "0x" + hex.EncodeToString(v.Share.ValidatorPubKey),
+		"role":      missed.Type.String(),
+		"slot":      strconv.FormatUint(uint64(missed.Slot), 10),
+	}))
+}
+
 // ProcessMessage processes Network Message of all types
 func (v *Validator) ProcessMessage(logger *zap.Logger, msg *queue.DecodedSSVMessage) error {
 	messageID := msg.GetID()
@@ -143,6 +173,18 @@ func (v *Validator) ProcessMessage(logger *zap.Logger, msg *queue.DecodedSSVMess
 			return dutyRunner.ProcessPostConsensus(logger, signedMsg)
 		}
 		return dutyRunner.ProcessPreConsensus(logger, signedMsg)
+	case message.SSVCommitteePartialSignatureMsgType:
+		logger = trySetDutyID(logger, v.dutyIDs, messageID.GetRoleType())
+
+		signedMsg, ok := msg.Body.(*types.CommitteePartialSignatures)
+		if !ok {
+			return errors.New("could not decode committee partial signatures from network message")
+		}
+		committeeRunner, ok := dutyRunner.(*runner.CommitteeRunner)
+		if !ok {
+			return errors.New("committee partial signatures for a non committee runner")
+		}
+		return committeeRunner.ProcessCommitteePostConsensus(logger, signedMsg)
 	case message.SSVEventMsgType:
 		return v.handleEventMessage(logger, msg, dutyRunner)
 	default:
@@ -1,18 +1,50 @@
 package types
 
 import (
+	"crypto/sha256"
+
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/herumi/bls-eth-go-binary/bls"
 )
 
 var blsPublicKeyCache *lru.Cache[string, bls.PublicKey]
 
+// verifiedSignatureCache holds the signatures already verified by message validation.
+var verifiedSignatureCache *lru.Cache[[32]byte, struct{}]
+
 func init() {
 	var err error
 	blsPublicKeyCache, err = lru.New[string, bls.PublicKey](128_000)
 	if err != nil {
 		panic(err)
 	}
+	verifiedSignatureCache, err = lru.New[[32]byte, struct{}](128_000)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// MarkSignatureVerified records that the signature of the given root by the aggregate of the given
+// public keys is valid, so that VerifyByOperators doesn't verify it again.
+func MarkSignatureVerified(sig []byte, root [32]byte, pubKeys [][]byte) {
+	verifiedSignatureCache.Add(verifiedSignatureKey(sig, root, pubKeys), struct{}{})
+}
+
+// signatureVerified returns whether the signature was recorded by MarkSignatureVerified.
+func signatureVerified(sig []byte, root [32]byte, pubKeys [][]byte) bool {
+	return verifiedSignatureCache.Contains(verifiedSignatureKey(sig, root, pubKeys))
+}
+
+func verifiedSignatureKey(sig []byte, root [32]byte, pubKeys [][]byte) [32]byte {
+	h := sha256.New()
+	h.Write(sig)
+	h.Write(root[:])
+	for _, pubKey := range pubKeys {
+		h.Write(pubKey)
+	}
+	var key [32]byte
+	copy(key[:], h.Sum(nil))
+	return key
 }
 
 // DeserializeBLSPublicKey deserializes a bls.PublicKey from bytes,
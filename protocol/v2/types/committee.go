@@ -0,0 +1,79 @@
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+// CommitteeConsensusVersion is the version of batched committee consensus on the wire,
+// messages of other versions are rejected.
+const CommitteeConsensusVersion = 1
+
+// ValidatorPartialSignature is an operator's partial signature for the duty of one of the committee's validators.
+type ValidatorPartialSignature struct {
+	ValidatorPubKey phase0.BLSPubKey                   `json:"validator_pubkey"`
+	Role            spectypes.BeaconRole               `json:"role"`
+	Message         *spectypes.PartialSignatureMessage `json:"message"`
+}
+
+// CommitteePartialSignatures batches an operator's post-consensus partial signatures
+// for the duties of all the committee's validators at a slot.
+type CommitteePartialSignatures struct {
+	Version  uint64                       `json:"version"`
+	Slot     phase0.Slot                  `json:"slot"`
+	Signer   spectypes.OperatorID         `json:"signer"`
+	Messages []*ValidatorPartialSignature `json:"messages"`
+}
+
+// Validate checks the message's structure, without verifying its signatures
+func (m *CommitteePartialSignatures) Validate() error {
+	if m.Version != CommitteeConsensusVersion {
+		return errors.Errorf("unsupported version %d", m.Version)
+	}
+	if m.Signer == 0 {
+		return errors.New("signer ID 0 not allowed")
+	}
+	if len(m.Messages) == 0 {
+		return errors.New("no partial signatures")
+	}
+
+	type validatorRole struct {
+		pubKey phase0.BLSPubKey
+		role   spectypes.BeaconRole
+	}
+	seen := make(map[validatorRole]struct{}, len(m.Messages))
+	for _, msg := range m.Messages {
+		if msg == nil || msg.Message == nil {
+			return errors.New("empty partial signature")
+		}
+		if msg.Role != spectypes.BNRoleAttester && msg.Role != spectypes.BNRoleSyncCommittee {
+			return errors.Errorf("unexpected role %s", msg.Role)
+		}
+		key := validatorRole{msg.ValidatorPubKey, msg.Role}
+		if _, ok := seen[key]; ok {
+			return errors.New("duplicated partial signature")
+		}
+		seen[key] = struct{}{}
+
+		if msg.Message.Signer != m.Signer {
+			return errors.New("partial signature of another signer")
+		}
+		if err := msg.Message.Validate(); err != nil {
+			return errors.Wrap(err, "invalid partial signature")
+		}
+	}
+	return nil
+}
+
+// Encode returns a msg encoded bytes or error
+func (m *CommitteePartialSignatures) Encode() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+// Decode returns error if decoding failed
+func (m *CommitteePartialSignatures) Decode(data []byte) error {
+	return json.Unmarshal(data, m)
+}
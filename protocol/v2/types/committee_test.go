@@ -0,0 +1,71 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitteePartialSignatures(t *testing.T) {
+	partialSig := func(pubKey byte, role spectypes.BeaconRole, signer spectypes.OperatorID) *ValidatorPartialSignature {
+		return &ValidatorPartialSignature{
+			ValidatorPubKey: phase0.BLSPubKey{pubKey},
+			Role:            role,
+			Message: &spectypes.PartialSignatureMessage{
+				PartialSignature: make([]byte, 96),
+				SigningRoot:      [32]byte{pubKey},
+				Signer:           signer,
+			},
+		}
+	}
+	valid := func() *CommitteePartialSignatures {
+		return &CommitteePartialSignatures{
+			Version: CommitteeConsensusVersion,
+			Slot:    10,
+			Signer:  1,
+			Messages: []*ValidatorPartialSignature{
+				partialSig(1, spectypes.BNRoleAttester, 1),
+				partialSig(1, spectypes.BNRoleSyncCommittee, 1),
+				partialSig(2, spectypes.BNRoleAttester, 1),
+			},
+		}
+	}
+
+	msg := valid()
+	require.NoError(t, msg.Validate())
+
+	// survives the round trip through the p2p message.
+	data, err := msg.Encode()
+	require.NoError(t, err)
+	decoded := &CommitteePartialSignatures{}
+	require.NoError(t, decoded.Decode(data))
+	require.Equal(t, msg, decoded)
+
+	tests := []struct {
+		name   string
+		modify func(msg *CommitteePartialSignatures)
+		err    string
+	}{
+		{"unsupported version", func(msg *CommitteePartialSignatures) { msg.Version = 2 }, "unsupported version"},
+		{"no signer", func(msg *CommitteePartialSignatures) { msg.Signer = 0 }, "signer ID 0"},
+		{"empty", func(msg *CommitteePartialSignatures) { msg.Messages = nil }, "no partial signatures"},
+		{"unexpected role", func(msg *CommitteePartialSignatures) {
+			msg.Messages[0].Role = spectypes.BNRoleProposer
+		}, "unexpected role"},
+		{"duplicated", func(msg *CommitteePartialSignatures) {
+			msg.Messages = append(msg.Messages, partialSig(2, spectypes.BNRoleAttester, 1))
+		}, "duplicated"},
+		{"another signer", func(msg *CommitteePartialSignatures) {
+			msg.Messages[2].Message.Signer = 2
+		}, "another signer"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := valid()
+			test.modify(msg)
+			require.ErrorContains(t, msg.Validate(), test.err)
+		})
+	}
+}
@@ -31,15 +31,11 @@ func init() {
 // DeserializeBLSPublicKey function and bounded.CGO
 //
 // TODO: rethink this function and consider moving/refactoring it.
+//
+// Signatures already verified by message validation (see MarkSignatureVerified) aren't verified again.
 func VerifyByOperators(s spectypes.Signature, data spectypes.MessageSignature, domain spectypes.DomainType, sigType spectypes.SignatureType, operators []*spectypes.Operator) error {
-	MetricsSignaturesVerifications.WithLabelValues().Inc()
-
-	sign := &bls.Sign{}
-	if err := sign.Deserialize(s); err != nil {
-		return errors.Wrap(err, "failed to deserialize signature")
-	}
-
 	pks := make([]bls.PublicKey, 0)
+	pkBytes := make([][]byte, 0, len(data.GetSigners()))
 	for _, id := range data.GetSigners() {
 		found := false
 		for _, n := range operators {
@@ -50,6 +46,7 @@ func VerifyByOperators(s spectypes.Signature, data spectypes.MessageSignature, d
 				}
 
 				pks = append(pks, pk)
+				pkBytes = append(pkBytes, n.GetPublicKey())
 				found = true
 			}
 		}
@@ -63,6 +60,17 @@ func VerifyByOperators(s spectypes.Signature, data spectypes.MessageSignature, d
 		return errors.Wrap(err, "could not compute signing root")
 	}
 
+	if signatureVerified(s, computedRoot, pkBytes) {
+		return nil
+	}
+
+	MetricsSignaturesVerifications.WithLabelValues().Inc()
+
+	sign := &bls.Sign{}
+	if err := sign.Deserialize(s); err != nil {
+		return errors.Wrap(err, "failed to deserialize signature")
+	}
+
 	if res := sign.FastAggregateVerify(pks, computedRoot[:]); !res {
 		return errors.New("failed to verify signature")
 	}
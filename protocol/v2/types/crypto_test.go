@@ -0,0 +1,29 @@
+package types
+
+import (
+	"testing"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	spectestingutils "github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyByOperators(t *testing.T) {
+	ks := spectestingutils.Testing4SharesSet()
+	committee := spectestingutils.TestingShare(ks).Committee
+
+	// signed by another operator's share.
+	signedMsg := spectestingutils.TestingProposalMessageWithHeight(ks.Shares[2], 1, 7)
+	require.Error(t, VerifyByOperators(signedMsg.Signature, signedMsg, spectypes.PrimusTestnet, spectypes.QBFTSignatureType, committee))
+
+	root, err := spectypes.ComputeSigningRoot(signedMsg, spectypes.ComputeSignatureDomain(spectypes.PrimusTestnet, spectypes.QBFTSignatureType))
+	require.NoError(t, err)
+
+	// A signature recorded as verified for other public keys is still verified.
+	MarkSignatureVerified(signedMsg.Signature, root, [][]byte{committee[1].PubKey})
+	require.Error(t, VerifyByOperators(signedMsg.Signature, signedMsg, spectypes.PrimusTestnet, spectypes.QBFTSignatureType, committee))
+
+	// A signature recorded as verified isn't verified again.
+	MarkSignatureVerified(signedMsg.Signature, root, [][]byte{committee[0].PubKey})
+	require.NoError(t, VerifyByOperators(signedMsg.Signature, signedMsg, spectypes.PrimusTestnet, spectypes.QBFTSignatureType, committee))
+}
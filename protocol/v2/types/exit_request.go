@@ -0,0 +1,302 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/networkconfig"
+)
+
+const (
+	exitRequestDomainName    = "SSVNetwork"
+	exitRequestDomainVersion = "1"
+	exitRequestPrimaryType   = "ValidatorExit"
+	exitClaimPrimaryType     = "ValidatorExitClaim"
+
+	// ExitClaimPurpose is the purpose which exit claims are signed for, so that the owner's wallet shows it.
+	ExitClaimPurpose = "claim the pre-signed exits of the validator"
+	// MaxExitClaimValidity is the latest expiry of exit claims, which are used once right after they're signed.
+	MaxExitClaimValidity = 5 * time.Minute
+	exitClaimNonceSize   = 32
+)
+
+// ExitRequest is a validator owner's request to exit a validator,
+// signed by the owner with EIP-712 so that it can be produced offline and submitted to any operator of the validator.
+type ExitRequest struct {
+	ValidatorPubKey hexutil.Bytes  `json:"validator_pubkey"`
+	Owner           common.Address `json:"owner"`
+	// Epoch, if set, is the epoch of the voluntary exit instead of the current epoch.
+	// It's only allowed for pre-signed exits, since the beacon chain rejects exits from future epochs.
+	Epoch phase0.Epoch `json:"epoch,omitempty"`
+	// PreSign requests that the voluntary exit is signed and returned to the owner rather than submitted.
+	PreSign bool `json:"presign,omitempty"`
+	// Deadline is the unix time after which the request is no longer accepted.
+	Deadline  uint64        `json:"deadline"`
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// TypedData returns the EIP-712 typed data which the owner signs.
+func (r *ExitRequest) TypedData(network networkconfig.NetworkConfig) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": exitTypedDataDomainType,
+			exitRequestPrimaryType: []apitypes.Type{
+				{Name: "validatorPubKey", Type: "bytes"},
+				{Name: "epoch", Type: "uint64"},
+				{Name: "preSign", Type: "bool"},
+				{Name: "deadline", Type: "uint256"},
+			},
+		},
+		PrimaryType: exitRequestPrimaryType,
+		Domain:      exitTypedDataDomain(network),
+		Message: apitypes.TypedDataMessage{
+			"validatorPubKey": r.ValidatorPubKey.String(),
+			"epoch":           (*math.HexOrDecimal256)(new(big.Int).SetUint64(uint64(r.Epoch))),
+			"preSign":         r.PreSign,
+			"deadline":        (*math.HexOrDecimal256)(new(big.Int).SetUint64(r.Deadline)),
+		},
+	}
+}
+
+// SigningHash returns the EIP-712 hash which the owner signs.
+func (r *ExitRequest) SigningHash(network networkconfig.NetworkConfig) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(r.TypedData(network))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash exit request")
+	}
+	return hash, nil
+}
+
+// Sign signs the request with the owner's key, and sets the owner accordingly.
+func (r *ExitRequest) Sign(network networkconfig.NetworkConfig, key *ecdsa.PrivateKey) error {
+	hash, err := r.SigningHash(network)
+	if err != nil {
+		return err
+	}
+	signature, err := signTypedDataHash(hash, key)
+	if err != nil {
+		return errors.Wrap(err, "could not sign exit request")
+	}
+	r.Owner = crypto.PubkeyToAddress(key.PublicKey)
+	r.Signature = signature
+	return nil
+}
+
+// Verify checks that the request is well formed, unexpired at the given time and signed by its owner.
+func (r *ExitRequest) Verify(network networkconfig.NetworkConfig, now time.Time) error {
+	if len(r.ValidatorPubKey) != len(phase0.BLSPubKey{}) {
+		return fmt.Errorf("invalid validator public key length %d", len(r.ValidatorPubKey))
+	}
+	if r.Epoch != 0 && !r.PreSign {
+		return errors.New("exit epoch can only be set for pre-signed exits")
+	}
+	if uint64(now.Unix()) > r.Deadline {
+		return errors.New("exit request has expired")
+	}
+	hash, err := r.SigningHash(network)
+	if err != nil {
+		return err
+	}
+	signer, err := typedDataSigner(hash, r.Signature)
+	if err != nil {
+		return errors.Wrap(err, "could not recover exit request signer")
+	}
+	if signer != r.Owner {
+		return fmt.Errorf("exit request is signed by %s rather than its owner %s", signer, r.Owner)
+	}
+	return nil
+}
+
+// ExitClaim is a validator owner's claim of the validator's pre-signed exits from an operator's API.
+// Unlike exit requests, which are shared with the committee, claims are sent only to the operator they're addressed to,
+// and each claim is accepted once before it expires shortly after it's signed.
+type ExitClaim struct {
+	ValidatorPubKey hexutil.Bytes `json:"validator_pubkey"`
+	// Purpose must be ExitClaimPurpose, so that exit claims can't be mistaken for other signed messages.
+	Purpose string `json:"purpose"`
+	// Claimant is the address which signed the claim, which must be the validator's owner.
+	Claimant common.Address `json:"claimant"`
+	// Operator is the operator whose API the claim is sent to.
+	Operator spectypes.OperatorID `json:"operator"`
+	// Nonce is random, so that each claim is accepted only once.
+	Nonce hexutil.Bytes `json:"nonce"`
+	// Expiry is the unix time after which the claim is no longer accepted.
+	Expiry    uint64        `json:"expiry"`
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// NewExitClaim returns an unsigned claim of the validator's pre-signed exits from the given operator,
+// with a random nonce, which expires after MaxExitClaimValidity.
+func NewExitClaim(validatorPubKey []byte, operator spectypes.OperatorID, now time.Time) (*ExitClaim, error) {
+	nonce := make([]byte, exitClaimNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "could not generate nonce")
+	}
+	return &ExitClaim{
+		ValidatorPubKey: validatorPubKey,
+		Purpose:         ExitClaimPurpose,
+		Operator:        operator,
+		Nonce:           nonce,
+		Expiry:          uint64(now.Add(MaxExitClaimValidity).Unix()),
+	}, nil
+}
+
+// TypedData returns the EIP-712 typed data which the claimant signs.
+func (c *ExitClaim) TypedData(network networkconfig.NetworkConfig) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": exitTypedDataDomainType,
+			exitClaimPrimaryType: []apitypes.Type{
+				{Name: "validatorPubKey", Type: "bytes"},
+				{Name: "purpose", Type: "string"},
+				{Name: "claimant", Type: "address"},
+				{Name: "operator", Type: "uint64"},
+				{Name: "nonce", Type: "bytes"},
+				{Name: "expiry", Type: "uint256"},
+			},
+		},
+		PrimaryType: exitClaimPrimaryType,
+		Domain:      exitTypedDataDomain(network),
+		Message: apitypes.TypedDataMessage{
+			"validatorPubKey": c.ValidatorPubKey.String(),
+			"purpose":         c.Purpose,
+			"claimant":        c.Claimant.String(),
+			"operator":        (*math.HexOrDecimal256)(new(big.Int).SetUint64(c.Operator)),
+			"nonce":           c.Nonce.String(),
+			"expiry":          (*math.HexOrDecimal256)(new(big.Int).SetUint64(c.Expiry)),
+		},
+	}
+}
+
+// SigningHash returns the EIP-712 hash which the claimant signs.
+func (c *ExitClaim) SigningHash(network networkconfig.NetworkConfig) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(c.TypedData(network))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash exit claim")
+	}
+	return hash, nil
+}
+
+// Sign sets the claimant to the given key's address and signs the claim with it.
+func (c *ExitClaim) Sign(network networkconfig.NetworkConfig, key *ecdsa.PrivateKey) error {
+	c.Claimant = crypto.PubkeyToAddress(key.PublicKey)
+	hash, err := c.SigningHash(network)
+	if err != nil {
+		return err
+	}
+	signature, err := signTypedDataHash(hash, key)
+	if err != nil {
+		return errors.Wrap(err, "could not sign exit claim")
+	}
+	c.Signature = signature
+	return nil
+}
+
+// Verify checks that the claim is well formed, addressed to the given operator,
+// unexpired at the given time without outliving MaxExitClaimValidity, and signed by its claimant.
+func (c *ExitClaim) Verify(network networkconfig.NetworkConfig, operator spectypes.OperatorID, now time.Time) error {
+	if len(c.ValidatorPubKey) != len(phase0.BLSPubKey{}) {
+		return fmt.Errorf("invalid validator public key length %d", len(c.ValidatorPubKey))
+	}
+	if c.Purpose != ExitClaimPurpose {
+		return fmt.Errorf("unexpected exit claim purpose %q", c.Purpose)
+	}
+	if c.Operator != operator {
+		return fmt.Errorf("exit claim is addressed to operator %d rather than %d", c.Operator, operator)
+	}
+	if len(c.Nonce) != exitClaimNonceSize {
+		return fmt.Errorf("invalid nonce length %d", len(c.Nonce))
+	}
+	if uint64(now.Unix()) > c.Expiry {
+		return errors.New("exit claim has expired")
+	}
+	if c.Expiry > uint64(now.Add(MaxExitClaimValidity).Unix()) {
+		return fmt.Errorf("exit claim expires later than %s from now", MaxExitClaimValidity)
+	}
+	hash, err := c.SigningHash(network)
+	if err != nil {
+		return err
+	}
+	signer, err := typedDataSigner(hash, c.Signature)
+	if err != nil {
+		return errors.Wrap(err, "could not recover exit claim signer")
+	}
+	if signer != c.Claimant {
+		return fmt.Errorf("exit claim is signed by %s rather than its claimant %s", signer, c.Claimant)
+	}
+	return nil
+}
+
+var exitTypedDataDomainType = []apitypes.Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+}
+
+func exitTypedDataDomain(network networkconfig.NetworkConfig) apitypes.TypedDataDomain {
+	return apitypes.TypedDataDomain{
+		Name:              exitRequestDomainName,
+		Version:           exitRequestDomainVersion,
+		ChainId:           (*math.HexOrDecimal256)(new(big.Int).SetUint64(network.ChainID())),
+		VerifyingContract: network.RegistryContractAddr,
+	}
+}
+
+// signTypedDataHash signs an EIP-712 hash with a recovery ID of 27 or 28, as wallets do.
+func signTypedDataHash(hash []byte, key *ecdsa.PrivateKey) ([]byte, error) {
+	signature, err := crypto.Sign(hash, key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+// typedDataSigner recovers the address which signed an EIP-712 hash, with either form of recovery ID.
+func typedDataSigner(hash []byte, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	signature = common.CopyBytes(signature)
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// ExitRequestMessage carries an exit request to the rest of the validator's committee,
+// so that they all exit the validator at the slot chosen by the operator which received it.
+// The message must be signed by that operator, so that only the committee can schedule the exit.
+type ExitRequestMessage struct {
+	Request  ExitRequest          `json:"request"`
+	Slot     phase0.Slot          `json:"slot"`
+	Operator spectypes.OperatorID `json:"operator"`
+}
+
+// Encode returns a msg encoded bytes or error
+func (m *ExitRequestMessage) Encode() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+// Decode returns error if decoding failed
+func (m *ExitRequestMessage) Decode(data []byte) error {
+	return json.Unmarshal(data, m)
+}
@@ -0,0 +1,161 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/networkconfig"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestExitRequest(t *testing.T) {
+	network := networkconfig.TestNetwork
+	now := time.Now()
+
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	request := &ExitRequest{
+		ValidatorPubKey: make([]byte, 48),
+		Deadline:        uint64(now.Add(time.Hour).Unix()),
+	}
+	require.NoError(t, request.Sign(network, ownerKey))
+	require.Equal(t, crypto.PubkeyToAddress(ownerKey.PublicKey), request.Owner)
+	require.NoError(t, request.Verify(network, now))
+
+	// survives the round trip through the p2p message.
+	data, err := (&ExitRequestMessage{Request: *request, Slot: 10, Operator: 1}).Encode()
+	require.NoError(t, err)
+	msg := &ExitRequestMessage{}
+	require.NoError(t, msg.Decode(data))
+	require.EqualValues(t, 10, msg.Slot)
+	require.EqualValues(t, 1, msg.Operator)
+	require.NoError(t, msg.Request.Verify(network, now))
+
+	t.Run("expired", func(t *testing.T) {
+		require.ErrorContains(t, request.Verify(network, now.Add(2*time.Hour)), "expired")
+	})
+
+	t.Run("other owner", func(t *testing.T) {
+		other := *request
+		other.Owner = common.HexToAddress("0x01")
+		require.ErrorContains(t, other.Verify(network, now), "rather than its owner")
+	})
+
+	t.Run("other validator", func(t *testing.T) {
+		other := *request
+		other.ValidatorPubKey = append([]byte{1}, request.ValidatorPubKey[1:]...)
+		require.Error(t, other.Verify(network, now))
+	})
+
+	t.Run("pre-signed", func(t *testing.T) {
+		preSigned := &ExitRequest{
+			ValidatorPubKey: request.ValidatorPubKey,
+			Epoch:           100,
+			PreSign:         true,
+			Deadline:        request.Deadline,
+		}
+		require.NoError(t, preSigned.Sign(network, ownerKey))
+		require.NoError(t, preSigned.Verify(network, now))
+
+		// the epoch and pre-sign mode are signed by the owner.
+		other := *preSigned
+		other.Epoch = 101
+		require.Error(t, other.Verify(network, now))
+		other = *preSigned
+		other.PreSign = false
+		require.Error(t, other.Verify(network, now))
+	})
+
+	t.Run("epoch without pre-sign", func(t *testing.T) {
+		other := &ExitRequest{
+			ValidatorPubKey: request.ValidatorPubKey,
+			Epoch:           100,
+			Deadline:        request.Deadline,
+		}
+		require.NoError(t, other.Sign(network, ownerKey))
+		require.ErrorContains(t, other.Verify(network, now), "pre-signed")
+	})
+
+	t.Run("other network", func(t *testing.T) {
+		other := network
+		other.RegistryContractAddr = "0x0000000000000000000000000000000000000002"
+		require.Error(t, request.Verify(other, now))
+	})
+
+	t.Run("other chain", func(t *testing.T) {
+		other := network
+		other.Beacon = beacon.NewNetwork(spectypes.MainNetwork)
+		require.NotEqual(t, network.ChainID(), other.ChainID())
+		require.Error(t, request.Verify(other, now))
+	})
+}
+
+func TestExitClaim(t *testing.T) {
+	network := networkconfig.TestNetwork
+	now := time.Now()
+
+	ownerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	claim, err := NewExitClaim(make([]byte, 48), 1, now)
+	require.NoError(t, err)
+	require.NoError(t, claim.Sign(network, ownerKey))
+	require.Equal(t, crypto.PubkeyToAddress(ownerKey.PublicKey), claim.Claimant)
+	require.NoError(t, claim.Verify(network, 1, now))
+
+	t.Run("fresh nonce", func(t *testing.T) {
+		other, err := NewExitClaim(claim.ValidatorPubKey, 1, now)
+		require.NoError(t, err)
+		require.NotEqual(t, claim.Nonce, other.Nonce)
+	})
+
+	t.Run("other operator", func(t *testing.T) {
+		require.ErrorContains(t, claim.Verify(network, 2, now), "addressed to operator")
+
+		other := *claim
+		other.Operator = 2
+		require.ErrorContains(t, other.Verify(network, 2, now), "rather than its claimant")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		require.ErrorContains(t, claim.Verify(network, 1, now.Add(MaxExitClaimValidity+time.Minute)), "expired")
+	})
+
+	t.Run("long-lived", func(t *testing.T) {
+		other := *claim
+		other.Expiry = uint64(now.Add(time.Hour).Unix())
+		require.NoError(t, other.Sign(network, ownerKey))
+		require.ErrorContains(t, other.Verify(network, 1, now), "expires later")
+	})
+
+	t.Run("other purpose", func(t *testing.T) {
+		other := *claim
+		other.Purpose = "exit"
+		require.NoError(t, other.Sign(network, ownerKey))
+		require.ErrorContains(t, other.Verify(network, 1, now), "purpose")
+	})
+
+	t.Run("other claimant", func(t *testing.T) {
+		other := *claim
+		other.Claimant = common.HexToAddress("0x01")
+		require.ErrorContains(t, other.Verify(network, 1, now), "rather than its claimant")
+	})
+
+	t.Run("exit request signature", func(t *testing.T) {
+		// an exit request, which is shared with the committee, can't pass for a claim.
+		request := &ExitRequest{
+			ValidatorPubKey: claim.ValidatorPubKey,
+			Deadline:        claim.Expiry,
+		}
+		require.NoError(t, request.Sign(network, ownerKey))
+		other := *claim
+		other.Signature = request.Signature
+		require.ErrorContains(t, other.Verify(network, 1, now), "rather than its claimant")
+	})
+}
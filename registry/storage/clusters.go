@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var (
+	clustersPrefix    = []byte("clusters")
+	networkFeesPrefix = []byte("network_fees")
+)
+
+// Cluster is the state of a cluster in the contract, as of the last event which changed it.
+type Cluster struct {
+	Owner           common.Address `json:"owner"`
+	OperatorIDs     []uint64       `json:"operator_ids"`
+	ValidatorCount  uint32         `json:"validator_count"`
+	NetworkFeeIndex uint64         `json:"network_fee_index"`
+	Index           uint64         `json:"index"`
+	Active          bool           `json:"active"`
+	Balance         *big.Int       `json:"balance"`
+	// UpdatedBlock is the block of the event which last changed the cluster, as of which Balance is accurate.
+	UpdatedBlock uint64 `json:"updated_block"`
+}
+
+// ID returns the cluster's ID, which is the hash of its owner and operator IDs.
+func (c *Cluster) ID() []byte {
+	return clusterID(c.Owner, c.OperatorIDs)
+}
+
+// BalanceAt estimates the cluster's balance at the given block, given the fees it pays per block.
+func (c *Cluster) BalanceAt(blockNumber uint64, burnRate *big.Int) *big.Int {
+	balance := new(big.Int)
+	if c.Balance != nil {
+		balance.Set(c.Balance)
+	}
+	if blockNumber > c.UpdatedBlock {
+		balance.Sub(balance, new(big.Int).Mul(burnRate, new(big.Int).SetUint64(blockNumber-c.UpdatedBlock)))
+	}
+	if balance.Sign() < 0 {
+		balance.SetUint64(0)
+	}
+	return balance
+}
+
+// BlocksToLiquidation estimates the number of blocks after the given block until the cluster can be liquidated,
+// given the fees it pays per block. Returns false if the cluster doesn't pay fees and so can't be liquidated.
+func (c *Cluster) BlocksToLiquidation(blockNumber uint64, burnRate *big.Int, networkFees *NetworkFees) (uint64, bool) {
+	if burnRate.Sign() <= 0 {
+		return 0, false
+	}
+	surplus := new(big.Int).Sub(c.BalanceAt(blockNumber, burnRate), networkFees.LiquidationCollateral(burnRate))
+	if surplus.Sign() <= 0 {
+		return 0, true
+	}
+	blocks := surplus.Div(surplus, burnRate)
+	if !blocks.IsUint64() {
+		return 0, false
+	}
+	return blocks.Uint64(), true
+}
+
+// RunwayDays converts a number of blocks to days, given the time between blocks.
+func RunwayDays(blocks uint64, blockTime time.Duration) float64 {
+	return float64(blocks) * blockTime.Seconds() / (24 * time.Hour).Seconds()
+}
+
+// BalanceInSSV converts a balance from the SSV token's smallest unit to SSV.
+func BalanceInSSV(balance *big.Int) float64 {
+	ssv, _ := new(big.Float).Quo(new(big.Float).SetInt(balance), big.NewFloat(1e18)).Float64()
+	return ssv
+}
+
+// BurnRate returns the fees a cluster pays per block, given the fees of its operators and the network fee.
+func BurnRate(validatorCount uint32, operatorFees []*big.Int, networkFee *big.Int) *big.Int {
+	fee := new(big.Int)
+	if networkFee != nil {
+		fee.Set(networkFee)
+	}
+	for _, operatorFee := range operatorFees {
+		if operatorFee != nil {
+			fee.Add(fee, operatorFee)
+		}
+	}
+	return fee.Mul(fee, new(big.Int).SetUint64(uint64(validatorCount)))
+}
+
+// NetworkFees is the network-wide fee and liquidation parameters of the contract.
+type NetworkFees struct {
+	NetworkFee                   *big.Int `json:"network_fee"`
+	LiquidationThresholdPeriod   uint64   `json:"liquidation_threshold_period"`
+	MinimumLiquidationCollateral *big.Int `json:"minimum_liquidation_collateral"`
+}
+
+// LiquidationCollateral returns the balance below which a cluster which pays the given fees per block can be liquidated.
+func (n *NetworkFees) LiquidationCollateral(burnRate *big.Int) *big.Int {
+	collateral := new(big.Int).Mul(burnRate, new(big.Int).SetUint64(n.LiquidationThresholdPeriod))
+	if n.MinimumLiquidationCollateral != nil && collateral.Cmp(n.MinimumLiquidationCollateral) < 0 {
+		collateral.Set(n.MinimumLiquidationCollateral)
+	}
+	return collateral
+}
+
+// Clusters is the interface for the state of clusters and the network fees they pay
+type Clusters interface {
+	GetCluster(r basedb.Reader, owner common.Address, operatorIDs []uint64) (*Cluster, bool, error)
+	SaveCluster(rw basedb.ReadWriter, cluster *Cluster) error
+	DeleteCluster(rw basedb.ReadWriter, owner common.Address, operatorIDs []uint64) error
+	// ListClusters returns the clusters, ordered by ID.
+	ListClusters(r basedb.Reader) ([]*Cluster, error)
+	// GetNetworkFees returns the network fees, which are zero until the contract's events update them.
+	GetNetworkFees(r basedb.Reader) (*NetworkFees, error)
+	SaveNetworkFees(rw basedb.ReadWriter, networkFees *NetworkFees) error
+	DropClusters() error
+}
+
+type clustersStorage struct {
+	logger *zap.Logger
+	db     basedb.Database
+	lock   sync.RWMutex
+	prefix []byte
+}
+
+// NewClustersStorage creates a new instance of Clusters
+func NewClustersStorage(logger *zap.Logger, db basedb.Database, prefix []byte) Clusters {
+	return &clustersStorage{
+		logger: logger,
+		db:     db,
+		prefix: prefix,
+	}
+}
+
+func (s *clustersStorage) GetCluster(r basedb.Reader, owner common.Address, operatorIDs []uint64) (*Cluster, bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	obj, found, err := s.db.UsingReader(r).Get(s.prefix, buildClusterKey(clusterID(owner, operatorIDs)))
+	if err != nil || !found {
+		return nil, found, err
+	}
+	cluster := &Cluster{}
+	if err := json.Unmarshal(obj.Value, cluster); err != nil {
+		return nil, found, errors.Wrap(err, "could not unmarshal cluster")
+	}
+	return cluster, found, nil
+}
+
+func (s *clustersStorage) SaveCluster(rw basedb.ReadWriter, cluster *Cluster) error {
+	value, err := json.Marshal(cluster)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal cluster")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Using(rw).Set(s.prefix, buildClusterKey(cluster.ID()), value)
+}
+
+func (s *clustersStorage) DeleteCluster(rw basedb.ReadWriter, owner common.Address, operatorIDs []uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Using(rw).Delete(s.prefix, buildClusterKey(clusterID(owner, operatorIDs)))
+}
+
+func (s *clustersStorage) ListClusters(r basedb.Reader) ([]*Cluster, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var clusters []*Cluster
+	err := s.db.UsingReader(r).GetAll(bytes.Join([][]byte{s.prefix, clustersPrefix, []byte("/")}, nil), func(i int, obj basedb.Obj) error {
+		cluster := &Cluster{}
+		if err := json.Unmarshal(obj.Value, cluster); err != nil {
+			return errors.Wrap(err, "could not unmarshal cluster")
+		}
+		clusters = append(clusters, cluster)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return bytes.Compare(clusters[i].ID(), clusters[j].ID()) < 0
+	})
+	return clusters, nil
+}
+
+func (s *clustersStorage) GetNetworkFees(r basedb.Reader) (*NetworkFees, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	networkFees := &NetworkFees{
+		NetworkFee:                   new(big.Int),
+		MinimumLiquidationCollateral: new(big.Int),
+	}
+	obj, found, err := s.db.UsingReader(r).Get(s.prefix, networkFeesPrefix)
+	if err != nil || !found {
+		return networkFees, err
+	}
+	if err := json.Unmarshal(obj.Value, networkFees); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal network fees")
+	}
+	return networkFees, nil
+}
+
+func (s *clustersStorage) SaveNetworkFees(rw basedb.ReadWriter, networkFees *NetworkFees) error {
+	value, err := json.Marshal(networkFees)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal network fees")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Using(rw).Set(s.prefix, networkFeesPrefix, value)
+}
+
+// DropClusters deletes all clusters and the network fees
+func (s *clustersStorage) DropClusters() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.db.DropPrefix(bytes.Join([][]byte{s.prefix, clustersPrefix, []byte("/")}, nil)); err != nil {
+		return err
+	}
+	return s.db.Delete(s.prefix, networkFeesPrefix)
+}
+
+// clusterID computes the ID of a cluster without reordering the given operator IDs.
+func clusterID(owner common.Address, operatorIDs []uint64) []byte {
+	return types.ComputeClusterIDHash(owner, append([]uint64{}, operatorIDs...))
+}
+
+// buildClusterKey builds cluster key using clustersPrefix & cluster ID, e.g. "clusters/0x00..01"
+func buildClusterKey(id []byte) []byte {
+	return bytes.Join([][]byte{clustersPrefix, id}, []byte("/"))
+}
+
+// ClusterBurnRate returns the fees the given cluster pays per block, looking up the fees of its operators.
+// Operators which aren't found are assumed to charge no fee.
+func ClusterBurnRate(r basedb.Reader, operators Operators, cluster *Cluster, networkFees *NetworkFees) (*big.Int, error) {
+	operatorFees := make([]*big.Int, 0, len(cluster.OperatorIDs))
+	for _, id := range cluster.OperatorIDs {
+		od, found, err := operators.GetOperatorData(r, id)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get operator data")
+		}
+		if found {
+			operatorFees = append(operatorFees, od.Fee)
+		}
+	}
+	return BurnRate(cluster.ValidatorCount, operatorFees, networkFees.NetworkFee), nil
+}
@@ -0,0 +1,88 @@
+package storage_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestClustersStorage(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	clusters := storage.NewClustersStorage(logger, db, []byte("test"))
+
+	networkFees, err := clusters.GetNetworkFees(nil)
+	require.NoError(t, err)
+	require.Zero(t, networkFees.NetworkFee.Sign())
+
+	owner := common.HexToAddress("0x1")
+	require.NoError(t, clusters.SaveCluster(nil, &storage.Cluster{Owner: owner, OperatorIDs: []uint64{1, 2, 3, 4}, Balance: big.NewInt(1)}))
+	require.NoError(t, clusters.SaveCluster(nil, &storage.Cluster{Owner: owner, OperatorIDs: []uint64{5, 6, 7, 8}, Balance: big.NewInt(2)}))
+
+	// Clusters are found regardless of the order of their operator IDs.
+	cluster, found, err := clusters.GetCluster(nil, owner, []uint64{4, 3, 2, 1})
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 1, cluster.Balance.Int64())
+
+	list, err := clusters.ListClusters(nil)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	require.NoError(t, clusters.DeleteCluster(nil, owner, []uint64{1, 2, 3, 4}))
+	_, found, err = clusters.GetCluster(nil, owner, []uint64{1, 2, 3, 4})
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, clusters.SaveNetworkFees(nil, &storage.NetworkFees{NetworkFee: big.NewInt(3), LiquidationThresholdPeriod: 4}))
+	require.NoError(t, clusters.DropClusters())
+	list, err = clusters.ListClusters(nil)
+	require.NoError(t, err)
+	require.Empty(t, list)
+	networkFees, err = clusters.GetNetworkFees(nil)
+	require.NoError(t, err)
+	require.Zero(t, networkFees.LiquidationThresholdPeriod)
+}
+
+func TestClusterLiquidation(t *testing.T) {
+	networkFees := &storage.NetworkFees{
+		NetworkFee:                   big.NewInt(10),
+		LiquidationThresholdPeriod:   100,
+		MinimumLiquidationCollateral: big.NewInt(5000),
+	}
+	cluster := &storage.Cluster{ValidatorCount: 2, Balance: big.NewInt(10000), UpdatedBlock: 10}
+
+	burnRate := storage.BurnRate(cluster.ValidatorCount, []*big.Int{big.NewInt(5), big.NewInt(5), nil}, networkFees.NetworkFee)
+	require.EqualValues(t, 40, burnRate.Int64())
+
+	// The minimum collateral applies while it's above the fees of the liquidation threshold period.
+	require.EqualValues(t, 5000, networkFees.LiquidationCollateral(burnRate).Int64())
+	require.EqualValues(t, 20000, networkFees.LiquidationCollateral(big.NewInt(200)).Int64())
+
+	require.EqualValues(t, 10000, cluster.BalanceAt(5, burnRate).Int64())
+	require.EqualValues(t, 9600, cluster.BalanceAt(20, burnRate).Int64())
+	require.Zero(t, cluster.BalanceAt(1000, burnRate).Sign())
+
+	blocks, ok := cluster.BlocksToLiquidation(20, burnRate, networkFees)
+	require.True(t, ok)
+	require.EqualValues(t, (9600-5000)/40, blocks)
+	blocks, ok = cluster.BlocksToLiquidation(1000, burnRate, networkFees)
+	require.True(t, ok)
+	require.Zero(t, blocks)
+	_, ok = cluster.BlocksToLiquidation(20, new(big.Int), networkFees)
+	require.False(t, ok)
+
+	require.Equal(t, 1.0, storage.RunwayDays(7200, 12*time.Second))
+	require.Equal(t, 2.5, storage.BalanceInSSV(new(big.Int).Mul(big.NewInt(25), big.NewInt(1e17))))
+}
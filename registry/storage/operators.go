@@ -3,6 +3,7 @@ package storage
 import (
 	"bytes"
 	"encoding/json"
+	"math/big"
 	"strconv"
 	"sync"
 
@@ -24,6 +25,12 @@ type OperatorData struct {
 	ID           spectypes.OperatorID `json:"id"`
 	PublicKey    []byte               `json:"publicKey"`
 	OwnerAddress common.Address       `json:"ownerAddress"`
+	// Fee is the operator's fee per block and validator.
+	Fee *big.Int `json:"fee,omitempty"`
+	// DeclaredFee is the fee which the operator declared and hasn't executed or cancelled yet.
+	DeclaredFee *big.Int `json:"declaredFee,omitempty"`
+	// WhitelistedAddress is the only address which can register validators with the operator, if set.
+	WhitelistedAddress common.Address `json:"whitelistedAddress"`
 }
 
 // GetOperatorData is a function that returns the operator data
@@ -35,6 +42,8 @@ type Operators interface {
 	GetOperatorData(r basedb.Reader, id spectypes.OperatorID) (*OperatorData, bool, error)
 	OperatorsExist(r basedb.Reader, ids []spectypes.OperatorID) (bool, error)
 	SaveOperatorData(rw basedb.ReadWriter, operatorData *OperatorData) (bool, error)
+	// UpdateOperatorData saves operator data, replacing the existing data of the operator.
+	UpdateOperatorData(rw basedb.ReadWriter, operatorData *OperatorData) error
 	DeleteOperatorData(rw basedb.ReadWriter, id spectypes.OperatorID) error
 	ListOperators(r basedb.Reader, from uint64, to uint64) ([]OperatorData, error)
 	GetOperatorsPrefix() []byte
@@ -206,6 +215,21 @@ func (s *operatorsStorage) SaveOperatorData(
 	return found, s.db.Using(rw).Set(s.prefix, buildOperatorKey(operatorData.ID), raw)
 }
 
+// UpdateOperatorData saves operator data, replacing the existing data of the operator
+func (s *operatorsStorage) UpdateOperatorData(
+	rw basedb.ReadWriter,
+	operatorData *OperatorData,
+) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	raw, err := json.Marshal(operatorData)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal operator data")
+	}
+	return s.db.Using(rw).Set(s.prefix, buildOperatorKey(operatorData.ID), raw)
+}
+
 func (s *operatorsStorage) DeleteOperatorData(rw basedb.ReadWriter, id spectypes.OperatorID) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
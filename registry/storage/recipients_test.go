@@ -163,6 +163,16 @@ func TestStorage_SaveAndGetRecipientData(t *testing.T) {
 		for _, r := range savedRecipients {
 			require.Equal(t, r.FeeRecipient, recipients[r.Owner])
 		}
+
+		listed, err := storageCollection.ListRecipients(nil)
+		require.NoError(t, err)
+		listedOwners := make(map[common.Address]bool, len(listed))
+		for _, r := range listed {
+			listedOwners[r.Owner] = true
+		}
+		for _, owner := range ownerAddresses {
+			require.True(t, listedOwners[owner])
+		}
 	})
 
 	t.Run("create recipient should not initializing nonce", func(t *testing.T) {
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var (
+	signedExitsPrefix = []byte("signed_exits")
+)
+
+// SignedExit is the record of a voluntary exit which the validator's committee signed.
+type SignedExit struct {
+	ValidatorPubKey hexutil.Bytes               `json:"validator_pubkey"`
+	Exit            *phase0.SignedVoluntaryExit `json:"exit"`
+	// PreSigned is true if the exit was returned to the owner rather than submitted to the beacon node.
+	PreSigned bool      `json:"pre_signed"`
+	SignedAt  time.Time `json:"signed_at"`
+}
+
+// SignedExits is the interface for the audit records of signed voluntary exits
+type SignedExits interface {
+	SaveSignedExit(rw basedb.ReadWriter, signedExit *SignedExit) error
+	// ListSignedExits returns the signed exits of the given validator, or of all validators if pubKey is nil.
+	ListSignedExits(r basedb.Reader, pubKey []byte) ([]*SignedExit, error)
+}
+
+type signedExitsStorage struct {
+	logger *zap.Logger
+	db     basedb.Database
+	lock   sync.RWMutex
+	prefix []byte
+}
+
+// NewSignedExitsStorage creates a new instance of SignedExits
+func NewSignedExitsStorage(logger *zap.Logger, db basedb.Database, prefix []byte) SignedExits {
+	return &signedExitsStorage{
+		logger: logger,
+		db:     db,
+		prefix: prefix,
+	}
+}
+
+// SaveSignedExit saves the record of a signed exit, replacing a previous record of the same validator and epoch
+func (s *signedExitsStorage) SaveSignedExit(rw basedb.ReadWriter, signedExit *SignedExit) error {
+	if signedExit.Exit == nil || signedExit.Exit.Message == nil {
+		return errors.New("signed exit has no message")
+	}
+	value, err := json.Marshal(signedExit)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal signed exit")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Using(rw).Set(s.prefix, buildSignedExitKey(signedExit.ValidatorPubKey, signedExit.Exit.Message.Epoch), value)
+}
+
+func (s *signedExitsStorage) ListSignedExits(r basedb.Reader, pubKey []byte) ([]*SignedExit, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	prefix := append(append([]byte{}, s.prefix...), signedExitsPrefix...)
+	if pubKey != nil {
+		prefix = append(prefix, append([]byte("/"), pubKey...)...)
+	}
+
+	var signedExits []*SignedExit
+	err := s.db.UsingReader(r).GetAll(prefix, func(i int, obj basedb.Obj) error {
+		signedExit := &SignedExit{}
+		if err := json.Unmarshal(obj.Value, signedExit); err != nil {
+			return errors.Wrap(err, "could not unmarshal signed exit")
+		}
+		signedExits = append(signedExits, signedExit)
+		return nil
+	})
+	return signedExits, err
+}
+
+// buildSignedExitKey builds signed exit key using signedExitsPrefix, validator public key & epoch,
+// e.g. "signed_exits/0x00..01/0x00..0a"
+func buildSignedExitKey(pubKey []byte, epoch phase0.Epoch) []byte {
+	epochBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(epochBytes, uint64(epoch))
+	return bytes.Join([][]byte{signedExitsPrefix, pubKey, epochBytes}, []byte("/"))
+}
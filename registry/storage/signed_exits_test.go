@@ -0,0 +1,55 @@
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/kv"
+)
+
+func TestStorage_SaveAndListSignedExits(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	s := storage.NewSignedExitsStorage(logger, db, []byte("test"))
+
+	signedExit := func(pubKey byte, epoch phase0.Epoch, preSigned bool) *storage.SignedExit {
+		return &storage.SignedExit{
+			ValidatorPubKey: append([]byte{pubKey}, make([]byte, 47)...),
+			Exit: &phase0.SignedVoluntaryExit{
+				Message:   &phase0.VoluntaryExit{Epoch: epoch, ValidatorIndex: phase0.ValidatorIndex(pubKey)},
+				Signature: phase0.BLSSignature{pubKey},
+			},
+			PreSigned: preSigned,
+			SignedAt:  time.Unix(int64(epoch), 0).UTC(),
+		}
+	}
+
+	signedExits, err := s.ListSignedExits(nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, signedExits)
+
+	require.NoError(t, s.SaveSignedExit(nil, signedExit(1, 10, true)))
+	require.NoError(t, s.SaveSignedExit(nil, signedExit(1, 20, false)))
+	require.NoError(t, s.SaveSignedExit(nil, signedExit(2, 10, true)))
+	// the same exit is recorded once.
+	require.NoError(t, s.SaveSignedExit(nil, signedExit(2, 10, true)))
+
+	signedExits, err = s.ListSignedExits(nil, nil)
+	require.NoError(t, err)
+	require.Len(t, signedExits, 3)
+
+	signedExits, err = s.ListSignedExits(nil, signedExit(1, 0, false).ValidatorPubKey)
+	require.NoError(t, err)
+	require.Equal(t, []*storage.SignedExit{signedExit(1, 10, true), signedExit(1, 20, false)}, signedExits)
+
+	require.Error(t, s.SaveSignedExit(nil, &storage.SignedExit{ValidatorPubKey: make([]byte, 48)}))
+}
@@ -11,6 +11,12 @@ type Options struct {
 	Path       string        `yaml:"Path" env:"DB_PATH" env-default:"./data/db" env-description:"Path for storage"`
 	Reporting  bool          `yaml:"Reporting" env:"DB_REPORTING" env-default:"false" env-description:"Flag to run on-off db size reporting"`
 	GCInterval time.Duration `yaml:"GCInterval" env:"DB_GC_INTERVAL" env-default:"6m" env-description:"Interval between garbage collection cycles. Set to 0 to disable."`
+
+	Encrypt                        bool   `yaml:"Encrypt" env:"DB_ENCRYPT" env-description:"Encrypt the database at rest, with a key derived from the operator key or from EncryptionPasswordFile if set. Existing databases are migrated"`
+	EncryptionPasswordFile         string `yaml:"EncryptionPasswordFile" env:"DB_ENCRYPTION_PASSWORD_FILE" env-description:"File containing the password to derive the database's encryption key from, instead of the operator key"`
+	PreviousEncryptionPasswordFile string `yaml:"PreviousEncryptionPasswordFile" env:"DB_PREVIOUS_ENCRYPTION_PASSWORD_FILE" env-description:"File containing the password the database was encrypted with before it was changed, to rotate its key"`
+	// EncryptionKey is the AES-256 key encrypting the database, which is unencrypted if it's empty.
+	EncryptionKey []byte `yaml:"-"`
 }
 
 // Reader is a read-only accessor to the database.
@@ -41,25 +41,10 @@ func NewInMemory(logger *zap.Logger, options basedb.Options) (*BadgerDB, error)
 }
 
 func createDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*BadgerDB, error) {
-	// Open the Badger database located in the /tmp/badger directory.
-	// It will be created if it doesn't exist.
-	opt := badger.DefaultOptions(options.Path)
-
-	if inMemory {
-		opt.InMemory = true
-		opt.Dir = ""
-		opt.ValueDir = ""
-	}
-
-	// TODO: we should set the default logger here to log Error and higher levels
-	opt.Logger = newLogger(zap.NewNop())
-	if logger != nil && options.Reporting {
-		opt.Logger = newLogger(logger)
-	} else {
-		opt.Logger = newLogger(zap.NewNop()) // TODO: we should allow only errors to be logged
+	opt, err := badgerOptions(logger, options, inMemory)
+	if err != nil {
+		return nil, err
 	}
-
-	opt.ValueLogFileSize = 1024 * 1024 * 100 // TODO:need to set the vlog proper (max) size
 	db, err := badger.Open(opt)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open badger")
@@ -94,6 +79,39 @@ func createDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*Badge
 	return &badgerDB, nil
 }
 
+func badgerOptions(logger *zap.Logger, options basedb.Options, inMemory bool) (badger.Options, error) {
+	// Open the Badger database located in the /tmp/badger directory.
+	// It will be created if it doesn't exist.
+	opt := badger.DefaultOptions(options.Path)
+
+	if inMemory {
+		opt.InMemory = true
+		opt.Dir = ""
+		opt.ValueDir = ""
+	}
+
+	// TODO: we should set the default logger here to log Error and higher levels
+	opt.Logger = newLogger(zap.NewNop())
+	if logger != nil && options.Reporting {
+		opt.Logger = newLogger(logger)
+	} else {
+		opt.Logger = newLogger(zap.NewNop()) // TODO: we should allow only errors to be logged
+	}
+
+	opt.ValueLogFileSize = 1024 * 1024 * 100 // TODO:need to set the vlog proper (max) size
+
+	if len(options.EncryptionKey) > 0 {
+		if len(options.EncryptionKey) != EncryptionKeySize {
+			return opt, errors.Errorf("encryption key must be %d bytes", EncryptionKeySize)
+		}
+		// Badger encrypts the data with data keys, which are rotated periodically and encrypted with this key.
+		opt.EncryptionKey = options.EncryptionKey
+		// Caching the indices of tables saves decrypting them on every read.
+		opt.IndexCacheSize = indexCacheSize
+	}
+	return opt, nil
+}
+
 // Badger returns the underlying badger.DB
 func (b *BadgerDB) Badger() *badger.DB {
 	return b.db
@@ -0,0 +1,255 @@
+package kv
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+const (
+	// EncryptionKeySize is the size of the AES-256 keys encrypting databases.
+	EncryptionKeySize = 32
+
+	indexCacheSize = 100 << 20
+
+	// saltFileName is the file in the database's directory holding the salt of keys derived from passwords.
+	saltFileName = "ENCRYPTION_SALT"
+	saltSize     = 32
+
+	// A database being rewritten is written beside it, and replaces it once it's complete.
+	rewrittenDirSuffix = ".rewriting"
+	replacedDirSuffix  = ".replaced"
+)
+
+var (
+	// ErrUnknownEncryptionKey is returned when a database isn't encrypted with any of the given keys.
+	ErrUnknownEncryptionKey = errors.New("database is encrypted with an unknown key")
+	// ErrDatabaseNotFound is returned when there's no database at the given path.
+	ErrDatabaseNotFound = errors.New("database doesn't exist")
+)
+
+// KeyFromPassword derives a database encryption key from the password with scrypt,
+// salted with a random salt kept in the database's directory, which is created if it doesn't exist.
+func KeyFromPassword(path string, password []byte) ([]byte, error) {
+	if err := recoverRewrite(path); err != nil {
+		return nil, err
+	}
+	saltPath := filepath.Join(path, saltFileName)
+	salt, err := os.ReadFile(saltPath)
+	if os.IsNotExist(err) {
+		salt = make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, errors.Wrap(err, "could not generate salt")
+		}
+		if err := os.MkdirAll(path, 0700); err != nil {
+			return nil, errors.Wrap(err, "could not create database directory")
+		}
+		if err := os.WriteFile(saltPath, salt, 0600); err != nil {
+			return nil, errors.Wrap(err, "could not write salt")
+		}
+	} else if err != nil {
+		return nil, errors.Wrap(err, "could not read salt")
+	}
+	return keyFromSalt(password, salt)
+}
+
+// ReadKeyFromPassword derives a database encryption key from the password like KeyFromPassword,
+// without modifying the database's directory. It fails if the database has no salt.
+func ReadKeyFromPassword(path string, password []byte) ([]byte, error) {
+	salt, err := os.ReadFile(filepath.Join(path, saltFileName))
+	if os.IsNotExist(err) {
+		return nil, errors.New("database has no salt, it wasn't encrypted with a password")
+	} else if err != nil {
+		return nil, errors.Wrap(err, "could not read salt")
+	}
+	return keyFromSalt(password, salt)
+}
+
+func keyFromSalt(password, salt []byte) ([]byte, error) {
+	return scrypt.Key(password, salt, 1<<15, 8, 1, EncryptionKeySize)
+}
+
+// MigrateEncryption migrates the database at options.Path to be encrypted with options.EncryptionKey,
+// or to be unencrypted if it's empty, from the previous key it's encrypted with or from being unencrypted.
+// Replacing the key of an encrypted database re-encrypts its data keys, while encrypting or decrypting
+// a database rewrites it. It returns whether the database was migrated, and must be called before it's opened.
+func MigrateEncryption(logger *zap.Logger, options basedb.Options, previousKeys ...[]byte) (bool, error) {
+	if err := recoverRewrite(options.Path); err != nil {
+		return false, err
+	}
+	key, err := EncryptionKey(options.Path, append([][]byte{options.EncryptionKey}, previousKeys...)...)
+	if errors.Is(err, ErrDatabaseNotFound) {
+		// The database is created with the key when it's opened.
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if bytes.Equal(key, options.EncryptionKey) {
+		return false, nil
+	}
+
+	if len(key) > 0 && len(options.EncryptionKey) > 0 {
+		logger.Info("rotating database encryption key")
+		return true, rotateEncryptionKey(options.Path, key, options.EncryptionKey)
+	}
+	logger.Info("rewriting database to change its encryption, this may take a while",
+		zap.Bool("encrypted", len(key) > 0),
+		zap.Bool("encrypt", len(options.EncryptionKey) > 0))
+	return true, rewrite(logger, options, key)
+}
+
+// EncryptionKey returns the first of the keys which the database at the path is encrypted with,
+// or nil if it's unencrypted. It doesn't modify the database, and returns ErrDatabaseNotFound if it doesn't exist.
+func EncryptionKey(path string, keys ...[]byte) ([]byte, error) {
+	if _, err := os.Stat(filepath.Join(path, badger.KeyRegistryFileName)); os.IsNotExist(err) {
+		return nil, ErrDatabaseNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "could not read key registry")
+	}
+
+	for _, key := range append(keys[:len(keys):len(keys)], nil) {
+		ok, err := encryptedWith(path, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return key, nil
+		}
+	}
+	return nil, ErrUnknownEncryptionKey
+}
+
+// encryptedWith returns whether the database is encrypted with the key, or is unencrypted if it's empty.
+func encryptedWith(path string, key []byte) (bool, error) {
+	_, err := badger.OpenKeyRegistry(badger.KeyRegistryOptions{
+		Dir:           path,
+		ReadOnly:      true,
+		EncryptionKey: key,
+	})
+	if errors.Is(err, badger.ErrEncryptionKeyMismatch) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "could not read key registry")
+	}
+	return true, nil
+}
+
+// rotateEncryptionKey re-encrypts the data keys of the database with the new key.
+// The key registry is replaced atomically, so the database is encrypted with either of the keys if it's interrupted.
+func rotateEncryptionKey(path string, oldKey, newKey []byte) error {
+	registry, err := badger.OpenKeyRegistry(badger.KeyRegistryOptions{
+		Dir:           path,
+		ReadOnly:      true,
+		EncryptionKey: oldKey,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not read key registry")
+	}
+	if err := badger.WriteKeyRegistry(registry, badger.KeyRegistryOptions{
+		Dir:           path,
+		EncryptionKey: newKey,
+	}); err != nil {
+		return errors.Wrap(err, "could not write key registry")
+	}
+	return nil
+}
+
+// rewrite copies the database, encrypted with the old key, to a new one encrypted with options.EncryptionKey,
+// and replaces it with the copy.
+func rewrite(logger *zap.Logger, options basedb.Options, oldKey []byte) error {
+	rewrittenPath := options.Path + rewrittenDirSuffix
+	if err := os.RemoveAll(rewrittenPath); err != nil {
+		return err
+	}
+
+	oldOptions := options
+	oldOptions.EncryptionKey = oldKey
+	if err := copyDB(logger, oldOptions, basedb.Options{Path: rewrittenPath, EncryptionKey: options.EncryptionKey}); err != nil {
+		return err
+	}
+
+	salt, err := os.ReadFile(filepath.Join(options.Path, saltFileName))
+	if err == nil {
+		err = os.WriteFile(filepath.Join(rewrittenPath, saltFileName), salt, 0600)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "could not copy salt")
+	}
+
+	// The copy replaces the database in two renames, which recoverRewrite completes if they're interrupted.
+	replacedPath := options.Path + replacedDirSuffix
+	if err := os.Rename(options.Path, replacedPath); err != nil {
+		return err
+	}
+	if err := os.Rename(rewrittenPath, options.Path); err != nil {
+		return err
+	}
+	return os.RemoveAll(replacedPath)
+}
+
+func copyDB(logger *zap.Logger, from, to basedb.Options) error {
+	fromOpt, err := badgerOptions(logger, from, false)
+	if err != nil {
+		return err
+	}
+	fromDB, err := badger.Open(fromOpt)
+	if err != nil {
+		return errors.Wrap(err, "failed to open badger")
+	}
+	defer fromDB.Close()
+
+	toOpt, err := badgerOptions(logger, to, false)
+	if err != nil {
+		return err
+	}
+	toDB, err := badger.Open(toOpt)
+	if err != nil {
+		return errors.Wrap(err, "failed to open badger")
+	}
+
+	// The database is streamed from a backup of the old one into the new one.
+	reader, writer := io.Pipe()
+	backupErr := make(chan error, 1)
+	go func() {
+		_, err := fromDB.Backup(writer, 0)
+		_ = writer.CloseWithError(err)
+		backupErr <- err
+	}()
+	loadErr := toDB.Load(reader, 256)
+	_ = reader.CloseWithError(loadErr)
+	if err := <-backupErr; err != nil && loadErr == nil {
+		loadErr = err
+	}
+	if err := toDB.Close(); err != nil && loadErr == nil {
+		loadErr = err
+	}
+	return errors.Wrap(loadErr, "could not copy database")
+}
+
+// recoverRewrite completes or reverts replacing the database with its rewritten copy, if it was interrupted.
+func recoverRewrite(path string) error {
+	replacedPath := path + replacedDirSuffix
+	if _, err := os.Stat(replacedPath); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		// The database was moved aside, but its copy didn't replace it, so it's restored and rewritten again.
+		return os.Rename(replacedPath, path)
+	} else if err != nil {
+		return err
+	}
+	return os.RemoveAll(replacedPath)
+}
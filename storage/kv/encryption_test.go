@@ -0,0 +1,161 @@
+package kv
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var (
+	encryptionTestPrefix = []byte("prefix")
+	encryptionTestValue  = []byte("share metadata of validator 0x8f3a2c71e9d0b645 owned by 0x4b133c68a084b8a8")
+)
+
+func writeTestData(t *testing.T, options basedb.Options) {
+	db, err := New(logging.TestLogger(t), options)
+	require.NoError(t, err)
+	require.NoError(t, db.Set(encryptionTestPrefix, []byte("key"), encryptionTestValue))
+	require.NoError(t, db.Close())
+}
+
+func requireTestData(t *testing.T, options basedb.Options) {
+	db, err := New(logging.TestLogger(t), options)
+	require.NoError(t, err)
+	obj, found, err := db.Get(encryptionTestPrefix, []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, encryptionTestValue, obj.Value)
+	require.NoError(t, db.Close())
+}
+
+// containsPlaintext returns whether any of the database's files contain the test value.
+func containsPlaintext(t *testing.T, path string) bool {
+	entries, err := os.ReadDir(path)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(path, entry.Name()))
+		require.NoError(t, err)
+		if bytes.Contains(data, encryptionTestValue) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMigrateEncryption(t *testing.T) {
+	logger := logging.TestLogger(t)
+	path := filepath.Join(t.TempDir(), "db")
+	key1 := bytes.Repeat([]byte{1}, EncryptionKeySize)
+	key2 := bytes.Repeat([]byte{2}, EncryptionKeySize)
+
+	// A new database needs no migration, and has no key until it's created.
+	migrated, err := MigrateEncryption(logger, basedb.Options{Path: path, EncryptionKey: key1})
+	require.NoError(t, err)
+	require.False(t, migrated)
+	_, err = EncryptionKey(path, key1)
+	require.ErrorIs(t, err, ErrDatabaseNotFound)
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	writeTestData(t, basedb.Options{Path: path})
+	require.True(t, containsPlaintext(t, path))
+
+	// An unencrypted database is rewritten encrypted.
+	migrated, err = MigrateEncryption(logger, basedb.Options{Path: path, EncryptionKey: key1})
+	require.NoError(t, err)
+	require.True(t, migrated)
+	require.False(t, containsPlaintext(t, path))
+	requireTestData(t, basedb.Options{Path: path, EncryptionKey: key1})
+	_, err = New(logger, basedb.Options{Path: path})
+	require.Error(t, err)
+	_, err = os.Stat(path + rewrittenDirSuffix)
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(path + replacedDirSuffix)
+	require.True(t, os.IsNotExist(err))
+
+	// Migrating again is a no-op.
+	migrated, err = MigrateEncryption(logger, basedb.Options{Path: path, EncryptionKey: key1})
+	require.NoError(t, err)
+	require.False(t, migrated)
+
+	// The key is rotated from the previous key.
+	_, err = MigrateEncryption(logger, basedb.Options{Path: path, EncryptionKey: key2})
+	require.ErrorIs(t, err, ErrUnknownEncryptionKey)
+	migrated, err = MigrateEncryption(logger, basedb.Options{Path: path, EncryptionKey: key2}, key1)
+	require.NoError(t, err)
+	require.True(t, migrated)
+	requireTestData(t, basedb.Options{Path: path, EncryptionKey: key2})
+	_, err = New(logger, basedb.Options{Path: path, EncryptionKey: key1})
+	require.Error(t, err)
+	key, err := EncryptionKey(path, key1, key2)
+	require.NoError(t, err)
+	require.Equal(t, key2, key)
+
+	// Without a key, the database is decrypted.
+	migrated, err = MigrateEncryption(logger, basedb.Options{Path: path}, key1, key2)
+	require.NoError(t, err)
+	require.True(t, migrated)
+	requireTestData(t, basedb.Options{Path: path})
+}
+
+func TestMigrateEncryptionRecovery(t *testing.T) {
+	logger := logging.TestLogger(t)
+	path := filepath.Join(t.TempDir(), "db")
+	key := bytes.Repeat([]byte{1}, EncryptionKeySize)
+	writeTestData(t, basedb.Options{Path: path})
+
+	// The database was moved aside before its rewritten copy replaced it.
+	require.NoError(t, os.Rename(path, path+replacedDirSuffix))
+	require.NoError(t, os.Mkdir(path+rewrittenDirSuffix, 0700))
+
+	migrated, err := MigrateEncryption(logger, basedb.Options{Path: path, EncryptionKey: key})
+	require.NoError(t, err)
+	require.True(t, migrated)
+	requireTestData(t, basedb.Options{Path: path, EncryptionKey: key})
+	_, err = os.Stat(path + replacedDirSuffix)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestKeyFromPassword(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+
+	// Reading a key doesn't create the salt.
+	_, err := ReadKeyFromPassword(path, []byte("password"))
+	require.Error(t, err)
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	key, err := KeyFromPassword(path, []byte("password"))
+	require.NoError(t, err)
+	require.Len(t, key, EncryptionKeySize)
+	readKey, err := ReadKeyFromPassword(path, []byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, key, readKey)
+
+	// The salt is kept, so the key is stable.
+	sameKey, err := KeyFromPassword(path, []byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, key, sameKey)
+	otherKey, err := KeyFromPassword(path, []byte("other password"))
+	require.NoError(t, err)
+	require.NotEqual(t, key, otherKey)
+
+	// The salt is kept when the database is rewritten.
+	writeTestData(t, basedb.Options{Path: path, EncryptionKey: key})
+	_, err = MigrateEncryption(logging.TestLogger(t), basedb.Options{Path: path}, key)
+	require.NoError(t, err)
+	sameKey, err = KeyFromPassword(path, []byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, key, sameKey)
+
+	// Each database has its own salt.
+	otherDBKey, err := KeyFromPassword(filepath.Join(t.TempDir(), "db"), []byte("password"))
+	require.NoError(t, err)
+	require.NotEqual(t, key, otherDBKey)
+}
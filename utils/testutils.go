@@ -39,6 +39,7 @@ func SetupMockBeaconNetwork(t *testing.T, currentSlot *SlotValue) *mocknetwork.M
 
 	mockBeaconNetwork := mocknetwork.NewMockBeaconNetwork(ctrl)
 	mockBeaconNetwork.EXPECT().GetBeaconNetwork().Return(networkconfig.TestNetwork.Beacon.GetBeaconNetwork()).AnyTimes()
+	mockBeaconNetwork.EXPECT().SlotDurationSec().Return(networkconfig.TestNetwork.Beacon.SlotDurationSec()).AnyTimes()
 
 	mockBeaconNetwork.EXPECT().EstimatedCurrentSlot().DoAndReturn(
 		func() phase0.Slot {